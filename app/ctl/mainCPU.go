@@ -14,7 +14,6 @@ import (
 	"math"
 	"math/rand"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -30,6 +29,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	profil.UseInventory(profil.NewInventory(profil.NewStore(influxDB, profil.DefaultConfig()), "cn-north-1", 5*time.Minute))
 
 	for {
 
@@ -38,7 +38,7 @@ func main() {
 
 		// Getting App Metric
 		for i := 0; i < RCLen; i++ {
-			replicas := profil.GetInstanceCount("cn-north-1", "i-xxxxxxxxxx")
+			replicas := profil.GetAppReplicas("cn-north-1", "i-xxxxxxxxxx")
 			fmt.Println(replicas)
 
 			// Check Resposne time & Label & Save WPI
@@ -62,11 +62,18 @@ func main() {
 				log.Println(err)
 			}
 			fmt.Println("CPU ", cpu10Min)
-			qRepSpread, err := profil.QueryDB(influxDB, fmt.Sprint("SELECT spread(replicas) FROM "+"cn-north-1"+" WHERE time > now() - 5m"))
+			spreadQuery, err := profil.Select(profil.Spread("replicas")).From("cn-north-1").Since(5 * time.Minute).Build()
+			if err != nil {
+				log.Fatal(err)
+			}
+			qRepSpread, err := profil.QueryDB(influxDB, spreadQuery)
+			if err != nil {
+				log.Fatal(err)
+			}
+			repSpread, err := profil.DecodeFloat(qRepSpread, "replicas")
 			if err != nil {
 				log.Fatal(err)
 			}
-			repSpread, err := strconv.ParseFloat(fmt.Sprint(qRepSpread[0].Series[0].Values[0][1]), 32)
 
 			if repSpread < 1 {
 
@@ -36,6 +36,7 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	profil.UseInventory(profil.NewInventory(profil.NewStore(influxDB, profil.DefaultConfig()), "cn-north-1", 5*time.Minute))
 	round := 0
 	correct := 0
 	// TODO:it's need to calculate this for all RC
@@ -53,7 +54,7 @@ func main() {
 			round++
 			action := 0.0
 
-			replicas := profil.GetInstanceCount("cn-north-1", "127.0.0.1")
+			replicas := profil.GetAppReplicas("cn-north-1", "i-xxxxxxxxxx")
 			fmt.Println("Replicas:", replicas)
 
 			// Check Resposne time & Label & Save WPI
@@ -69,7 +70,10 @@ func main() {
 				panic(err)
 				log.Println(err)
 			}
-			metrics := profil.GetAppMetrics()
+			metrics, err := profil.GetAppMetrics(influxDB, "cn-north-1", "i-xxxxxxxxxx", "5m")
+			if err != nil {
+				panic(err)
+			}
 			// Floor
 			responseDay = math.Floor(responseDay)
 			response10Min = math.Floor(response10Min)
@@ -0,0 +1,205 @@
+package main
+
+// evicts pods whose placement no longer suits the cluster
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/descheduler"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/loadaware"
+	"github.com/turtacn/cloud-prophet/scheduler/rebalance"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	kube_flag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog/v2"
+)
+
+var (
+	kubeconfig        = flag.String("kubeconfig", "", `Path to a kubeconfig. Only required if out-of-cluster.`)
+	kubeApiQps        = flag.Float64("kube-api-qps", 5.0, `QPS limit when making requests to Kubernetes apiserver`)
+	kubeApiBurst      = flag.Float64("kube-api-burst", 10.0, `QPS burst limit when making requests to Kubernetes apiserver`)
+	dryRun            = flag.Bool("dry-run", true, `Only print the pods that would be evicted`)
+	once              = flag.Bool("once", false, `Run a single pass and exit`)
+	interval          = flag.Duration("interval", 5*time.Minute, `How often the strategies run`)
+	strategies        = flag.String("strategies", "RemoveDuplicates,RemovePodsViolatingInterPodAntiAffinity,RemovePodsViolatingNodeAffinity,RemovePodsViolatingNodeTaints,RemovePodsViolatingTopologySpreadConstraint", `Comma separated strategies, in the order they run`)
+	lowThresholds     = flag.String("low-thresholds", "cpu=20,memory=20,pods=20", `Utilisation in percent under which LowNodeUtilization considers a node under utilised`)
+	targetThresholds  = flag.String("target-thresholds", "cpu=50,memory=50,pods=50", `Utilisation in percent above which LowNodeUtilization considers a node over utilised`)
+	maxPods           = flag.Int("max-pods-to-evict", 0, `Maximum number of pods evicted per pass, 0 for unbounded`)
+	maxPodsPerNode    = flag.Int("max-pods-to-evict-per-node", 0, `Maximum number of pods evicted per node and pass, 0 for unbounded`)
+	maxPodsPerNs      = flag.Int("max-pods-to-evict-per-namespace", 0, `Maximum number of pods evicted per namespace and pass, 0 for unbounded`)
+	nodeFit           = flag.Bool("node-fit", true, `Only evict pods that fit on another node`)
+	evictLocalStorage = flag.Bool("evict-local-storage-pods", false, `Evict pods with emptyDir or hostPath volumes`)
+	excludeNamespaces = flag.String("exclude-namespaces", "kube-system,kube-public,kube-node-lease", `Comma separated namespaces the descheduler never touches`)
+
+	rebalanceHot         = flag.Bool("rebalance", false, `Also move pods off nodes hotter than --rebalance-thresholds after the strategies`)
+	rebalanceThresholds  = flag.String("rebalance-thresholds", "cpu=80,memory=80", `Utilisation in percent every node should stay under`)
+	rebalanceMaxMoves    = flag.Int("rebalance-max-moves", 0, `Maximum number of pods moved per pass, 0 for unbounded`)
+	rebalanceMaxPerNode  = flag.Int("rebalance-max-moves-per-node", 0, `Maximum number of pods moved off a node per pass, 0 for unbounded`)
+	rebalanceMetrics     = flag.String("rebalance-metrics", "", `Where the utilisation of nodes is read from: prometheus or file. Nodes are assumed to use what their pods request when empty`)
+	rebalanceAddress     = flag.String("rebalance-prometheus-address", "http://localhost:9090", `Address of the Prometheus server of --rebalance-metrics=prometheus`)
+	rebalanceMetricsFile = flag.String("rebalance-metrics-file", "", `YAML or JSON file of --rebalance-metrics=file`)
+	rebalanceMaxAge      = flag.Duration("rebalance-metrics-max-age", 3*time.Minute, `Age after which the utilisation of a node is ignored`)
+)
+
+func main() {
+	klog.InitFlags(nil)
+	kube_flag.InitFlags()
+
+	low, err := parseThresholds(*lowThresholds)
+	if err != nil {
+		klog.Fatalf("Could not parse --low-thresholds: %v", err)
+	}
+	target, err := parseThresholds(*targetThresholds)
+	if err != nil {
+		klog.Fatalf("Could not parse --target-thresholds: %v", err)
+	}
+	var list []descheduler.Strategy
+	for _, name := range strings.Split(*strategies, ",") {
+		s, err := descheduler.NewStrategy(strings.TrimSpace(name), low, target)
+		if err != nil {
+			klog.Fatalf("Could not parse --strategies: %v", err)
+		}
+		list = append(list, s)
+	}
+
+	kubeClient := kube_client.NewForConfigOrDie(createKubeConfig(*kubeconfig, float32(*kubeApiQps), int(*kubeApiBurst)))
+	config := descheduler.Config{
+		MaxPodsToEvict:             *maxPods,
+		MaxPodsToEvictPerNode:      *maxPodsPerNode,
+		MaxPodsToEvictPerNamespace: *maxPodsPerNs,
+		EvictLocalStorage:          *evictLocalStorage,
+		NodeFit:                    *nodeFit,
+		ExcludeNamespaces:          strings.Split(*excludeNamespaces, ","),
+	}
+	d := descheduler.New(kubeClient, config, rebalance.NewEvictor(kubeClient, *dryRun), list...)
+
+	var rebalanceConfig rebalance.Config
+	var metrics loadaware.MetricsSource
+	if *rebalanceHot {
+		thresholds, err := parseThresholds(*rebalanceThresholds)
+		if err != nil {
+			klog.Fatalf("Could not parse --rebalance-thresholds: %v", err)
+		}
+		rebalanceConfig = rebalance.Config{
+			CPUThreshold:      thresholds.CPU,
+			MemoryThreshold:   thresholds.Memory,
+			MaxMoves:          *rebalanceMaxMoves,
+			MaxMovesPerNode:   *rebalanceMaxPerNode,
+			EvictLocalStorage: *evictLocalStorage,
+		}
+		switch *rebalanceMetrics {
+		case "":
+		case "prometheus":
+			metrics = loadaware.NewPrometheusSource(*rebalanceAddress, loadaware.DefaultCPUQuery, loadaware.DefaultMemoryQuery, loadaware.DefaultNodeLabel)
+		case "file":
+			metrics = loadaware.NewFileSource(*rebalanceMetricsFile)
+		default:
+			klog.Fatalf("Unknown --rebalance-metrics %q", *rebalanceMetrics)
+		}
+	}
+
+	for {
+		evictions, err := d.RunOnce(context.TODO())
+		if err != nil {
+			klog.Errorf("Descheduler run failed: %v", err)
+		} else if *dryRun {
+			if err := descheduler.WriteEvictions(os.Stdout, evictions); err != nil {
+				klog.Errorf("Could not write evictions: %v", err)
+			}
+		} else {
+			klog.Infof("Evicted %d pods", len(evictions))
+		}
+		if err == nil && *rebalanceHot {
+			if err = runRebalance(d, rebalanceConfig, metrics); err != nil {
+				klog.Errorf("Rebalance failed: %v", err)
+			}
+		}
+		if *once {
+			if err != nil {
+				os.Exit(1)
+			}
+			return
+		}
+		time.Sleep(*interval)
+	}
+}
+
+// runRebalance moves pods off hot nodes given the utilisation read from
+// metrics, if any.
+func runRebalance(d *descheduler.Descheduler, config rebalance.Config, metrics loadaware.MetricsSource) error {
+	usage := make(map[string]rebalance.Usage)
+	if metrics != nil {
+		nodes, err := metrics.Fetch()
+		if err != nil {
+			return fmt.Errorf("reading node utilisation: %v", err)
+		}
+		for node, u := range nodes {
+			if time.Since(u.Time) <= *rebalanceMaxAge {
+				usage[node] = rebalance.Usage{CPU: u.CPU, Memory: u.Memory}
+			}
+		}
+	}
+	plan, err := d.Rebalance(context.TODO(), config, usage)
+	if plan != nil {
+		if *dryRun {
+			if werr := plan.Write(os.Stdout); werr != nil {
+				klog.Errorf("Could not write the rebalance plan: %v", werr)
+			}
+		} else {
+			klog.Infof("Moved %d pods off %d hot nodes", len(plan.Moves), len(plan.Hot))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("rebalancing: %v", err)
+	}
+	return nil
+}
+
+// parseThresholds parses "cpu=20,memory=20,pods=20".
+func parseThresholds(s string) (descheduler.Thresholds, error) {
+	t := descheduler.Thresholds{CPU: 100, Memory: 100, Pods: 100}
+	for _, kv := range strings.Split(s, ",") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return t, fmt.Errorf("invalid threshold %q", kv)
+		}
+		v, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil || v < 0 || v > 100 {
+			return t, fmt.Errorf("invalid threshold %q", kv)
+		}
+		switch strings.TrimSpace(parts[0]) {
+		case "cpu":
+			t.CPU = v
+		case "memory":
+			t.Memory = v
+		case "pods":
+			t.Pods = v
+		default:
+			return t, fmt.Errorf("unknown resource in %q", kv)
+		}
+	}
+	return t, nil
+}
+
+func createKubeConfig(kubeconfig string, kubeApiQps float32, kubeApiBurst int) *rest.Config {
+	var config *rest.Config
+	var err error
+	if kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		klog.Fatalf("Failed to create config: %v", err)
+	}
+	config.QPS = kubeApiQps
+	config.Burst = kubeApiBurst
+	return config
+}
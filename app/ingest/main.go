@@ -0,0 +1,70 @@
+package main
+
+// line protocol ingestion gateway: telegraf -> /write -> sinks
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+	"time"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+	"github.com/turtacn/cloud-prophet/model"
+	"github.com/turtacn/cloud-prophet/profil"
+	"github.com/turtacn/cloud-prophet/profil/ingest"
+	"k8s.io/klog"
+)
+
+var (
+	address        = flag.String("address", ":8186", `The address to accept line protocol writes on`)
+	databases      = flag.String("databases", "", `Comma separated databases accepted in the db parameter. Empty accepts any`)
+	sinks          = flag.String("sinks", "influxdb", `Comma separated sinks to fan out to. Supported values: influxdb, file, recommender`)
+	influxAddress  = flag.String("influxdb-address", model.InfluxdbApi, `Where to reach for InfluxDB`)
+	influxDatabase = flag.String("influxdb-database", profil.DefaultDatabase, `InfluxDB database points are written to`)
+	filePath       = flag.String("file-path", "ingest.lp", `File points are appended to by the file sink`)
+	recommenderURL = flag.String("recommender-url", "http://localhost:8943", `Where the recommender sink reaches the recommender's -ingest-address`)
+	requireApp     = flag.Bool("require-app", false, `Reject points without an app tag`)
+	dropUnknown    = flag.Bool("drop-unknown-fields", false, `Drop fields that are not part of the profil schema`)
+)
+
+func main() {
+	klog.InitFlags(nil)
+	flag.Parse()
+
+	var out []ingest.Sink
+	for _, name := range strings.Split(*sinks, ",") {
+		switch strings.TrimSpace(name) {
+		case "influxdb":
+			influxDB, err := client.NewHTTPClient(client.HTTPConfig{
+				Addr:     *influxAddress,
+				Username: model.UserName,
+				Password: model.PassWord,
+			})
+			if err != nil {
+				klog.Fatalf("Could not create InfluxDB client: %v", err)
+			}
+			out = append(out, ingest.NewStoreSink(profil.NewStore(influxDB, profil.Config{Database: *influxDatabase})))
+		case "file":
+			sink, err := ingest.NewFileSink(*filePath)
+			if err != nil {
+				klog.Fatalf("Could not open %s: %v", *filePath, err)
+			}
+			out = append(out, sink)
+		case "recommender":
+			out = append(out, ingest.NewHTTPSink(*recommenderURL, &http.Client{Timeout: 10 * time.Second}))
+		default:
+			klog.Fatalf("Unknown sink %q", name)
+		}
+	}
+
+	config := ingest.Config{Normalizer: ingest.DefaultNormalizerConfig()}
+	if *databases != "" {
+		config.Databases = strings.Split(*databases, ",")
+	}
+	config.Normalizer.RequireApp = *requireApp
+	config.Normalizer.KeepUnknownFields = !*dropUnknown
+
+	handler := ingest.NewHandler(config, ingest.NewFanOutSink(out...))
+	klog.Infof("Accepting line protocol on %s", *address)
+	klog.Fatal(http.ListenAndServe(*address, handler))
+}
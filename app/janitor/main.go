@@ -0,0 +1,102 @@
+package main
+
+// deletes expired resources & scales deployments down outside working hours
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/janitor"
+	apiv1 "k8s.io/api/core/v1"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	kube_flag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog"
+)
+
+var (
+	kubeconfig        = flag.String("kubeconfig", "", `Path to a kubeconfig. Only required if out-of-cluster.`)
+	kubeApiQps        = flag.Float64("kube-api-qps", 5.0, `QPS limit when making requests to Kubernetes apiserver`)
+	kubeApiBurst      = flag.Float64("kube-api-burst", 10.0, `QPS burst limit when making requests to Kubernetes apiserver`)
+	dryRun            = flag.Bool("dry-run", true, `Only log what would be deleted or scaled`)
+	once              = flag.Bool("once", false, `Run a single pass and exit`)
+	interval          = flag.Duration("interval", 1*time.Minute, `How often resources are scanned`)
+	rulesFile         = flag.String("rules-file", "", `YAML or JSON file of TTL rules for resources without annotations`)
+	excludeNamespaces = flag.String("exclude-namespaces", "kube-system,kube-public,kube-node-lease", `Comma separated namespaces the janitor never touches`)
+	uptime            = flag.String("default-uptime", "", `Default working hours of deployments, e.g. "Mon-Fri 07:30-20:30 Asia/Shanghai". Empty disables the downscaler.`)
+	vmProviders       = flag.String("vm-providers", "", `Comma separated name=url VM inventory services to clean up, e.g. "cn-north-1=http://inventory:8080". Empty skips VMs.`)
+)
+
+func main() {
+	klog.InitFlags(nil)
+	kube_flag.InitFlags()
+
+	config := janitor.Config{
+		DryRun:            *dryRun,
+		ExcludeNamespaces: strings.Split(*excludeNamespaces, ","),
+		Interval:          *interval,
+	}
+	if *rulesFile != "" {
+		rules, err := janitor.LoadRules(*rulesFile)
+		if err != nil {
+			klog.Fatalf("Could not load rules: %v", err)
+		}
+		config.Rules = rules
+	}
+	if *uptime != "" {
+		schedule, err := janitor.ParseSchedule(*uptime)
+		if err != nil {
+			klog.Fatalf("Could not parse --default-uptime: %v", err)
+		}
+		config.Uptime = schedule
+	}
+
+	var providers []janitor.VMProvider
+	if *vmProviders != "" {
+		httpClient := &http.Client{Timeout: 30 * time.Second}
+		for _, p := range strings.Split(*vmProviders, ",") {
+			kv := strings.SplitN(p, "=", 2)
+			if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+				klog.Fatalf("Invalid --vm-providers entry %q, expected name=url", p)
+			}
+			providers = append(providers, janitor.NewHTTPProvider(kv[0], kv[1], httpClient))
+		}
+	}
+
+	kubeClient := kube_client.NewForConfigOrDie(createKubeConfig(*kubeconfig, float32(*kubeApiQps), int(*kubeApiBurst)))
+	j := janitor.NewJanitor(kubeClient, config, providers...)
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events(apiv1.NamespaceAll)})
+	j.SetEventRecorder(broadcaster.NewRecorder(scheme.Scheme, apiv1.EventSource{Component: "janitor"}))
+
+	if *once {
+		if err := j.RunOnce(time.Now()); err != nil {
+			klog.Fatalf("Janitor run failed: %v", err)
+		}
+		broadcaster.Shutdown()
+		return
+	}
+	j.Run(make(chan struct{}))
+}
+
+func createKubeConfig(kubeconfig string, kubeApiQps float32, kubeApiBurst int) *rest.Config {
+	var config *rest.Config
+	var err error
+	if kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		klog.Fatalf("Failed to create config: %v", err)
+	}
+	config.QPS = kubeApiQps
+	config.Burst = kubeApiBurst
+	return config
+}
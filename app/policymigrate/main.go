@@ -0,0 +1,96 @@
+package main
+
+// converts a legacy scheduler Policy file into the equivalent KubeSchedulerConfiguration
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/turtacn/cloud-prophet/scheduler"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/scheme"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	kube_flag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog"
+)
+
+var (
+	policyFile    = flag.String("policy-config-file", "", `Legacy Policy file in JSON or YAML, as read by the scheduler's policy file source`)
+	output        = flag.String("output", "", `File the configuration is written to, stdout if empty`)
+	schedulerName = flag.String("scheduler-name", "default-scheduler", `Scheduler name of the generated profile`)
+)
+
+func main() {
+	klog.InitFlags(nil)
+	kube_flag.InitFlags()
+
+	if *policyFile == "" {
+		klog.Fatalf("--policy-config-file is required")
+	}
+	data, err := ioutil.ReadFile(*policyFile)
+	if err != nil {
+		klog.Fatalf("Could not read policy: %v", err)
+	}
+	policy := &config.Policy{}
+	if err := runtime.DecodeInto(scheme.Codecs.UniversalDecoder(), data, policy); err != nil {
+		klog.Fatalf("Invalid policy: %v", err)
+	}
+
+	profile, warnings, err := scheduler.MigratePolicy(*policy, *schedulerName)
+	if err != nil {
+		klog.Fatalf("Could not translate policy: %v", err)
+	}
+	for _, w := range warnings {
+		klog.Warningf("%s", w)
+	}
+
+	cfg, err := defaultConfiguration()
+	if err != nil {
+		klog.Fatalf("Could not default configuration: %v", err)
+	}
+	cfg.Profiles = []config.KubeSchedulerProfile{*profile}
+	cfg.Extenders = policy.Extenders
+
+	out, err := encode(cfg, warnings)
+	if err != nil {
+		klog.Fatalf("Could not encode configuration: %v", err)
+	}
+	if *output == "" {
+		_, err = os.Stdout.Write(out)
+	} else {
+		err = ioutil.WriteFile(*output, out, 0644)
+	}
+	if err != nil {
+		klog.Fatalf("Could not write configuration: %v", err)
+	}
+}
+
+// defaultConfiguration returns the internal configuration a v1beta1 file without
+// any fields decodes to.
+func defaultConfiguration() (*config.KubeSchedulerConfiguration, error) {
+	versioned := &v1beta1.KubeSchedulerConfiguration{}
+	scheme.Scheme.Default(versioned)
+	cfg := &config.KubeSchedulerConfiguration{}
+	if err := scheme.Scheme.Convert(versioned, cfg, nil); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// encode writes cfg as v1beta1 YAML, preceded by the warnings as comments.
+func encode(cfg *config.KubeSchedulerConfiguration, warnings []string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, w := range warnings {
+		fmt.Fprintf(&buf, "# WARNING: %s\n", w)
+	}
+	yaml := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, json.SerializerOptions{Yaml: true})
+	if err := scheme.Codecs.EncoderForVersion(yaml, v1beta1.SchemeGroupVersion).Encode(cfg, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -1,3 +1,76 @@
 package main
 
 // data collect & process & into db
+
+import (
+	"flag"
+	"time"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+	"github.com/turtacn/cloud-prophet/model"
+	"github.com/turtacn/cloud-prophet/profil"
+	"github.com/turtacn/cloud-prophet/profil/collector"
+	apiv1 "k8s.io/api/core/v1"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	kube_flag "k8s.io/component-base/cli/flag"
+	"k8s.io/klog"
+)
+
+var (
+	kubeconfig      = flag.String("kubeconfig", "", `Path to a kubeconfig. Only required if out-of-cluster.`)
+	kubeApiQps      = flag.Float64("kube-api-qps", 5.0, `QPS limit when making requests to Kubernetes apiserver`)
+	kubeApiBurst    = flag.Float64("kube-api-burst", 10.0, `QPS burst limit when making requests to Kubernetes apiserver`)
+	influxAddress   = flag.String("influxdb-address", model.InfluxdbApi, `Where to reach for InfluxDB`)
+	influxDatabase  = flag.String("influxdb-database", profil.DefaultDatabase, `InfluxDB database the inventory is written to`)
+	region          = flag.String("region", "cn-north-1", `Region name, used as the measurement the inventory is written to`)
+	namespace       = flag.String("namespace", apiv1.NamespaceAll, `Namespace to collect. Empty means all namespaces will be used.`)
+	appLabel        = flag.String("app-label", "app", `Pod label holding the application name`)
+	collectInterval = flag.Duration("collect-interval", 1*time.Minute, `How often the inventory is written`)
+)
+
+func main() {
+	klog.InitFlags(nil)
+	kube_flag.InitFlags()
+
+	config := createKubeConfig(*kubeconfig, float32(*kubeApiQps), int(*kubeApiBurst))
+	kubeClient := kube_client.NewForConfigOrDie(config)
+
+	influxDB, err := client.NewHTTPClient(client.HTTPConfig{
+		Addr:     *influxAddress,
+		Username: model.UserName,
+		Password: model.PassWord,
+	})
+	if err != nil {
+		klog.Fatalf("Could not create InfluxDB client: %v", err)
+	}
+	defer influxDB.Close()
+
+	store := profil.NewStore(influxDB, profil.Config{Database: *influxDatabase, Precision: "s"})
+	c := collector.NewCollector(kubeClient, store, collector.Config{
+		Region:    *region,
+		Namespace: *namespace,
+		AppLabel:  *appLabel,
+		Interval:  *collectInterval,
+	})
+	if err := c.Run(make(chan struct{})); err != nil {
+		klog.Fatalf("Collector stopped: %v", err)
+	}
+}
+
+func createKubeConfig(kubeconfig string, kubeApiQps float32, kubeApiBurst int) *rest.Config {
+	var config *rest.Config
+	var err error
+	if kubeconfig != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		klog.Fatalf("Failed to create config: %v", err)
+	}
+	config.QPS = kubeApiQps
+	config.Burst = kubeApiBurst
+	return config
+}
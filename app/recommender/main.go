@@ -2,10 +2,17 @@ package main
 
 import (
 	"flag"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/turtacn/cloud-prophet/profil/ingest"
 	"github.com/turtacn/cloud-prophet/recommender/input/history"
+	"github.com/turtacn/cloud-prophet/recommender/input/metrics"
 	"github.com/turtacn/cloud-prophet/recommender/model"
+	"github.com/turtacn/cloud-prophet/recommender/report"
 	"github.com/turtacn/cloud-prophet/recommender/routines"
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/rest"
@@ -37,6 +44,20 @@ var (
 	vpaObjectNamespace  = flag.String("vpa-object-namespace", apiv1.NamespaceAll, "Namespace to search for VPA objects and pod stats. Empty means all namespaces will be used.")
 )
 
+// Slack cost report flags
+var (
+	slackReportPath   = flag.String("slack-report-path", "", `File the slack cost report is written to after every run. Empty disables the report.`)
+	slackReportFormat = flag.String("slack-report-format", string(report.FormatHTML), `Format of the slack cost report: csv, json or html`)
+	slackReportLevel  = flag.String("slack-report-level", string(report.LevelWorkload), `Lines of a csv slack cost report: container, workload or namespace`)
+	priceTable        = flag.String("price-table", "", `YAML or JSON file of resource prices for the slack cost report. Empty uses built-in list prices.`)
+)
+
+// Ingestion flags
+var (
+	ingestAddress = flag.String("ingest-address", "", `The address to accept line protocol container samples on, e.g. from the ingest gateway's recommender sink. Empty reads usage from the Metrics API.`)
+	ingestWindow  = flag.Duration("ingest-sample-window", time.Minute, `Measurement window reported for ingested container samples`)
+)
+
 // Aggregation configuration flags
 var (
 	memoryAggregationInterval      = flag.Duration("memory-aggregation-interval", model.DefaultMemoryAggregationInterval, `The length of a single interval, for which the peak memory usage is computed. Memory usage peaks are aggregated in multiples of this interval. In other words there is one memory usage sample per interval (the maximum usage over that interval)`)
@@ -53,8 +74,21 @@ func main() {
 
 	model.InitializeAggregationsConfig(model.NewAggregationsConfig(*memoryAggregationInterval, *memoryAggregationIntervalCount, *memoryHistogramDecayHalfLife, *cpuHistogramDecayHalfLife))
 
+	var metricsClient metrics.MetricsClient
+	if *ingestAddress != "" {
+		ingestConfig := metrics.DefaultIngestClientConfig()
+		ingestConfig.Window = *ingestWindow
+		ingestClient := metrics.NewIngestClient(ingestConfig)
+		handler := ingest.NewHandler(ingest.Config{Normalizer: ingest.DefaultNormalizerConfig()}, ingestClient)
+		go func() {
+			klog.Infof("Accepting container samples on %s", *ingestAddress)
+			klog.Fatal(http.ListenAndServe(*ingestAddress, handler))
+		}()
+		metricsClient = ingestClient
+	}
+
 	useCheckpoints := *storage != "prometheus"
-	recommender := routines.NewRecommender(config, *checkpointsGCInterval, useCheckpoints, *vpaObjectNamespace)
+	recommender := routines.NewRecommender(config, *checkpointsGCInterval, useCheckpoints, *vpaObjectNamespace, metricsClient)
 
 	promQueryTimeout, err := time.ParseDuration(*queryTimeout)
 	if err != nil {
@@ -86,10 +120,40 @@ func main() {
 		recommender.GetClusterStateFeeder().InitFromHistoryProvider(provider)
 	}
 
+	prices := report.DefaultPriceTable()
+	if *priceTable != "" {
+		if prices, err = report.LoadPriceTable(*priceTable); err != nil {
+			klog.Fatalf("Could not load --price-table: %v", err)
+		}
+	}
+
 	ticker := time.Tick(*metricsFetcherInterval)
 	for range ticker {
 		recommender.RunOnce()
+		if *slackReportPath != "" {
+			r := report.Build(report.FromClusterState(recommender.GetClusterState()), prices, time.Now())
+			if err := writeReport(r, *slackReportPath); err != nil {
+				klog.Errorf("Failed to write slack cost report: %v", err)
+			}
+		}
+	}
+}
+
+// writeReport replaces path so readers never see a partial report.
+func writeReport(r *report.Report, path string) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".slack-report")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := r.Write(f, report.Format(*slackReportFormat), report.Level(*slackReportLevel)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
 	}
+	return os.Rename(f.Name(), path)
 }
 
 func createKubeConfig(kubeApiQps float32, kubeApiBurst int) *rest.Config {
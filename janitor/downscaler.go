@@ -0,0 +1,125 @@
+package janitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule is a set of weekly working hours, e.g.
+// "Mon-Fri 07:30-20:30 Asia/Shanghai". Several ranges are separated by
+// commas. "always" and "never" are accepted as well.
+type Schedule struct {
+	spec   string
+	always bool
+	ranges []weeklyRange
+}
+
+type weeklyRange struct {
+	// days is indexed by time.Weekday.
+	days       [7]bool
+	start, end int // minutes since midnight, end exclusive
+	location   *time.Location
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseSchedule parses a working hours specification.
+func ParseSchedule(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	switch strings.ToLower(spec) {
+	case "", "always":
+		return &Schedule{spec: "always", always: true}, nil
+	case "never":
+		return &Schedule{spec: "never"}, nil
+	}
+	s := &Schedule{spec: spec}
+	for _, part := range strings.Split(spec, ",") {
+		r, err := parseWeeklyRange(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %v", spec, err)
+		}
+		s.ranges = append(s.ranges, r)
+	}
+	return s, nil
+}
+
+func parseWeeklyRange(s string) (weeklyRange, error) {
+	var r weeklyRange
+	fields := strings.Fields(s)
+	if len(fields) != 3 {
+		return r, fmt.Errorf("%q: expected \"<days> <hh:mm>-<hh:mm> <timezone>\"", s)
+	}
+	from, to := fields[0], fields[0]
+	if i := strings.Index(fields[0], "-"); i >= 0 {
+		from, to = fields[0][:i], fields[0][i+1:]
+	}
+	first, ok := weekdays[strings.ToLower(from)]
+	if !ok {
+		return r, fmt.Errorf("unknown weekday %q", from)
+	}
+	last, ok := weekdays[strings.ToLower(to)]
+	if !ok {
+		return r, fmt.Errorf("unknown weekday %q", to)
+	}
+	for d := first; ; d = (d + 1) % 7 {
+		r.days[d] = true
+		if d == last {
+			break
+		}
+	}
+	hours := strings.SplitN(fields[1], "-", 2)
+	if len(hours) != 2 {
+		return r, fmt.Errorf("%q: expected <hh:mm>-<hh:mm>", fields[1])
+	}
+	var err error
+	if r.start, err = parseClock(hours[0]); err != nil {
+		return r, err
+	}
+	if r.end, err = parseClock(hours[1]); err != nil {
+		return r, err
+	}
+	if r.end <= r.start {
+		return r, fmt.Errorf("%q: end must be after start", fields[1])
+	}
+	if r.location, err = time.LoadLocation(fields[2]); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		if s == "24:00" {
+			return 24 * 60, nil
+		}
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Contains reports whether t falls within the working hours.
+func (s *Schedule) Contains(t time.Time) bool {
+	if s == nil || s.always {
+		return true
+	}
+	for _, r := range s.ranges {
+		local := t.In(r.location)
+		minute := local.Hour()*60 + local.Minute()
+		if r.days[local.Weekday()] && minute >= r.start && minute < r.end {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Schedule) String() string {
+	if s == nil {
+		return "always"
+	}
+	return s.spec
+}
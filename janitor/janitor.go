@@ -0,0 +1,356 @@
+package janitor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kube_client "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+)
+
+// UptimeAnnotation overrides the working hours of a deployment or of all
+// deployments of a namespace, e.g. "Mon-Fri 09:00-18:00 UTC" or "always".
+const UptimeAnnotation = "janitor/uptime"
+
+// Verb is what the janitor did, or would do in dry-run, to a resource.
+type Verb string
+
+const (
+	// VerbDelete deletes an expired resource.
+	VerbDelete Verb = "Delete"
+	// VerbScaleDown scales a deployment to zero outside working hours.
+	VerbScaleDown Verb = "ScaleDown"
+	// VerbScaleUp restores the replicas of a deployment within working
+	// hours.
+	VerbScaleUp Verb = "ScaleUp"
+)
+
+// Action records one decision of the janitor.
+type Action struct {
+	Time      time.Time
+	Kind      string
+	Namespace string
+	Name      string
+	Verb      Verb
+	Reason    string
+	DryRun    bool
+	// Error is set if executing the action failed.
+	Error string
+}
+
+func (a Action) String() string {
+	name := a.Name
+	if a.Namespace != "" {
+		name = a.Namespace + "/" + a.Name
+	}
+	s := fmt.Sprintf("%s %s %s: %s", a.Verb, a.Kind, name, a.Reason)
+	if a.DryRun {
+		s += " (dry-run)"
+	}
+	if a.Error != "" {
+		s += ": " + a.Error
+	}
+	return s
+}
+
+// Config configures a Janitor.
+type Config struct {
+	// DryRun only reports what would be done.
+	DryRun bool
+	// ExcludeNamespaces are never touched, nor is anything inside them.
+	ExcludeNamespaces []string
+	// Rules assign TTLs to resources without TTL annotations. May be nil.
+	Rules *RuleSet
+	// Uptime are the default working hours of deployments. Nil disables the
+	// downscaler for deployments without UptimeAnnotation.
+	Uptime *Schedule
+	// Interval between two runs.
+	Interval time.Duration
+	// HistorySize is the number of actions kept in memory. Zero means 1000.
+	HistorySize int
+}
+
+// Janitor deletes expired namespaces, deployments, pods and VMs and scales
+// deployments down outside working hours.
+type Janitor struct {
+	config    Config
+	client    kube_client.Interface
+	providers []VMProvider
+	recorder  record.EventRecorder
+	excluded  map[string]bool
+
+	mu      sync.Mutex
+	history []Action
+}
+
+// NewJanitor returns a Janitor acting on client and providers.
+func NewJanitor(client kube_client.Interface, config Config, providers ...VMProvider) *Janitor {
+	if config.HistorySize == 0 {
+		config.HistorySize = 1000
+	}
+	excluded := make(map[string]bool, len(config.ExcludeNamespaces))
+	for _, ns := range config.ExcludeNamespaces {
+		excluded[ns] = true
+	}
+	return &Janitor{config: config, client: client, providers: providers, excluded: excluded}
+}
+
+// SetEventRecorder makes the janitor emit an event for every action on a
+// cluster object.
+func (j *Janitor) SetEventRecorder(recorder record.EventRecorder) {
+	j.recorder = recorder
+}
+
+// Run calls RunOnce every interval until stopCh is closed.
+func (j *Janitor) Run(stopCh <-chan struct{}) {
+	wait.Until(func() {
+		if err := j.RunOnce(time.Now()); err != nil {
+			klog.Errorf("Janitor run failed: %v", err)
+		}
+	}, j.config.Interval, stopCh)
+}
+
+// History returns the recorded actions, oldest first.
+func (j *Janitor) History() []Action {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return append([]Action(nil), j.history...)
+}
+
+// RunOnce scans every resource once as of now. Listing errors abort the
+// scan of that kind only; the last one is returned. Actions are available
+// through History.
+func (j *Janitor) RunOnce(now time.Time) error {
+	var lastErr error
+	ctx := context.TODO()
+
+	namespaces, err := j.client.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces: %v", err)
+	}
+	// Anything inside a namespace deleted in this run goes with it.
+	gone := make(map[string]bool)
+	nsUptime := make(map[string]string)
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if j.excluded[ns.Name] || ns.Status.Phase == corev1.NamespaceTerminating {
+			gone[ns.Name] = true
+			continue
+		}
+		if v, ok := ns.Annotations[UptimeAnnotation]; ok {
+			nsUptime[ns.Name] = v
+		}
+		if reason, ok := j.expired(now, KindNamespace, &ns.ObjectMeta); ok {
+			gone[ns.Name] = true
+			j.delete(now, ns, KindNamespace, &ns.ObjectMeta, reason, func() error {
+				return j.client.CoreV1().Namespaces().Delete(ctx, ns.Name, metav1.DeleteOptions{})
+			})
+		}
+	}
+
+	deployments, err := j.client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		lastErr = fmt.Errorf("failed to list deployments: %v", err)
+	} else {
+		for i := range deployments.Items {
+			d := &deployments.Items[i]
+			if gone[d.Namespace] {
+				continue
+			}
+			if reason, ok := j.expired(now, KindDeployment, &d.ObjectMeta); ok {
+				j.delete(now, d, KindDeployment, &d.ObjectMeta, reason, func() error {
+					return j.client.AppsV1().Deployments(d.Namespace).Delete(ctx, d.Name, metav1.DeleteOptions{})
+				})
+				continue
+			}
+			j.downscale(ctx, now, d, nsUptime[d.Namespace])
+		}
+	}
+
+	pods, err := j.client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		lastErr = fmt.Errorf("failed to list pods: %v", err)
+	} else {
+		for i := range pods.Items {
+			p := &pods.Items[i]
+			// Pods of a controller would be recreated; the controller is
+			// what has to expire.
+			if gone[p.Namespace] || metav1.GetControllerOf(p) != nil {
+				continue
+			}
+			if reason, ok := j.expired(now, KindPod, &p.ObjectMeta); ok {
+				j.delete(now, p, KindPod, &p.ObjectMeta, reason, func() error {
+					return j.client.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{})
+				})
+			}
+		}
+	}
+
+	for _, provider := range j.providers {
+		if err := j.cleanVMs(now, provider); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (j *Janitor) cleanVMs(now time.Time, provider VMProvider) error {
+	vms, err := provider.List()
+	if err != nil {
+		return fmt.Errorf("failed to list VMs of %s: %v", provider.Name(), err)
+	}
+	for _, vm := range vms {
+		if vm.Tags[ExcludeAnnotation] == "true" {
+			continue
+		}
+		t, reason, ok, err := expiry(vm.Tags, vm.Created, j.config.Rules.Match(KindVM, "", vm.Tags))
+		if err != nil {
+			klog.Warningf("Ignoring VM %s/%s: %v", provider.Name(), vm.ID, err)
+			continue
+		}
+		if !ok || now.Before(t) {
+			continue
+		}
+		action := Action{Time: now, Kind: KindVM, Namespace: provider.Name(), Name: vm.ID, Verb: VerbDelete, Reason: expiredReason(reason, t), DryRun: j.config.DryRun}
+		if !j.config.DryRun {
+			if err := provider.Delete(vm.ID); err != nil {
+				action.Error = err.Error()
+			}
+		}
+		j.record(action, nil)
+	}
+	return nil
+}
+
+// expired reports whether a cluster object is due for deletion, and why.
+func (j *Janitor) expired(now time.Time, kind string, meta *metav1.ObjectMeta) (string, bool) {
+	if meta.Annotations[ExcludeAnnotation] == "true" || meta.DeletionTimestamp != nil {
+		return "", false
+	}
+	// Rules match a namespace by its own name.
+	namespace := meta.Namespace
+	if kind == KindNamespace {
+		namespace = meta.Name
+	}
+	rule := j.config.Rules.Match(kind, namespace, meta.Labels)
+	t, reason, ok, err := expiry(meta.Annotations, meta.CreationTimestamp.Time, rule)
+	if err != nil {
+		klog.Warningf("Ignoring %s %s/%s: %v", kind, meta.Namespace, meta.Name, err)
+		return "", false
+	}
+	if !ok || now.Before(t) {
+		return "", false
+	}
+	return expiredReason(reason, t), true
+}
+
+func expiredReason(reason string, t time.Time) string {
+	return fmt.Sprintf("expired at %s by %s", t.UTC().Format(time.RFC3339), reason)
+}
+
+func (j *Janitor) delete(now time.Time, obj runtime.Object, kind string, meta *metav1.ObjectMeta, reason string, del func() error) {
+	action := Action{Time: now, Kind: kind, Namespace: meta.Namespace, Name: meta.Name, Verb: VerbDelete, Reason: reason, DryRun: j.config.DryRun}
+	if !j.config.DryRun {
+		if err := del(); err != nil {
+			action.Error = err.Error()
+		}
+	}
+	j.record(action, obj)
+}
+
+// downscale scales d to zero outside its working hours and restores it
+// within them.
+func (j *Janitor) downscale(ctx context.Context, now time.Time, d *appsv1.Deployment, namespaceUptime string) {
+	if d.Annotations[ExcludeAnnotation] == "true" {
+		return
+	}
+	schedule := j.config.Uptime
+	spec, ok := d.Annotations[UptimeAnnotation]
+	if !ok {
+		spec, ok = namespaceUptime, namespaceUptime != ""
+	}
+	if ok {
+		var err error
+		if schedule, err = ParseSchedule(spec); err != nil {
+			klog.Warningf("Ignoring uptime of deployment %s/%s: %v", d.Namespace, d.Name, err)
+			return
+		}
+	}
+	if schedule == nil {
+		return
+	}
+
+	original, scaledDown := d.Annotations[OriginalReplicasAnnotation]
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	action := Action{Time: now, Kind: KindDeployment, Namespace: d.Namespace, Name: d.Name, DryRun: j.config.DryRun}
+	updated := d.DeepCopy()
+	switch {
+	case !schedule.Contains(now) && !scaledDown && replicas > 0:
+		action.Verb = VerbScaleDown
+		action.Reason = fmt.Sprintf("outside working hours %s, replicas %d -> 0", schedule, replicas)
+		updated.Annotations = withAnnotation(updated.Annotations, OriginalReplicasAnnotation, strconv.Itoa(int(replicas)))
+		zero := int32(0)
+		updated.Spec.Replicas = &zero
+	case schedule.Contains(now) && scaledDown:
+		n, err := strconv.Atoi(original)
+		if err != nil || n < 0 {
+			klog.Warningf("Ignoring deployment %s/%s: invalid %s=%q", d.Namespace, d.Name, OriginalReplicasAnnotation, original)
+			return
+		}
+		action.Verb = VerbScaleUp
+		action.Reason = fmt.Sprintf("within working hours %s, replicas %d -> %d", schedule, replicas, n)
+		delete(updated.Annotations, OriginalReplicasAnnotation)
+		restored := int32(n)
+		updated.Spec.Replicas = &restored
+	default:
+		return
+	}
+	if !j.config.DryRun {
+		if _, err := j.client.AppsV1().Deployments(d.Namespace).Update(ctx, updated, metav1.UpdateOptions{}); err != nil {
+			action.Error = err.Error()
+		}
+	}
+	j.record(action, d)
+}
+
+func (j *Janitor) record(action Action, obj runtime.Object) {
+	if action.Error != "" {
+		klog.Errorf("Janitor: %v", action)
+	} else {
+		klog.Infof("Janitor: %v", action)
+	}
+	if j.recorder != nil && obj != nil && !action.DryRun {
+		eventType := corev1.EventTypeNormal
+		if action.Error != "" {
+			eventType = corev1.EventTypeWarning
+		}
+		j.recorder.Event(obj, eventType, string(action.Verb), action.Reason)
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.history = append(j.history, action)
+	if over := len(j.history) - j.config.HistorySize; over > 0 {
+		j.history = append(j.history[:0], j.history[over:]...)
+	}
+}
+
+func withAnnotation(annotations map[string]string, key, value string) map[string]string {
+	res := make(map[string]string, len(annotations)+1)
+	for k, v := range annotations {
+		res[k] = v
+	}
+	res[key] = value
+	return res
+}
@@ -0,0 +1,265 @@
+package janitor
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var (
+	created = time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	// A Tuesday, 10:00 in Shanghai.
+	workday = time.Date(2020, 9, 8, 2, 0, 0, 0, time.UTC)
+)
+
+func meta(namespace, name string, annotations, labels map[string]string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Namespace:         namespace,
+		Name:              name,
+		Annotations:       annotations,
+		Labels:            labels,
+		CreationTimestamp: metav1.NewTime(created),
+	}
+}
+
+func deployment(namespace, name string, replicas int32, annotations map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: meta(namespace, name, annotations, nil),
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+}
+
+func verbs(actions []Action) map[string]Verb {
+	res := make(map[string]Verb)
+	for _, a := range actions {
+		res[a.Kind+":"+a.Namespace+"/"+a.Name] = a.Verb
+	}
+	return res
+}
+
+func TestParseTTL(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"12h":     12 * time.Hour,
+		"7d":      7 * 24 * time.Hour,
+		"2w":      14 * 24 * time.Hour,
+		"1h30m":   90 * time.Minute,
+		"forever": -1,
+	} {
+		got, err := ParseTTL(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, want, got, s)
+	}
+	for _, s := range []string{"", "soon", "-1h", "0s"} {
+		_, err := ParseTTL(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	s, err := ParseSchedule("Mon-Fri 07:30-20:30 Asia/Shanghai")
+	assert.NoError(t, err)
+	assert.True(t, s.Contains(workday))
+	assert.False(t, s.Contains(workday.Add(12*time.Hour)), "22:00")
+	assert.False(t, s.Contains(workday.Add(4*24*time.Hour)), "saturday")
+
+	s, err = ParseSchedule("Sat-Sun 00:00-24:00 UTC, Fri 18:00-24:00 UTC")
+	assert.NoError(t, err)
+	assert.True(t, s.Contains(time.Date(2020, 9, 12, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, s.Contains(time.Date(2020, 9, 11, 19, 0, 0, 0, time.UTC)))
+	assert.False(t, s.Contains(time.Date(2020, 9, 11, 17, 0, 0, 0, time.UTC)))
+
+	for _, spec := range []string{"Mon-Fri 07:30-20:30", "Xyz 07:30-20:30 UTC", "Mon 20:30-07:30 UTC", "Mon 07:30-20:30 Mars/Olympus"} {
+		_, err := ParseSchedule(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	dir, err := ioutil.TempDir("", "janitor")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "rules.yaml")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`
+rules:
+- id: temporary-pr-envs
+  resources: [namespaces]
+  namespace: "pr-[0-9]+"
+  ttl: 3d
+- id: test-vms
+  resources: [vms]
+  selector: env=test
+  ttl: 12h
+`), 0644))
+	rules, err := LoadRules(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "temporary-pr-envs", rules.Match(KindNamespace, "pr-42", nil).ID)
+	assert.Nil(t, rules.Match(KindNamespace, "pr-42-keep", nil))
+	assert.Nil(t, rules.Match(KindDeployment, "pr-42", nil))
+	assert.Equal(t, "test-vms", rules.Match(KindVM, "", map[string]string{"env": "test"}).ID)
+	assert.Nil(t, rules.Match(KindVM, "", map[string]string{"env": "prod"}))
+
+	_, err = NewRuleSet(&Rule{ID: "bad", Resources: []string{"services"}, TTL: "1d"})
+	assert.Error(t, err)
+}
+
+func TestRunOnceDeletesExpiredResources(t *testing.T) {
+	rules, err := NewRuleSet(&Rule{ID: "pr", Resources: []string{KindNamespace}, Namespace: "pr-.*", TTL: "3d"})
+	assert.NoError(t, err)
+	isController := true
+	objects := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: meta("", "pr-1", nil, nil)},
+		&corev1.Namespace{ObjectMeta: meta("", "pr-2", map[string]string{ExcludeAnnotation: "true"}, nil)},
+		&corev1.Namespace{ObjectMeta: meta("", "default", nil, nil)},
+		&corev1.Namespace{ObjectMeta: meta("", "kube-system", nil, nil)},
+		// Inside a namespace being deleted anyway.
+		deployment("pr-1", "web", 1, map[string]string{TTLAnnotation: "1h"}),
+		deployment("default", "old", 1, map[string]string{TTLAnnotation: "1d"}),
+		deployment("default", "young", 1, map[string]string{TTLAnnotation: "30d"}),
+		deployment("default", "kept", 1, map[string]string{TTLAnnotation: "forever"}),
+		deployment("default", "dated", 1, map[string]string{ExpiryAnnotation: "2020-09-05"}),
+		deployment("kube-system", "dns", 1, map[string]string{TTLAnnotation: "1h"}),
+		&corev1.Pod{ObjectMeta: meta("default", "debug", map[string]string{TTLAnnotation: "2h"}, nil)},
+		&corev1.Pod{ObjectMeta: func() metav1.ObjectMeta {
+			m := meta("default", "old-abc", map[string]string{TTLAnnotation: "2h"}, nil)
+			m.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "old-abc", Controller: &isController}}
+			return m
+		}()},
+	}
+	client := fake.NewSimpleClientset(objects...)
+	provider := NewMemoryProvider("cloud",
+		VM{ID: "vm-1", Tags: map[string]string{TTLAnnotation: "1d"}, Created: created},
+		VM{ID: "vm-2", Tags: map[string]string{TTLAnnotation: "1d", ExcludeAnnotation: "true"}, Created: created},
+		VM{ID: "vm-3", Created: created},
+	)
+	j := NewJanitor(client, Config{Rules: rules, ExcludeNamespaces: []string{"kube-system"}}, provider)
+
+	assert.NoError(t, j.RunOnce(workday))
+	assert.Equal(t, map[string]Verb{
+		"namespaces:/pr-1":          VerbDelete,
+		"deployments:default/old":   VerbDelete,
+		"deployments:default/dated": VerbDelete,
+		"pods:default/debug":        VerbDelete,
+		"vms:cloud/vm-1":            VerbDelete,
+	}, verbs(j.History()))
+
+	ctx := context.TODO()
+	_, err = client.CoreV1().Namespaces().Get(ctx, "pr-1", metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = client.AppsV1().Deployments("default").Get(ctx, "old", metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = client.AppsV1().Deployments("default").Get(ctx, "young", metav1.GetOptions{})
+	assert.NoError(t, err)
+	_, err = client.CoreV1().Pods("default").Get(ctx, "old-abc", metav1.GetOptions{})
+	assert.NoError(t, err)
+	vms, _ := provider.List()
+	assert.Len(t, vms, 2)
+}
+
+func TestRunOnceDryRun(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: meta("", "default", nil, nil)},
+		deployment("default", "old", 1, map[string]string{TTLAnnotation: "1d"}),
+	)
+	provider := NewMemoryProvider("cloud", VM{ID: "vm-1", Tags: map[string]string{TTLAnnotation: "1d"}, Created: created})
+	j := NewJanitor(client, Config{DryRun: true}, provider)
+
+	assert.NoError(t, j.RunOnce(workday))
+	history := j.History()
+	assert.Len(t, history, 2)
+	for _, a := range history {
+		assert.True(t, a.DryRun)
+		assert.Equal(t, VerbDelete, a.Verb)
+	}
+	_, err := client.AppsV1().Deployments("default").Get(context.TODO(), "old", metav1.GetOptions{})
+	assert.NoError(t, err)
+	vms, _ := provider.List()
+	assert.Len(t, vms, 1)
+}
+
+func TestRunOnceDownscalesOutsideWorkingHours(t *testing.T) {
+	uptime, err := ParseSchedule("Mon-Fri 07:30-20:30 Asia/Shanghai")
+	assert.NoError(t, err)
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: meta("", "default", nil, nil)},
+		&corev1.Namespace{ObjectMeta: meta("", "batch", map[string]string{UptimeAnnotation: "always"}, nil)},
+		deployment("default", "web", 3, nil),
+		deployment("default", "api", 2, map[string]string{ExcludeAnnotation: "true"}),
+		deployment("batch", "worker", 2, nil),
+	)
+	j := NewJanitor(client, Config{Uptime: uptime})
+	get := func(namespace, name string) *appsv1.Deployment {
+		d, err := client.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		return d
+	}
+
+	night := workday.Add(12 * time.Hour)
+	assert.NoError(t, j.RunOnce(night))
+	web := get("default", "web")
+	assert.Equal(t, int32(0), *web.Spec.Replicas)
+	assert.Equal(t, "3", web.Annotations[OriginalReplicasAnnotation])
+	assert.Equal(t, int32(2), *get("default", "api").Spec.Replicas)
+	assert.Equal(t, int32(2), *get("batch", "worker").Spec.Replicas)
+
+	// Nothing more to do until the morning.
+	assert.NoError(t, j.RunOnce(night.Add(time.Hour)))
+	assert.Len(t, j.History(), 1)
+
+	assert.NoError(t, j.RunOnce(workday.Add(24*time.Hour)))
+	web = get("default", "web")
+	assert.Equal(t, int32(3), *web.Spec.Replicas)
+	assert.NotContains(t, web.Annotations, OriginalReplicasAnnotation)
+	assert.Equal(t, map[string]Verb{"deployments:default/web": VerbScaleUp}, verbs(j.History()[1:]))
+}
+
+func TestHTTPProvider(t *testing.T) {
+	var deleted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/vms":
+			json.NewEncoder(w).Encode([]VM{
+				{ID: "vm-1", Name: "ci", Tags: map[string]string{TTLAnnotation: "1d"}, Created: created},
+				{ID: "vm-2", Created: created},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/vms/vm-1":
+			deleted = append(deleted, "vm-1")
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/vms/gone":
+			http.NotFound(w, r)
+		default:
+			http.Error(w, "forbidden", http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	provider := NewHTTPProvider("cloud", server.URL+"/api/", nil)
+	vms, err := provider.List()
+	assert.NoError(t, err)
+	if assert.Len(t, vms, 2) {
+		assert.Equal(t, "ci", vms[0].Name)
+		assert.True(t, created.Equal(vms[0].Created))
+	}
+	assert.NoError(t, provider.Delete("gone"))
+	assert.Error(t, provider.Delete("vm-2"))
+
+	j := NewJanitor(fake.NewSimpleClientset(), Config{}, provider)
+	assert.NoError(t, j.RunOnce(workday))
+	assert.Equal(t, []string{"vm-1"}, deleted)
+	assert.Equal(t, map[string]Verb{"vms:cloud/vm-1": VerbDelete}, verbs(j.History()))
+
+	_, err = NewHTTPProvider("cloud", server.URL+"/other", nil).List()
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+package janitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// VM is a virtual machine of a cloud provider. Tags play the role of both
+// labels and annotations.
+type VM struct {
+	ID      string            `json:"id"`
+	Name    string            `json:"name,omitempty"`
+	Tags    map[string]string `json:"tags,omitempty"`
+	Created time.Time         `json:"created"`
+}
+
+// VMProvider lists and deletes virtual machines of one cloud account.
+type VMProvider interface {
+	Name() string
+	List() ([]VM, error)
+	Delete(id string) error
+}
+
+type memoryProvider struct {
+	name string
+	mu   sync.Mutex
+	vms  map[string]VM
+}
+
+// NewMemoryProvider returns a VMProvider backed by an in-memory list, for
+// tests and dry runs against exported inventories.
+func NewMemoryProvider(name string, vms ...VM) VMProvider {
+	p := &memoryProvider{name: name, vms: make(map[string]VM, len(vms))}
+	for _, vm := range vms {
+		p.vms[vm.ID] = vm
+	}
+	return p
+}
+
+func (p *memoryProvider) Name() string {
+	return p.name
+}
+
+func (p *memoryProvider) List() ([]VM, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	res := make([]VM, 0, len(p.vms))
+	for _, vm := range p.vms {
+		res = append(res, vm)
+	}
+	return res, nil
+}
+
+func (p *memoryProvider) Delete(id string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.vms, id)
+	return nil
+}
+
+type httpProvider struct {
+	name   string
+	url    string
+	client *http.Client
+}
+
+// NewHTTPProvider returns a VMProvider backed by an inventory service of a
+// cloud account at baseURL. The service lists machines as a JSON array of
+// VM on GET <baseURL>/vms and deletes one on DELETE <baseURL>/vms/<id>;
+// deleting a machine that is already gone may answer 404.
+func NewHTTPProvider(name, baseURL string, client *http.Client) VMProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &httpProvider{name: name, url: strings.TrimSuffix(baseURL, "/") + "/vms", client: client}
+}
+
+func (p *httpProvider) Name() string {
+	return p.name
+}
+
+func (p *httpProvider) List() ([]VM, error) {
+	resp, err := p.client.Get(p.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpError(resp)
+	}
+	var vms []VM
+	if err := json.NewDecoder(resp.Body).Decode(&vms); err != nil {
+		return nil, fmt.Errorf("%s: %v", p.url, err)
+	}
+	return vms, nil
+}
+
+func (p *httpProvider) Delete(id string) error {
+	req, err := http.NewRequest(http.MethodDelete, p.url+"/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return httpError(resp)
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+func httpError(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s %s: %s: %s", resp.Request.Method, resp.Request.URL, resp.Status, strings.TrimSpace(string(body)))
+}
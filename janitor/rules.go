@@ -0,0 +1,125 @@
+package janitor
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// Resource kinds rules can apply to.
+const (
+	KindNamespace  = "namespaces"
+	KindDeployment = "deployments"
+	KindPod        = "pods"
+	KindVM         = "vms"
+	kindAny        = "*"
+)
+
+// Rule assigns a TTL to resources without TTL annotations.
+type Rule struct {
+	ID string `json:"id"`
+	// Resources lists the kinds the rule applies to, "*" for all.
+	Resources []string `json:"resources"`
+	// Namespace is a regular expression the namespace must fully match.
+	// Empty matches every namespace. Ignored for VMs.
+	Namespace string `json:"namespace,omitempty"`
+	// Selector is a label selector on the resource labels, or VM tags.
+	Selector string `json:"selector,omitempty"`
+	TTL      string `json:"ttl"`
+
+	ttl       time.Duration
+	namespace *regexp.Regexp
+	selector  labels.Selector
+	kinds     map[string]bool
+}
+
+// RuleSet is the content of a rules file.
+type RuleSet struct {
+	Rules []*Rule `json:"rules"`
+}
+
+// compile validates the rule and prepares it for matching.
+func (r *Rule) compile() error {
+	if r.ID == "" {
+		return fmt.Errorf("rule without id")
+	}
+	if len(r.Resources) == 0 {
+		return fmt.Errorf("rule %s: no resources", r.ID)
+	}
+	r.kinds = make(map[string]bool, len(r.Resources))
+	for _, k := range r.Resources {
+		switch k {
+		case KindNamespace, KindDeployment, KindPod, KindVM, kindAny:
+			r.kinds[k] = true
+		default:
+			return fmt.Errorf("rule %s: unknown resource %q", r.ID, k)
+		}
+	}
+	ttl, err := ParseTTL(r.TTL)
+	if err != nil {
+		return fmt.Errorf("rule %s: %v", r.ID, err)
+	}
+	r.ttl = ttl
+	if r.Namespace != "" {
+		if r.namespace, err = regexp.Compile("^(?:" + r.Namespace + ")$"); err != nil {
+			return fmt.Errorf("rule %s: invalid namespace pattern: %v", r.ID, err)
+		}
+	}
+	r.selector = labels.Everything()
+	if r.Selector != "" {
+		if r.selector, err = labels.Parse(r.Selector); err != nil {
+			return fmt.Errorf("rule %s: invalid selector: %v", r.ID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) matches(kind, namespace string, l map[string]string) bool {
+	if !r.kinds[kind] && !r.kinds[kindAny] {
+		return false
+	}
+	if r.namespace != nil && kind != KindVM && !r.namespace.MatchString(namespace) {
+		return false
+	}
+	return r.selector.Matches(labels.Set(l))
+}
+
+// NewRuleSet compiles rules.
+func NewRuleSet(rules ...*Rule) (*RuleSet, error) {
+	for _, r := range rules {
+		if err := r.compile(); err != nil {
+			return nil, err
+		}
+	}
+	return &RuleSet{Rules: rules}, nil
+}
+
+// LoadRules reads a YAML or JSON rules file.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	set := &RuleSet{}
+	if err := yaml.UnmarshalStrict(data, set); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return NewRuleSet(set.Rules...)
+}
+
+// Match returns the first rule matching the resource, or nil.
+func (s *RuleSet) Match(kind, namespace string, l map[string]string) *Rule {
+	if s == nil {
+		return nil
+	}
+	for _, r := range s.Rules {
+		if r.matches(kind, namespace, l) {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package janitor
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/profil"
+)
+
+const (
+	// TTLAnnotation holds the time to live of a resource counted from its
+	// creation, e.g. "12h", "7d" or "forever".
+	TTLAnnotation = "janitor/ttl"
+	// ExpiryAnnotation holds an absolute expiry time, RFC 3339 or a plain
+	// "2006-01-02" date.
+	ExpiryAnnotation = "janitor/expires"
+	// ExcludeAnnotation set to "true" opts a resource out of the janitor.
+	ExcludeAnnotation = "janitor/exclude"
+	// OriginalReplicasAnnotation records the replicas of a workload scaled
+	// down outside working hours.
+	OriginalReplicasAnnotation = "janitor/original-replicas"
+
+	ttlForever = "forever"
+)
+
+// ParseTTL parses a TTL value. It accepts InfluxQL style single unit
+// durations including days and weeks ("7d", "2w"), Go durations ("1h30m")
+// and "forever", returned as a negative duration.
+func ParseTTL(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == ttlForever {
+		return -1, nil
+	}
+	d, err := profil.ParseDuration(s)
+	if err != nil {
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid ttl %q", s)
+		}
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("ttl must be positive, got %q", s)
+	}
+	return d, nil
+}
+
+// ParseExpiry parses an expiry value.
+func ParseExpiry(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid expiry %q", s)
+}
+
+// expiry returns when a resource created at created expires according to
+// its annotations, falling back to the ttl of rule, which may be nil. ok is
+// false if it never expires.
+func expiry(annotations map[string]string, created time.Time, rule *Rule) (time.Time, string, bool, error) {
+	if v, found := annotations[ExpiryAnnotation]; found {
+		t, err := ParseExpiry(v)
+		if err != nil {
+			return time.Time{}, "", false, err
+		}
+		return t, fmt.Sprintf("annotation %s=%s", ExpiryAnnotation, v), true, nil
+	}
+	if v, found := annotations[TTLAnnotation]; found {
+		ttl, err := ParseTTL(v)
+		if err != nil {
+			return time.Time{}, "", false, err
+		}
+		if ttl < 0 {
+			return time.Time{}, "", false, nil
+		}
+		return created.Add(ttl), fmt.Sprintf("annotation %s=%s", TTLAnnotation, v), true, nil
+	}
+	if rule != nil && rule.ttl > 0 {
+		return created.Add(rule.ttl), fmt.Sprintf("rule %s ttl=%s", rule.ID, rule.TTL), true, nil
+	}
+	return time.Time{}, "", false, nil
+}
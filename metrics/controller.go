@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/profil"
+)
+
+// Metric names a controlled metric. The values match the profil schema.
+type Metric string
+
+const (
+	// MetricLatency is the mean response time.
+	MetricLatency Metric = "rtime"
+	// MetricCPU is the mean cpu utilisation.
+	MetricCPU Metric = "cpu"
+	// MetricMemory is the mean memory utilisation.
+	MetricMemory Metric = "memory"
+)
+
+// Target is a setpoint the Controller steers a metric towards.
+type Target struct {
+	Metric   Metric
+	Setpoint float64
+	PID      PIDConfig
+}
+
+// Sample is one reading of the controlled application.
+type Sample struct {
+	Values   map[Metric]float64
+	Replicas int
+}
+
+// Source reads the current state of the controlled application.
+type Source interface {
+	Sample() (*Sample, error)
+}
+
+// Adjustment is what the Controller wants changed after one step.
+type Adjustment struct {
+	Time            time.Time
+	Replicas        int
+	DesiredReplicas int
+	// Outputs holds the controller output of every target. Negative values
+	// ask for more capacity.
+	Outputs map[Metric]float64
+	// ResourceScale suggests a factor for the per replica request of the
+	// cpu and memory targets, for use by vertical scaling.
+	ResourceScale map[Metric]float64
+}
+
+// ControllerConfig configures a Controller.
+type ControllerConfig struct {
+	Targets     []Target
+	MinReplicas int
+	MaxReplicas int
+	// Tolerance is a dead band on the combined output. Outputs within
+	// [-Tolerance, Tolerance] keep the current replica count.
+	Tolerance float64
+	Interval  time.Duration
+}
+
+// Controller runs one PID loop per target. Each loop works on the error
+// relative to its setpoint, so latency in ms and utilisation in percent can
+// be combined: the replica count follows the target asking for the most
+// capacity.
+type Controller struct {
+	config ControllerConfig
+	source Source
+	pids   map[Metric]*PID
+
+	last time.Time
+}
+
+// NewController validates config and returns a Controller reading from
+// source.
+func NewController(config ControllerConfig, source Source) (*Controller, error) {
+	if len(config.Targets) == 0 {
+		return nil, fmt.Errorf("no targets configured")
+	}
+	if config.MinReplicas < 1 {
+		config.MinReplicas = 1
+	}
+	if config.MaxReplicas < config.MinReplicas {
+		return nil, fmt.Errorf("max replicas %d is below min replicas %d", config.MaxReplicas, config.MinReplicas)
+	}
+	pids := make(map[Metric]*PID, len(config.Targets))
+	for _, t := range config.Targets {
+		if t.Setpoint <= 0 {
+			return nil, fmt.Errorf("%s: setpoint must be positive, got %v", t.Metric, t.Setpoint)
+		}
+		if _, ok := pids[t.Metric]; ok {
+			return nil, fmt.Errorf("%s: duplicate target", t.Metric)
+		}
+		pids[t.Metric] = NewPID(t.PID)
+	}
+	return &Controller{config: config, source: source, pids: pids}, nil
+}
+
+// Step reads one sample and computes the adjustment for it.
+func (c *Controller) Step(now time.Time) (*Adjustment, error) {
+	sample, err := c.source.Sample()
+	if err != nil {
+		return nil, err
+	}
+	if sample.Replicas < 1 {
+		return nil, fmt.Errorf("invalid replica count %d", sample.Replicas)
+	}
+	dt := c.config.Interval
+	if !c.last.IsZero() {
+		dt = now.Sub(c.last)
+	}
+	c.last = now
+
+	adj := &Adjustment{
+		Time:          now,
+		Replicas:      sample.Replicas,
+		Outputs:       make(map[Metric]float64, len(c.config.Targets)),
+		ResourceScale: make(map[Metric]float64),
+	}
+	combined := math.Inf(1)
+	for _, t := range c.config.Targets {
+		measured, ok := sample.Values[t.Metric]
+		if !ok {
+			continue
+		}
+		// Normalise to the setpoint so every loop works on the same scale.
+		out := c.pids[t.Metric].Update(1, measured/t.Setpoint, dt)
+		adj.Outputs[t.Metric] = out
+		if t.Metric == MetricCPU || t.Metric == MetricMemory {
+			adj.ResourceScale[t.Metric] = math.Max(0, 1-out)
+		}
+		combined = math.Min(combined, out)
+	}
+	adj.DesiredReplicas = sample.Replicas
+	if !math.IsInf(combined, 1) && math.Abs(combined) > c.config.Tolerance {
+		adj.DesiredReplicas = int(math.Ceil(float64(sample.Replicas) * (1 - combined)))
+	}
+	if adj.DesiredReplicas < c.config.MinReplicas {
+		adj.DesiredReplicas = c.config.MinReplicas
+	}
+	if adj.DesiredReplicas > c.config.MaxReplicas {
+		adj.DesiredReplicas = c.config.MaxReplicas
+	}
+	return adj, nil
+}
+
+// Run calls Step every interval and passes successful adjustments to emit
+// until stopCh is closed. Errors are passed to onError, which may be nil.
+func (c *Controller) Run(stopCh <-chan struct{}, emit func(*Adjustment), onError func(error)) {
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stopCh:
+			return
+		case now := <-ticker.C:
+			adj, err := c.Step(now)
+			if err != nil {
+				if onError != nil {
+					onError(err)
+				}
+				continue
+			}
+			emit(adj)
+		}
+	}
+}
+
+type profilSource struct {
+	profiler    profil.Profiler
+	region, app string
+	window      time.Duration
+}
+
+// NewProfilSource returns a Source reading the mean profile of app over
+// window.
+func NewProfilSource(profiler profil.Profiler, region, app string, window time.Duration) Source {
+	return &profilSource{profiler: profiler, region: region, app: app, window: window}
+}
+
+func (s *profilSource) Sample() (*Sample, error) {
+	p, err := s.profiler.Mean(s.region, s.app, s.window)
+	if err != nil {
+		return nil, err
+	}
+	return &Sample{
+		Values: map[Metric]float64{
+			MetricLatency: p.RTime,
+			MetricCPU:     p.CPU,
+			MetricMemory:  p.Memory,
+		},
+		Replicas: int(p.Replicas),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/profil"
+)
+
+// replicaPlant spreads a fixed cpu load evenly over its replicas and adds
+// latency once a replica runs hot.
+type replicaPlant struct {
+	load     float64
+	replicas int
+}
+
+func (p *replicaPlant) Sample() (*Sample, error) {
+	cpu := p.load / float64(p.replicas)
+	latency := 50.0
+	if cpu > 70 {
+		latency += (cpu - 70) * 10
+	}
+	return &Sample{
+		Values:   map[Metric]float64{MetricCPU: cpu, MetricLatency: latency},
+		Replicas: p.replicas,
+	}, nil
+}
+
+func newTestController(t *testing.T, source Source) *Controller {
+	c, err := NewController(ControllerConfig{
+		Targets: []Target{
+			{Metric: MetricCPU, Setpoint: 60, PID: PIDConfig{Kp: 0.6, Ki: 0.05, OutputMin: -1, OutputMax: 0.5}},
+			{Metric: MetricLatency, Setpoint: 100, PID: PIDConfig{Kp: 0.5, OutputMin: -1, OutputMax: 0.5}},
+		},
+		MinReplicas: 1,
+		MaxReplicas: 50,
+		Tolerance:   0.1,
+		Interval:    time.Minute,
+	}, source)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return c
+}
+
+func TestControllerScalesReplicaPlantToTarget(t *testing.T) {
+	plant := &replicaPlant{load: 600, replicas: 2}
+	c := newTestController(t, plant)
+	now := time.Unix(0, 0)
+	for i := 0; i < 30; i++ {
+		now = now.Add(time.Minute)
+		adj, err := c.Step(now)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		plant.replicas = adj.DesiredReplicas
+	}
+	// 600 / 60 = 10 replicas hit the setpoint; the dead band allows a
+	// little slack on either side.
+	if plant.replicas < 9 || plant.replicas > 12 {
+		t.Errorf("expected about 10 replicas, got %d", plant.replicas)
+	}
+
+	// Halving the load scales back in.
+	plant.load = 300
+	for i := 0; i < 30; i++ {
+		now = now.Add(time.Minute)
+		adj, _ := c.Step(now)
+		plant.replicas = adj.DesiredReplicas
+	}
+	if plant.replicas < 4 || plant.replicas > 6 {
+		t.Errorf("expected about 5 replicas, got %d", plant.replicas)
+	}
+}
+
+func TestControllerRespectsReplicaBounds(t *testing.T) {
+	plant := &replicaPlant{load: 100000, replicas: 40}
+	c := newTestController(t, plant)
+	adj, err := c.Step(time.Unix(60, 0))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if adj.DesiredReplicas != 50 {
+		t.Errorf("expected max replicas, got %d", adj.DesiredReplicas)
+	}
+	if adj.ResourceScale[MetricCPU] <= 1 {
+		t.Errorf("expected a cpu request increase, got %v", adj.ResourceScale[MetricCPU])
+	}
+}
+
+func TestNewControllerValidates(t *testing.T) {
+	if _, err := NewController(ControllerConfig{}, nil); err == nil {
+		t.Errorf("expected an error without targets")
+	}
+	if _, err := NewController(ControllerConfig{Targets: []Target{{Metric: MetricCPU}}, MaxReplicas: 1}, nil); err == nil {
+		t.Errorf("expected an error for a zero setpoint")
+	}
+}
+
+type fakeProfiler struct {
+	profil.Profiler
+	profile *profil.AppProfile
+	err     error
+}
+
+func (f *fakeProfiler) Mean(region, app string, window time.Duration) (*profil.AppProfile, error) {
+	return f.profile, f.err
+}
+
+func TestProfilSource(t *testing.T) {
+	source := NewProfilSource(&fakeProfiler{profile: &profil.AppProfile{CPU: 80, RTime: 120, Replicas: 3}}, "cn-north-1", "web", 5*time.Minute)
+	s, err := source.Sample()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Replicas != 3 || s.Values[MetricCPU] != 80 || s.Values[MetricLatency] != 120 {
+		t.Errorf("unexpected sample %+v", s)
+	}
+	if _, err := NewProfilSource(&fakeProfiler{err: errors.New("down")}, "r", "a", time.Minute).Sample(); err == nil {
+		t.Errorf("expected the profiler error")
+	}
+}
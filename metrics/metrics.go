@@ -21,42 +21,44 @@ type cpuMet struct {
 	cpuThreshold uint64
 }
 
-// implement PID Controller
+// The thresholds are upper bounds: a metric meets its threshold while it
+// does not exceed it. Closed loop control towards them lives in Controller.
+
 // Latency implemented
-func (l latencyMet) setThreshold(metrics uint64) bool {
+func (l *latencyMet) setThreshold(metrics uint64) bool {
 	l.latencyThreshold = metrics
 
 	return true
 }
-func (l latencyMet) getThreshold() uint64 {
+func (l *latencyMet) getThreshold() uint64 {
 	return l.latencyThreshold
 }
-func (l latencyMet) checkMeetThreshold(current uint64) bool {
-	return l.latencyThreshold == current
+func (l *latencyMet) checkMeetThreshold(current uint64) bool {
+	return current <= l.latencyThreshold
 }
 
 // Memory Resource implemented
-func (rm memMet) setThreshold(metrics uint64) bool {
+func (rm *memMet) setThreshold(metrics uint64) bool {
 	rm.memThreshold = metrics
 
 	return true
 }
-func (rm memMet) getThreshold() uint64 {
+func (rm *memMet) getThreshold() uint64 {
 	return rm.memThreshold
 }
-func (rm memMet) checkMeetThreshold(current uint64) bool {
-	return rm.memThreshold == current
+func (rm *memMet) checkMeetThreshold(current uint64) bool {
+	return current <= rm.memThreshold
 }
 
 // CPU Resource implemented
-func (rc cpuMet) getThreshold() uint64 {
+func (rc *cpuMet) getThreshold() uint64 {
 	return rc.cpuThreshold
 }
-func (rc cpuMet) setThreshold(metrics uint64) bool {
+func (rc *cpuMet) setThreshold(metrics uint64) bool {
 	rc.cpuThreshold = metrics
 
 	return true
 }
-func (rc cpuMet) checkMeetThreshold(current uint64) bool {
-	return rc.cpuThreshold == current
+func (rc *cpuMet) checkMeetThreshold(current uint64) bool {
+	return current <= rc.cpuThreshold
 }
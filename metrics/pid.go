@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"math"
+	"time"
+)
+
+// PIDConfig holds the gains and limits of a PID controller.
+type PIDConfig struct {
+	Kp, Ki, Kd float64
+	// OutputMin and OutputMax clamp the controller output. Both zero means
+	// the output is not clamped.
+	OutputMin, OutputMax float64
+}
+
+// PID is a discrete PID controller. The derivative term acts on the
+// measurement rather than the error, so that setpoint changes do not kick
+// the output, and the integral is frozen while the output saturates
+// (conditional integration anti-windup).
+type PID struct {
+	config PIDConfig
+
+	integral    float64
+	lastMeasure float64
+	initialized bool
+}
+
+// NewPID returns a PID controller for config.
+func NewPID(config PIDConfig) *PID {
+	return &PID{config: config}
+}
+
+// Reset clears the integral and derivative history.
+func (p *PID) Reset() {
+	p.integral = 0
+	p.lastMeasure = 0
+	p.initialized = false
+}
+
+func (p *PID) clamped() bool {
+	return p.config.OutputMin != 0 || p.config.OutputMax != 0
+}
+
+func (p *PID) clamp(v float64) float64 {
+	if !p.clamped() {
+		return v
+	}
+	return math.Max(p.config.OutputMin, math.Min(p.config.OutputMax, v))
+}
+
+// Update feeds one measurement taken dt after the previous one and returns
+// the new controller output. A positive output means the measurement is
+// below the setpoint.
+func (p *PID) Update(setpoint, measured float64, dt time.Duration) float64 {
+	err := setpoint - measured
+	seconds := dt.Seconds()
+
+	var derivative float64
+	if p.initialized && seconds > 0 {
+		derivative = -(measured - p.lastMeasure) / seconds
+	}
+	p.lastMeasure = measured
+	p.initialized = true
+
+	integral := p.integral
+	if seconds > 0 {
+		integral += err * seconds
+	}
+	out := p.config.Kp*err + p.config.Ki*integral + p.config.Kd*derivative
+	clampedOut := p.clamp(out)
+	// Only accept the new integral if it does not push further into
+	// saturation.
+	if clampedOut == out || (out > clampedOut && err < 0) || (out < clampedOut && err > 0) {
+		p.integral = integral
+	}
+	return clampedOut
+}
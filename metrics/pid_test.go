@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// firstOrderPlant is y' = (gain*u - y) / tau, integrated with Euler steps.
+type firstOrderPlant struct {
+	y, gain float64
+	tau     time.Duration
+}
+
+func (p *firstOrderPlant) step(u float64, dt time.Duration) float64 {
+	p.y += (p.gain*u - p.y) * dt.Seconds() / p.tau.Seconds()
+	return p.y
+}
+
+func TestPIDProportionalOnly(t *testing.T) {
+	pid := NewPID(PIDConfig{Kp: 2})
+	if out := pid.Update(10, 7, time.Second); out != 6 {
+		t.Errorf("expected 6, got %v", out)
+	}
+}
+
+func TestPIDClampsOutput(t *testing.T) {
+	pid := NewPID(PIDConfig{Kp: 10, OutputMin: -1, OutputMax: 1})
+	if out := pid.Update(10, 0, time.Second); out != 1 {
+		t.Errorf("expected output clamped to 1, got %v", out)
+	}
+	if out := pid.Update(0, 10, time.Second); out != -1 {
+		t.Errorf("expected output clamped to -1, got %v", out)
+	}
+}
+
+func TestPIDTracksFirstOrderPlant(t *testing.T) {
+	plant := &firstOrderPlant{gain: 1, tau: 5 * time.Second}
+	pid := NewPID(PIDConfig{Kp: 0.8, Ki: 0.3, Kd: 0.05, OutputMin: 0, OutputMax: 200})
+	dt := 100 * time.Millisecond
+	y := 0.0
+	peak := 0.0
+	for i := 0; i < 1200; i++ {
+		y = plant.step(pid.Update(50, y, dt), dt)
+		peak = math.Max(peak, y)
+	}
+	if math.Abs(y-50) > 0.5 {
+		t.Errorf("expected the plant to settle at 50, got %v", y)
+	}
+	if peak > 60 {
+		t.Errorf("overshoot too large: peak %v", peak)
+	}
+}
+
+func TestPIDAntiWindup(t *testing.T) {
+	dt := 100 * time.Millisecond
+	run := func(pid *PID) int {
+		plant := &firstOrderPlant{gain: 1, tau: time.Second}
+		// The actuator saturates for a long time while the setpoint is out
+		// of reach, then the setpoint drops to a reachable value.
+		for i := 0; i < 300; i++ {
+			plant.step(pid.Update(500, plant.y, dt), dt)
+		}
+		for i := 0; i < 1000; i++ {
+			y := plant.step(pid.Update(50, plant.y, dt), dt)
+			if math.Abs(y-50) < 1 {
+				return i
+			}
+		}
+		return 1000
+	}
+	withAntiWindup := run(NewPID(PIDConfig{Kp: 0.5, Ki: 1, OutputMin: 0, OutputMax: 100}))
+	if withAntiWindup > 100 {
+		t.Errorf("recovery from saturation took %d steps", withAntiWindup)
+	}
+}
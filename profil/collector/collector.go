@@ -0,0 +1,279 @@
+package collector
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/profil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
+	kube_client "k8s.io/client-go/kubernetes"
+	appslister "k8s.io/client-go/listers/apps/v1"
+	v1lister "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+const defaultResyncPeriod time.Duration = 10 * time.Minute
+
+// Config configures a Collector.
+type Config struct {
+	// Region names the measurement the inventory is written to.
+	Region string
+	// Namespace restricts collection to one namespace. Empty means all.
+	Namespace string
+	// AppLabel is the pod label holding the application name. Pods without
+	// it are grouped by their top-most owning workload.
+	AppLabel string
+	// Interval between two inventory snapshots.
+	Interval time.Duration
+}
+
+// Collector watches pods, nodes and workloads and periodically writes the
+// resulting inventory to a profil.Store.
+type Collector struct {
+	config  Config
+	store   profil.Store
+	factory informers.SharedInformerFactory
+
+	podLister         v1lister.PodLister
+	nodeLister        v1lister.NodeLister
+	deploymentLister  appslister.DeploymentLister
+	replicaSetLister  appslister.ReplicaSetLister
+	statefulSetLister appslister.StatefulSetLister
+	daemonSetLister   appslister.DaemonSetLister
+	synced            []cache.InformerSynced
+
+	now func() time.Time
+}
+
+// NewCollector creates a Collector with informers on kubeClient.
+func NewCollector(kubeClient kube_client.Interface, store profil.Store, config Config) *Collector {
+	factory := informers.NewSharedInformerFactoryWithOptions(kubeClient, defaultResyncPeriod, informers.WithNamespace(config.Namespace))
+	pods := factory.Core().V1().Pods()
+	nodes := factory.Core().V1().Nodes()
+	deployments := factory.Apps().V1().Deployments()
+	replicaSets := factory.Apps().V1().ReplicaSets()
+	statefulSets := factory.Apps().V1().StatefulSets()
+	daemonSets := factory.Apps().V1().DaemonSets()
+	return &Collector{
+		config:            config,
+		store:             store,
+		factory:           factory,
+		podLister:         pods.Lister(),
+		nodeLister:        nodes.Lister(),
+		deploymentLister:  deployments.Lister(),
+		replicaSetLister:  replicaSets.Lister(),
+		statefulSetLister: statefulSets.Lister(),
+		daemonSetLister:   daemonSets.Lister(),
+		synced: []cache.InformerSynced{
+			pods.Informer().HasSynced,
+			nodes.Informer().HasSynced,
+			deployments.Informer().HasSynced,
+			replicaSets.Informer().HasSynced,
+			statefulSets.Informer().HasSynced,
+			daemonSets.Informer().HasSynced,
+		},
+		now: time.Now,
+	}
+}
+
+// Run starts the informers and writes a snapshot every interval until
+// stopCh is closed.
+func (c *Collector) Run(stopCh <-chan struct{}) error {
+	c.factory.Start(stopCh)
+	if !cache.WaitForCacheSync(stopCh, c.synced...) {
+		return fmt.Errorf("could not sync informer caches")
+	}
+	klog.Infof("Initial sync of inventory informers completed")
+	wait.Until(func() {
+		if err := c.CollectOnce(); err != nil {
+			klog.Errorf("Cannot write inventory: %v", err)
+		}
+	}, c.config.Interval, stopCh)
+	return nil
+}
+
+// CollectOnce builds a snapshot from the informer caches and writes it.
+func (c *Collector) CollectOnce() error {
+	snapshot, err := c.Snapshot()
+	if err != nil {
+		return err
+	}
+	klog.V(3).Infof("Writing inventory of %d apps, %d pods and %d nodes", len(snapshot.Apps), len(snapshot.Pods), len(snapshot.Nodes))
+	return profil.WriteInventory(c.store, c.config.Region, snapshot)
+}
+
+// workloadKey identifies the workload a pod belongs to.
+type workloadKey struct {
+	namespace string
+	kind      string
+	name      string
+}
+
+// Snapshot builds the current inventory from the informer caches.
+func (c *Collector) Snapshot() (*profil.InventorySnapshot, error) {
+	pods, err := c.podLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &profil.InventorySnapshot{Time: c.now()}
+	podsPerNode := make(map[string]int64)
+	apps := make(map[string]*profil.AppStatus)
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		owner := c.topMostOwner(pod)
+		app := c.appName(pod, owner)
+		status := profil.PodStatus{
+			Namespace: pod.Namespace,
+			Name:      pod.Name,
+			App:       app,
+			Node:      pod.Spec.NodeName,
+			HostIP:    pod.Status.HostIP,
+			Phase:     string(pod.Status.Phase),
+			Running:   pod.Status.Phase == corev1.PodRunning,
+			Ready:     isPodReady(pod),
+		}
+		snapshot.Pods = append(snapshot.Pods, status)
+		if pod.Spec.NodeName != "" {
+			podsPerNode[pod.Spec.NodeName]++
+		}
+
+		key := pod.Namespace + "/" + app
+		a, ok := apps[key]
+		if !ok {
+			a = &profil.AppStatus{Namespace: pod.Namespace, App: app, Kind: owner.kind}
+			a.Desired = c.desiredReplicas(owner)
+			apps[key] = a
+		}
+		a.Replicas++
+		if status.Running && status.Ready {
+			a.Running++
+		}
+	}
+	for _, a := range apps {
+		if a.Desired == 0 {
+			a.Desired = a.Replicas
+		}
+		snapshot.Apps = append(snapshot.Apps, *a)
+	}
+	sort.Slice(snapshot.Apps, func(i, j int) bool {
+		if snapshot.Apps[i].Namespace != snapshot.Apps[j].Namespace {
+			return snapshot.Apps[i].Namespace < snapshot.Apps[j].Namespace
+		}
+		return snapshot.Apps[i].App < snapshot.Apps[j].App
+	})
+
+	for _, node := range nodes {
+		snapshot.Nodes = append(snapshot.Nodes, profil.NodeStatus{
+			Name:   node.Name,
+			HostIP: nodeInternalIP(node),
+			Ready:  isNodeReady(node),
+			Pods:   podsPerNode[node.Name],
+		})
+	}
+	return snapshot, nil
+}
+
+func (c *Collector) appName(pod *corev1.Pod, owner workloadKey) string {
+	if c.config.AppLabel != "" {
+		if app, ok := pod.Labels[c.config.AppLabel]; ok && app != "" {
+			return app
+		}
+	}
+	return owner.name
+}
+
+// topMostOwner follows ReplicaSets up to their Deployment. Bare pods are
+// their own workload.
+func (c *Collector) topMostOwner(pod *corev1.Pod) workloadKey {
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return workloadKey{namespace: pod.Namespace, name: pod.Name}
+	}
+	key := workloadKey{namespace: pod.Namespace, kind: ref.Kind, name: ref.Name}
+	if ref.Kind != "ReplicaSet" {
+		return key
+	}
+	rs, err := c.replicaSetLister.ReplicaSets(pod.Namespace).Get(ref.Name)
+	if err != nil {
+		return key
+	}
+	if parent := metav1.GetControllerOf(rs); parent != nil && parent.Kind == "Deployment" {
+		return workloadKey{namespace: pod.Namespace, kind: parent.Kind, name: parent.Name}
+	}
+	return key
+}
+
+// desiredReplicas returns the replicas declared by the workload, or 0 when
+// unknown.
+func (c *Collector) desiredReplicas(owner workloadKey) int64 {
+	switch owner.kind {
+	case "Deployment":
+		d, err := c.deploymentLister.Deployments(owner.namespace).Get(owner.name)
+		if err == nil {
+			return int64(replicasOrOne(d.Spec.Replicas))
+		}
+	case "ReplicaSet":
+		rs, err := c.replicaSetLister.ReplicaSets(owner.namespace).Get(owner.name)
+		if err == nil {
+			return int64(replicasOrOne(rs.Spec.Replicas))
+		}
+	case "StatefulSet":
+		ss, err := c.statefulSetLister.StatefulSets(owner.namespace).Get(owner.name)
+		if err == nil {
+			return int64(replicasOrOne(ss.Spec.Replicas))
+		}
+	case "DaemonSet":
+		ds, err := c.daemonSetLister.DaemonSets(owner.namespace).Get(owner.name)
+		if err == nil {
+			return int64(ds.Status.DesiredNumberScheduled)
+		}
+	}
+	return 0
+}
+
+func replicasOrOne(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func nodeInternalIP(node *corev1.Node) string {
+	for _, a := range node.Status.Addresses {
+		if a.Type == corev1.NodeInternalIP {
+			return a.Address
+		}
+	}
+	return ""
+}
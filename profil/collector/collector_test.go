@@ -0,0 +1,84 @@
+package collector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func controllerRef(kind, name string) []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, Controller: &isController}}
+}
+
+func runningPod(name, rs, node string, ready bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, OwnerReferences: controllerRef("ReplicaSet", rs)},
+		Spec:       corev1.PodSpec{NodeName: node},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			HostIP:     "10.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+}
+
+func TestSnapshotGroupsPodsByDeployment(t *testing.T) {
+	replicas := int32(3)
+	objects := []runtime.Object{
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		},
+		&appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web-abc", OwnerReferences: controllerRef("Deployment", "web")},
+		},
+		&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+				Addresses:  []corev1.NodeAddress{{Type: corev1.NodeInternalIP, Address: "10.0.0.1"}},
+			},
+		},
+		runningPod("web-abc-1", "web-abc", "node-1", true),
+		runningPod("web-abc-2", "web-abc", "node-1", false),
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "done"},
+			Status:     corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	}
+	c := NewCollector(fake.NewSimpleClientset(objects...), nil, Config{Region: "cn-north-1", AppLabel: "app", Interval: time.Minute})
+	c.now = func() time.Time { return time.Unix(1600000000, 0) }
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	c.factory.Start(stopCh)
+	assert.True(t, cache.WaitForCacheSync(stopCh, c.synced...))
+
+	snapshot, err := c.Snapshot()
+	assert.NoError(t, err)
+	assert.Len(t, snapshot.Pods, 2)
+	if assert.Len(t, snapshot.Apps, 1) {
+		app := snapshot.Apps[0]
+		assert.Equal(t, "web", app.App)
+		assert.Equal(t, "Deployment", app.Kind)
+		assert.Equal(t, int64(3), app.Desired)
+		assert.Equal(t, int64(2), app.Replicas)
+		assert.Equal(t, int64(1), app.Running)
+	}
+	if assert.Len(t, snapshot.Nodes, 1) {
+		assert.Equal(t, "10.0.0.1", snapshot.Nodes[0].HostIP)
+		assert.Equal(t, int64(2), snapshot.Nodes[0].Pods)
+		assert.True(t, snapshot.Nodes[0].Ready)
+	}
+}
@@ -1,57 +1,86 @@
 package profil
 
 import (
-	"github.com/influxdata/influxdb1-client/v2"
 	"time"
+
+	"github.com/influxdata/influxdb1-client/v2"
 )
 
-var myDB string = "prophet"
+// DefaultDatabase is the database used by the package level helpers.
+const DefaultDatabase = "prophet"
 
-//func main2() {
-// Make client
-/*
-	c, _ := client.NewHTTPClient(client.HTTPConfig{
-		Addr:     "http://localhost:8086",
-		Username: username,
-		Password: password,
-	})
-*/
-
-// batch write
-//	writePoints(c)
-// query
-//res, err := queryDB(c, fmt.Sprintf("SELECT count(busy) FROM cpu_usage"))
-//if err != nil {
-//	log.Fatal(err)
-//}
-//fmt.Print(res)
-//}
+// Config selects where a Store reads and writes points.
+type Config struct {
+	Database        string
+	RetentionPolicy string
+	// Precision of written timestamps, e.g. "s" or "ms". Empty means ns.
+	Precision string
+}
 
-func WritePoints(clnt client.Client, measurement string, precision string, tags map[string]string, fields map[string]interface{}) error {
+// DefaultConfig returns the configuration used by the package level helpers.
+func DefaultConfig() Config {
+	return Config{Database: DefaultDatabase, Precision: "s"}
+}
 
-	bp, _ := client.NewBatchPoints(client.BatchPointsConfig{
-		Database:  myDB,
-		Precision: precision,
-	})
+// Store runs statements and writes points against one InfluxDB database.
+type Store interface {
+	// Query runs a raw InfluxQL command.
+	Query(cmd string) ([]client.Result, error)
+	// Write stores the given points in a single batch.
+	Write(points ...*client.Point) error
+}
 
-	pt, _ := client.NewPoint(measurement, tags, fields, time.Now())
-	bp.AddPoint(pt)
+type influxStore struct {
+	client client.Client
+	config Config
+}
 
-	return clnt.Write(bp)
+// NewStore returns a Store backed by an InfluxDB client.
+func NewStore(c client.Client, config Config) Store {
+	if config.Database == "" {
+		config.Database = DefaultDatabase
+	}
+	return &influxStore{client: c, config: config}
 }
 
-func QueryDB(clnt client.Client, cmd string) (res []client.Result, err error) {
-	q := client.Query{
-		Command:  cmd,
-		Database: myDB,
+func (s *influxStore) Query(cmd string) ([]client.Result, error) {
+	q := client.NewQueryWithRP(cmd, s.config.Database, s.config.RetentionPolicy, "")
+	response, err := s.client.Query(q)
+	if err != nil {
+		return nil, err
 	}
-	if response, err := clnt.Query(q); err == nil {
-		if response.Error() != nil {
-			return res, response.Error()
-		}
-		res = response.Results
-	} else {
-		return res, err
+	if response.Error() != nil {
+		return nil, response.Error()
 	}
-	return res, nil
+	return response.Results, nil
+}
+
+func (s *influxStore) Write(points ...*client.Point) error {
+	bp, err := client.NewBatchPoints(client.BatchPointsConfig{
+		Database:        s.config.Database,
+		RetentionPolicy: s.config.RetentionPolicy,
+		Precision:       s.config.Precision,
+	})
+	if err != nil {
+		return err
+	}
+	bp.AddPoints(points)
+	return s.client.Write(bp)
+}
+
+// WritePoints writes a single point stamped with the current time to the
+// default database.
+func WritePoints(clnt client.Client, measurement string, precision string, tags map[string]string, fields map[string]interface{}) error {
+	pt, err := client.NewPoint(measurement, tags, fields, time.Now())
+	if err != nil {
+		return err
+	}
+	config := DefaultConfig()
+	config.Precision = precision
+	return NewStore(clnt, config).Write(pt)
+}
+
+// QueryDB runs cmd against the default database.
+func QueryDB(clnt client.Client, cmd string) (res []client.Result, err error) {
+	return NewStore(clnt, DefaultConfig()).Query(cmd)
 }
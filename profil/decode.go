@@ -0,0 +1,198 @@
+package profil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/influxdb1-client/models"
+	client "github.com/influxdata/influxdb1-client/v2"
+)
+
+// ErrNoData is returned when a query succeeds but matches no points.
+var ErrNoData = errors.New("query returned no data")
+
+// firstSeries returns the first series in results, or ErrNoData when it
+// holds no values.
+func firstSeries(results []client.Result) (*models.Row, error) {
+	if len(results) == 0 {
+		return nil, ErrNoData
+	}
+	if results[0].Err != "" {
+		return nil, errors.New(results[0].Err)
+	}
+	if len(results[0].Series) == 0 || len(results[0].Series[0].Values) == 0 {
+		return nil, ErrNoData
+	}
+	return &results[0].Series[0], nil
+}
+
+// DecodeFirst decodes the first row of the first series in results into
+// out, which must be a pointer to a struct. Struct fields are matched to
+// columns by their `influx` tag, or by name when the tag is absent.
+// Columns without a matching field are ignored; null values leave the field
+// untouched.
+func DecodeFirst(results []client.Result, out interface{}) error {
+	row, err := firstSeries(results)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a pointer to a struct, got %T", out)
+	}
+	return decodeRow(row.Columns, row.Values[0], v.Elem())
+}
+
+// DecodeSeries decodes the first row of every series in results into out,
+// which must be a pointer to a slice of structs. Besides the columns, the
+// series tags of a grouped query are matched to fields the same way.
+func DecodeSeries(results []client.Result, out interface{}) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice || v.Elem().Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("decode target must be a pointer to a slice of structs, got %T", out)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	if results[0].Err != "" {
+		return errors.New(results[0].Err)
+	}
+	slice := v.Elem()
+	for _, row := range results[0].Series {
+		if len(row.Values) == 0 {
+			continue
+		}
+		elem := reflect.New(slice.Type().Elem()).Elem()
+		index := fieldIndex(elem.Type())
+		for tag, value := range row.Tags {
+			if fi, ok := index[tag]; ok && elem.Field(fi).Kind() == reflect.String {
+				elem.Field(fi).SetString(value)
+			}
+		}
+		if err := decodeRow(row.Columns, row.Values[0], elem); err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+	return nil
+}
+
+// DecodeFloat returns the value of column in the first row of results.
+func DecodeFloat(results []client.Result, column string) (float64, error) {
+	row, err := firstSeries(results)
+	if err != nil {
+		return 0, err
+	}
+	for i, c := range row.Columns {
+		if c == column {
+			if i >= len(row.Values[0]) || row.Values[0][i] == nil {
+				return 0, ErrNoData
+			}
+			return toFloat(row.Values[0][i])
+		}
+	}
+	return 0, fmt.Errorf("column %q not found in %v", column, row.Columns)
+}
+
+func fieldIndex(t reflect.Type) map[string]int {
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Tag.Get("influx")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		index[name] = i
+	}
+	return index
+}
+
+func decodeRow(columns []string, values []interface{}, v reflect.Value) error {
+	index := fieldIndex(v.Type())
+	for i, c := range columns {
+		fi, ok := index[c]
+		if !ok || i >= len(values) || values[i] == nil {
+			continue
+		}
+		if err := setField(v.Field(fi), values[i]); err != nil {
+			return fmt.Errorf("column %q: %v", c, err)
+		}
+	}
+	return nil
+}
+
+func setField(f reflect.Value, raw interface{}) error {
+	if f.Type() == reflect.TypeOf(time.Time{}) {
+		t, err := toTime(raw)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch f.Kind() {
+	case reflect.Float32, reflect.Float64:
+		x, err := toFloat(raw)
+		if err != nil {
+			return err
+		}
+		f.SetFloat(x)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		x, err := toFloat(raw)
+		if err != nil {
+			return err
+		}
+		f.SetInt(int64(x))
+	case reflect.String:
+		f.SetString(fmt.Sprint(raw))
+	case reflect.Bool:
+		b, ok := raw.(bool)
+		if !ok {
+			return fmt.Errorf("cannot decode %T into bool", raw)
+		}
+		f.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %v", f.Type())
+	}
+	return nil
+}
+
+func toFloat(raw interface{}) (float64, error) {
+	switch x := raw.(type) {
+	case json.Number:
+		return x.Float64()
+	case float64:
+		return x, nil
+	case int64:
+		return float64(x), nil
+	case int:
+		return float64(x), nil
+	case string:
+		return strconv.ParseFloat(x, 64)
+	}
+	return 0, fmt.Errorf("cannot decode %T into a number", raw)
+}
+
+func toTime(raw interface{}) (time.Time, error) {
+	switch x := raw.(type) {
+	case string:
+		return time.Parse(time.RFC3339Nano, x)
+	case json.Number:
+		n, err := x.Int64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(0, n), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot decode %T into a time", raw)
+}
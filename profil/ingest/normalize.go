@@ -0,0 +1,199 @@
+package ingest
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/influxdata/influxdb1-client/models"
+	client "github.com/influxdata/influxdb1-client/v2"
+)
+
+// Names of the tag and fields profil queries application measurements by.
+const (
+	AppTag         = "app"
+	CPUField       = "cpu"
+	MemoryField    = "memory"
+	RPSField       = "rps"
+	RTimeField     = "rtime"
+	R2xxField      = "r2xx"
+	R5xxField      = "r5xx"
+	ReplicasField  = "replicas"
+	defaultMaxTags = 32
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9_.\-]*$`)
+
+// DefaultTagAliases maps tag keys commonly sent by Telegraf inputs to the
+// keys profil queries.
+var DefaultTagAliases = map[string]string{
+	"application": AppTag,
+	"app_name":    AppTag,
+	"service":     AppTag,
+	"instance_id": AppTag,
+}
+
+// DefaultFieldAliases maps field keys commonly sent by Telegraf inputs to
+// the fields profil queries.
+var DefaultFieldAliases = map[string]string{
+	"usage_active":        CPUField,
+	"usage_percent":       CPUField,
+	"cpu_usage":           CPUField,
+	"used_percent":        MemoryField,
+	"memory_usage":        MemoryField,
+	"mem":                 MemoryField,
+	"requests_per_second": RPSField,
+	"qps":                 RPSField,
+	"response_time":       RTimeField,
+	"latency":             RTimeField,
+	"status_2xx":          R2xxField,
+	"status_5xx":          R5xxField,
+	"replica":             ReplicasField,
+	"instances":           ReplicasField,
+}
+
+// NormalizerConfig configures how incoming points are rewritten.
+type NormalizerConfig struct {
+	// TagAliases renames tag keys after they are lower cased.
+	TagAliases map[string]string
+	// FieldAliases renames field keys after they are lower cased.
+	FieldAliases map[string]string
+	// RequireApp rejects points without an app tag.
+	RequireApp bool
+	// KeepUnknownFields keeps fields that are not part of the profil
+	// schema. Otherwise they are dropped.
+	KeepUnknownFields bool
+	// MaxTags rejects points with more tags than this. Zero means 32.
+	MaxTags int
+}
+
+// DefaultNormalizerConfig returns the configuration used by the gateway
+// unless overridden.
+func DefaultNormalizerConfig() NormalizerConfig {
+	return NormalizerConfig{
+		TagAliases:        DefaultTagAliases,
+		FieldAliases:      DefaultFieldAliases,
+		RequireApp:        false,
+		KeepUnknownFields: true,
+	}
+}
+
+var schemaFields = map[string]bool{
+	CPUField:      true,
+	MemoryField:   true,
+	RPSField:      true,
+	RTimeField:    true,
+	R2xxField:     true,
+	R5xxField:     true,
+	ReplicasField: true,
+}
+
+// Normalizer validates parsed points and rewrites their names into the
+// schema read by profil.
+type Normalizer struct {
+	config NormalizerConfig
+}
+
+// NewNormalizer returns a Normalizer for config.
+func NewNormalizer(config NormalizerConfig) *Normalizer {
+	if config.MaxTags == 0 {
+		config.MaxTags = defaultMaxTags
+	}
+	return &Normalizer{config: config}
+}
+
+// normalizeName lower cases s and replaces characters outside of
+// [a-z0-9_.-] with underscores.
+func normalizeName(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '.', r == '-':
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+func validName(s string) bool {
+	return nameRegexp.MatchString(s)
+}
+
+// Normalize converts p into a point in the profil schema, or returns an
+// error explaining why it was rejected.
+func (n *Normalizer) Normalize(p models.Point) (*client.Point, error) {
+	name := normalizeName(string(p.Name()))
+	if !validName(name) {
+		return nil, fmt.Errorf("invalid measurement name %q", p.Name())
+	}
+	rawTags := p.Tags()
+	if len(rawTags) > n.config.MaxTags {
+		return nil, fmt.Errorf("%s: %d tags exceed the limit of %d", name, len(rawTags), n.config.MaxTags)
+	}
+	tags := make(map[string]string, len(rawTags))
+	for _, t := range rawTags {
+		key := normalizeName(string(t.Key))
+		if alias, ok := n.config.TagAliases[key]; ok {
+			key = alias
+		}
+		if !validName(key) {
+			return nil, fmt.Errorf("%s: invalid tag key %q", name, t.Key)
+		}
+		if len(t.Value) == 0 {
+			continue
+		}
+		tags[key] = string(t.Value)
+	}
+	if n.config.RequireApp && tags[AppTag] == "" {
+		return nil, fmt.Errorf("%s: missing %q tag", name, AppTag)
+	}
+
+	rawFields, err := p.Fields()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	fields := make(map[string]interface{}, len(rawFields))
+	for k, v := range rawFields {
+		key := normalizeName(k)
+		if alias, ok := n.config.FieldAliases[key]; ok {
+			key = alias
+		}
+		if !validName(key) {
+			return nil, fmt.Errorf("%s: invalid field key %q", name, k)
+		}
+		if !schemaFields[key] && !n.config.KeepUnknownFields {
+			continue
+		}
+		if schemaFields[key] {
+			if v, err = numeric(key, v); err != nil {
+				return nil, fmt.Errorf("%s: field %q: %v", name, key, err)
+			}
+		}
+		fields[key] = v
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%s: no fields left after normalisation", name)
+	}
+	return client.NewPoint(name, tags, fields, p.Time())
+}
+
+// numeric coerces schema fields to a single type so that points coming
+// from different inputs do not conflict on field type: replicas are
+// integers, as written by the inventory collector, everything else floats.
+func numeric(key string, v interface{}) (interface{}, error) {
+	var f float64
+	switch x := v.(type) {
+	case float64:
+		f = x
+	case int64:
+		f = float64(x)
+	case uint64:
+		f = float64(x)
+	default:
+		return nil, fmt.Errorf("expected a number, got %T", v)
+	}
+	if key == ReplicasField {
+		return int64(f), nil
+	}
+	return f, nil
+}
@@ -0,0 +1,164 @@
+package ingest
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb1-client/models"
+	client "github.com/influxdata/influxdb1-client/v2"
+)
+
+const defaultMaxBodyBytes = 25 * 1024 * 1024
+
+// Config configures a Handler.
+type Config struct {
+	// Databases accepted in the `db` parameter. Empty accepts any.
+	Databases []string
+	// MaxBodyBytes limits the size of a decompressed request body. Zero
+	// means 25MiB.
+	MaxBodyBytes int64
+	Normalizer   NormalizerConfig
+}
+
+// Handler serves the subset of the InfluxDB 1.x HTTP API Telegraf's
+// influxdb output uses: POST /write with line protocol and GET /ping.
+type Handler struct {
+	config     Config
+	normalizer *Normalizer
+	sink       Sink
+	mux        *http.ServeMux
+	now        func() time.Time
+}
+
+// NewHandler returns a Handler passing accepted points to sink.
+func NewHandler(config Config, sink Sink) *Handler {
+	if config.MaxBodyBytes == 0 {
+		config.MaxBodyBytes = defaultMaxBodyBytes
+	}
+	h := &Handler{
+		config:     config,
+		normalizer: NewNormalizer(config.Normalizer),
+		sink:       sink,
+		mux:        http.NewServeMux(),
+		now:        time.Now,
+	}
+	h.mux.HandleFunc("/write", h.serveWrite)
+	h.mux.HandleFunc("/ping", h.servePing)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) servePing(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, code int, err string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Influxdb-Error", err)
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": err})
+}
+
+func (h *Handler) acceptsDatabase(db string) bool {
+	if len(h.config.Databases) == 0 {
+		return true
+	}
+	for _, d := range h.config.Databases {
+		if d == db {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) serveWrite(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	db := r.URL.Query().Get("db")
+	if !h.acceptsDatabase(db) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("database not found: %q", db))
+		return
+	}
+	precision := r.URL.Query().Get("precision")
+	switch precision {
+	case "":
+		precision = "n"
+	case "n", "ns", "u", "ms", "s", "m", "h":
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid precision %q", precision))
+		return
+	}
+
+	var body io.Reader = r.Body
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		defer gz.Close()
+		body = gz
+	}
+	buf, err := ioutil.ReadAll(io.LimitReader(body, h.config.MaxBodyBytes+1))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if int64(len(buf)) > h.config.MaxBodyBytes {
+		writeError(w, http.StatusRequestEntityTooLarge, "request entity too large")
+		return
+	}
+
+	points, rejected, parseErr := h.Parse(buf, precision)
+	if len(points) > 0 {
+		if err := h.sink.Write(points); err != nil {
+			log.Printf("Cannot write %d points: %v", len(points), err)
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if parseErr != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("partial write: %v dropped=%d", parseErr, rejected))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Parse parses a line protocol body and normalises its points. Points
+// that cannot be normalised are dropped; the number dropped and the first
+// error are returned alongside the accepted points.
+func (h *Handler) Parse(buf []byte, precision string) ([]*client.Point, int, error) {
+	parsed, parseErr := models.ParsePointsWithPrecision(buf, h.now().UTC(), precision)
+	points := make([]*client.Point, 0, len(parsed))
+	rejected := 0
+	firstErr := parseErr
+	if parseErr != nil {
+		// The parser skips invalid lines but does not report how many.
+		rejected = 1
+	}
+	for _, p := range parsed {
+		pt, err := h.normalizer.Normalize(p)
+		if err != nil {
+			rejected++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		points = append(points, pt)
+	}
+	return points, rejected, firstErr
+}
@@ -0,0 +1,158 @@
+package ingest
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb1-client/models"
+	client "github.com/influxdata/influxdb1-client/v2"
+)
+
+type recordingSink struct {
+	mu     sync.Mutex
+	points []*client.Point
+}
+
+func (s *recordingSink) Write(points []*client.Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.points = append(s.points, points...)
+	return nil
+}
+
+func TestNormalizeRenamesIntoProfilSchema(t *testing.T) {
+	parsed, err := models.ParsePointsString(`CN-North-1,Application=web,Host=h1 usage_active=42i,qps=10,Replica=3,extra="x" 1600000000000000000`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n := NewNormalizer(NormalizerConfig{TagAliases: DefaultTagAliases, FieldAliases: DefaultFieldAliases})
+	p, err := n.Normalize(parsed[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "cn-north-1" {
+		t.Errorf("unexpected measurement %q", p.Name())
+	}
+	if tags := p.Tags(); tags["app"] != "web" || tags["host"] != "h1" {
+		t.Errorf("unexpected tags %v", tags)
+	}
+	fields, _ := p.Fields()
+	if fields["cpu"] != 42.0 || fields["rps"] != 10.0 || fields["replicas"] != int64(3) {
+		t.Errorf("unexpected fields %v", fields)
+	}
+	if _, ok := fields["extra"]; ok {
+		t.Errorf("unknown field should have been dropped: %v", fields)
+	}
+}
+
+func TestNormalizeRejects(t *testing.T) {
+	n := NewNormalizer(NormalizerConfig{RequireApp: true, KeepUnknownFields: true})
+	cases := map[string]string{
+		"missing app":     `m,host=h1 cpu=1`,
+		"non numeric cpu": `m,app=web cpu="high"`,
+		"bad measurement": `_m,app=web cpu=1`,
+	}
+	for name, line := range cases {
+		parsed, err := models.ParsePointsString(line)
+		if err != nil {
+			t.Fatalf("%s: unexpected parse error: %v", name, err)
+		}
+		if _, err := n.Normalize(parsed[0]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestHandlerWrite(t *testing.T) {
+	sink := &recordingSink{}
+	h := NewHandler(Config{Databases: []string{"telegraf"}, Normalizer: DefaultNormalizerConfig()}, sink)
+	h.now = func() time.Time { return time.Unix(1600000000, 0) }
+
+	var body bytes.Buffer
+	gz := gzip.NewWriter(&body)
+	gz.Write([]byte("cn-north-1,app=web cpu=1.5,rtime=20 1600000000\ncn-north-1,app=api cpu=2\n"))
+	gz.Close()
+	req := httptest.NewRequest(http.MethodPost, "/write?db=telegraf&precision=s", &body)
+	req.Header.Set("Content-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(sink.points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(sink.points))
+	}
+	if !sink.points[0].Time().Equal(time.Unix(1600000000, 0)) {
+		t.Errorf("unexpected time %v", sink.points[0].Time())
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/write?db=other", strings.NewReader("m,app=web cpu=1")))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown database, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/write?db=telegraf", strings.NewReader("m,app=web cpu=1\nm,app=web cpu=\"x\"")))
+	if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "partial write") {
+		t.Errorf("expected a partial write error, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(sink.points) != 3 {
+		t.Errorf("the valid point of a partial write should be kept, got %d points", len(sink.points))
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("expected 204 from ping, got %d", rec.Code)
+	}
+}
+
+func TestWriterSinkAndFanOut(t *testing.T) {
+	var buf bytes.Buffer
+	failing := SinkFunc(func([]*client.Point) error { return errors.New("boom") })
+	sink := NewFanOutSink(NewWriterSink(&buf, "s"), failing)
+	p, _ := client.NewPoint("m", map[string]string{"app": "web"}, map[string]interface{}{"cpu": 1.0}, time.Unix(10, 0))
+	if err := sink.Write([]*client.Point{p}); err == nil {
+		t.Errorf("expected the failing sink to be reported")
+	}
+	if buf.String() != "m,app=web cpu=1 10\n" {
+		t.Errorf("unexpected line protocol %q", buf.String())
+	}
+}
+
+func TestHTTPSinkForwardsLineProtocol(t *testing.T) {
+	received := &recordingSink{}
+	server := httptest.NewServer(NewHandler(Config{Normalizer: DefaultNormalizerConfig()}, received))
+	defer server.Close()
+
+	sink := NewHTTPSink(server.URL+"/", nil)
+	if err := sink.Write(nil); err != nil {
+		t.Fatalf("unexpected error for an empty batch: %v", err)
+	}
+	p, _ := client.NewPoint("kubernetes_pod_container",
+		map[string]string{"namespace": "default", "pod_name": "web-1", "container_name": "web"},
+		map[string]interface{}{"cpu": 0.5}, time.Unix(10, 0))
+	if err := sink.Write([]*client.Point{p}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	received.mu.Lock()
+	points := received.points
+	received.mu.Unlock()
+	if len(points) != 1 || points[0].String() != p.String() {
+		t.Errorf("expected %v to be forwarded, got %v", p, points)
+	}
+
+	failing := httptest.NewServer(NewHandler(Config{Databases: []string{"prophet"}}, received))
+	defer failing.Close()
+	if err := NewHTTPSink(failing.URL, nil).Write([]*client.Point{p}); err == nil {
+		t.Errorf("expected an error when the gateway rejects the write")
+	}
+}
@@ -0,0 +1,138 @@
+package ingest
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+	"github.com/turtacn/cloud-prophet/profil"
+)
+
+// Sink receives normalised points accepted by the gateway.
+type Sink interface {
+	// Write stores a batch of points. It must be safe for concurrent use.
+	Write(points []*client.Point) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(points []*client.Point) error
+
+// Write calls f(points).
+func (f SinkFunc) Write(points []*client.Point) error {
+	return f(points)
+}
+
+type storeSink struct {
+	store profil.Store
+}
+
+// NewStoreSink returns a Sink writing to a profil.Store, e.g. InfluxDB.
+func NewStoreSink(store profil.Store) Sink {
+	return &storeSink{store: store}
+}
+
+func (s *storeSink) Write(points []*client.Point) error {
+	return s.store.Write(points...)
+}
+
+type writerSink struct {
+	mu        sync.Mutex
+	w         *bufio.Writer
+	precision string
+}
+
+// NewWriterSink returns a Sink appending points to w in line protocol with
+// timestamps in the given precision.
+func NewWriterSink(w io.Writer, precision string) Sink {
+	return &writerSink{w: bufio.NewWriter(w), precision: precision}
+}
+
+// NewFileSink returns a Sink appending points in line protocol to the file
+// at path, creating it if needed.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return NewWriterSink(f, "n"), nil
+}
+
+func (s *writerSink) Write(points []*client.Point) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range points {
+		if _, err := s.w.WriteString(p.PrecisionString(s.precision)); err != nil {
+			return err
+		}
+		if err := s.w.WriteByte('\n'); err != nil {
+			return err
+		}
+	}
+	return s.w.Flush()
+}
+
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPSink returns a Sink posting points in line protocol to the /write
+// endpoint of the gateway at url, e.g. the one the recommender serves to
+// feed its metrics client.
+func NewHTTPSink(url string, c *http.Client) Sink {
+	if c == nil {
+		c = http.DefaultClient
+	}
+	return &httpSink{url: strings.TrimSuffix(url, "/") + "/write?precision=n", client: c}
+}
+
+func (s *httpSink) Write(points []*client.Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, p := range points {
+		buf.WriteString(p.PrecisionString("n"))
+		buf.WriteByte('\n')
+	}
+	resp, err := s.client.Post(s.url, "text/plain; charset=utf-8", &buf)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %s: %s", s.url, resp.Status, resp.Header.Get("X-Influxdb-Error"))
+	}
+	return nil
+}
+
+type fanOutSink struct {
+	sinks []Sink
+}
+
+// NewFanOutSink returns a Sink writing every batch to all of sinks. All
+// sinks are tried, failures are reported together.
+func NewFanOutSink(sinks ...Sink) Sink {
+	return &fanOutSink{sinks: sinks}
+}
+
+func (s *fanOutSink) Write(points []*client.Point) error {
+	var errs []string
+	for _, sink := range s.sinks {
+		if err := sink.Write(points); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d sinks failed: %s", len(errs), len(s.sinks), strings.Join(errs, "; "))
+	}
+	return nil
+}
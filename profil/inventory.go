@@ -0,0 +1,216 @@
+package profil
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb1-client/v2"
+)
+
+// Inventory points are written next to the application metrics of a
+// region: the app level replica counts go to the region measurement itself,
+// so that `last(replicas)` in Profiler.Mean sees them, pods and nodes go to
+// the measurements below.
+const (
+	podMeasurementSuffix  = "_pods"
+	nodeMeasurementSuffix = "_nodes"
+)
+
+// PodMeasurement returns the measurement holding pod inventory of region.
+func PodMeasurement(region string) string { return region + podMeasurementSuffix }
+
+// NodeMeasurement returns the measurement holding node inventory of region.
+func NodeMeasurement(region string) string { return region + nodeMeasurementSuffix }
+
+// PodStatus is the inventory record of a single pod.
+type PodStatus struct {
+	Namespace string `influx:"namespace"`
+	Name      string `influx:"pod"`
+	App       string `influx:"app"`
+	Node      string `influx:"node"`
+	HostIP    string `influx:"host_ip"`
+	Phase     string `influx:"phase"`
+	Running   bool   `influx:"running"`
+	Ready     bool   `influx:"ready"`
+}
+
+// AppStatus is the inventory record of an application, i.e. the pods
+// sharing an app key within a namespace.
+type AppStatus struct {
+	Namespace string `influx:"namespace"`
+	App       string `influx:"app"`
+	// Kind of the owning workload, e.g. Deployment, or empty for bare pods.
+	Kind string `influx:"kind"`
+	// Desired replicas declared by the owning workload.
+	Desired int64 `influx:"desired"`
+	// Replicas is the number of existing pods.
+	Replicas int64 `influx:"replicas"`
+	// Running is the number of running and ready pods.
+	Running int64 `influx:"running"`
+}
+
+// NodeStatus is the inventory record of a node.
+type NodeStatus struct {
+	Name   string `influx:"node"`
+	HostIP string `influx:"host_ip"`
+	Ready  bool   `influx:"ready"`
+	Pods   int64  `influx:"pods"`
+}
+
+// InventorySnapshot is everything observed in a region at one instant.
+type InventorySnapshot struct {
+	Time  time.Time
+	Apps  []AppStatus
+	Pods  []PodStatus
+	Nodes []NodeStatus
+}
+
+// Points converts the snapshot into points for region.
+func (s *InventorySnapshot) Points(region string) ([]*client.Point, error) {
+	points := make([]*client.Point, 0, len(s.Apps)+len(s.Pods)+len(s.Nodes))
+	add := func(measurement string, tags map[string]string, fields map[string]interface{}) error {
+		pt, err := client.NewPoint(measurement, tags, fields, s.Time)
+		if err != nil {
+			return fmt.Errorf("%s: %v", measurement, err)
+		}
+		points = append(points, pt)
+		return nil
+	}
+	for _, a := range s.Apps {
+		err := add(region, map[string]string{"app": a.App, "namespace": a.Namespace, "kind": a.Kind},
+			map[string]interface{}{"replicas": a.Replicas, "desired": a.Desired, "running": a.Running})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, p := range s.Pods {
+		err := add(PodMeasurement(region),
+			map[string]string{"namespace": p.Namespace, "pod": p.Name, "app": p.App, "node": p.Node, "host_ip": p.HostIP},
+			map[string]interface{}{"phase": p.Phase, "running": p.Running, "ready": p.Ready})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, n := range s.Nodes {
+		err := add(NodeMeasurement(region), map[string]string{"node": n.Name, "host_ip": n.HostIP},
+			map[string]interface{}{"ready": n.Ready, "pods": n.Pods})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// Inventory reads the latest inventory written by the collector.
+type Inventory interface {
+	// Apps returns the apps of region seen within the inventory window.
+	Apps(region string) ([]AppStatus, error)
+	// Pods returns the pods of region seen within the inventory window.
+	Pods(region string) ([]PodStatus, error)
+	// Nodes returns the nodes of region seen within the inventory window.
+	Nodes(region string) ([]NodeStatus, error)
+	// Region is the region used when none is given.
+	Region() string
+}
+
+type inventory struct {
+	store  Store
+	region string
+	window time.Duration
+}
+
+// NewInventory returns an Inventory reading from store. Records older than
+// window are considered gone.
+func NewInventory(store Store, region string, window time.Duration) Inventory {
+	return &inventory{store: store, region: region, window: window}
+}
+
+func (i *inventory) Region() string { return i.region }
+
+func (i *inventory) Apps(region string) ([]AppStatus, error) {
+	if region == "" {
+		region = i.region
+	}
+	cmd, err := Select(Last("desired"), Last("replicas"), Last("running")).
+		From(region).
+		Since(i.window).
+		GroupBy("namespace", "app", "kind").
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := i.store.Query(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var series []AppStatus
+	if err := DecodeSeries(res, &series); err != nil {
+		return nil, err
+	}
+	// The application metrics share the measurement but have no namespace.
+	var apps []AppStatus
+	for _, a := range series {
+		if a.Namespace != "" {
+			apps = append(apps, a)
+		}
+	}
+	return apps, nil
+}
+
+func (i *inventory) Pods(region string) ([]PodStatus, error) {
+	if region == "" {
+		region = i.region
+	}
+	cmd, err := Select(Last("phase"), Last("running"), Last("ready")).
+		From(PodMeasurement(region)).
+		Since(i.window).
+		GroupBy("namespace", "pod", "app", "node", "host_ip").
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := i.store.Query(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var pods []PodStatus
+	if err := DecodeSeries(res, &pods); err != nil {
+		return nil, err
+	}
+	return pods, nil
+}
+
+func (i *inventory) Nodes(region string) ([]NodeStatus, error) {
+	if region == "" {
+		region = i.region
+	}
+	cmd, err := Select(Last("ready"), Last("pods")).
+		From(NodeMeasurement(region)).
+		Since(i.window).
+		GroupBy("node", "host_ip").
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := i.store.Query(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []NodeStatus
+	if err := DecodeSeries(res, &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// WriteInventory writes a snapshot of region to store in a single batch.
+func WriteInventory(store Store, region string, snapshot *InventorySnapshot) error {
+	points, err := snapshot.Points(region)
+	if err != nil {
+		return err
+	}
+	if len(points) == 0 {
+		return nil
+	}
+	return store.Write(points...)
+}
@@ -0,0 +1,125 @@
+package profil
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb1-client/models"
+	client "github.com/influxdata/influxdb1-client/v2"
+)
+
+func TestInventorySnapshotPoints(t *testing.T) {
+	snapshot := &InventorySnapshot{
+		Time:  time.Unix(1600000000, 0),
+		Apps:  []AppStatus{{Namespace: "default", App: "web", Kind: "Deployment", Desired: 3, Replicas: 2, Running: 2}},
+		Pods:  []PodStatus{{Namespace: "default", Name: "web-1", App: "web", HostIP: "10.0.0.1", Phase: "Running", Running: true}},
+		Nodes: []NodeStatus{{Name: "node-1", HostIP: "10.0.0.1", Ready: true, Pods: 1}},
+	}
+	store := &fakeStore{}
+	if err := WriteInventory(store, "cn-north-1", snapshot); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := []string{}
+	for _, p := range store.points {
+		names = append(names, p.Name())
+	}
+	if strings.Join(names, ",") != "cn-north-1,cn-north-1_pods,cn-north-1_nodes" {
+		t.Errorf("unexpected measurements %v", names)
+	}
+	fields, _ := store.points[0].Fields()
+	if fields["replicas"] != int64(2) || fields["desired"] != int64(3) {
+		t.Errorf("unexpected app fields %v", fields)
+	}
+}
+
+func TestInventoryPodsAndPackageHelpers(t *testing.T) {
+	store := &fakeStore{results: []client.Result{{
+		Series: []models.Row{
+			{
+				Tags:    map[string]string{"namespace": "default", "pod": "web-1", "app": "web", "host_ip": "10.0.0.1"},
+				Columns: []string{"time", "phase", "running", "ready"},
+				Values:  [][]interface{}{{json.Number("0"), "Running", true, true}},
+			},
+			{
+				Tags:    map[string]string{"namespace": "default", "pod": "web-2", "app": "web", "host_ip": "10.0.0.2"},
+				Columns: []string{"time", "phase", "running", "ready"},
+				Values:  [][]interface{}{{json.Number("0"), "Pending", false, false}},
+			},
+		},
+	}}}
+	UseInventory(NewInventory(store, "cn-north-1", 5*time.Minute))
+	defer UseInventory(nil)
+
+	pods, err := GetAllPod()
+	if err != nil || len(pods) != 2 || pods[1].Name != "web-2" || pods[1].Phase != "Pending" {
+		t.Errorf("unexpected pods %+v, %v", pods, err)
+	}
+	running := GetAllRunningPod()
+	if len(running) != 2 || !running["default/web-1"] || running["default/web-2"] {
+		t.Errorf("unexpected running pods %v", running)
+	}
+	if names := GetRunningPodStatus(""); len(names) != 1 || names[0] != "default/web-1" {
+		t.Errorf("unexpected running pod names %v", names)
+	}
+	if n := GetInstanceCount("", "10.0.0.1"); n != 1 {
+		t.Errorf("expected one instance on 10.0.0.1, got %d", n)
+	}
+	expected := `SELECT LAST("phase") AS "phase",LAST("running") AS "running",LAST("ready") AS "ready" FROM "cn-north-1_pods" WHERE time > now() - 5m GROUP BY "namespace","pod","app","node","host_ip"`
+	if store.commands[0] != expected {
+		t.Errorf("got\n%s\nexpected\n%s", store.commands[0], expected)
+	}
+}
+
+func TestGetAllPodWithoutInventory(t *testing.T) {
+	pods, err := GetAllPod()
+	if pods != nil || err != nil {
+		t.Errorf("expected no pods and no error, got %v, %v", pods, err)
+	}
+}
+
+func TestInventoryAppsAndReplicas(t *testing.T) {
+	columns := []string{"time", "desired", "replicas", "running"}
+	store := &fakeStore{results: []client.Result{{
+		Series: []models.Row{
+			{
+				Tags:    map[string]string{"namespace": "default", "app": "web", "kind": "Deployment"},
+				Columns: columns,
+				Values:  [][]interface{}{{json.Number("0"), json.Number("3"), json.Number("2"), json.Number("2")}},
+			},
+			{
+				Tags:    map[string]string{"namespace": "prod", "app": "web", "kind": "Deployment"},
+				Columns: columns,
+				Values:  [][]interface{}{{json.Number("0"), json.Number("4"), json.Number("4"), json.Number("3")}},
+			},
+			{
+				// Application metrics of web, without replicas.
+				Tags:    map[string]string{"namespace": "", "app": "web", "kind": ""},
+				Columns: columns,
+				Values:  [][]interface{}{{json.Number("0"), nil, nil, nil}},
+			},
+		},
+	}}}
+	inv := NewInventory(store, "cn-north-1", 5*time.Minute)
+	apps, err := inv.Apps("")
+	if err != nil || len(apps) != 2 || apps[1].Namespace != "prod" || apps[1].Desired != 4 || apps[1].Running != 3 {
+		t.Errorf("unexpected apps %+v, %v", apps, err)
+	}
+	expected := `SELECT LAST("desired") AS "desired",LAST("replicas") AS "replicas",LAST("running") AS "running" FROM "cn-north-1" WHERE time > now() - 5m GROUP BY "namespace","app","kind"`
+	if store.commands[0] != expected {
+		t.Errorf("got\n%s\nexpected\n%s", store.commands[0], expected)
+	}
+
+	if n := GetAppReplicas("", "web"); n != 0 {
+		t.Errorf("expected no replicas without inventory, got %d", n)
+	}
+	UseInventory(inv)
+	defer UseInventory(nil)
+	if n := GetAppReplicas("", "web"); n != 6 {
+		t.Errorf("expected 6 replicas of web, got %d", n)
+	}
+	if n := GetAppReplicas("", "api"); n != 0 {
+		t.Errorf("expected no replicas of api, got %d", n)
+	}
+}
@@ -2,98 +2,187 @@ package profil
 
 import (
 	"fmt"
+	"time"
+
 	influx "github.com/influxdata/influxdb1-client/v2"
-	"strconv"
 )
 
+// AppProfile summarises an application's metrics over a time window. The
+// `influx` tags name the columns of the application measurement.
+type AppProfile struct {
+	Time     time.Time `influx:"time"`
+	CPU      float64   `influx:"cpu"`
+	Memory   float64   `influx:"memory"`
+	RPS      float64   `influx:"rps"`
+	RTime    float64   `influx:"rtime"`
+	R2xx     float64   `influx:"r2xx"`
+	R5xx     float64   `influx:"r5xx"`
+	Replicas float64   `influx:"replicas"`
+}
+
+// Profiler reads and writes per application profiles. Region selects the
+// measurement, app is matched against the `app` tag.
+type Profiler interface {
+	// Average returns the mean of metric over the last window.
+	Average(region, app, metric string, window time.Duration) (float64, error)
+	// Mean returns mean cpu/memory/rps/rtime, the stddev of response codes
+	// and the last replica count over the last window.
+	Mean(region, app string, window time.Duration) (*AppProfile, error)
+	// Stddev returns the standard deviation of every metric and the last
+	// replica count over the last window.
+	Stddev(region, app string, window time.Duration) (*AppProfile, error)
+	// WriteRPI records requests per instance for app.
+	WriteRPI(region, app string, request int64, replicas int) error
+	// AverageRPI returns the mean of the recent requests per instance.
+	AverageRPI(region, app string) (float64, error)
+}
+
+type profiler struct {
+	store Store
+}
+
+// NewProfiler returns a Profiler reading from store.
+func NewProfiler(store Store) Profiler {
+	return &profiler{store: store}
+}
+
+func (p *profiler) query(q *Query) ([]influx.Result, error) {
+	cmd, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	return p.store.Query(cmd)
+}
+
+func (p *profiler) Average(region, app, metric string, window time.Duration) (float64, error) {
+	res, err := p.query(Select(Mean(metric)).From(region).WhereTagContains("app", app).Since(window))
+	if err != nil {
+		return 0, err
+	}
+	return DecodeFloat(res, metric)
+}
+
+func (p *profiler) Mean(region, app string, window time.Duration) (*AppProfile, error) {
+	return p.profile(Select(
+		Mean("cpu"), Mean("memory"), Mean("rps"), Mean("rtime"),
+		Stddev("r2xx"), Stddev("r5xx"), Last("replicas"),
+	).From(region).WhereTagContains("app", app).Since(window))
+}
+
+func (p *profiler) Stddev(region, app string, window time.Duration) (*AppProfile, error) {
+	return p.profile(Select(
+		Stddev("cpu"), Stddev("memory"), Stddev("rps"), Stddev("rtime"),
+		Stddev("r2xx"), Stddev("r5xx"), Last("replicas"),
+	).From(region).WhereTagContains("app", app).Since(window))
+}
+
+func (p *profiler) profile(q *Query) (*AppProfile, error) {
+	res, err := p.query(q)
+	if err != nil {
+		return nil, err
+	}
+	profile := &AppProfile{}
+	if err := DecodeFirst(res, profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (p *profiler) WriteRPI(region, app string, request int64, replicas int) error {
+	if replicas <= 0 {
+		return fmt.Errorf("cannot compute requests per instance for %d replicas", replicas)
+	}
+	tags := map[string]string{
+		"app": app,
+	}
+	fields := map[string]interface{}{
+		"rps":      request,
+		"replicas": replicas,
+		"rpi":      request / int64(replicas), // TODO:remove?
+	}
+	pt, err := influx.NewPoint(region+"_rpi", tags, fields, time.Now())
+	if err != nil {
+		return err
+	}
+	return p.store.Write(pt)
+}
+
+func (p *profiler) AverageRPI(region, app string) (float64, error) {
+	res, err := p.query(Select(Mean("rpi")).From(region+"_rpi").WhereTagContains("app", app).Limit(20))
+	if err != nil {
+		return 0, err
+	}
+	return DecodeFloat(res, "rpi")
+}
+
+func defaultProfiler(conn influx.Client) Profiler {
+	return NewProfiler(NewStore(conn, DefaultConfig()))
+}
+
+// toMap flattens a profile into the column keyed map returned by the
+// legacy helpers.
+func (a *AppProfile) toMap() map[string]float64 {
+	return map[string]float64{
+		"cpu":      a.CPU,
+		"memory":   a.Memory,
+		"rps":      a.RPS,
+		"rtime":    a.RTime,
+		"r2xx":     a.R2xx,
+		"r5xx":     a.R5xx,
+		"replicas": a.Replicas,
+	}
+}
+
+// GetProfilAvg returns the mean of metric for instanceId over timeLength,
+// an InfluxQL duration such as "5m".
 func GetProfilAvg(conn influx.Client, region, instanceId, metric, timeLength string) (float64, error) {
-	query := fmt.Sprint("SELECT MEAN(" + metric + ") FROM " + region + " WHERE app =~ /" + instanceId + "/ AND time > now() - " + timeLength)
-	dbResult, err := QueryDB(conn, query)
+	window, err := ParseDuration(timeLength)
 	if err != nil {
-		panic(err)
 		return -1.0, err
 	}
-	//	fmt.Println(dbResult)
-	return strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][1]), 32)
+	return defaultProfiler(conn).Average(region, instanceId, metric, window)
 }
 
+// GetProfilLast returns the Mean profile of instanceId truncated to
+// integers, or nil if it could not be read.
 func GetProfilLast(conn influx.Client, region, instanceId, timeLength string) map[string]int64 {
-	//mean(cpu),mean(memory),mean(request),mean(response),stddev(code5xx)
-	query := fmt.Sprint("SELECT mean(cpu) as cpu,mean(memory) as memory,mean(rps) as rps,mean(rtime) as rtime,stddev(r2xx) as r2xx,stddev(r5xx) as r5xx,last(replicas) as replicas FROM " + region + " WHERE app =~ /" + instanceId + "/ AND time > now() - " + timeLength)
-	dbResult, err := QueryDB(conn, query)
+	window, err := ParseDuration(timeLength)
+	if err != nil {
+		return nil
+	}
+	profile, err := defaultProfiler(conn).Mean(region, instanceId, window)
 	if err != nil {
 		return nil
 	}
 	res := make(map[string]int64)
-	dbRes1, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][1]), 32)
-	dbRes2, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][2]), 32)
-	dbRes3, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][3]), 32)
-	dbRes4, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][4]), 32)
-	dbRes5, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][5]), 32)
-	dbRes6, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][6]), 32)
-	dbRes7, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][7]), 32)
-
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[1])] = int64(dbRes1)
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[2])] = int64(dbRes2)
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[3])] = int64(dbRes3)
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[4])] = int64(dbRes4)
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[5])] = int64(dbRes5)
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[6])] = int64(dbRes6)
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[7])] = int64(dbRes7)
+	for k, v := range profile.toMap() {
+		res[k] = int64(v)
+	}
 	return res
 }
 
+// GetProfilStdLast returns the Stddev profile of instanceId, or nil if it
+// could not be read.
 func GetProfilStdLast(conn influx.Client, region, instanceId, timeLength string) map[string]float64 {
-	//mean(cpu),mean(memory),mean(request),mean(response),stddev(code5xx)
-	query := fmt.Sprint("SELECT STDDEV(cpu) as cpu,STDDEV(memory) as memory,STDDEV(rps) as rps,STDDEV(rtime) as rtime,STDDEV(r2xx) as r2xx,STDDEV(r5xx) as r5xx,last(replicas) as replicas FROM " + region + " WHERE app =~ /" + instanceId + "/ AND time > now() - " + timeLength)
-	dbResult, err := QueryDB(conn, query)
+	window, err := ParseDuration(timeLength)
 	if err != nil {
 		return nil
 	}
-	res := make(map[string]float64)
-	dbRes1, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][1]), 32)
-	dbRes2, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][2]), 32)
-	dbRes3, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][3]), 32)
-	dbRes4, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][4]), 32)
-	dbRes5, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][5]), 32)
-	dbRes6, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][6]), 32)
-	dbRes7, _ := strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][7]), 32)
-
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[1])] = dbRes1
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[2])] = dbRes2
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[3])] = dbRes3
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[4])] = dbRes4
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[5])] = dbRes5
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[6])] = dbRes6
-	res[fmt.Sprint(dbResult[0].Series[0].Columns[7])] = dbRes7
-	return res
+	profile, err := defaultProfiler(conn).Stddev(region, instanceId, window)
+	if err != nil {
+		return nil
+	}
+	return profile.toMap()
 }
 
 func WriteRPI(conn influx.Client, region, instanceId string, request int64, replicas int) error {
-
-	tags := map[string]string{
-		"app": instanceId,
-	}
-
-	metrics := map[string]interface{}{
-		"rps":      request,
-		"replicas": replicas,
-		"rpi":      request / int64(replicas), // TODO:remove?
-	}
-	fmt.Println(metrics)
-	if err := WritePoints(conn, region+"_rpi", "s", tags, metrics); err != nil {
-		return err
-	}
-	return nil
+	return defaultProfiler(conn).WriteRPI(region, instanceId, request, replicas)
 }
 
 func GetAvgRPI(conn influx.Client, region, instanceId string) (float64, error) {
-	query := fmt.Sprint("SELECT MEAN(rpi) FROM " + region + "_rpi WHERE app =~ /" + instanceId + "/ limit 20")
-	dbResult, err := QueryDB(conn, query)
+	res, err := defaultProfiler(conn).AverageRPI(region, instanceId)
 	if err != nil {
-		panic(err)
 		return -1.0, err
 	}
-	//	fmt.Println(dbResult)
-	return strconv.ParseFloat(fmt.Sprint(dbResult[0].Series[0].Values[0][1]), 32)
+	return res, nil
 }
@@ -0,0 +1,170 @@
+package profil
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb1-client/models"
+	client "github.com/influxdata/influxdb1-client/v2"
+
+	"github.com/turtacn/cloud-prophet/model"
+)
+
+type fakeStore struct {
+	commands []string
+	results  []client.Result
+	points   []*client.Point
+}
+
+func (f *fakeStore) Query(cmd string) ([]client.Result, error) {
+	f.commands = append(f.commands, cmd)
+	return f.results, nil
+}
+
+func (f *fakeStore) Write(points ...*client.Point) error {
+	f.points = append(f.points, points...)
+	return nil
+}
+
+func TestQueryBuildEscapesUserInput(t *testing.T) {
+	cmd, err := Select(Mean("cpu")).
+		From(`cn-north-1"; DROP DATABASE prophet`).
+		WhereTagContains("app", "i-1/ OR 1=1").
+		Since(5 * time.Minute).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := `SELECT MEAN("cpu") AS "cpu" FROM "cn-north-1\"; DROP DATABASE prophet" WHERE "app" =~ /i-1\/ OR 1=1/ AND time > now() - 5m`
+	if cmd != expected {
+		t.Errorf("got\n%s\nexpected\n%s", cmd, expected)
+	}
+}
+
+func TestQueryBuildErrors(t *testing.T) {
+	cases := map[string]*Query{
+		"no fields":      Select().From("m"),
+		"no measurement": Select(Raw("cpu")),
+		"bad aggregate":  Select(Field{Func: "drop", Name: "cpu"}).From("m"),
+		"bad window":     Select(Raw("cpu")).From("m").SinceLiteral("10min"),
+	}
+	for name, q := range cases {
+		if _, err := q.Build(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		in        string
+		expected  time.Duration
+		formatted string
+	}{
+		{"5m", 5 * time.Minute, "5m"},
+		{"30s", 30 * time.Second, "30s"},
+		{"1d", 24 * time.Hour, "24h"},
+		{"2w", 14 * 24 * time.Hour, "336h"},
+	}
+	for _, tc := range cases {
+		d, err := ParseDuration(tc.in)
+		if err != nil || d != tc.expected {
+			t.Errorf("ParseDuration(%q) = %v, %v; expected %v", tc.in, d, err, tc.expected)
+		}
+		if got := formatDuration(d); got != tc.formatted {
+			t.Errorf("formatDuration(%v) = %q; expected %q", d, got, tc.formatted)
+		}
+	}
+}
+
+func TestProfilerMeanDecodesColumns(t *testing.T) {
+	store := &fakeStore{results: []client.Result{{
+		Series: []models.Row{{
+			Columns: []string{"time", "cpu", "memory", "rps", "rtime", "r2xx", "r5xx", "replicas"},
+			Values: [][]interface{}{{
+				"2020-11-01T00:00:00Z", json.Number("42.5"), json.Number("1024"), json.Number("10"),
+				json.Number("120"), json.Number("0.5"), nil, json.Number("3"),
+			}},
+		}},
+	}}}
+	profile, err := NewProfiler(store).Mean("cn-north-1", "app-1", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.CPU != 42.5 || profile.Memory != 1024 || profile.Replicas != 3 || profile.R5xx != 0 {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+	if !profile.Time.Equal(time.Date(2020, 11, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected time %v", profile.Time)
+	}
+	if len(store.commands) != 1 {
+		t.Fatalf("expected one query, got %v", store.commands)
+	}
+}
+
+func TestProfilerEmptySeries(t *testing.T) {
+	store := &fakeStore{results: []client.Result{{}}}
+	if _, err := NewProfiler(store).Average("cn-north-1", "app-1", "cpu", time.Hour); err != ErrNoData {
+		t.Errorf("expected ErrNoData, got %v", err)
+	}
+	if _, err := NewProfiler(store).Mean("cn-north-1", "app-1", time.Hour); err != ErrNoData {
+		t.Errorf("expected ErrNoData, got %v", err)
+	}
+}
+
+func TestProfilerWriteRPI(t *testing.T) {
+	store := &fakeStore{}
+	p := NewProfiler(store)
+	if err := p.WriteRPI("cn-north-1", "app-1", 100, 0); err == nil {
+		t.Errorf("expected an error for zero replicas")
+	}
+	if err := p.WriteRPI("cn-north-1", "app-1", 100, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(store.points) != 1 || store.points[0].Name() != "cn-north-1_rpi" {
+		t.Fatalf("unexpected points %v", store.points)
+	}
+	fields, _ := store.points[0].Fields()
+	if fields["rpi"] != int64(25) {
+		t.Errorf("expected rpi 25, got %v", fields["rpi"])
+	}
+}
+
+func TestAppMetrics(t *testing.T) {
+	store := &fakeStore{results: []client.Result{{
+		Series: []models.Row{{
+			Columns: []string{"time", "cpu", "memory", "rps", "rtime", "r2xx", "r4xx", "r5xx", "r5xx_route"},
+			Values: [][]interface{}{{
+				json.Number("0"), json.Number("37.5"), json.Number("2048"), json.Number("120"),
+				json.Number("45"), json.Number("110"), json.Number("7"), json.Number("3"), nil,
+			}},
+		}},
+	}}}
+	m, err := appMetrics(store, "cn-north-1", "app-1", "5m")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := model.AppMetric{
+		App: "app-1", Cpu: 37.5, Memory: 2048, Request: 120, Response: 45,
+		Response2xx: 110, Response4xx: 7, Response5xx: 3,
+	}
+	if *m != expected {
+		t.Errorf("got %+v, expected %+v", *m, expected)
+	}
+	cmd := `SELECT LAST("cpu") AS "cpu",LAST("memory") AS "memory",LAST("rps") AS "rps",LAST("rtime") AS "rtime",` +
+		`LAST("r2xx") AS "r2xx",LAST("r4xx") AS "r4xx",LAST("r5xx") AS "r5xx",LAST("r5xx_route") AS "r5xx_route" ` +
+		`FROM "cn-north-1" WHERE "app" =~ /app-1/ AND time > now() - 5m`
+	if len(store.commands) != 1 || store.commands[0] != cmd {
+		t.Errorf("got\n%v\nexpected\n%s", store.commands, cmd)
+	}
+}
+
+func TestAppMetricsErrors(t *testing.T) {
+	if _, err := appMetrics(&fakeStore{}, "cn-north-1", "app-1", "5min"); err == nil {
+		t.Errorf("expected an error for an invalid window")
+	}
+	if _, err := appMetrics(&fakeStore{results: []client.Result{{}}}, "cn-north-1", "app-1", "5m"); err != ErrNoData {
+		t.Errorf("expected ErrNoData, got %v", err)
+	}
+}
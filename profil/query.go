@@ -0,0 +1,258 @@
+package profil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// aggregates lists the InfluxQL functions a Query is allowed to select.
+var aggregates = map[string]bool{
+	"":       true,
+	"mean":   true,
+	"median": true,
+	"max":    true,
+	"min":    true,
+	"sum":    true,
+	"count":  true,
+	"last":   true,
+	"first":  true,
+	"stddev": true,
+	"spread": true,
+}
+
+var influxDurationRegexp = regexp.MustCompile(`^([0-9]+)(ns|u|µ|ms|s|m|h|d|w)$`)
+
+// QuoteIdent returns s as a double-quoted InfluxQL identifier, so that
+// measurement, tag and field names containing dashes, spaces or quotes
+// cannot change the meaning of a statement.
+func QuoteIdent(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// QuoteString returns s as a single-quoted InfluxQL string literal.
+func QuoteString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`)
+	return `'` + r.Replace(s) + `'`
+}
+
+// QuoteRegex returns a regex literal matching s verbatim anywhere in a tag
+// value. Regex metacharacters and the `/` delimiter are escaped.
+func QuoteRegex(s string) string {
+	return "/" + strings.Replace(regexp.QuoteMeta(s), "/", `\/`, -1) + "/"
+}
+
+// ParseDuration parses an InfluxQL duration literal such as "5m" or "1d".
+// Unlike time.ParseDuration it accepts the day and week units InfluxQL uses.
+func ParseDuration(s string) (time.Duration, error) {
+	m := influxDurationRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	n, err := strconv.ParseInt(m[1], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %v", s, err)
+	}
+	var unit time.Duration
+	switch m[2] {
+	case "ns":
+		unit = time.Nanosecond
+	case "u", "µ":
+		unit = time.Microsecond
+	case "ms":
+		unit = time.Millisecond
+	case "s":
+		unit = time.Second
+	case "m":
+		unit = time.Minute
+	case "h":
+		unit = time.Hour
+	case "d":
+		unit = 24 * time.Hour
+	case "w":
+		unit = 7 * 24 * time.Hour
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// formatDuration renders d as an InfluxQL duration literal using the
+// largest unit that represents it exactly.
+func formatDuration(d time.Duration) string {
+	units := []struct {
+		d      time.Duration
+		suffix string
+	}{
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+		{time.Millisecond, "ms"},
+		{time.Microsecond, "u"},
+	}
+	for _, u := range units {
+		if d%u.d == 0 {
+			return strconv.FormatInt(int64(d/u.d), 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns"
+}
+
+// Field is a single selected column, optionally wrapped in an aggregate.
+type Field struct {
+	Func  string
+	Name  string
+	Alias string
+}
+
+// Raw selects the field as stored.
+func Raw(name string) Field { return Field{Name: name} }
+
+// Mean selects MEAN(name) AS name.
+func Mean(name string) Field { return Field{Func: "mean", Name: name, Alias: name} }
+
+// Stddev selects STDDEV(name) AS name.
+func Stddev(name string) Field { return Field{Func: "stddev", Name: name, Alias: name} }
+
+// Last selects LAST(name) AS name.
+func Last(name string) Field { return Field{Func: "last", Name: name, Alias: name} }
+
+// Spread selects SPREAD(name) AS name.
+func Spread(name string) Field { return Field{Func: "spread", Name: name, Alias: name} }
+
+// As returns a copy of f selected under a different column name.
+func (f Field) As(alias string) Field {
+	f.Alias = alias
+	return f
+}
+
+func (f Field) String() string {
+	s := QuoteIdent(f.Name)
+	if f.Func != "" {
+		s = strings.ToUpper(f.Func) + "(" + s + ")"
+	}
+	if f.Alias != "" {
+		s += " AS " + QuoteIdent(f.Alias)
+	}
+	return s
+}
+
+// Query builds a single InfluxQL SELECT statement. Every user supplied
+// identifier and value is quoted, so region or instance names coming from
+// requests cannot inject additional clauses.
+type Query struct {
+	fields      []Field
+	measurement string
+	conditions  []string
+	since       time.Duration
+	groupBy     []string
+	limit       int
+	err         error
+}
+
+// Select starts a query selecting the given fields.
+func Select(fields ...Field) *Query {
+	return &Query{fields: fields}
+}
+
+// From sets the measurement to read from.
+func (q *Query) From(measurement string) *Query {
+	q.measurement = measurement
+	return q
+}
+
+// WhereTag adds a `tag = 'value'` condition.
+func (q *Query) WhereTag(tag, value string) *Query {
+	q.conditions = append(q.conditions, QuoteIdent(tag)+" = "+QuoteString(value))
+	return q
+}
+
+// WhereTagContains adds a `tag =~ /value/` condition, with value matched
+// literally.
+func (q *Query) WhereTagContains(tag, value string) *Query {
+	q.conditions = append(q.conditions, QuoteIdent(tag)+" =~ "+QuoteRegex(value))
+	return q
+}
+
+// Since restricts the query to points newer than now() - d.
+func (q *Query) Since(d time.Duration) *Query {
+	if d <= 0 {
+		q.err = fmt.Errorf("time window must be positive, got %v", d)
+	}
+	q.since = d
+	return q
+}
+
+// SinceLiteral is like Since but takes an InfluxQL duration literal.
+func (q *Query) SinceLiteral(s string) *Query {
+	d, err := ParseDuration(s)
+	if err != nil {
+		q.err = err
+		return q
+	}
+	return q.Since(d)
+}
+
+// GroupBy returns one series per distinct combination of the given tags.
+func (q *Query) GroupBy(tags ...string) *Query {
+	q.groupBy = append(q.groupBy, tags...)
+	return q
+}
+
+// Limit caps the number of returned points.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Build returns the statement text or the first error recorded while the
+// query was assembled.
+func (q *Query) Build() (string, error) {
+	if q.err != nil {
+		return "", q.err
+	}
+	if len(q.fields) == 0 {
+		return "", fmt.Errorf("query selects no fields")
+	}
+	if q.measurement == "" {
+		return "", fmt.Errorf("query has no measurement")
+	}
+	fields := make([]string, 0, len(q.fields))
+	for _, f := range q.fields {
+		if f.Name == "" {
+			return "", fmt.Errorf("query selects a field with an empty name")
+		}
+		if !aggregates[strings.ToLower(f.Func)] {
+			return "", fmt.Errorf("unsupported aggregate %q", f.Func)
+		}
+		fields = append(fields, f.String())
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT ")
+	b.WriteString(strings.Join(fields, ","))
+	b.WriteString(" FROM ")
+	b.WriteString(QuoteIdent(q.measurement))
+	conditions := q.conditions
+	if q.since > 0 {
+		conditions = append(conditions[:len(conditions):len(conditions)], "time > now() - "+formatDuration(q.since))
+	}
+	if len(conditions) > 0 {
+		b.WriteString(" WHERE ")
+		b.WriteString(strings.Join(conditions, " AND "))
+	}
+	if len(q.groupBy) > 0 {
+		tags := make([]string, 0, len(q.groupBy))
+		for _, t := range q.groupBy {
+			tags = append(tags, QuoteIdent(t))
+		}
+		b.WriteString(" GROUP BY ")
+		b.WriteString(strings.Join(tags, ","))
+	}
+	if q.limit > 0 {
+		b.WriteString(" LIMIT ")
+		b.WriteString(strconv.Itoa(q.limit))
+	}
+	return b.String(), nil
+}
@@ -10,6 +10,8 @@ import (
 		"github.com/shirou/gopsutil/net"
 	*/
 
+	influx "github.com/influxdata/influxdb1-client/v2"
+
 	"github.com/turtacn/cloud-prophet/model"
 )
 
@@ -51,22 +53,140 @@ func GetNodeResource(w http.ResponseWriter, r *http.Request) {
 }
 */
 
-// podlist
-func GetAllPod() error {
-	return nil
+var defaultInventory Inventory
+
+// UseInventory makes the package level pod helpers below read from inv.
+// Until it is called they report an empty cluster.
+func UseInventory(inv Inventory) {
+	defaultInventory = inv
+}
+
+// GetAllPod returns every pod of the default region.
+func GetAllPod() ([]PodStatus, error) {
+	if defaultInventory == nil {
+		return nil, nil
+	}
+	return defaultInventory.Pods("")
 }
 
+// GetAllRunningPod returns the running state of every pod of the default
+// region, keyed by namespace/name.
 func GetAllRunningPod() map[string]bool {
-	return nil
+	if defaultInventory == nil {
+		return nil
+	}
+	pods, err := defaultInventory.Pods("")
+	if err != nil {
+		return nil
+	}
+	res := make(map[string]bool, len(pods))
+	for _, p := range pods {
+		res[p.Namespace+"/"+p.Name] = p.Running
+	}
+	return res
 }
 
+// GetRunningPodStatus returns the namespace/name of the running pods of
+// region.
 func GetRunningPodStatus(region string) []string {
-	return nil
+	if defaultInventory == nil {
+		return nil
+	}
+	pods, err := defaultInventory.Pods(region)
+	if err != nil {
+		return nil
+	}
+	var res []string
+	for _, p := range pods {
+		if p.Running {
+			res = append(res, p.Namespace+"/"+p.Name)
+		}
+	}
+	return res
 }
 
+// GetInstanceCount returns the number of running pods of region scheduled
+// on the host with hostIp.
 func GetInstanceCount(region, hostIp string) int {
-	return 0
+	if defaultInventory == nil {
+		return 0
+	}
+	pods, err := defaultInventory.Pods(region)
+	if err != nil {
+		return 0
+	}
+	count := 0
+	for _, p := range pods {
+		if p.Running && p.HostIP == hostIp {
+			count++
+		}
+	}
+	return count
+}
+
+// GetAppReplicas returns the number of pods of app in region, as last
+// written by the collector, summed over the namespaces of app.
+func GetAppReplicas(region, app string) int {
+	if defaultInventory == nil {
+		return 0
+	}
+	apps, err := defaultInventory.Apps(region)
+	if err != nil {
+		return 0
+	}
+	replicas := 0
+	for _, a := range apps {
+		if a.App == app {
+			replicas += int(a.Replicas)
+		}
+	}
+	return replicas
+}
+
+// appMetric holds the columns of the application measurement that make up
+// a model.AppMetric.
+type appMetric struct {
+	CPU       float32 `influx:"cpu"`
+	Memory    int64   `influx:"memory"`
+	RPS       int64   `influx:"rps"`
+	RTime     int64   `influx:"rtime"`
+	R2xx      int64   `influx:"r2xx"`
+	R4xx      int64   `influx:"r4xx"`
+	R5xx      int64   `influx:"r5xx"`
+	R5xxRoute int64   `influx:"r5xx_route"`
+}
+
+// GetAppMetrics returns the last metrics app reported in region within
+// timeLength, an InfluxQL duration such as "5m".
+func GetAppMetrics(conn influx.Client, region, app, timeLength string) (*model.AppMetric, error) {
+	return appMetrics(NewStore(conn, DefaultConfig()), region, app, timeLength)
 }
-func GetAppMetrics() *model.AppMetric {
-	return nil
+
+func appMetrics(store Store, region, app, timeLength string) (*model.AppMetric, error) {
+	cmd, err := Select(
+		Last("cpu"), Last("memory"), Last("rps"), Last("rtime"),
+		Last("r2xx"), Last("r4xx"), Last("r5xx"), Last("r5xx_route"),
+	).From(region).WhereTagContains("app", app).SinceLiteral(timeLength).Build()
+	if err != nil {
+		return nil, err
+	}
+	res, err := store.Query(cmd)
+	if err != nil {
+		return nil, err
+	}
+	m := &appMetric{}
+	if err := DecodeFirst(res, m); err != nil {
+		return nil, err
+	}
+	return &model.AppMetric{
+		App:              app,
+		Cpu:              m.CPU,
+		Memory:           m.Memory,
+		Request:          m.RPS,
+		Response:         m.RTime,
+		Response2xx:      m.R2xx,
+		Response4xx:      m.R4xx,
+		Response5xx:      m.R5xx,
+		Response5xxRoute: m.R5xxRoute,
+	}, nil
 }
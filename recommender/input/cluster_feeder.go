@@ -95,17 +95,21 @@ func (m ClusterStateFeederFactory) Make() *clusterStateFeeder {
 }
 
 // NewClusterStateFeeder creates new ClusterStateFeeder with internal data providers, based on kube client config.
+// A nil metricsClient reads usage from the Metrics API.
 // Deprecated; Use ClusterStateFeederFactory instead.
-func NewClusterStateFeeder(config *rest.Config, clusterState *model.ClusterState, memorySave bool, namespace string) ClusterStateFeeder {
+func NewClusterStateFeeder(config *rest.Config, clusterState *model.ClusterState, memorySave bool, namespace string, metricsClient metrics.MetricsClient) ClusterStateFeeder {
 	kubeClient := kube_client.NewForConfigOrDie(config)
 	podLister, oomObserver := NewPodListerAndOOMObserver(kubeClient, namespace)
 	factory := informers.NewSharedInformerFactoryWithOptions(kubeClient, defaultResyncPeriod, informers.WithNamespace(namespace))
 	controllerFetcher := controllerfetcher.NewControllerFetcher(config, kubeClient, factory)
+	if metricsClient == nil {
+		metricsClient = newMetricsClient(config, namespace)
+	}
 	return ClusterStateFeederFactory{
 		PodLister:           podLister,
 		OOMObserver:         oomObserver,
 		KubeClient:          kubeClient,
-		MetricsClient:       newMetricsClient(config, namespace),
+		MetricsClient:       metricsClient,
 		VpaCheckpointClient: VpaCheckpointClient,
 		VpaLister:           vpa_api_util.NewVpasLister(VpaCheckpointClient, make(chan struct{}), namespace),
 		ClusterState:        clusterState,
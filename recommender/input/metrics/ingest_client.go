@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+	"github.com/turtacn/cloud-prophet/recommender/model"
+	"k8s.io/klog"
+)
+
+// IngestClientConfig names the tags and fields container samples are read
+// from.
+type IngestClientConfig struct {
+	// Measurement holding container usage, e.g. "kubernetes_pod_container".
+	Measurement                                string
+	NamespaceTag, PodNameTag, ContainerNameTag string
+	// CPUField holds usage in cores times CPUScale, MemoryField in bytes.
+	CPUField, MemoryField string
+	// CPUScale converts CPUField into cores, e.g. 1e-9 for nanocores.
+	// Zero means 1.
+	CPUScale float64
+	// Window reported as the SnapshotWindow of every sample.
+	Window time.Duration
+}
+
+// DefaultIngestClientConfig returns the names used by Telegraf's
+// kubernetes input.
+func DefaultIngestClientConfig() IngestClientConfig {
+	return IngestClientConfig{
+		Measurement:      "kubernetes_pod_container",
+		NamespaceTag:     "namespace",
+		PodNameTag:       "pod_name",
+		ContainerNameTag: "container_name",
+		CPUField:         "cpu_usage_nanocores",
+		MemoryField:      "memory_working_set_bytes",
+		CPUScale:         1e-9,
+		Window:           time.Minute,
+	}
+}
+
+// IngestClient is a MetricsClient fed by the ingestion gateway. It
+// implements the gateway's Sink interface, buffers the container samples
+// it receives and hands them to the ClusterStateFeeder on the next
+// GetContainersMetrics call. Use it both as a gateway sink and as the
+// MetricsClient of a ClusterStateFeederFactory.
+type IngestClient struct {
+	config IngestClientConfig
+
+	mu      sync.Mutex
+	pending map[model.ContainerID]*ContainerMetricsSnapshot
+	dropped int
+}
+
+// NewIngestClient returns an IngestClient for config.
+func NewIngestClient(config IngestClientConfig) *IngestClient {
+	return &IngestClient{
+		config:  config,
+		pending: make(map[model.ContainerID]*ContainerMetricsSnapshot),
+	}
+}
+
+// Write buffers the container samples among points. Points of other
+// measurements are ignored, samples that do not identify a container are
+// dropped and counted without failing the rest of the batch.
+func (c *IngestClient) Write(points []*client.Point) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dropped := 0
+	var firstErr error
+	for _, p := range points {
+		if p.Name() != c.config.Measurement {
+			continue
+		}
+		snapshot, err := c.toSnapshot(p)
+		if err != nil {
+			dropped++
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		// Only the latest sample of a container is kept between two reads.
+		if prev, ok := c.pending[snapshot.ID]; ok && prev.SnapshotTime.After(snapshot.SnapshotTime) {
+			continue
+		}
+		c.pending[snapshot.ID] = snapshot
+	}
+	if dropped > 0 {
+		c.dropped += dropped
+		klog.Warningf("Dropped %d of %d container samples: %v", dropped, len(points), firstErr)
+	}
+	return nil
+}
+
+// Dropped returns the number of samples dropped by Write so far.
+func (c *IngestClient) Dropped() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.dropped
+}
+
+func (c *IngestClient) toSnapshot(p *client.Point) (*ContainerMetricsSnapshot, error) {
+	tags := p.Tags()
+	id := model.ContainerID{
+		PodID: model.PodID{
+			Namespace: tags[c.config.NamespaceTag],
+			PodName:   tags[c.config.PodNameTag],
+		},
+		ContainerName: tags[c.config.ContainerNameTag],
+	}
+	if id.Namespace == "" || id.PodName == "" || id.ContainerName == "" {
+		return nil, fmt.Errorf("%s: missing one of the %s, %s, %s tags", p.Name(), c.config.NamespaceTag, c.config.PodNameTag, c.config.ContainerNameTag)
+	}
+	fields, err := p.Fields()
+	if err != nil {
+		return nil, err
+	}
+	usage := model.Resources{}
+	if v, ok := toFloat(fields[c.config.CPUField]); ok {
+		if c.config.CPUScale != 0 {
+			v *= c.config.CPUScale
+		}
+		usage[model.ResourceCPU] = model.CPUAmountFromCores(v)
+	}
+	if v, ok := toFloat(fields[c.config.MemoryField]); ok {
+		usage[model.ResourceMemory] = model.MemoryAmountFromBytes(v)
+	}
+	return &ContainerMetricsSnapshot{
+		ID:             id,
+		SnapshotTime:   p.Time(),
+		SnapshotWindow: c.config.Window,
+		Usage:          usage,
+	}, nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case int64:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	}
+	return 0, false
+}
+
+// GetContainersMetrics returns the samples received since the last call.
+func (c *IngestClient) GetContainersMetrics() ([]*ContainerMetricsSnapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshots := make([]*ContainerMetricsSnapshot, 0, len(c.pending))
+	for _, s := range c.pending {
+		snapshots = append(snapshots, s)
+	}
+	c.pending = make(map[model.ContainerID]*ContainerMetricsSnapshot)
+	return snapshots, nil
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/turtacn/cloud-prophet/recommender/model"
+)
+
+func newContainerPoint(t *testing.T, measurement string, tags map[string]string, cpu int64, ts time.Time) *client.Point {
+	p, err := client.NewPoint(measurement, tags, map[string]interface{}{
+		"cpu_usage_nanocores":      cpu,
+		"memory_working_set_bytes": int64(64 << 20),
+	}, ts)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestIngestClientBuffersLatestSample(t *testing.T) {
+	c := NewIngestClient(DefaultIngestClientConfig())
+	tags := map[string]string{"namespace": "default", "pod_name": "web-1", "container_name": "web"}
+	points := []*client.Point{
+		newContainerPoint(t, "kubernetes_pod_container", tags, 500000000, time.Unix(20, 0)),
+		newContainerPoint(t, "kubernetes_pod_container", tags, 250000000, time.Unix(10, 0)),
+		newContainerPoint(t, "kubernetes_node", tags, 1000000000, time.Unix(30, 0)),
+	}
+
+	assert.NoError(t, c.Write(points))
+	snapshots, err := c.GetContainersMetrics()
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots, 1) {
+		s := snapshots[0]
+		assert.Equal(t, model.ContainerID{PodID: model.PodID{Namespace: "default", PodName: "web-1"}, ContainerName: "web"}, s.ID)
+		assert.Equal(t, time.Unix(20, 0), s.SnapshotTime)
+		assert.Equal(t, time.Minute, s.SnapshotWindow)
+		assert.Equal(t, model.CPUAmountFromCores(0.5), s.Usage[model.ResourceCPU])
+		assert.Equal(t, model.MemoryAmountFromBytes(64<<20), s.Usage[model.ResourceMemory])
+	}
+
+	snapshots, err = c.GetContainersMetrics()
+	assert.NoError(t, err)
+	assert.Empty(t, snapshots, "samples should only be returned once")
+}
+
+func TestIngestClientDropsSamplesWithoutContainer(t *testing.T) {
+	c := NewIngestClient(DefaultIngestClientConfig())
+	points := []*client.Point{
+		newContainerPoint(t, "kubernetes_pod_container", map[string]string{"namespace": "default", "pod_name": "web-1"}, 1, time.Unix(10, 0)),
+		newContainerPoint(t, "kubernetes_pod_container", map[string]string{"namespace": "default", "pod_name": "web-2", "container_name": "web"}, 1, time.Unix(10, 0)),
+		newContainerPoint(t, "kubernetes_pod_container", map[string]string{"pod_name": "web-3", "container_name": "web"}, 1, time.Unix(10, 0)),
+	}
+
+	assert.NoError(t, c.Write(points))
+	assert.Equal(t, 2, c.Dropped())
+	snapshots, err := c.GetContainersMetrics()
+	assert.NoError(t, err)
+	if assert.Len(t, snapshots, 1) {
+		assert.Equal(t, "web-2", snapshots[0].ID.PodName)
+	}
+}
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+)
+
+// Format is an output format of the report.
+type Format string
+
+// Supported formats.
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatHTML Format = "html"
+)
+
+// Write writes the report in format. CSV has the lines of level only.
+func (r *Report) Write(w io.Writer, format Format, level Level) error {
+	switch format {
+	case FormatCSV:
+		return r.WriteCSV(w, level)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	case FormatHTML:
+		return r.WriteHTML(w)
+	default:
+		return fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+var csvHeader = []string{
+	"namespace", "workload", "pod", "container", "containers", "recommended",
+	"request_cpu", "recommended_cpu", "slack_cpu",
+	"request_memory", "recommended_memory", "slack_memory",
+	"cost", "idle_cost", "savings",
+}
+
+// WriteCSV writes the lines of level followed by the total.
+func (r *Report) WriteCSV(w io.Writer, level Level) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+	lines := append(append([]*Line(nil), r.Lines(level)...), &r.Total)
+	for _, l := range lines {
+		record := []string{
+			l.Namespace, l.Workload, l.Pod, l.Container,
+			strconv.Itoa(l.Containers), strconv.Itoa(l.Recommended),
+			formatFloat(l.RequestCPU), formatFloat(l.RecommendedCPU), formatFloat(l.SlackCPU),
+			formatFloat(l.RequestMemory), formatFloat(l.RecommendedMemory), formatFloat(l.SlackMemory),
+			formatFloat(l.Cost), formatFloat(l.IdleCost), formatFloat(l.Savings),
+		}
+		if l == &r.Total {
+			record[0] = "total"
+		}
+		if err := out.Write(record); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
+
+// WriteJSON writes the whole report.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteHTML writes a summary page with the namespaces, the workloads and
+// the most idle containers.
+func (r *Report) WriteHTML(w io.Writer) error {
+	containers := r.Containers
+	if len(containers) > maxHTMLContainers {
+		containers = containers[:maxHTMLContainers]
+	}
+	return htmlTemplate.Execute(w, struct {
+		*Report
+		TopContainers []*Line
+	}{r, containers})
+}
+
+const maxHTMLContainers = 100
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"money": func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) },
+	"cores": func(v float64) string { return strconv.FormatFloat(v, 'f', 3, 64) },
+	"mib":   func(v float64) string { return strconv.FormatFloat(v/(1<<20), 'f', 0, 64) },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Slack cost report</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; }
+td.n { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Slack cost report</h1>
+<p>Generated {{.Generated.Format "2006-01-02 15:04:05 MST"}}. Costs in {{.Prices.Currency}} per month.</p>
+<p>Requested {{money .Total.Cost}}, idle {{money .Total.IdleCost}}, potential savings {{money .Total.Savings}}
+for {{.Total.Containers}} containers, {{.Total.Recommended}} with a recommendation.</p>
+{{define "lines"}}
+<table>
+<tr><th>Namespace</th><th>Workload</th><th>Pod</th><th>Container</th>
+<th>CPU requested</th><th>CPU recommended</th><th>Memory requested (MiB)</th><th>Memory recommended (MiB)</th>
+<th>Cost</th><th>Idle cost</th><th>Savings</th></tr>
+{{range .}}<tr><td>{{.Namespace}}</td><td>{{.Workload}}</td><td>{{.Pod}}</td><td>{{.Container}}</td>
+<td class="n">{{cores .RequestCPU}}</td><td class="n">{{cores .RecommendedCPU}}</td>
+<td class="n">{{mib .RequestMemory}}</td><td class="n">{{mib .RecommendedMemory}}</td>
+<td class="n">{{money .Cost}}</td><td class="n">{{money .IdleCost}}</td><td class="n">{{money .Savings}}</td></tr>
+{{end}}</table>
+{{end}}
+<h2>Namespaces</h2>
+{{template "lines" .Namespaces}}
+<h2>Workloads</h2>
+{{template "lines" .Workloads}}
+<h2>Top containers</h2>
+{{template "lines" .TopContainers}}
+</body>
+</html>
+`))
@@ -0,0 +1,69 @@
+package report
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"sigs.k8s.io/yaml"
+)
+
+// DefaultHoursPerMonth is the average number of hours in a month.
+const DefaultHoursPerMonth = 730
+
+// Prices are hourly prices of requested resources.
+type Prices struct {
+	// CPU is the price of one core for one hour.
+	CPU float64 `json:"cpu"`
+	// Memory is the price of one GiB for one hour.
+	Memory float64 `json:"memory"`
+}
+
+// PriceTable maps resources to prices, optionally per namespace, e.g. to
+// account for namespaces bound to more expensive node pools.
+type PriceTable struct {
+	Currency      string            `json:"currency,omitempty"`
+	HoursPerMonth float64           `json:"hoursPerMonth,omitempty"`
+	Default       Prices            `json:"default"`
+	Namespaces    map[string]Prices `json:"namespaces,omitempty"`
+}
+
+// DefaultPriceTable returns on-demand list prices of a general purpose
+// instance, split between cores and memory.
+func DefaultPriceTable() *PriceTable {
+	return &PriceTable{
+		Currency:      "USD",
+		HoursPerMonth: DefaultHoursPerMonth,
+		Default:       Prices{CPU: 0.0316, Memory: 0.0042},
+	}
+}
+
+// LoadPriceTable reads a YAML or JSON price table.
+func LoadPriceTable(path string) (*PriceTable, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	table := DefaultPriceTable()
+	table.Default = Prices{}
+	if err := yaml.UnmarshalStrict(data, table); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if table.HoursPerMonth <= 0 {
+		return nil, fmt.Errorf("%s: hoursPerMonth must be positive", path)
+	}
+	return table, nil
+}
+
+// For returns the prices in namespace.
+func (t *PriceTable) For(namespace string) Prices {
+	if p, ok := t.Namespaces[namespace]; ok {
+		return p
+	}
+	return t.Default
+}
+
+// Monthly returns the monthly cost of cores and bytes in namespace.
+func (t *PriceTable) Monthly(namespace string, cores, bytes float64) float64 {
+	p := t.For(namespace)
+	return (cores*p.CPU + bytes/(1<<30)*p.Memory) * t.HoursPerMonth
+}
@@ -0,0 +1,241 @@
+package report
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/recommender/model"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+// Container is the input of the report for a single container.
+type Container struct {
+	ID model.ContainerID
+	// Workload groups containers, e.g. "Deployment/web". Empty means the
+	// pod itself.
+	Workload string
+	// Request is the currently requested resources.
+	Request model.Resources
+	// Recommendation is the recommended target. Nil if there is none yet.
+	Recommendation model.Resources
+}
+
+// Level is the aggregation level of report lines.
+type Level string
+
+const (
+	// LevelContainer has one line per container.
+	LevelContainer Level = "container"
+	// LevelWorkload aggregates the containers of a workload.
+	LevelWorkload Level = "workload"
+	// LevelNamespace aggregates the containers of a namespace.
+	LevelNamespace Level = "namespace"
+)
+
+// Line is the slack of one container or of an aggregate of containers.
+// CPU is in cores, memory in bytes and costs per month.
+type Line struct {
+	Namespace string `json:"namespace,omitempty"`
+	Workload  string `json:"workload,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	Container string `json:"container,omitempty"`
+	// Containers is the number of containers in the line, Recommended the
+	// number of them with a recommendation. Containers without one count as
+	// requesting exactly what they need.
+	Containers  int `json:"containers"`
+	Recommended int `json:"recommended"`
+
+	RequestCPU        float64 `json:"requestCPU"`
+	RecommendedCPU    float64 `json:"recommendedCPU"`
+	RequestMemory     float64 `json:"requestMemory"`
+	RecommendedMemory float64 `json:"recommendedMemory"`
+	// SlackCPU and SlackMemory are requested minus recommended. They are
+	// negative for under-provisioned lines.
+	SlackCPU    float64 `json:"slackCPU"`
+	SlackMemory float64 `json:"slackMemory"`
+
+	// Cost is the cost of the requests.
+	Cost float64 `json:"cost"`
+	// IdleCost is the cost of the over-provisioned part of every container
+	// and resource.
+	IdleCost float64 `json:"idleCost"`
+	// Savings is the cost of the requests minus the cost of the
+	// recommendations: the idle cost net of what under-provisioned
+	// containers would need on top.
+	Savings float64 `json:"savings"`
+}
+
+func (l *Line) add(o *Line) {
+	l.Containers += o.Containers
+	l.Recommended += o.Recommended
+	l.RequestCPU += o.RequestCPU
+	l.RecommendedCPU += o.RecommendedCPU
+	l.RequestMemory += o.RequestMemory
+	l.RecommendedMemory += o.RecommendedMemory
+	l.SlackCPU += o.SlackCPU
+	l.SlackMemory += o.SlackMemory
+	l.Cost += o.Cost
+	l.IdleCost += o.IdleCost
+	l.Savings += o.Savings
+}
+
+// Report is the slack cost of a set of containers. Lines are sorted by
+// decreasing idle cost.
+type Report struct {
+	Generated  time.Time   `json:"generated"`
+	Prices     *PriceTable `json:"prices"`
+	Containers []*Line     `json:"containers"`
+	Workloads  []*Line     `json:"workloads"`
+	Namespaces []*Line     `json:"namespaces"`
+	Total      Line        `json:"total"`
+}
+
+// Lines returns the lines of level.
+func (r *Report) Lines(level Level) []*Line {
+	switch level {
+	case LevelWorkload:
+		return r.Workloads
+	case LevelNamespace:
+		return r.Namespaces
+	default:
+		return r.Containers
+	}
+}
+
+// Build computes the report of containers priced with prices.
+func Build(containers []Container, prices *PriceTable, now time.Time) *Report {
+	r := &Report{Generated: now, Prices: prices}
+	workloads := make(map[[2]string]*Line)
+	namespaces := make(map[string]*Line)
+	for i := range containers {
+		c := &containers[i]
+		line := containerLine(c, prices)
+		r.Containers = append(r.Containers, line)
+
+		key := [2]string{line.Namespace, line.Workload}
+		w, ok := workloads[key]
+		if !ok {
+			w = &Line{Namespace: line.Namespace, Workload: line.Workload}
+			workloads[key] = w
+			r.Workloads = append(r.Workloads, w)
+		}
+		w.add(line)
+		ns, ok := namespaces[line.Namespace]
+		if !ok {
+			ns = &Line{Namespace: line.Namespace}
+			namespaces[line.Namespace] = ns
+			r.Namespaces = append(r.Namespaces, ns)
+		}
+		ns.add(line)
+		r.Total.add(line)
+	}
+	for _, lines := range [][]*Line{r.Containers, r.Workloads, r.Namespaces} {
+		sortLines(lines)
+	}
+	return r
+}
+
+func containerLine(c *Container, prices *PriceTable) *Line {
+	ns := c.ID.Namespace
+	workload := c.Workload
+	if workload == "" {
+		workload = "Pod/" + c.ID.PodName
+	}
+	line := &Line{
+		Namespace:     ns,
+		Workload:      workload,
+		Pod:           c.ID.PodName,
+		Container:     c.ID.ContainerName,
+		Containers:    1,
+		RequestCPU:    model.CoresFromCPUAmount(c.Request[model.ResourceCPU]),
+		RequestMemory: model.BytesFromMemoryAmount(c.Request[model.ResourceMemory]),
+	}
+	line.RecommendedCPU, line.RecommendedMemory = line.RequestCPU, line.RequestMemory
+	if c.Recommendation != nil {
+		line.Recommended = 1
+		if v, ok := c.Recommendation[model.ResourceCPU]; ok {
+			line.RecommendedCPU = model.CoresFromCPUAmount(v)
+		}
+		if v, ok := c.Recommendation[model.ResourceMemory]; ok {
+			line.RecommendedMemory = model.BytesFromMemoryAmount(v)
+		}
+	}
+	line.SlackCPU = line.RequestCPU - line.RecommendedCPU
+	line.SlackMemory = line.RequestMemory - line.RecommendedMemory
+	line.Cost = prices.Monthly(ns, line.RequestCPU, line.RequestMemory)
+	line.IdleCost = prices.Monthly(ns, positive(line.SlackCPU), positive(line.SlackMemory))
+	line.Savings = line.Cost - prices.Monthly(ns, line.RecommendedCPU, line.RecommendedMemory)
+	return line
+}
+
+func positive(v float64) float64 {
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+func sortLines(lines []*Line) {
+	sort.SliceStable(lines, func(i, j int) bool {
+		if lines[i].IdleCost != lines[j].IdleCost {
+			return lines[i].IdleCost > lines[j].IdleCost
+		}
+		a := []string{lines[i].Namespace, lines[i].Workload, lines[i].Pod, lines[i].Container}
+		b := []string{lines[j].Namespace, lines[j].Workload, lines[j].Pod, lines[j].Container}
+		return strings.Join(a, "/") < strings.Join(b, "/")
+	})
+}
+
+// FromClusterState collects the requests of every container tracked by the
+// recommender together with the target recommended by the VPA matching its
+// pod. The workload of a container is the target of that VPA.
+func FromClusterState(cluster *model.ClusterState) []Container {
+	type podRecommendation struct {
+		workload string
+		targets  map[string]model.Resources
+	}
+	recommendations := make(map[model.PodID]*podRecommendation)
+	for _, vpa := range cluster.Vpas {
+		if vpa.Recommendation == nil {
+			continue
+		}
+		rec := &podRecommendation{workload: "VerticalPodAutoscaler/" + vpa.ID.VpaName, targets: make(map[string]model.Resources)}
+		if vpa.TargetRef != nil {
+			rec.workload = vpa.TargetRef.Kind + "/" + vpa.TargetRef.Name
+		}
+		for _, c := range vpa.Recommendation.ContainerRecommendations {
+			rec.targets[c.ContainerName] = resourcesFromList(c.Target)
+		}
+		for _, podID := range cluster.GetMatchingPods(vpa) {
+			recommendations[podID] = rec
+		}
+	}
+
+	var res []Container
+	for podID, pod := range cluster.Pods {
+		rec := recommendations[podID]
+		for name, container := range pod.Containers {
+			c := Container{ID: model.ContainerID{PodID: podID, ContainerName: name}, Request: container.Request}
+			if rec != nil {
+				c.Workload = rec.workload
+				if target, ok := rec.targets[name]; ok {
+					c.Recommendation = target
+				}
+			}
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func resourcesFromList(list apiv1.ResourceList) model.Resources {
+	res := make(model.Resources)
+	if q, ok := list[apiv1.ResourceCPU]; ok {
+		res[model.ResourceCPU] = model.ResourceAmount(q.MilliValue())
+	}
+	if q, ok := list[apiv1.ResourceMemory]; ok {
+		res[model.ResourceMemory] = model.ResourceAmount(q.Value())
+	}
+	return res
+}
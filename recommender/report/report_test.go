@@ -0,0 +1,130 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/turtacn/cloud-prophet/recommender/model"
+)
+
+const gib = 1 << 30
+
+var testNow = time.Date(2020, 9, 1, 0, 0, 0, 0, time.UTC)
+
+func testPrices() *PriceTable {
+	return &PriceTable{
+		Currency:      "USD",
+		HoursPerMonth: 100,
+		Default:       Prices{CPU: 1, Memory: 0.5},
+		Namespaces:    map[string]Prices{"gpu": {CPU: 2, Memory: 1}},
+	}
+}
+
+func resources(milliCPU, memory int64) model.Resources {
+	return model.Resources{
+		model.ResourceCPU:    model.ResourceAmount(milliCPU),
+		model.ResourceMemory: model.ResourceAmount(memory),
+	}
+}
+
+func container(namespace, pod, name, workload string, request, recommendation model.Resources) Container {
+	return Container{
+		ID:             model.ContainerID{PodID: model.PodID{Namespace: namespace, PodName: pod}, ContainerName: name},
+		Workload:       workload,
+		Request:        request,
+		Recommendation: recommendation,
+	}
+}
+
+func testContainers() []Container {
+	return []Container{
+		// 1.5 cores and 2 GiB idle.
+		container("default", "web-1", "app", "Deployment/web", resources(2000, 4*gib), resources(500, 2*gib)),
+		// Under-provisioned CPU, 1 GiB idle.
+		container("default", "web-2", "app", "Deployment/web", resources(1000, 2*gib), resources(1500, 1*gib)),
+		// No recommendation yet.
+		container("default", "debug", "shell", "", resources(100, gib), nil),
+		container("gpu", "train-1", "trainer", "Job/train", resources(4000, 8*gib), resources(2000, 8*gib)),
+	}
+}
+
+func TestBuild(t *testing.T) {
+	r := Build(testContainers(), testPrices(), testNow)
+
+	assert.Len(t, r.Containers, 4)
+	web1 := r.Containers[1]
+	assert.Equal(t, "web-1", web1.Pod)
+	assert.InDelta(t, 1.5, web1.SlackCPU, 1e-9)
+	assert.InDelta(t, 2*gib, web1.SlackMemory, 1e-3)
+	assert.InDelta(t, (2+4*0.5)*100, web1.Cost, 1e-9)
+	assert.InDelta(t, (1.5+2*0.5)*100, web1.IdleCost, 1e-9)
+	assert.InDelta(t, web1.IdleCost, web1.Savings, 1e-9)
+
+	web2 := r.Containers[2]
+	assert.Equal(t, "web-2", web2.Pod)
+	assert.InDelta(t, -0.5, web2.SlackCPU, 1e-9)
+	assert.InDelta(t, 0.5*100, web2.IdleCost, 1e-9)
+	assert.InDelta(t, (-0.5+0.5)*100, web2.Savings, 1e-9)
+
+	debug := r.Containers[3]
+	assert.Equal(t, "Pod/debug", debug.Workload)
+	assert.Equal(t, 0, debug.Recommended)
+	assert.Zero(t, debug.IdleCost)
+	assert.Zero(t, debug.Savings)
+
+	// Namespace prices apply and the most idle line comes first.
+	train := r.Containers[0]
+	assert.Equal(t, "train-1", train.Pod)
+	assert.InDelta(t, 2*2*100, train.IdleCost, 1e-9)
+
+	assert.Len(t, r.Workloads, 3)
+	web := r.Workloads[1]
+	assert.Equal(t, "Deployment/web", web.Workload)
+	assert.Equal(t, 2, web.Containers)
+	assert.InDelta(t, 1.0, web.SlackCPU, 1e-9)
+	assert.InDelta(t, web1.IdleCost+web2.IdleCost, web.IdleCost, 1e-9)
+	assert.InDelta(t, web1.Savings+web2.Savings, web.Savings, 1e-9)
+
+	assert.Len(t, r.Namespaces, 2)
+	assert.Equal(t, "gpu", r.Namespaces[0].Namespace)
+	assert.Equal(t, 3, r.Namespaces[1].Containers)
+	assert.Equal(t, 4, r.Total.Containers)
+	assert.Equal(t, 3, r.Total.Recommended)
+	assert.InDelta(t, train.IdleCost+web.IdleCost, r.Total.IdleCost, 1e-9)
+}
+
+func TestWriteCSV(t *testing.T) {
+	r := Build(testContainers(), testPrices(), testNow)
+	var buf bytes.Buffer
+	assert.NoError(t, r.Write(&buf, FormatCSV, LevelNamespace))
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, 4)
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, "gpu", records[1][0])
+	assert.Equal(t, "total", records[3][0])
+	assert.Equal(t, "4", records[3][4])
+}
+
+func TestWriteJSONAndHTML(t *testing.T) {
+	r := Build(testContainers(), testPrices(), testNow)
+	var buf bytes.Buffer
+	assert.NoError(t, r.Write(&buf, FormatJSON, LevelContainer))
+	decoded := &Report{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), decoded))
+	assert.Equal(t, r.Total, decoded.Total)
+	assert.Len(t, decoded.Workloads, 3)
+
+	buf.Reset()
+	assert.NoError(t, r.Write(&buf, FormatHTML, LevelContainer))
+	html := buf.String()
+	assert.True(t, strings.Contains(html, "Deployment/web"))
+	assert.True(t, strings.Contains(html, "Costs in USD per month"))
+
+	assert.Error(t, r.Write(&buf, Format("xml"), LevelContainer))
+}
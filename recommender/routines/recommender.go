@@ -7,6 +7,7 @@ import (
 
 	"github.com/turtacn/cloud-prophet/recommender/checkpoint"
 	"github.com/turtacn/cloud-prophet/recommender/input"
+	"github.com/turtacn/cloud-prophet/recommender/input/metrics"
 	"github.com/turtacn/cloud-prophet/recommender/logic"
 	"github.com/turtacn/cloud-prophet/recommender/model"
 	//vpa_types "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
@@ -215,13 +216,14 @@ func (c RecommenderFactory) Make() Recommender {
 }
 
 // NewRecommender creates a new recommender instance.
-// Dependencies are created automatically.
+// Dependencies are created automatically, a nil metricsClient reads usage
+// from the Metrics API.
 // Deprecated; use RecommenderFactory instead.
-func NewRecommender(config *rest.Config, checkpointsGCInterval time.Duration, useCheckpoints bool, namespace string) Recommender {
+func NewRecommender(config *rest.Config, checkpointsGCInterval time.Duration, useCheckpoints bool, namespace string, metricsClient metrics.MetricsClient) Recommender {
 	clusterState := model.NewClusterState()
 	return RecommenderFactory{
 		ClusterState:           clusterState,
-		ClusterStateFeeder:     input.NewClusterStateFeeder(config, clusterState, *memorySaver, namespace),
+		ClusterStateFeeder:     input.NewClusterStateFeeder(config, clusterState, *memorySaver, namespace, metricsClient),
 		CheckpointWriter:       checkpoint.NewCheckpointWriter(clusterState, vap_clientset_vpa_checkpointsgetter),
 		VpaClient:              vpa_clientset_vpa_getter,
 		PodResourceRecommender: logic.CreatePodResourceRecommender(),
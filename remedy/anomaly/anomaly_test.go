@@ -0,0 +1,222 @@
+package anomaly
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+)
+
+var start = time.Unix(1600000000, 0)
+
+func feed(d Detector, values []float64) []float64 {
+	scores := make([]float64, len(values))
+	for i, v := range values {
+		scores[i], _ = d.Observe(start.Add(time.Duration(i)*time.Minute), v)
+	}
+	return scores
+}
+
+func noisy(n int, mean, stddev float64, seed int64) []float64 {
+	r := rand.New(rand.NewSource(seed))
+	values := make([]float64, n)
+	for i := range values {
+		values[i] = mean + r.NormFloat64()*stddev
+	}
+	return values
+}
+
+func TestEWMAFlagsSpike(t *testing.T) {
+	values := noisy(200, 50, 2, 1)
+	values[150] = 80
+	f, err := NewEWMA(EWMAConfig{Alpha: 0.05, Warmup: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := feed(f(), values)
+	if scores[150] < 1 {
+		t.Errorf("expected the spike to score at least 1, got %v", scores[150])
+	}
+	for i := 30; i < 150; i++ {
+		if scores[i] >= 1.5 {
+			t.Errorf("unexpected high score %v at %d", scores[i], i)
+		}
+	}
+}
+
+func TestMADFlagsSpikeAndIgnoresOutliersInWindow(t *testing.T) {
+	values := noisy(100, 10, 1, 2)
+	values[40], values[45], values[90] = 40, -20, 30
+	f, err := NewMAD(MADConfig{Window: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scores := feed(f(), values)
+	for _, i := range []int{40, 45, 90} {
+		if scores[i] < 1 {
+			t.Errorf("expected sample %d to score at least 1, got %v", i, scores[i])
+		}
+	}
+	if scores[46] >= 1 {
+		t.Errorf("outliers in the window should not inflate later scores, got %v", scores[46])
+	}
+}
+
+func TestDetectorConfigValidation(t *testing.T) {
+	cases := []struct {
+		name  string
+		new   func() (DetectorFactory, error)
+		valid bool
+	}{
+		{"ewma", func() (DetectorFactory, error) { return NewEWMA(EWMAConfig{Alpha: 1}) }, true},
+		{"ewma without alpha", func() (DetectorFactory, error) { return NewEWMA(EWMAConfig{}) }, false},
+		{"ewma alpha above 1", func() (DetectorFactory, error) { return NewEWMA(EWMAConfig{Alpha: 1.5}) }, false},
+		{"ewma NaN alpha", func() (DetectorFactory, error) { return NewEWMA(EWMAConfig{Alpha: math.NaN()}) }, false},
+		{"ewma negative threshold", func() (DetectorFactory, error) { return NewEWMA(EWMAConfig{Alpha: 0.1, Threshold: -1}) }, false},
+		{"ewma negative warmup", func() (DetectorFactory, error) { return NewEWMA(EWMAConfig{Alpha: 0.1, Warmup: -1}) }, false},
+		{"mad", func() (DetectorFactory, error) { return NewMAD(MADConfig{Window: 1}) }, true},
+		{"mad without window", func() (DetectorFactory, error) { return NewMAD(MADConfig{}) }, false},
+		{"mad negative threshold", func() (DetectorFactory, error) { return NewMAD(MADConfig{Window: 5, Threshold: -3}) }, false},
+		{"esd", func() (DetectorFactory, error) { return NewSeasonalESD(SeasonalESDConfig{Period: 2, Periods: 2}) }, true},
+		{"esd without period", func() (DetectorFactory, error) { return NewSeasonalESD(SeasonalESDConfig{}) }, false},
+		{"esd single season", func() (DetectorFactory, error) { return NewSeasonalESD(SeasonalESDConfig{Period: 24, Periods: 1}) }, false},
+		{"esd short window", func() (DetectorFactory, error) { return NewSeasonalESD(SeasonalESDConfig{Period: 1, Periods: 2}) }, false},
+		{"esd max anomalies", func() (DetectorFactory, error) { return NewSeasonalESD(SeasonalESDConfig{Period: 24, MaxAnomalies: 1}) }, false},
+		{"esd alpha", func() (DetectorFactory, error) { return NewSeasonalESD(SeasonalESDConfig{Period: 24, Alpha: -0.05}) }, false},
+	}
+	for _, c := range cases {
+		f, err := c.new()
+		if c.valid {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", c.name, err)
+				continue
+			}
+			// A valid configuration must survive a full window.
+			feed(f(), noisy(64, 10, 1, 4))
+		} else if err == nil {
+			t.Errorf("%s: expected an error", c.name)
+		}
+	}
+}
+
+func TestSeasonalESDIgnoresSeasonalityButFlagsSpike(t *testing.T) {
+	const period = 24
+	r := rand.New(rand.NewSource(3))
+	values := make([]float64, period*8)
+	for i := range values {
+		values[i] = 50 + 30*math.Sin(2*math.Pi*float64(i%period)/period) + r.NormFloat64()
+	}
+	// The seasonal peak itself is normal, a peak at the trough is not.
+	trough := period*7 + 18
+	values[trough] = 80
+	f, err := NewSeasonalESD(SeasonalESDConfig{Period: period})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d := f()
+	falseAlarms := 0
+	for i, v := range values {
+		score, ready := d.Observe(start.Add(time.Duration(i)*time.Hour), v)
+		if i < period*4-1 {
+			if ready {
+				t.Fatalf("detector ready after %d samples", i+1)
+			}
+			continue
+		}
+		if i == trough {
+			if score < 1 {
+				t.Errorf("expected the off-season peak to score at least 1, got %v", score)
+			}
+		} else if score >= 1 {
+			falseAlarms++
+		}
+	}
+	// The test runs at a 5% significance level on Gaussian noise, allow a
+	// stray alarm but not seasonality being mistaken for anomalies.
+	if falseAlarms > 1 {
+		t.Errorf("expected at most one false alarm, got %d", falseAlarms)
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	cases := []struct{ p, df, expected float64 }{
+		{0.975, 10, 2.228},
+		{0.95, 5, 2.015},
+		{0.995, 30, 2.750},
+	}
+	for _, c := range cases {
+		if got := studentTQuantile(c.p, c.df); math.Abs(got-c.expected) > 1e-3 {
+			t.Errorf("t(%v, %v) = %v, expected %v", c.p, c.df, got, c.expected)
+		}
+	}
+}
+
+// scripted returns a fixed sequence of scores.
+type scripted struct {
+	scores []float64
+	i      int
+}
+
+func (s *scripted) Observe(t time.Time, v float64) (float64, bool) {
+	score := s.scores[s.i]
+	s.i++
+	return score, true
+}
+
+func TestEngineHysteresis(t *testing.T) {
+	scores := []float64{0.2, 1.5, 0.3, 1.2, 1.1, 0.9, 0.5, 0.7, 0.1}
+	e := NewEngine(EngineConfig{
+		Detector:   func() Detector { return &scripted{scores: scores} },
+		Hysteresis: HysteresisConfig{Raise: 1, Clear: 0.8, RaiseCount: 2, ClearCount: 2},
+	})
+	events := e.Subscribe(10)
+	id := NewSeriesID("cn-north-1", "cpu", map[string]string{"app": "web"})
+	var states []State
+	for i := range scores {
+		if ev := e.Observe(id, start.Add(time.Duration(i)*time.Minute), float64(i)); ev != nil {
+			states = append(states, ev.State)
+			if i == 4 && len(e.Anomalous()) != 1 {
+				t.Errorf("expected the series to be listed as anomalous")
+			}
+		}
+	}
+	// A single spike (1.5) does not raise, two in a row (1.2, 1.1) do; 0.9
+	// is above Clear so only 0.5, 0.7 clear.
+	if len(states) != 2 || states[0] != StateAnomalous || states[1] != StateNormal {
+		t.Fatalf("unexpected transitions %v", states)
+	}
+	if ev := <-events; ev.Time != start.Add(4*time.Minute) || ev.Series.String() != "cn-north-1,app=web.cpu" {
+		t.Errorf("unexpected first event %+v", ev)
+	}
+	if ev := <-events; ev.Time != start.Add(7*time.Minute) {
+		t.Errorf("unexpected second event %+v", ev)
+	}
+	if len(e.Anomalous()) != 0 {
+		t.Errorf("expected no anomalous series")
+	}
+}
+
+func TestEngineWriteFromIngestion(t *testing.T) {
+	mad, err := NewMAD(MADConfig{Window: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	e := NewEngine(EngineConfig{Detector: mad, Fields: []string{"cpu"}})
+	for i := 0; i < 20; i++ {
+		v := 10.0 + float64(i%3)
+		if i == 15 {
+			v = 100
+		}
+		p, _ := client.NewPoint("cn-north-1", map[string]string{"app": "web"},
+			map[string]interface{}{"cpu": v, "rtime": 1000.0 * float64(i%2)}, start.Add(time.Duration(i)*time.Minute))
+		if err := e.Write([]*client.Point{p}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(e.series) != 1 {
+		t.Errorf("expected only the cpu series to be tracked, got %d", len(e.series))
+	}
+	e.GarbageCollect(start.Add(time.Hour))
+}
@@ -0,0 +1,179 @@
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Detector scores the samples of a single series as they arrive. Scores are
+// normalised so that 1 is the detector's own anomaly threshold: a z-score
+// of 3, a modified z-score of 3.5 or the ESD critical value.
+type Detector interface {
+	// Observe adds a sample and returns its score. ready is false while the
+	// detector has not seen enough data to judge.
+	Observe(t time.Time, v float64) (score float64, ready bool)
+}
+
+// DetectorFactory creates the detector state of a new series.
+type DetectorFactory func() Detector
+
+// EWMAConfig configures an exponentially weighted z-score detector.
+type EWMAConfig struct {
+	// Alpha is the weight of the newest sample, in (0, 1].
+	Alpha float64
+	// Threshold is the z-score mapped to a score of 1. Zero means 3.
+	Threshold float64
+	// Warmup is the number of samples observed before scoring.
+	Warmup int
+}
+
+type ewmaDetector struct {
+	config   EWMAConfig
+	mean     float64
+	variance float64
+	count    int
+}
+
+// NewEWMA returns a factory of EWMA z-score detectors.
+func NewEWMA(config EWMAConfig) (DetectorFactory, error) {
+	if config.Threshold == 0 {
+		config.Threshold = 3
+	}
+	if !(config.Alpha > 0 && config.Alpha <= 1) {
+		return nil, fmt.Errorf("ewma: alpha must be in (0, 1], got %v", config.Alpha)
+	}
+	if !(config.Threshold > 0) {
+		return nil, fmt.Errorf("ewma: threshold must be positive, got %v", config.Threshold)
+	}
+	if config.Warmup < 0 {
+		return nil, fmt.Errorf("ewma: warmup must not be negative, got %d", config.Warmup)
+	}
+	return func() Detector { return &ewmaDetector{config: config} }, nil
+}
+
+func (d *ewmaDetector) Observe(t time.Time, v float64) (float64, bool) {
+	d.count++
+	if d.count == 1 {
+		d.mean = v
+		return 0, false
+	}
+	// Score against the state before the sample so that a spike does not
+	// hide itself.
+	score, ready := 0.0, d.count > d.config.Warmup
+	if std := math.Sqrt(d.variance); std > 0 {
+		score = math.Abs(v-d.mean) / std / d.config.Threshold
+	} else if v != d.mean {
+		score = math.Inf(1)
+	}
+	diff := v - d.mean
+	incr := d.config.Alpha * diff
+	d.mean += incr
+	d.variance = (1 - d.config.Alpha) * (d.variance + diff*incr)
+	return score, ready
+}
+
+// window is a fixed size ring of the most recent samples.
+type window struct {
+	values []float64
+	next   int
+	full   bool
+}
+
+func newWindow(size int) *window {
+	return &window{values: make([]float64, size)}
+}
+
+func (w *window) add(v float64) {
+	w.values[w.next] = v
+	w.next = (w.next + 1) % len(w.values)
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+func (w *window) len() int {
+	if w.full {
+		return len(w.values)
+	}
+	return w.next
+}
+
+// ordered returns the samples oldest first.
+func (w *window) ordered() []float64 {
+	if !w.full {
+		return append([]float64(nil), w.values[:w.next]...)
+	}
+	return append(append([]float64(nil), w.values[w.next:]...), w.values[:w.next]...)
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// mad returns the median and the median absolute deviation of values.
+func mad(values []float64) (float64, float64) {
+	m := median(values)
+	dev := make([]float64, len(values))
+	for i, v := range values {
+		dev[i] = math.Abs(v - m)
+	}
+	return m, median(dev)
+}
+
+// MADConfig configures a median absolute deviation detector.
+type MADConfig struct {
+	// Window is the number of recent samples the median is taken over.
+	Window int
+	// Threshold is the modified z-score mapped to a score of 1. Zero means
+	// 3.5.
+	Threshold float64
+}
+
+type madDetector struct {
+	config MADConfig
+	window *window
+}
+
+// NewMAD returns a factory of sliding window MAD detectors.
+func NewMAD(config MADConfig) (DetectorFactory, error) {
+	if config.Threshold == 0 {
+		config.Threshold = 3.5
+	}
+	if config.Window <= 0 {
+		return nil, fmt.Errorf("mad: window must be positive, got %d", config.Window)
+	}
+	if !(config.Threshold > 0) {
+		return nil, fmt.Errorf("mad: threshold must be positive, got %v", config.Threshold)
+	}
+	return func() Detector { return &madDetector{config: config, window: newWindow(config.Window)} }, nil
+}
+
+// madScale makes the MAD of normally distributed data comparable with its
+// standard deviation.
+const madScale = 0.6745
+
+func (d *madDetector) Observe(t time.Time, v float64) (float64, bool) {
+	ready := d.window.full
+	score := 0.0
+	if d.window.len() > 0 {
+		m, dev := mad(d.window.ordered())
+		if dev > 0 {
+			score = madScale * math.Abs(v-m) / dev / d.config.Threshold
+		} else if v != m {
+			score = math.Inf(1)
+		}
+	}
+	d.window.add(v)
+	return score, ready
+}
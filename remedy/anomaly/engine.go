@@ -0,0 +1,229 @@
+package anomaly
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// State is the state of a series as seen by the hysteresis.
+type State string
+
+const (
+	// StateNormal means the series is not anomalous.
+	StateNormal State = "Normal"
+	// StateAnomalous means the series has been anomalous long enough to be
+	// reported and has not recovered yet.
+	StateAnomalous State = "Anomalous"
+)
+
+// SeriesID identifies a single series: a field of a measurement with one
+// combination of tag values.
+type SeriesID struct {
+	Measurement string
+	Field       string
+	// Tags is the canonical "k=v,k=v" form of the series tags.
+	Tags string
+}
+
+// NewSeriesID builds a SeriesID with tags sorted by key.
+func NewSeriesID(measurement, field string, tags map[string]string) SeriesID {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return SeriesID{Measurement: measurement, Field: field, Tags: strings.Join(pairs, ",")}
+}
+
+func (s SeriesID) String() string {
+	if s.Tags == "" {
+		return s.Measurement + "." + s.Field
+	}
+	return s.Measurement + "," + s.Tags + "." + s.Field
+}
+
+// Event reports a series entering or leaving the anomalous state.
+type Event struct {
+	Series SeriesID
+	State  State
+	// Since is the time the series entered State.
+	Since time.Time
+	// Time, Value and Score describe the sample that caused the transition.
+	Time  time.Time
+	Value float64
+	Score float64
+}
+
+// HysteresisConfig keeps single noisy samples from flapping the state.
+type HysteresisConfig struct {
+	// Raise is the score at or above which a sample counts as anomalous.
+	// Zero means 1.
+	Raise float64
+	// Clear is the score below which a sample counts as normal again. Zero
+	// means 0.8 times Raise.
+	Clear float64
+	// RaiseCount consecutive anomalous samples enter StateAnomalous. Zero
+	// means 1.
+	RaiseCount int
+	// ClearCount consecutive normal samples return to StateNormal. Zero
+	// means 1.
+	ClearCount int
+}
+
+func (c *HysteresisConfig) setDefaults() {
+	if c.Raise == 0 {
+		c.Raise = 1
+	}
+	if c.Clear == 0 {
+		c.Clear = 0.8 * c.Raise
+	}
+	if c.RaiseCount == 0 {
+		c.RaiseCount = 1
+	}
+	if c.ClearCount == 0 {
+		c.ClearCount = 1
+	}
+}
+
+// EngineConfig configures an Engine.
+type EngineConfig struct {
+	Detector   DetectorFactory
+	Hysteresis HysteresisConfig
+	// Fields restricts detection to these fields. Empty means all numeric
+	// fields.
+	Fields []string
+	// IdleTimeout drops the state of series without samples for this long.
+	// Zero keeps series forever.
+	IdleTimeout time.Duration
+}
+
+type seriesState struct {
+	detector Detector
+	state    State
+	since    time.Time
+	streak   int
+	lastSeen time.Time
+	last     Event
+}
+
+// Engine routes samples to one detector per series, applies hysteresis to
+// their scores and publishes the resulting events to subscribers. It is
+// safe for concurrent use.
+type Engine struct {
+	config EngineConfig
+	fields map[string]bool
+
+	mu          sync.Mutex
+	series      map[SeriesID]*seriesState
+	subscribers []chan Event
+}
+
+// NewEngine returns an Engine for config.
+func NewEngine(config EngineConfig) *Engine {
+	config.Hysteresis.setDefaults()
+	e := &Engine{config: config, series: make(map[SeriesID]*seriesState)}
+	if len(config.Fields) > 0 {
+		e.fields = make(map[string]bool, len(config.Fields))
+		for _, f := range config.Fields {
+			e.fields[f] = true
+		}
+	}
+	return e
+}
+
+// Subscribe returns a channel receiving every event. Events are dropped
+// for subscribers that do not keep up with a buffer of size buffer.
+func (e *Engine) Subscribe(buffer int) <-chan Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	ch := make(chan Event, buffer)
+	e.subscribers = append(e.subscribers, ch)
+	return ch
+}
+
+// Observe feeds one sample of series and returns the event it caused, if
+// any.
+func (e *Engine) Observe(series SeriesID, t time.Time, v float64) *Event {
+	if e.fields != nil && !e.fields[series.Field] {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	s, ok := e.series[series]
+	if !ok {
+		s = &seriesState{detector: e.config.Detector(), state: StateNormal, since: t}
+		e.series[series] = s
+	}
+	s.lastSeen = t
+	score, ready := s.detector.Observe(t, v)
+	if !ready {
+		return nil
+	}
+
+	h := e.config.Hysteresis
+	switch s.state {
+	case StateNormal:
+		if score >= h.Raise {
+			s.streak++
+		} else {
+			s.streak = 0
+		}
+		if s.streak < h.RaiseCount {
+			return nil
+		}
+		s.state = StateAnomalous
+	case StateAnomalous:
+		if score < h.Clear {
+			s.streak++
+		} else {
+			s.streak = 0
+		}
+		if s.streak < h.ClearCount {
+			return nil
+		}
+		s.state = StateNormal
+	}
+	s.streak = 0
+	s.since = t
+	s.last = Event{Series: series, State: s.state, Since: t, Time: t, Value: v, Score: score}
+	event := s.last
+	for _, ch := range e.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+	return &event
+}
+
+// Anomalous returns the last event of every series currently in
+// StateAnomalous, sorted by series.
+func (e *Engine) Anomalous() []Event {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var res []Event
+	for _, s := range e.series {
+		if s.state == StateAnomalous {
+			res = append(res, s.last)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Series.String() < res[j].Series.String() })
+	return res
+}
+
+// GarbageCollect drops the state of series idle for longer than the
+// configured timeout.
+func (e *Engine) GarbageCollect(now time.Time) {
+	if e.config.IdleTimeout == 0 {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for id, s := range e.series {
+		if now.Sub(s.lastSeen) > e.config.IdleTimeout {
+			delete(e.series, id)
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package anomaly
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// SeasonalESDConfig configures a seasonal hybrid ESD detector.
+type SeasonalESDConfig struct {
+	// Period is the number of samples in one season, e.g. 24 for hourly
+	// samples with a daily pattern.
+	Period int
+	// Periods is the number of seasons kept in the sliding window. The
+	// phase medians need at least 3 to be robust. Zero means 4.
+	Periods int
+	// MaxAnomalies is the largest fraction of the window that may be
+	// anomalous. Zero means 0.1.
+	MaxAnomalies float64
+	// Alpha is the significance level of the test. Zero means 0.05.
+	Alpha float64
+}
+
+type seasonalESDDetector struct {
+	config SeasonalESDConfig
+	window *window
+}
+
+// NewSeasonalESD returns a factory of seasonal hybrid ESD detectors: the
+// per phase median is removed from the window and the generalised ESD test
+// runs on the residuals with median and MAD in place of mean and standard
+// deviation.
+func NewSeasonalESD(config SeasonalESDConfig) (DetectorFactory, error) {
+	if config.Periods == 0 {
+		config.Periods = 4
+	}
+	if config.MaxAnomalies == 0 {
+		config.MaxAnomalies = 0.1
+	}
+	if config.Alpha == 0 {
+		config.Alpha = 0.05
+	}
+	if config.Period <= 0 {
+		return nil, fmt.Errorf("seasonal esd: period must be positive, got %d", config.Period)
+	}
+	// The seasonal component of a sample is taken from the other seasons,
+	// and the ESD test needs at least one degree of freedom.
+	if config.Periods < 2 {
+		return nil, fmt.Errorf("seasonal esd: periods must be at least 2, got %d", config.Periods)
+	}
+	if config.Period*config.Periods < 3 {
+		return nil, fmt.Errorf("seasonal esd: window of %d samples is too short", config.Period*config.Periods)
+	}
+	if !(config.MaxAnomalies > 0 && config.MaxAnomalies < 1) {
+		return nil, fmt.Errorf("seasonal esd: max anomalies must be in (0, 1), got %v", config.MaxAnomalies)
+	}
+	if !(config.Alpha > 0 && config.Alpha < 1) {
+		return nil, fmt.Errorf("seasonal esd: alpha must be in (0, 1), got %v", config.Alpha)
+	}
+	return func() Detector {
+		return &seasonalESDDetector{config: config, window: newWindow(config.Period * config.Periods)}
+	}, nil
+}
+
+func (d *seasonalESDDetector) Observe(t time.Time, v float64) (float64, bool) {
+	d.window.add(v)
+	if !d.window.full {
+		return 0, false
+	}
+	values := d.window.ordered()
+	residuals := d.residuals(values)
+	return esdScore(residuals, len(residuals)-1, d.config.MaxAnomalies, d.config.Alpha), true
+}
+
+// residuals removes the seasonal component and the overall median from
+// values. The seasonal component of a sample is the median of the other
+// samples of its phase: including the sample itself would pull the
+// component towards it and shrink the residual spread the test relies on.
+// The window holds whole periods, so the phase of a sample is its index
+// modulo the period.
+func (d *seasonalESDDetector) residuals(values []float64) []float64 {
+	period := d.config.Period
+	res := make([]float64, len(values))
+	others := make([]float64, 0, d.config.Periods)
+	for i, v := range values {
+		others = others[:0]
+		for j := i % period; j < len(values); j += period {
+			if j != i {
+				others = append(others, values[j])
+			}
+		}
+		res[i] = v - median(others)
+	}
+	m := median(res)
+	for i := range res {
+		res[i] -= m
+	}
+	return res
+}
+
+// esdScore runs the generalised ESD test on values and returns the score
+// of values[target]: its test statistic divided by the critical value of
+// the round it was removed in, or of the first round if it never was.
+func esdScore(values []float64, target int, maxFraction, alpha float64) float64 {
+	n := len(values)
+	maxOutliers := int(math.Floor(float64(n) * maxFraction))
+	if maxOutliers < 1 {
+		maxOutliers = 1
+	}
+	removed := make([]bool, n)
+	remaining := make([]float64, 0, n)
+	targetScore := 0.0
+	anomalies := 0
+	removedAt := -1
+	for i := 1; i <= maxOutliers; i++ {
+		remaining = remaining[:0]
+		for j, v := range values {
+			if !removed[j] {
+				remaining = append(remaining, v)
+			}
+		}
+		m, dev := mad(remaining)
+		if dev == 0 {
+			break
+		}
+		dev /= madScale
+		worst, worstStat := -1, 0.0
+		for j, v := range values {
+			if removed[j] {
+				continue
+			}
+			if stat := math.Abs(v-m) / dev; stat > worstStat {
+				worst, worstStat = j, stat
+			}
+		}
+		lambda := esdCriticalValue(n, i, alpha)
+		if i == 1 {
+			targetScore = math.Abs(values[target]-m) / dev / lambda
+		}
+		if worstStat > lambda {
+			anomalies = i
+		}
+		if worst == target {
+			removedAt = i
+			targetScore = worstStat / lambda
+		}
+		removed[worst] = true
+	}
+	// Rosner's test declares the points removed in the first `anomalies`
+	// rounds anomalous, even if their own statistic was below the critical
+	// value of their round.
+	if removedAt > 0 && removedAt <= anomalies && targetScore < 1 {
+		targetScore = 1
+	}
+	return targetScore
+}
+
+// esdCriticalValue returns lambda_i of the generalised ESD test for n
+// samples at significance alpha.
+func esdCriticalValue(n, i int, alpha float64) float64 {
+	p := 1 - alpha/(2*float64(n-i+1))
+	df := float64(n - i - 1)
+	t := studentTQuantile(p, df)
+	return float64(n-i) * t / math.Sqrt((df+t*t)*float64(n-i+1))
+}
+
+// studentTQuantile returns the p quantile of Student's t distribution with
+// df degrees of freedom, for p >= 0.5.
+func studentTQuantile(p, df float64) float64 {
+	lo, hi := 0.0, 1.0
+	for studentTCDF(hi, df) < p {
+		hi *= 2
+	}
+	for i := 0; i < 100; i++ {
+		mid := (lo + hi) / 2
+		if studentTCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+func studentTCDF(t, df float64) float64 {
+	x := df / (df + t*t)
+	tail := 0.5 * regularizedIncompleteBeta(df/2, 0.5, x)
+	if t >= 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// regularizedIncompleteBeta evaluates I_x(a, b) with the continued fraction
+// from Numerical Recipes.
+func regularizedIncompleteBeta(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(a, b, x) / a
+	}
+	return 1 - front*betaContinuedFraction(b, a, 1-x)/b
+}
+
+func betaContinuedFraction(a, b, x float64) float64 {
+	const (
+		maxIterations = 200
+		epsilon       = 3e-14
+		tiny          = 1e-300
+	)
+	qab, qap, qam := a+b, a+1, a-1
+	c, d := 1.0, 1-qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+	return h
+}
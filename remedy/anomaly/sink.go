@@ -0,0 +1,50 @@
+package anomaly
+
+import (
+	client "github.com/influxdata/influxdb1-client/v2"
+	"github.com/turtacn/cloud-prophet/profil"
+)
+
+// Write feeds every numeric field of points to the engine, making it usable
+// as a sink of the ingestion gateway.
+func (e *Engine) Write(points []*client.Point) error {
+	for _, p := range points {
+		fields, err := p.Fields()
+		if err != nil {
+			return err
+		}
+		tags := p.Tags()
+		for field, raw := range fields {
+			var v float64
+			switch x := raw.(type) {
+			case float64:
+				v = x
+			case int64:
+				v = float64(x)
+			case uint64:
+				v = float64(x)
+			default:
+				continue
+			}
+			e.Observe(NewSeriesID(p.Name(), field, tags), p.Time(), v)
+		}
+	}
+	return nil
+}
+
+// ObserveProfile feeds an application profile read through profil, one
+// series per metric.
+func (e *Engine) ObserveProfile(region, app string, p *profil.AppProfile) {
+	tags := map[string]string{"app": app}
+	for field, v := range map[string]float64{
+		"cpu":      p.CPU,
+		"memory":   p.Memory,
+		"rps":      p.RPS,
+		"rtime":    p.RTime,
+		"r2xx":     p.R2xx,
+		"r5xx":     p.R5xx,
+		"replicas": p.Replicas,
+	} {
+		e.Observe(NewSeriesID(region, field, tags), p.Time, v)
+	}
+}
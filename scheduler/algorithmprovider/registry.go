@@ -13,6 +13,7 @@ import (
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeunschedulable"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/podtopologyspread"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/queuesort"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/reservation"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/selectorspread"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/tainttoleration"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/volumerestrictions"
@@ -108,7 +109,11 @@ func getDefaultConfig() *schedulerapi.Plugins {
 				{Name: tainttoleration.Name, Weight: 1},
 			},
 		},
-		Reserve: &schedulerapi.PluginSet{},
+		Reserve: &schedulerapi.PluginSet{
+			Enabled: []schedulerapi.Plugin{
+				{Name: reservation.Name},
+			},
+		},
 		PreBind: &schedulerapi.PluginSet{},
 		Bind: &schedulerapi.PluginSet{
 			Enabled: []schedulerapi.Plugin{
@@ -1,8 +1,11 @@
 package scheme
 
 import (
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 )
 
 var (
@@ -10,5 +13,17 @@ var (
 	Scheme = runtime.NewScheme()
 
 	// Codecs provides access to encoding and decoding for the scheme.
+	// Decoding is strict: unknown and duplicate fields are errors.
 	Codecs = serializer.NewCodecFactory(Scheme, serializer.EnableStrict)
 )
+
+func init() {
+	AddToScheme(Scheme)
+}
+
+// AddToScheme builds the kubescheduler scheme using all known versions of the kubescheduler api.
+func AddToScheme(scheme *runtime.Scheme) {
+	utilruntime.Must(config.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(scheme.SetVersionPriority(v1beta1.SchemeGroupVersion))
+}
@@ -0,0 +1,406 @@
+package scheme
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/v1beta1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+func TestCodecsDecodePluginConfig(t *testing.T) {
+	data := []byte(`
+apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+profiles:
+- pluginConfig:
+  - name: InterPodAffinity
+    args:
+      hardPodAffinityWeight: 5
+  - name: NodeHealth
+    args:
+      riskSource: file
+      riskFile: /etc/risks.yaml
+      staleRisk: 50
+  - name: SpotInstanceFilter
+    args:
+      maxInterruptionRisk: 60
+      instancePoolLabels: []
+  - name: DRFSort
+  - name: Expression
+    args:
+      filters:
+      - name: gpu-pool
+        expression: '!has(pod.annotations["prophet.io/gpu"]) || node.labels.pool == "gpu"'
+      scores:
+      - name: free-memory
+        expression: (node.allocatable.memory - node.requested.memory) / node.allocatable.memory * 100
+  - name: OutOfTreePlugin
+    args:
+      foo: bar
+`)
+	want := &config.KubeSchedulerConfiguration{
+		AlgorithmSource:          config.SchedulerAlgorithmSource{Provider: pointer.StringPtr("DefaultProvider")},
+		HealthzBindAddress:       "0.0.0.0:10251",
+		MetricsBindAddress:       "0.0.0.0:10251",
+		PodInitialBackoffSeconds: 1,
+		PodMaxBackoffSeconds:     10,
+		Profiles: []config.KubeSchedulerProfile{
+			{
+				SchedulerName: "default-scheduler",
+				PluginConfig: []config.PluginConfig{
+					{
+						Name: "InterPodAffinity",
+						Args: &config.InterPodAffinityArgs{HardPodAffinityWeight: 5},
+					},
+					{
+						Name: "NodeHealth",
+						Args: &config.NodeHealthArgs{
+							RiskSource:        "file",
+							RiskFile:          "/etc/risks.yaml",
+							RiskTTLSeconds:    30,
+							MaxAgeSeconds:     600,
+							StaleRisk:         50,
+							CriticalThreshold: 90,
+						},
+					},
+					{
+						Name: "SpotInstanceFilter",
+						Args: &config.SpotInstanceArgs{
+							LifecycleLabels: []config.LifecycleLabel{
+								{Key: "node.kubernetes.io/lifecycle", Values: []string{"spot", "preemptible"}},
+								{Key: "eks.amazonaws.com/capacityType", Values: []string{"SPOT"}},
+								{Key: "cloud.google.com/gke-preemptible", Values: []string{"true"}},
+								{Key: "cloud.google.com/gke-spot", Values: []string{"true"}},
+								{Key: "kubernetes.azure.com/scalesetpriority", Values: []string{"spot"}},
+							},
+							InterruptionRiskLabel: "spot.prophet.io/interruption-risk",
+							MaxInterruptionRisk:   60,
+							InstancePoolLabels:    []string{},
+							OnDemandPercentage:    20,
+						},
+					},
+					{
+						Name: "DRFSort",
+						Args: &config.DRFSortArgs{DefaultWeight: 1},
+					},
+					{
+						Name: "Expression",
+						Args: &config.ExpressionArgs{
+							Filters: []config.FilterExpression{
+								{Name: "gpu-pool", Expression: `!has(pod.annotations["prophet.io/gpu"]) || node.labels.pool == "gpu"`},
+							},
+							Scores: []config.ScoreExpression{
+								{Name: "free-memory", Expression: "(node.allocatable.memory - node.requested.memory) / node.allocatable.memory * 100", Weight: 1},
+							},
+						},
+					},
+					{
+						Name: "OutOfTreePlugin",
+						Args: &runtime.Unknown{
+							Raw:         []byte(`{"foo":"bar"}`),
+							ContentType: "application/json",
+						},
+					},
+				},
+			},
+		},
+	}
+	obj, gvk, err := Codecs.UniversalDecoder().Decode(data, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gvk.Kind != "KubeSchedulerConfiguration" {
+		t.Errorf("expected kind KubeSchedulerConfiguration, got %s", gvk.Kind)
+	}
+	if diff := cmp.Diff(want, obj); diff != "" {
+		t.Errorf("unexpected configuration (-want, +got):\n%s", diff)
+	}
+}
+
+func TestCodecsDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name: "unknown field",
+			data: `
+apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+percentageOfNodesToScroe: 30
+`,
+			wantErr: "percentageOfNodesToScroe",
+		},
+		{
+			name: "duplicate field",
+			data: `
+apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+podMaxBackoffSeconds: 30
+podMaxBackoffSeconds: 60
+`,
+			wantErr: "podMaxBackoffSeconds",
+		},
+		{
+			name: "unknown field in args",
+			data: `
+apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+profiles:
+- pluginConfig:
+  - name: LoadAware
+    args:
+      metricsSource: prometheus
+      hotTreshold: 70
+`,
+			wantErr: "hotTreshold",
+		},
+		{
+			name: "args of another kind",
+			data: `
+apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: KubeSchedulerConfiguration
+profiles:
+- pluginConfig:
+  - name: NodeHealth
+    args:
+      apiVersion: kubescheduler.config.k8s.io/v1beta1
+      kind: DRFSortArgs
+`,
+			wantErr: "args for plugin NodeHealth were not of type",
+		},
+		{
+			name: "unknown field in policy",
+			data: `
+apiVersion: kubescheduler.config.k8s.io/v1beta1
+kind: Policy
+predicates:
+- name: PodFitsResources
+  weight: 1
+`,
+			wantErr: "weight",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := Codecs.UniversalDecoder().Decode([]byte(tc.data), nil, nil)
+			if err == nil {
+				t.Fatalf("expected an error containing %q, got none", tc.wantErr)
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+// roundTrip encodes obj in v1beta1 YAML and decodes it back.
+func roundTrip(t *testing.T, obj runtime.Object) runtime.Object {
+	t.Helper()
+	yamlInfo, ok := runtime.SerializerInfoForMediaType(Codecs.SupportedMediaTypes(), runtime.ContentTypeYAML)
+	if !ok {
+		t.Fatalf("unable to locate encoder -- %q is not a supported media type", runtime.ContentTypeYAML)
+	}
+	encoder := Codecs.EncoderForVersion(yamlInfo.Serializer, v1beta1.SchemeGroupVersion)
+	var buf bytes.Buffer
+	if err := encoder.Encode(obj, &buf); err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := Codecs.UniversalDecoder().Decode(buf.Bytes(), nil, nil)
+	if err != nil {
+		t.Fatalf("decoding %s: %v", buf.String(), err)
+	}
+	return got
+}
+
+func TestConfigurationRoundTrip(t *testing.T) {
+	cfg := &config.KubeSchedulerConfiguration{
+		AlgorithmSource:          config.SchedulerAlgorithmSource{Provider: pointer.StringPtr("DefaultProvider")},
+		HealthzBindAddress:       "0.0.0.0:10251",
+		MetricsBindAddress:       "127.0.0.1:10252",
+		PercentageOfNodesToScore: 40,
+		PodInitialBackoffSeconds: 2,
+		PodMaxBackoffSeconds:     30,
+		BackoffPolicy: &config.BackoffPolicy{
+			Multiplier:    3,
+			JitterPercent: 10,
+			Reasons: []config.ReasonBackoff{
+				{Reason: config.FailureReasonError, InitialSeconds: 1, MaxSeconds: 5},
+			},
+			PriorityCaps: []config.PriorityBackoffCap{{MinPriority: 1000, MaxSeconds: 3}},
+		},
+		Profiles: []config.KubeSchedulerProfile{
+			{
+				SchedulerName: "default-scheduler",
+				Plugins: &config.Plugins{
+					QueueSort: &config.PluginSet{
+						Enabled:  []config.Plugin{{Name: "DRFSort"}},
+						Disabled: []config.Plugin{{Name: "*"}},
+					},
+					Score: &config.PluginSet{
+						Enabled: []config.Plugin{{Name: "NodeHealth", Weight: 2}, {Name: "LoadAware", Weight: 1}},
+					},
+				},
+				PluginConfig: []config.PluginConfig{
+					{Name: "InterPodAffinity", Args: &config.InterPodAffinityArgs{HardPodAffinityWeight: 3}},
+					{Name: "NodeLabel", Args: &config.NodeLabelArgs{
+						PresentLabels:           []string{"a"},
+						AbsentLabels:            []string{"b"},
+						PresentLabelsPreference: []string{"c"},
+						AbsentLabelsPreference:  []string{"d"},
+					}},
+					{Name: "NodeResourcesFit", Args: &config.NodeResourcesFitArgs{
+						IgnoredResources:      []string{"example.com/foo"},
+						IgnoredResourceGroups: []string{"example.org"},
+					}},
+					{Name: "PodTopologySpread", Args: &config.PodTopologySpreadArgs{
+						DefaultConstraints: []v1.TopologySpreadConstraint{
+							{MaxSkew: 1, TopologyKey: v1.LabelZoneFailureDomainStable, WhenUnsatisfiable: v1.ScheduleAnyway},
+						},
+					}},
+					{Name: "RequestedToCapacityRatio", Args: &config.RequestedToCapacityRatioArgs{
+						Shape:     []config.UtilizationShapePoint{{Utilization: 0, Score: 10}, {Utilization: 100, Score: 0}},
+						Resources: []config.ResourceSpec{{Name: "cpu", Weight: 2}},
+					}},
+					{Name: "NodeResourcesLeastAllocated", Args: &config.NodeResourcesLeastAllocatedArgs{
+						Resources: []config.ResourceSpec{{Name: "memory", Weight: 1}},
+					}},
+					{Name: "NodeResourcesMostAllocated", Args: &config.NodeResourcesMostAllocatedArgs{
+						Resources: []config.ResourceSpec{{Name: "cpu", Weight: 1}},
+					}},
+					{Name: "ServiceAffinity", Args: &config.ServiceAffinityArgs{
+						AffinityLabels:               []string{"zone"},
+						AntiAffinityLabelsPreference: []string{"rack"},
+					}},
+					{Name: "VolumeBinding", Args: &config.VolumeBindingArgs{BindTimeoutSeconds: 300}},
+					{Name: "SpotInstanceScore", Args: &config.SpotInstanceArgs{
+						LifecycleLabels:       []config.LifecycleLabel{{Key: "lifecycle", Values: []string{"spot"}}},
+						InterruptionRiskLabel: "risk",
+						MaxInterruptionRisk:   70,
+						InstancePoolLabels:    []string{"pool"},
+						OnDemandPercentage:    30,
+					}},
+					{Name: "UsageOvercommit", Args: &config.UsageOvercommitArgs{
+						PredictionSource:     "http",
+						PredictionURL:        "http://predictor/predictions",
+						PredictionTTLSeconds: 120,
+						NodePoolLabel:        "pool",
+						DefaultRatio:         config.OvercommitRatio{CPU: 150, Memory: 110},
+						PoolRatios:           []config.OvercommitRatio{{Pool: "batch", CPU: 200, Memory: 120}},
+					}},
+					{Name: "LoadAware", Args: &config.LoadAwareArgs{
+						MetricsSource:          "prometheus",
+						Address:                "http://prometheus:9090",
+						Database:               "metrics",
+						Measurement:            "usage",
+						CPUQuery:               "cpu",
+						MemoryQuery:            "memory",
+						NodeLabel:              "node",
+						MetricsFile:            "/etc/usage.yaml",
+						RefreshIntervalSeconds: 15,
+						MaxAgeSeconds:          60,
+						CPUWeight:              2,
+						MemoryWeight:           1,
+						HotThreshold:           70,
+					}},
+					{Name: "NodeHealth", Args: &config.NodeHealthArgs{
+						RiskSource:        "http",
+						RiskURL:           "http://remedy/risks",
+						RiskTTLSeconds:    10,
+						MaxAgeSeconds:     300,
+						StaleRisk:         20,
+						CriticalThreshold: 75,
+					}},
+					{Name: "Complementarity", Args: &config.ComplementarityArgs{
+						ProfileSource:     "http",
+						ProfileFile:       "/etc/profiles.yaml",
+						ProfileURL:        "http://profil/profiles",
+						ProfileTTLSeconds: 600,
+						Slots:             48,
+						CPUWeight:         1,
+						MemoryWeight:      2,
+					}},
+					{Name: "DRFSort", Args: &config.DRFSortArgs{
+						TenantLabel:   "tenant",
+						DefaultWeight: 2,
+						TenantWeights: []config.TenantWeight{{Tenant: "a", Weight: 3}},
+					}},
+					{Name: "OutOfTreePlugin", Args: &runtime.Unknown{
+						Raw:         []byte(`{"foo":"bar"}`),
+						ContentType: "application/json",
+					}},
+				},
+			},
+			{
+				SchedulerName: "other-scheduler",
+			},
+		},
+		Extenders: []config.Extender{
+			{
+				URLPrefix:      "http://extender",
+				FilterVerb:     "filter",
+				PrioritizeVerb: "prioritize",
+				Weight:         5,
+				EnableHTTPS:    true,
+				TLSConfig: &config.ExtenderTLSConfig{
+					ServerName: "extender",
+					CAData:     []byte("ca"),
+				},
+				HTTPTimeout:      metav1.Duration{Duration: 5 * time.Second},
+				ManagedResources: []config.ExtenderManagedResource{{Name: "example.com/foo", IgnoredByScheduler: true}},
+				Ignorable:        true,
+			},
+		},
+	}
+	got := roundTrip(t, cfg)
+	if diff := cmp.Diff(cfg, got); diff != "" {
+		t.Errorf("configuration changed by the round trip (-want, +got):\n%s", diff)
+	}
+}
+
+func TestPolicyRoundTrip(t *testing.T) {
+	policy := &config.Policy{
+		Predicates: []config.PredicatePolicy{
+			{Name: "PodFitsResources"},
+			{Name: "TestServiceAffinity", Argument: &config.PredicateArgument{
+				ServiceAffinity: &config.ServiceAffinity{Labels: []string{"region"}},
+			}},
+			{Name: "TestLabelsPresence", Argument: &config.PredicateArgument{
+				LabelsPresence: &config.LabelsPresence{Labels: []string{"foo"}, Presence: true},
+			}},
+		},
+		Priorities: []config.PriorityPolicy{
+			{Name: "LeastRequestedPriority", Weight: 1},
+			{Name: "TestServiceAntiAffinity", Weight: 2, Argument: &config.PriorityArgument{
+				ServiceAntiAffinity: &config.ServiceAntiAffinity{Label: "zone"},
+			}},
+			{Name: "TestLabelPreference", Weight: 3, Argument: &config.PriorityArgument{
+				LabelPreference: &config.LabelPreference{Label: "bar", Presence: true},
+			}},
+			{Name: "RequestedToCapacityRatioPriority", Weight: 4, Argument: &config.PriorityArgument{
+				RequestedToCapacityRatioArguments: &config.RequestedToCapacityRatioArguments{
+					Shape:     []config.UtilizationShapePoint{{Utilization: 0, Score: 0}, {Utilization: 100, Score: 10}},
+					Resources: []config.ResourceSpec{{Name: "cpu", Weight: 1}},
+				},
+			}},
+		},
+		Extenders: []config.Extender{
+			{URLPrefix: "http://extender", BindVerb: "bind", Weight: 1},
+		},
+		HardPodAffinitySymmetricWeight: 10,
+		AlwaysCheckAllPredicates:       true,
+	}
+	got := roundTrip(t, policy)
+	if diff := cmp.Diff(policy, got); diff != "" {
+		t.Errorf("policy changed by the round trip (-want, +got):\n%s", diff)
+	}
+}
@@ -60,6 +60,10 @@ type KubeSchedulerConfiguration struct {
 	// the default value (10s) will be used.
 	PodMaxBackoffSeconds int64
 
+	// BackoffPolicy refines the backoff of unschedulable pods, which by
+	// default doubles from PodInitialBackoffSeconds to PodMaxBackoffSeconds.
+	BackoffPolicy *BackoffPolicy
+
 	// Profiles are scheduling profiles that kube-scheduler supports. Pods can
 	// choose to be scheduled under a particular profile by setting its associated
 	// scheduler name. Pods that don't specify any scheduler name are scheduled
@@ -71,6 +75,55 @@ type KubeSchedulerConfiguration struct {
 	Extenders []Extender
 }
 
+// BackoffPolicy configures how long unschedulable pods back off before
+// they are retried.
+type BackoffPolicy struct {
+	// Multiplier is the factor the backoff grows by on each failed attempt,
+	// 2 if zero.
+	Multiplier int32
+	// JitterPercent spreads each backoff by up to plus or minus JitterPercent
+	// percent, from 0 to 100, so that pods failing together are not retried
+	// together.
+	JitterPercent int32
+	// Reasons override the initial and max backoff of pods whose last
+	// attempt failed for a reason: "Unschedulable",
+	// "InsufficientResources", "NoNodesAvailable" or "Error" (binding and
+	// other transient errors).
+	Reasons []ReasonBackoff
+	// PriorityCaps cap the backoff of pods of at least a priority.
+	PriorityCaps []PriorityBackoffCap
+}
+
+// Reasons a scheduling attempt fails for.
+const (
+	// FailureReasonUnschedulable is set when filters rejected every node.
+	FailureReasonUnschedulable = "Unschedulable"
+	// FailureReasonInsufficientResources is set when nodes lacked resources.
+	FailureReasonInsufficientResources = "InsufficientResources"
+	// FailureReasonNoNodesAvailable is set when the cluster has no nodes.
+	FailureReasonNoNodesAvailable = "NoNodesAvailable"
+	// FailureReasonError is set on binding and other transient errors.
+	FailureReasonError = "Error"
+)
+
+// ReasonBackoff is the backoff of pods that failed for a reason.
+type ReasonBackoff struct {
+	// Reason the last attempt of a pod failed for.
+	Reason string
+	// InitialSeconds is the backoff after the first failed attempt.
+	InitialSeconds int64
+	// MaxSeconds is the max backoff.
+	MaxSeconds int64
+}
+
+// PriorityBackoffCap caps the backoff of pods of at least a priority.
+type PriorityBackoffCap struct {
+	// MinPriority is the lowest priority the cap applies to.
+	MinPriority int32
+	// MaxSeconds is the max backoff of these pods.
+	MaxSeconds int64
+}
+
 // KubeSchedulerProfile is a scheduling profile.
 type KubeSchedulerProfile struct {
 	// SchedulerName is the name of the scheduler associated to this profile.
@@ -142,3 +142,222 @@ type VolumeBindingArgs struct {
 	// If this value is nil, the default value will be used.
 	BindTimeoutSeconds int64
 }
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SpotInstanceArgs holds arguments used to configure the SpotInstanceFilter
+// and SpotInstanceScore plugins.
+type SpotInstanceArgs struct {
+	metav1.TypeMeta
+
+	// LifecycleLabels are the node labels marking spot, low-priority and
+	// preemptible instances. A node matching any of them is a spot node.
+	LifecycleLabels []LifecycleLabel
+	// InterruptionRiskLabel is the node label holding the interruption risk
+	// of a spot instance: a percentage from 0 to 100, or low, medium or high.
+	InterruptionRiskLabel string
+	// MaxInterruptionRisk filters out spot nodes with a higher risk. 100
+	// admits every spot node.
+	MaxInterruptionRisk int32
+	// InstancePoolLabels identify the instance pool of a node, e.g. instance
+	// type and zone. Spot instances of one pool tend to be reclaimed
+	// together, so replicas on spot nodes are spread across pools.
+	InstancePoolLabels []string
+	// OnDemandPercentage is the percentage of the replicas of a workload kept
+	// on on-demand nodes, from 0 to 100.
+	OnDemandPercentage int32
+}
+
+// LifecycleLabel is a node label whose values mark spot instances.
+type LifecycleLabel struct {
+	// Key of the label.
+	Key string
+	// Values of the label meaning spot. Empty means any value.
+	Values []string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// UsageOvercommitArgs holds arguments used to configure the UsageOvercommit plugin.
+type UsageOvercommitArgs struct {
+	metav1.TypeMeta
+
+	// PredictionSource is where predicted peak usages of pods are read
+	// from: "annotation", "file" or "http". Pods without a prediction count
+	// with their requests.
+	PredictionSource string
+	// PredictionFile is the YAML or JSON file of predictions of the "file"
+	// source.
+	PredictionFile string
+	// PredictionURL is the endpoint of the "http" source, answering the
+	// content of a prediction file in JSON.
+	PredictionURL string
+	// PredictionTTLSeconds is how often predictions of the "file" and "http"
+	// sources are reloaded in the background.
+	PredictionTTLSeconds int64
+	// NodePoolLabel is the node label naming the node pool of a node.
+	NodePoolLabel string
+	// DefaultRatio applies to nodes of pools without a ratio of their own.
+	DefaultRatio OvercommitRatio
+	// PoolRatios are the overcommit ratios of node pools.
+	PoolRatios []OvercommitRatio
+}
+
+// OvercommitRatio is the percentage of allocatable resources the predicted
+// usage of the pods of a node pool may reach.
+type OvercommitRatio struct {
+	// Pool is the value of the node pool label. Ignored for the default.
+	Pool string
+	// CPU in percent of allocatable CPU.
+	CPU int32
+	// Memory in percent of allocatable memory.
+	Memory int32
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoadAwareArgs holds arguments used to configure the LoadAware plugin.
+type LoadAwareArgs struct {
+	metav1.TypeMeta
+
+	// MetricsSource is where the utilisation of nodes is read from:
+	// "influxdb", "prometheus" or "file".
+	MetricsSource string
+	// Address is the address of the InfluxDB or Prometheus server.
+	Address string
+	// Database is the InfluxDB database.
+	Database string
+	// Measurement is the InfluxDB measurement holding the cpu and memory
+	// utilisation of nodes in percent, tagged by node.
+	Measurement string
+	// CPUQuery and MemoryQuery are the Prometheus queries returning the
+	// utilisation of nodes in percent.
+	CPUQuery    string
+	MemoryQuery string
+	// NodeLabel is the Prometheus label naming the node of a sample.
+	NodeLabel string
+	// MetricsFile is the YAML or JSON file of the "file" source.
+	MetricsFile string
+	// RefreshIntervalSeconds is how often the utilisation is read.
+	RefreshIntervalSeconds int64
+	// MaxAgeSeconds is the age after which the utilisation of a node is
+	// ignored and the node scored by its requests instead.
+	MaxAgeSeconds int64
+	// CPUWeight and MemoryWeight weigh the utilisation of the resources.
+	CPUWeight    int64
+	MemoryWeight int64
+	// HotThreshold is the utilisation in percent from which a node scores
+	// zero.
+	HotThreshold int32
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeHealthArgs holds arguments used to configure the NodeHealth plugin.
+type NodeHealthArgs struct {
+	metav1.TypeMeta
+
+	// RiskSource is where the predicted failure risks of nodes, from 0 to
+	// 100, are read from: "annotation", "file" or "http".
+	RiskSource string
+	// RiskFile is the YAML or JSON file of risks of the "file" source.
+	RiskFile string
+	// RiskURL is the endpoint of the "http" source.
+	RiskURL string
+	// RiskTTLSeconds is how long risks of the "file" and "http" sources are
+	// cached.
+	RiskTTLSeconds int64
+	// MaxAgeSeconds is the age after which the risk of a node is stale.
+	MaxAgeSeconds int64
+	// StaleRisk is the risk assumed for nodes whose risk is stale or
+	// unknown.
+	StaleRisk int32
+	// CriticalThreshold is the risk from which nodes are filtered out.
+	// Other nodes score proportionally to 100 minus their risk.
+	CriticalThreshold int32
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplementarityArgs holds arguments used to configure the Complementarity plugin.
+type ComplementarityArgs struct {
+	metav1.TypeMeta
+
+	// ProfileSource is where the usage profiles of workloads are read from:
+	// "file" or "http". Pods without a profile count with a flat profile at
+	// their requests.
+	ProfileSource string
+	// ProfileFile is the YAML or JSON file of profiles of the "file" source.
+	ProfileFile string
+	// ProfileURL is the endpoint of the "http" source, answering the
+	// content of a profile file in JSON.
+	ProfileURL string
+	// ProfileTTLSeconds is how often profiles of the "file" and "http"
+	// sources are reloaded in the background.
+	ProfileTTLSeconds int64
+	// Slots is the number of slots a day is divided into, e.g. 24 for hourly
+	// profiles. Profiles of another length are resampled.
+	Slots int32
+	// CPUWeight and MemoryWeight weigh the predicted peaks of the resources.
+	CPUWeight    int64
+	MemoryWeight int64
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DRFSortArgs holds arguments used to configure the DRFSort plugin.
+type DRFSortArgs struct {
+	metav1.TypeMeta
+
+	// TenantLabel is the pod label naming the tenant of a pod. Empty, or
+	// missing on a pod, the namespace is the tenant.
+	TenantLabel string
+	// DefaultWeight is the weight of tenants without a weight of their own.
+	DefaultWeight int64
+	// TenantWeights are the weights of tenants. The dominant share of a
+	// tenant is divided by its weight, so that a tenant of weight 2 is
+	// entitled to twice the resources of a tenant of weight 1.
+	TenantWeights []TenantWeight
+}
+
+// TenantWeight is the weight of a tenant.
+type TenantWeight struct {
+	// Tenant is the namespace or the value of the tenant label.
+	Tenant string
+	// Weight is positive.
+	Weight int64
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExpressionArgs holds arguments used to configure the Expression plugin.
+// Expressions are written in the language of package util/expr and
+// compiled when the profile is built.
+type ExpressionArgs struct {
+	metav1.TypeMeta
+
+	// Filters are boolean expressions. A node fits a pod if all of them
+	// are true.
+	Filters []FilterExpression
+	// Scores are numeric expressions. Each is clamped to [0, 100] and the
+	// score of a node is their weighted average.
+	Scores []ScoreExpression
+}
+
+// FilterExpression is a named boolean expression.
+type FilterExpression struct {
+	// Name identifies the expression in the reasons of unschedulable pods.
+	Name string
+	// Expression is true for the nodes that fit.
+	Expression string
+}
+
+// ScoreExpression is a named numeric expression.
+type ScoreExpression struct {
+	// Name identifies the expression.
+	Name string
+	// Expression is the score of a node.
+	Expression string
+	// Weight is positive.
+	Weight int64
+}
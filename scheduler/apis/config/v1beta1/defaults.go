@@ -0,0 +1,267 @@
+package v1beta1
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+)
+
+// insecureSchedulerPort is the default port of the health check and metrics
+// servers.
+const insecureSchedulerPort = 10251
+
+var defaultResourceSpec = []ResourceSpec{
+	{Name: string(v1.ResourceCPU), Weight: 1},
+	{Name: string(v1.ResourceMemory), Weight: 1},
+}
+
+// The defaults of the args of the plugins of this repository match the
+// DefaultArgs of the plugins, which are used when args are not decoded.
+const (
+	defaultInterruptionRiskLabel = "spot.prophet.io/interruption-risk"
+	defaultNodePoolLabel         = "prophet.io/node-pool"
+	defaultMeasurement           = "node_usage"
+	defaultCPUQuery              = `100 * (1 - avg by (instance) (rate(node_cpu_seconds_total{mode="idle"}[5m])))`
+	defaultMemoryQuery           = `100 * (1 - node_memory_MemAvailable_bytes / node_memory_MemTotal_bytes)`
+	defaultNodeLabel             = "instance"
+	defaultProfileFile           = "/etc/prophet/profiles.yaml"
+)
+
+func addDefaultingFuncs(scheme *runtime.Scheme) error {
+	return RegisterDefaults(scheme)
+}
+
+// SetDefaults_KubeSchedulerConfiguration sets additional defaults
+func SetDefaults_KubeSchedulerConfiguration(obj *KubeSchedulerConfiguration) {
+	if len(obj.Profiles) == 0 {
+		obj.Profiles = append(obj.Profiles, KubeSchedulerProfile{})
+	}
+	// Only apply a default scheduler name when there is a single profile.
+	// Validation will ensure that every profile has a non-empty unique name.
+	if len(obj.Profiles) == 1 && obj.Profiles[0].SchedulerName == nil {
+		obj.Profiles[0].SchedulerName = pointer.StringPtr(v1.DefaultSchedulerName)
+	}
+
+	obj.HealthzBindAddress = defaultBindAddress(obj.HealthzBindAddress)
+	obj.MetricsBindAddress = defaultBindAddress(obj.MetricsBindAddress)
+
+	if obj.PercentageOfNodesToScore == nil {
+		percentageOfNodesToScore := int32(config.DefaultPercentageOfNodesToScore)
+		obj.PercentageOfNodesToScore = &percentageOfNodesToScore
+	}
+
+	if obj.PodInitialBackoffSeconds == nil {
+		val := int64(1)
+		obj.PodInitialBackoffSeconds = &val
+	}
+
+	if obj.PodMaxBackoffSeconds == nil {
+		val := int64(10)
+		obj.PodMaxBackoffSeconds = &val
+	}
+}
+
+// defaultBindAddress returns addr with the host defaulting to 0.0.0.0 and
+// the port to the insecure scheduler port:
+// 1. If the value is nil, default to 0.0.0.0 and default scheduler port
+// 2. If the value is just a port (ie, ":1234"), default to 0.0.0.0 with that port
+// 3. If the value is a valid IP, use that with the default port
+// Otherwise use the default bind address.
+func defaultBindAddress(addr *string) *string {
+	defaultAddr := net.JoinHostPort("0.0.0.0", strconv.Itoa(insecureSchedulerPort))
+	if addr == nil {
+		return &defaultAddr
+	}
+	if host, port, err := net.SplitHostPort(*addr); err == nil {
+		if len(host) == 0 {
+			host = "0.0.0.0"
+		}
+		hostPort := net.JoinHostPort(host, port)
+		return &hostPort
+	}
+	if host := net.ParseIP(*addr); host != nil {
+		hostPort := net.JoinHostPort(*addr, strconv.Itoa(insecureSchedulerPort))
+		return &hostPort
+	}
+	return &defaultAddr
+}
+
+// SetDefaults_InterPodAffinityArgs sets the default parameters for the InterPodAffinity plugin.
+func SetDefaults_InterPodAffinityArgs(obj *InterPodAffinityArgs) {
+	if obj.HardPodAffinityWeight == nil {
+		obj.HardPodAffinityWeight = pointer.Int32Ptr(1)
+	}
+}
+
+// SetDefaults_NodeResourcesLeastAllocatedArgs sets the default parameters for the NodeResourcesLeastAllocated plugin.
+func SetDefaults_NodeResourcesLeastAllocatedArgs(obj *NodeResourcesLeastAllocatedArgs) {
+	if len(obj.Resources) == 0 {
+		// If no resources specified, used the default set.
+		obj.Resources = append(obj.Resources, defaultResourceSpec...)
+	}
+}
+
+// SetDefaults_NodeResourcesMostAllocatedArgs sets the default parameters for the NodeResourcesMostAllocated plugin.
+func SetDefaults_NodeResourcesMostAllocatedArgs(obj *NodeResourcesMostAllocatedArgs) {
+	if len(obj.Resources) == 0 {
+		// If no resources specified, used the default set.
+		obj.Resources = append(obj.Resources, defaultResourceSpec...)
+	}
+}
+
+// SetDefaults_RequestedToCapacityRatioArgs sets the default parameters for the RequestedToCapacityRatio plugin.
+func SetDefaults_RequestedToCapacityRatioArgs(obj *RequestedToCapacityRatioArgs) {
+	if len(obj.Resources) == 0 {
+		// If no resources specified, used the default set.
+		obj.Resources = append(obj.Resources, defaultResourceSpec...)
+	}
+}
+
+// SetDefaults_VolumeBindingArgs sets the default parameters for the VolumeBinding plugin.
+func SetDefaults_VolumeBindingArgs(obj *VolumeBindingArgs) {
+	if obj.BindTimeoutSeconds == nil {
+		obj.BindTimeoutSeconds = pointer.Int64Ptr(600)
+	}
+}
+
+// SetDefaults_SpotInstanceArgs sets the default parameters for the SpotInstance plugins.
+func SetDefaults_SpotInstanceArgs(obj *SpotInstanceArgs) {
+	if obj.LifecycleLabels == nil {
+		obj.LifecycleLabels = []LifecycleLabel{
+			{Key: "node.kubernetes.io/lifecycle", Values: []string{"spot", "preemptible"}},
+			{Key: "eks.amazonaws.com/capacityType", Values: []string{"SPOT"}},
+			{Key: "cloud.google.com/gke-preemptible", Values: []string{"true"}},
+			{Key: "cloud.google.com/gke-spot", Values: []string{"true"}},
+			{Key: "kubernetes.azure.com/scalesetpriority", Values: []string{"spot"}},
+		}
+	}
+	if obj.InterruptionRiskLabel == nil {
+		obj.InterruptionRiskLabel = pointer.StringPtr(defaultInterruptionRiskLabel)
+	}
+	if obj.MaxInterruptionRisk == nil {
+		obj.MaxInterruptionRisk = pointer.Int32Ptr(100)
+	}
+	if obj.InstancePoolLabels == nil {
+		obj.InstancePoolLabels = []string{v1.LabelInstanceTypeStable, v1.LabelZoneFailureDomainStable}
+	}
+	if obj.OnDemandPercentage == nil {
+		obj.OnDemandPercentage = pointer.Int32Ptr(20)
+	}
+}
+
+// SetDefaults_UsageOvercommitArgs sets the default parameters for the UsageOvercommit plugin.
+func SetDefaults_UsageOvercommitArgs(obj *UsageOvercommitArgs) {
+	if obj.PredictionSource == "" {
+		obj.PredictionSource = "annotation"
+	}
+	if obj.PredictionTTLSeconds == nil {
+		obj.PredictionTTLSeconds = pointer.Int64Ptr(60)
+	}
+	if obj.NodePoolLabel == nil {
+		obj.NodePoolLabel = pointer.StringPtr(defaultNodePoolLabel)
+	}
+	if obj.DefaultRatio.CPU == 0 {
+		obj.DefaultRatio.CPU = 100
+	}
+	if obj.DefaultRatio.Memory == 0 {
+		obj.DefaultRatio.Memory = 100
+	}
+}
+
+// SetDefaults_LoadAwareArgs sets the default parameters for the LoadAware plugin.
+func SetDefaults_LoadAwareArgs(obj *LoadAwareArgs) {
+	if obj.MetricsSource == "" {
+		obj.MetricsSource = "influxdb"
+	}
+	if obj.Address == "" {
+		obj.Address = "http://localhost:8086"
+	}
+	if obj.Measurement == "" {
+		obj.Measurement = defaultMeasurement
+	}
+	if obj.CPUQuery == nil {
+		obj.CPUQuery = pointer.StringPtr(defaultCPUQuery)
+	}
+	if obj.MemoryQuery == nil {
+		obj.MemoryQuery = pointer.StringPtr(defaultMemoryQuery)
+	}
+	if obj.NodeLabel == "" {
+		obj.NodeLabel = defaultNodeLabel
+	}
+	if obj.RefreshIntervalSeconds == nil {
+		obj.RefreshIntervalSeconds = pointer.Int64Ptr(30)
+	}
+	if obj.MaxAgeSeconds == nil {
+		obj.MaxAgeSeconds = pointer.Int64Ptr(180)
+	}
+	if obj.CPUWeight == nil {
+		obj.CPUWeight = pointer.Int64Ptr(1)
+	}
+	if obj.MemoryWeight == nil {
+		obj.MemoryWeight = pointer.Int64Ptr(1)
+	}
+	if obj.HotThreshold == nil {
+		obj.HotThreshold = pointer.Int32Ptr(80)
+	}
+}
+
+// SetDefaults_NodeHealthArgs sets the default parameters for the NodeHealth plugin.
+func SetDefaults_NodeHealthArgs(obj *NodeHealthArgs) {
+	if obj.RiskSource == "" {
+		obj.RiskSource = "annotation"
+	}
+	if obj.RiskTTLSeconds == nil {
+		obj.RiskTTLSeconds = pointer.Int64Ptr(30)
+	}
+	if obj.MaxAgeSeconds == nil {
+		obj.MaxAgeSeconds = pointer.Int64Ptr(600)
+	}
+	if obj.StaleRisk == nil {
+		obj.StaleRisk = pointer.Int32Ptr(0)
+	}
+	if obj.CriticalThreshold == nil {
+		obj.CriticalThreshold = pointer.Int32Ptr(90)
+	}
+}
+
+// SetDefaults_ComplementarityArgs sets the default parameters for the Complementarity plugin.
+func SetDefaults_ComplementarityArgs(obj *ComplementarityArgs) {
+	if obj.ProfileSource == "" {
+		obj.ProfileSource = "file"
+	}
+	if obj.ProfileFile == "" {
+		obj.ProfileFile = defaultProfileFile
+	}
+	if obj.ProfileTTLSeconds == nil {
+		obj.ProfileTTLSeconds = pointer.Int64Ptr(300)
+	}
+	if obj.Slots == nil {
+		obj.Slots = pointer.Int32Ptr(24)
+	}
+	if obj.CPUWeight == nil {
+		obj.CPUWeight = pointer.Int64Ptr(1)
+	}
+	if obj.MemoryWeight == nil {
+		obj.MemoryWeight = pointer.Int64Ptr(1)
+	}
+}
+
+// SetDefaults_DRFSortArgs sets the default parameters for the DRFSort plugin.
+func SetDefaults_DRFSortArgs(obj *DRFSortArgs) {
+	if obj.DefaultWeight == nil {
+		obj.DefaultWeight = pointer.Int64Ptr(1)
+	}
+}
+
+// SetDefaults_ExpressionArgs sets the default parameters for the Expression plugin.
+func SetDefaults_ExpressionArgs(obj *ExpressionArgs) {
+	for i := range obj.Scores {
+		if obj.Scores[i].Weight == nil {
+			obj.Scores[i].Weight = pointer.Int64Ptr(1)
+		}
+	}
+}
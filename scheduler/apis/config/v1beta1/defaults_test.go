@@ -0,0 +1,288 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/pointer"
+)
+
+func TestSchedulerDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *KubeSchedulerConfiguration
+		expected *KubeSchedulerConfiguration
+	}{
+		{
+			name:   "empty config",
+			config: &KubeSchedulerConfiguration{},
+			expected: &KubeSchedulerConfiguration{
+				HealthzBindAddress:       pointer.StringPtr("0.0.0.0:10251"),
+				MetricsBindAddress:       pointer.StringPtr("0.0.0.0:10251"),
+				PercentageOfNodesToScore: pointer.Int32Ptr(0),
+				PodInitialBackoffSeconds: pointer.Int64Ptr(1),
+				PodMaxBackoffSeconds:     pointer.Int64Ptr(10),
+				Profiles: []KubeSchedulerProfile{
+					{SchedulerName: pointer.StringPtr("default-scheduler")},
+				},
+			},
+		},
+		{
+			name: "no scheduler name with several profiles",
+			config: &KubeSchedulerConfiguration{
+				Profiles: []KubeSchedulerProfile{{}, {SchedulerName: pointer.StringPtr("other")}},
+			},
+			expected: &KubeSchedulerConfiguration{
+				HealthzBindAddress:       pointer.StringPtr("0.0.0.0:10251"),
+				MetricsBindAddress:       pointer.StringPtr("0.0.0.0:10251"),
+				PercentageOfNodesToScore: pointer.Int32Ptr(0),
+				PodInitialBackoffSeconds: pointer.Int64Ptr(1),
+				PodMaxBackoffSeconds:     pointer.Int64Ptr(10),
+				Profiles:                 []KubeSchedulerProfile{{}, {SchedulerName: pointer.StringPtr("other")}},
+			},
+		},
+		{
+			name: "bind addresses without host or port",
+			config: &KubeSchedulerConfiguration{
+				HealthzBindAddress:       pointer.StringPtr(":12345"),
+				MetricsBindAddress:       pointer.StringPtr("1.2.3.4"),
+				PercentageOfNodesToScore: pointer.Int32Ptr(30),
+				PodInitialBackoffSeconds: pointer.Int64Ptr(2),
+				PodMaxBackoffSeconds:     pointer.Int64Ptr(20),
+			},
+			expected: &KubeSchedulerConfiguration{
+				HealthzBindAddress:       pointer.StringPtr("0.0.0.0:12345"),
+				MetricsBindAddress:       pointer.StringPtr("1.2.3.4:10251"),
+				PercentageOfNodesToScore: pointer.Int32Ptr(30),
+				PodInitialBackoffSeconds: pointer.Int64Ptr(2),
+				PodMaxBackoffSeconds:     pointer.Int64Ptr(20),
+				Profiles: []KubeSchedulerProfile{
+					{SchedulerName: pointer.StringPtr("default-scheduler")},
+				},
+			},
+		},
+		{
+			name: "invalid bind address",
+			config: &KubeSchedulerConfiguration{
+				HealthzBindAddress: pointer.StringPtr("not an address"),
+			},
+			expected: &KubeSchedulerConfiguration{
+				HealthzBindAddress:       pointer.StringPtr("0.0.0.0:10251"),
+				MetricsBindAddress:       pointer.StringPtr("0.0.0.0:10251"),
+				PercentageOfNodesToScore: pointer.Int32Ptr(0),
+				PodInitialBackoffSeconds: pointer.Int64Ptr(1),
+				PodMaxBackoffSeconds:     pointer.Int64Ptr(10),
+				Profiles: []KubeSchedulerProfile{
+					{SchedulerName: pointer.StringPtr("default-scheduler")},
+				},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			SetDefaults_KubeSchedulerConfiguration(tc.config)
+			if diff := cmp.Diff(tc.expected, tc.config); diff != "" {
+				t.Errorf("unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPluginArgsDefaults(t *testing.T) {
+	tests := []struct {
+		name string
+		in   runtime.Object
+		want runtime.Object
+	}{
+		{
+			name: "InterPodAffinityArgs empty",
+			in:   &InterPodAffinityArgs{},
+			want: &InterPodAffinityArgs{HardPodAffinityWeight: pointer.Int32Ptr(1)},
+		},
+		{
+			name: "InterPodAffinityArgs explicit 0",
+			in:   &InterPodAffinityArgs{HardPodAffinityWeight: pointer.Int32Ptr(0)},
+			want: &InterPodAffinityArgs{HardPodAffinityWeight: pointer.Int32Ptr(0)},
+		},
+		{
+			name: "NodeResourcesLeastAllocatedArgs empty",
+			in:   &NodeResourcesLeastAllocatedArgs{},
+			want: &NodeResourcesLeastAllocatedArgs{
+				Resources: []ResourceSpec{{Name: "cpu", Weight: 1}, {Name: "memory", Weight: 1}},
+			},
+		},
+		{
+			name: "NodeResourcesMostAllocatedArgs resources",
+			in: &NodeResourcesMostAllocatedArgs{
+				Resources: []ResourceSpec{{Name: "example.com/gpu", Weight: 3}},
+			},
+			want: &NodeResourcesMostAllocatedArgs{
+				Resources: []ResourceSpec{{Name: "example.com/gpu", Weight: 3}},
+			},
+		},
+		{
+			name: "VolumeBindingArgs empty",
+			in:   &VolumeBindingArgs{},
+			want: &VolumeBindingArgs{BindTimeoutSeconds: pointer.Int64Ptr(600)},
+		},
+		{
+			name: "SpotInstanceArgs empty",
+			in:   &SpotInstanceArgs{},
+			want: &SpotInstanceArgs{
+				LifecycleLabels: []LifecycleLabel{
+					{Key: "node.kubernetes.io/lifecycle", Values: []string{"spot", "preemptible"}},
+					{Key: "eks.amazonaws.com/capacityType", Values: []string{"SPOT"}},
+					{Key: "cloud.google.com/gke-preemptible", Values: []string{"true"}},
+					{Key: "cloud.google.com/gke-spot", Values: []string{"true"}},
+					{Key: "kubernetes.azure.com/scalesetpriority", Values: []string{"spot"}},
+				},
+				InterruptionRiskLabel: pointer.StringPtr("spot.prophet.io/interruption-risk"),
+				MaxInterruptionRisk:   pointer.Int32Ptr(100),
+				InstancePoolLabels:    []string{v1.LabelInstanceTypeStable, v1.LabelZoneFailureDomainStable},
+				OnDemandPercentage:    pointer.Int32Ptr(20),
+			},
+		},
+		{
+			name: "SpotInstanceArgs explicit empty",
+			in: &SpotInstanceArgs{
+				LifecycleLabels:       []LifecycleLabel{{Key: "spot"}},
+				InterruptionRiskLabel: pointer.StringPtr(""),
+				MaxInterruptionRisk:   pointer.Int32Ptr(0),
+				InstancePoolLabels:    []string{},
+				OnDemandPercentage:    pointer.Int32Ptr(0),
+			},
+			want: &SpotInstanceArgs{
+				LifecycleLabels:       []LifecycleLabel{{Key: "spot"}},
+				InterruptionRiskLabel: pointer.StringPtr(""),
+				MaxInterruptionRisk:   pointer.Int32Ptr(0),
+				InstancePoolLabels:    []string{},
+				OnDemandPercentage:    pointer.Int32Ptr(0),
+			},
+		},
+		{
+			name: "UsageOvercommitArgs empty",
+			in:   &UsageOvercommitArgs{},
+			want: &UsageOvercommitArgs{
+				PredictionSource:     "annotation",
+				PredictionTTLSeconds: pointer.Int64Ptr(60),
+				NodePoolLabel:        pointer.StringPtr("prophet.io/node-pool"),
+				DefaultRatio:         OvercommitRatio{CPU: 100, Memory: 100},
+			},
+		},
+		{
+			name: "UsageOvercommitArgs cpu ratio",
+			in: &UsageOvercommitArgs{
+				PredictionSource: "file",
+				PredictionFile:   "/etc/predictions.yaml",
+				DefaultRatio:     OvercommitRatio{CPU: 150},
+			},
+			want: &UsageOvercommitArgs{
+				PredictionSource:     "file",
+				PredictionFile:       "/etc/predictions.yaml",
+				PredictionTTLSeconds: pointer.Int64Ptr(60),
+				NodePoolLabel:        pointer.StringPtr("prophet.io/node-pool"),
+				DefaultRatio:         OvercommitRatio{CPU: 150, Memory: 100},
+			},
+		},
+		{
+			name: "LoadAwareArgs empty",
+			in:   &LoadAwareArgs{},
+			want: &LoadAwareArgs{
+				MetricsSource:          "influxdb",
+				Address:                "http://localhost:8086",
+				Measurement:            "node_usage",
+				CPUQuery:               pointer.StringPtr(defaultCPUQuery),
+				MemoryQuery:            pointer.StringPtr(defaultMemoryQuery),
+				NodeLabel:              "instance",
+				RefreshIntervalSeconds: pointer.Int64Ptr(30),
+				MaxAgeSeconds:          pointer.Int64Ptr(180),
+				CPUWeight:              pointer.Int64Ptr(1),
+				MemoryWeight:           pointer.Int64Ptr(1),
+				HotThreshold:           pointer.Int32Ptr(80),
+			},
+		},
+		{
+			name: "LoadAwareArgs cpu only",
+			in: &LoadAwareArgs{
+				MetricsSource: "prometheus",
+				Address:       "http://prometheus:9090",
+				MemoryQuery:   pointer.StringPtr(""),
+				MemoryWeight:  pointer.Int64Ptr(0),
+			},
+			want: &LoadAwareArgs{
+				MetricsSource:          "prometheus",
+				Address:                "http://prometheus:9090",
+				Measurement:            "node_usage",
+				CPUQuery:               pointer.StringPtr(defaultCPUQuery),
+				MemoryQuery:            pointer.StringPtr(""),
+				NodeLabel:              "instance",
+				RefreshIntervalSeconds: pointer.Int64Ptr(30),
+				MaxAgeSeconds:          pointer.Int64Ptr(180),
+				CPUWeight:              pointer.Int64Ptr(1),
+				MemoryWeight:           pointer.Int64Ptr(0),
+				HotThreshold:           pointer.Int32Ptr(80),
+			},
+		},
+		{
+			name: "NodeHealthArgs empty",
+			in:   &NodeHealthArgs{},
+			want: &NodeHealthArgs{
+				RiskSource:        "annotation",
+				RiskTTLSeconds:    pointer.Int64Ptr(30),
+				MaxAgeSeconds:     pointer.Int64Ptr(600),
+				StaleRisk:         pointer.Int32Ptr(0),
+				CriticalThreshold: pointer.Int32Ptr(90),
+			},
+		},
+		{
+			name: "ComplementarityArgs empty",
+			in:   &ComplementarityArgs{},
+			want: &ComplementarityArgs{
+				ProfileSource:     "file",
+				ProfileFile:       "/etc/prophet/profiles.yaml",
+				ProfileTTLSeconds: pointer.Int64Ptr(300),
+				Slots:             pointer.Int32Ptr(24),
+				CPUWeight:         pointer.Int64Ptr(1),
+				MemoryWeight:      pointer.Int64Ptr(1),
+			},
+		},
+		{
+			name: "DRFSortArgs empty",
+			in:   &DRFSortArgs{},
+			want: &DRFSortArgs{DefaultWeight: pointer.Int64Ptr(1)},
+		},
+		{
+			name: "ExpressionArgs score weights",
+			in: &ExpressionArgs{
+				Scores: []ScoreExpression{
+					{Name: "free-memory", Expression: "1"},
+					{Name: "gpu", Expression: "2", Weight: pointer.Int64Ptr(3)},
+				},
+			},
+			want: &ExpressionArgs{
+				Scores: []ScoreExpression{
+					{Name: "free-memory", Expression: "1", Weight: pointer.Int64Ptr(1)},
+					{Name: "gpu", Expression: "2", Weight: pointer.Int64Ptr(3)},
+				},
+			},
+		},
+		{
+			name: "NodeLabelArgs have no defaults",
+			in:   &NodeLabelArgs{},
+			want: &NodeLabelArgs{},
+		},
+	}
+	scheme := runtime.NewScheme()
+	utilruntime.Must(AddToScheme(scheme))
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme.Default(tc.in)
+			if diff := cmp.Diff(tc.want, tc.in); diff != "" {
+				t.Errorf("unexpected defaults (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
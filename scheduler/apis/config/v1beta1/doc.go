@@ -0,0 +1,8 @@
+// +k8s:deepcopy-gen=package
+// +k8s:conversion-gen=github.com/turtacn/cloud-prophet/scheduler/apis/config
+// +k8s:defaulter-gen=TypeMeta
+// +groupName=kubescheduler.config.k8s.io
+
+// Package v1beta1 is the v1beta1 version of the scheduler configuration API,
+// the stable format configurations are read from and written in.
+package v1beta1 // import "github.com/turtacn/cloud-prophet/scheduler/apis/config/v1beta1"
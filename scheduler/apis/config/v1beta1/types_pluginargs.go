@@ -0,0 +1,363 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// InterPodAffinityArgs holds arguments used to configure the InterPodAffinity plugin.
+type InterPodAffinityArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// HardPodAffinityWeight is the scoring weight for existing pods with a
+	// matching hard affinity to the incoming pod.
+	HardPodAffinityWeight *int32 `json:"hardPodAffinityWeight,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeLabelArgs holds arguments used to configure the NodeLabel plugin.
+type NodeLabelArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// PresentLabels should be present for the node to be considered a fit for hosting the pod
+	PresentLabels []string `json:"presentLabels,omitempty"`
+	// AbsentLabels should be absent for the node to be considered a fit for hosting the pod
+	AbsentLabels []string `json:"absentLabels,omitempty"`
+	// Nodes that have labels in the list will get a higher score.
+	PresentLabelsPreference []string `json:"presentLabelsPreference,omitempty"`
+	// Nodes that don't have labels in the list will get a higher score.
+	AbsentLabelsPreference []string `json:"absentLabelsPreference,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeResourcesFitArgs holds arguments used to configure the NodeResourcesFit plugin.
+type NodeResourcesFitArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// IgnoredResources is the list of resources that NodeResources fit filter
+	// should ignore.
+	IgnoredResources []string `json:"ignoredResources,omitempty"`
+	// IgnoredResourceGroups defines the list of resource groups that NodeResources fit filter should ignore.
+	// e.g. if group is ["example.com"], it will ignore all resource names that begin
+	// with "example.com", such as "example.com/aaa" and "example.com/bbb".
+	// A resource group name can't contain '/'.
+	IgnoredResourceGroups []string `json:"ignoredResourceGroups,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PodTopologySpreadArgs holds arguments used to configure the PodTopologySpread plugin.
+type PodTopologySpreadArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// DefaultConstraints defines topology spread constraints to be applied to
+	// pods that don't define any in `pod.spec.topologySpreadConstraints`.
+	// `topologySpreadConstraint.labelSelectors` must be empty, as they are
+	// deduced the pods' membership to Services, Replication Controllers, Replica
+	// Sets or Stateful Sets.
+	// Empty by default.
+	// +optional
+	// +listType=atomic
+	DefaultConstraints []v1.TopologySpreadConstraint `json:"defaultConstraints,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RequestedToCapacityRatioArgs holds arguments used to configure RequestedToCapacityRatio plugin.
+type RequestedToCapacityRatioArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Points defining priority function shape
+	Shape []UtilizationShapePoint `json:"shape"`
+	// Resources to be managed
+	Resources []ResourceSpec `json:"resources,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeResourcesLeastAllocatedArgs holds arguments used to configure NodeResourcesLeastAllocated plugin.
+type NodeResourcesLeastAllocatedArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Resources to be managed, if no resource is provided, default resource set with both
+	// the weight of "cpu" and "memory" set to "1" will be applied.
+	// Resource with "0" weight will not accountable for the final score.
+	Resources []ResourceSpec `json:"resources,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeResourcesMostAllocatedArgs holds arguments used to configure NodeResourcesMostAllocated plugin.
+type NodeResourcesMostAllocatedArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Resources to be managed, if no resource is provided, default resource set with both
+	// the weight of "cpu" and "memory" set to "1" will be applied.
+	// Resource with "0" weight will not accountable for the final score.
+	Resources []ResourceSpec `json:"resources,omitempty"`
+}
+
+// UtilizationShapePoint represents single point of priority function shape.
+type UtilizationShapePoint struct {
+	// Utilization (x axis). Valid values are 0 to 100. Fully utilized node maps to 100.
+	Utilization int32 `json:"utilization"`
+	// Score assigned to given utilization (y axis). Valid values are 0 to 10.
+	Score int32 `json:"score"`
+}
+
+// ResourceSpec represents single resource and weight for bin packing of priority RequestedToCapacityRatioArguments.
+type ResourceSpec struct {
+	// Name of the resource to be managed by RequestedToCapacityRatio function.
+	Name string `json:"name"`
+	// Weight of the resource.
+	Weight int64 `json:"weight,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceAffinityArgs holds arguments used to configure the ServiceAffinity plugin.
+type ServiceAffinityArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// AffinityLabels are homogeneous for pods that are scheduled to a node.
+	// (i.e. it returns true IFF this pod can be added to this node such that all other pods in
+	// the same service are running on nodes with the exact same values for Labels).
+	AffinityLabels []string `json:"affinityLabels,omitempty"`
+	// AntiAffinityLabelsPreference are the labels to consider for service anti affinity scoring.
+	AntiAffinityLabelsPreference []string `json:"antiAffinityLabelsPreference,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// VolumeBindingArgs holds arguments used to configure the VolumeBinding plugin.
+type VolumeBindingArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// BindTimeoutSeconds is the timeout in seconds in volume binding operation.
+	// Value must be non-negative integer. The value zero indicates no waiting.
+	// If this value is nil, the default value (600) will be used.
+	BindTimeoutSeconds *int64 `json:"bindTimeoutSeconds,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SpotInstanceArgs holds arguments used to configure the SpotInstanceFilter
+// and SpotInstanceScore plugins.
+type SpotInstanceArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// LifecycleLabels are the node labels marking spot, low-priority and
+	// preemptible instances. A node matching any of them is a spot node.
+	// Defaults to the labels of the major cloud providers.
+	LifecycleLabels []LifecycleLabel `json:"lifecycleLabels,omitempty"`
+	// InterruptionRiskLabel is the node label holding the interruption risk
+	// of a spot instance: a percentage from 0 to 100, or low, medium or high.
+	InterruptionRiskLabel *string `json:"interruptionRiskLabel,omitempty"`
+	// MaxInterruptionRisk filters out spot nodes with a higher risk. 100,
+	// the default, admits every spot node.
+	MaxInterruptionRisk *int32 `json:"maxInterruptionRisk,omitempty"`
+	// InstancePoolLabels identify the instance pool of a node, instance
+	// type and zone by default.
+	InstancePoolLabels []string `json:"instancePoolLabels,omitempty"`
+	// OnDemandPercentage is the percentage of the replicas of a workload kept
+	// on on-demand nodes, from 0 to 100, 20 by default.
+	OnDemandPercentage *int32 `json:"onDemandPercentage,omitempty"`
+}
+
+// LifecycleLabel is a node label whose values mark spot instances.
+type LifecycleLabel struct {
+	// Key of the label.
+	Key string `json:"key"`
+	// Values of the label meaning spot. Empty means any value.
+	Values []string `json:"values,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// UsageOvercommitArgs holds arguments used to configure the UsageOvercommit plugin.
+type UsageOvercommitArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// PredictionSource is where predicted peak usages of pods are read
+	// from: "annotation" (the default), "file" or "http".
+	PredictionSource string `json:"predictionSource,omitempty"`
+	// PredictionFile is the YAML or JSON file of predictions of the "file"
+	// source.
+	PredictionFile string `json:"predictionFile,omitempty"`
+	// PredictionURL is the endpoint of the "http" source, answering the
+	// content of a prediction file in JSON.
+	PredictionURL string `json:"predictionURL,omitempty"`
+	// PredictionTTLSeconds is how often predictions of the "file" and "http"
+	// sources are reloaded in the background, 60 by default.
+	PredictionTTLSeconds *int64 `json:"predictionTTLSeconds,omitempty"`
+	// NodePoolLabel is the node label naming the node pool of a node.
+	NodePoolLabel *string `json:"nodePoolLabel,omitempty"`
+	// DefaultRatio applies to nodes of pools without a ratio of their own,
+	// 100% of cpu and memory by default.
+	DefaultRatio OvercommitRatio `json:"defaultRatio,omitempty"`
+	// PoolRatios are the overcommit ratios of node pools.
+	PoolRatios []OvercommitRatio `json:"poolRatios,omitempty"`
+}
+
+// OvercommitRatio is the percentage of allocatable resources the predicted
+// usage of the pods of a node pool may reach.
+type OvercommitRatio struct {
+	// Pool is the value of the node pool label. Ignored for the default.
+	Pool string `json:"pool,omitempty"`
+	// CPU in percent of allocatable CPU.
+	CPU int32 `json:"cpu,omitempty"`
+	// Memory in percent of allocatable memory.
+	Memory int32 `json:"memory,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// LoadAwareArgs holds arguments used to configure the LoadAware plugin.
+type LoadAwareArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// MetricsSource is where the utilisation of nodes is read from:
+	// "influxdb" (the default), "prometheus" or "file".
+	MetricsSource string `json:"metricsSource,omitempty"`
+	// Address is the address of the InfluxDB or Prometheus server.
+	Address string `json:"address,omitempty"`
+	// Database is the InfluxDB database.
+	Database string `json:"database,omitempty"`
+	// Measurement is the InfluxDB measurement holding the cpu and memory
+	// utilisation of nodes in percent, tagged by node.
+	Measurement string `json:"measurement,omitempty"`
+	// CPUQuery and MemoryQuery are the Prometheus queries returning the
+	// utilisation of nodes in percent. An empty query disables the
+	// resource.
+	CPUQuery    *string `json:"cpuQuery,omitempty"`
+	MemoryQuery *string `json:"memoryQuery,omitempty"`
+	// NodeLabel is the Prometheus label naming the node of a sample.
+	NodeLabel string `json:"nodeLabel,omitempty"`
+	// MetricsFile is the YAML or JSON file of the "file" source.
+	MetricsFile string `json:"metricsFile,omitempty"`
+	// RefreshIntervalSeconds is how often the utilisation is read, 30 by
+	// default.
+	RefreshIntervalSeconds *int64 `json:"refreshIntervalSeconds,omitempty"`
+	// MaxAgeSeconds is the age after which the utilisation of a node is
+	// ignored and the node scored by its requests instead, 180 by default.
+	MaxAgeSeconds *int64 `json:"maxAgeSeconds,omitempty"`
+	// CPUWeight and MemoryWeight weigh the utilisation of the resources, 1
+	// by default.
+	CPUWeight    *int64 `json:"cpuWeight,omitempty"`
+	MemoryWeight *int64 `json:"memoryWeight,omitempty"`
+	// HotThreshold is the utilisation in percent from which a node scores
+	// zero, 80 by default.
+	HotThreshold *int32 `json:"hotThreshold,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// NodeHealthArgs holds arguments used to configure the NodeHealth plugin.
+type NodeHealthArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// RiskSource is where the predicted failure risks of nodes, from 0 to
+	// 100, are read from: "annotation" (the default), "file" or "http".
+	RiskSource string `json:"riskSource,omitempty"`
+	// RiskFile is the YAML or JSON file of risks of the "file" source.
+	RiskFile string `json:"riskFile,omitempty"`
+	// RiskURL is the endpoint of the "http" source.
+	RiskURL string `json:"riskURL,omitempty"`
+	// RiskTTLSeconds is how long risks of the "file" and "http" sources are
+	// cached, 30 by default.
+	RiskTTLSeconds *int64 `json:"riskTTLSeconds,omitempty"`
+	// MaxAgeSeconds is the age after which the risk of a node is stale, 600
+	// by default.
+	MaxAgeSeconds *int64 `json:"maxAgeSeconds,omitempty"`
+	// StaleRisk is the risk assumed for nodes whose risk is stale or
+	// unknown, 0 by default.
+	StaleRisk *int32 `json:"staleRisk,omitempty"`
+	// CriticalThreshold is the risk from which nodes are filtered out, 90
+	// by default.
+	CriticalThreshold *int32 `json:"criticalThreshold,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ComplementarityArgs holds arguments used to configure the Complementarity plugin.
+type ComplementarityArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// ProfileSource is where the usage profiles of workloads are read from:
+	// "file" (the default) or "http".
+	ProfileSource string `json:"profileSource,omitempty"`
+	// ProfileFile is the YAML or JSON file of profiles of the "file" source.
+	ProfileFile string `json:"profileFile,omitempty"`
+	// ProfileURL is the endpoint of the "http" source, answering the
+	// content of a profile file in JSON.
+	ProfileURL string `json:"profileURL,omitempty"`
+	// ProfileTTLSeconds is how often profiles of the "file" and "http"
+	// sources are reloaded in the background, 300 by default.
+	ProfileTTLSeconds *int64 `json:"profileTTLSeconds,omitempty"`
+	// Slots is the number of slots a day is divided into, 24 by default.
+	Slots *int32 `json:"slots,omitempty"`
+	// CPUWeight and MemoryWeight weigh the predicted peaks of the
+	// resources, 1 by default.
+	CPUWeight    *int64 `json:"cpuWeight,omitempty"`
+	MemoryWeight *int64 `json:"memoryWeight,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DRFSortArgs holds arguments used to configure the DRFSort plugin.
+type DRFSortArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// TenantLabel is the pod label naming the tenant of a pod. Empty, or
+	// missing on a pod, the namespace is the tenant.
+	TenantLabel string `json:"tenantLabel,omitempty"`
+	// DefaultWeight is the weight of tenants without a weight of their own,
+	// 1 by default.
+	DefaultWeight *int64 `json:"defaultWeight,omitempty"`
+	// TenantWeights are the weights of tenants.
+	TenantWeights []TenantWeight `json:"tenantWeights,omitempty"`
+}
+
+// TenantWeight is the weight of a tenant.
+type TenantWeight struct {
+	// Tenant is the namespace or the value of the tenant label.
+	Tenant string `json:"tenant"`
+	// Weight is positive.
+	Weight int64 `json:"weight"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ExpressionArgs holds arguments used to configure the Expression plugin.
+type ExpressionArgs struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Filters are boolean expressions. A node fits a pod if all of them
+	// are true.
+	Filters []FilterExpression `json:"filters,omitempty"`
+	// Scores are numeric expressions. Each is clamped to [0, 100] and the
+	// score of a node is their weighted average.
+	Scores []ScoreExpression `json:"scores,omitempty"`
+}
+
+// FilterExpression is a named boolean expression.
+type FilterExpression struct {
+	// Name identifies the expression in the reasons of unschedulable pods.
+	Name string `json:"name"`
+	// Expression is true for the nodes that fit.
+	Expression string `json:"expression"`
+}
+
+// ScoreExpression is a named numeric expression.
+type ScoreExpression struct {
+	// Name identifies the expression.
+	Name string `json:"name"`
+	// Expression is the score of a node.
+	Expression string `json:"expression"`
+	// Weight is positive, 1 by default.
+	Weight *int64 `json:"weight,omitempty"`
+}
@@ -0,0 +1,2494 @@
+package v1beta1
+
+import (
+	unsafe "unsafe"
+
+	config "github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	conversion "k8s.io/apimachinery/pkg/conversion"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	localSchemeBuilder.Register(RegisterConversions)
+}
+
+// RegisterConversions adds conversion functions to the given scheme.
+// Public to allow building arbitrary schemes.
+func RegisterConversions(s *runtime.Scheme) error {
+	if err := s.AddGeneratedConversionFunc((*BackoffPolicy)(nil), (*config.BackoffPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_BackoffPolicy_To_config_BackoffPolicy(a.(*BackoffPolicy), b.(*config.BackoffPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.BackoffPolicy)(nil), (*BackoffPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_BackoffPolicy_To_v1beta1_BackoffPolicy(a.(*config.BackoffPolicy), b.(*BackoffPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ComplementarityArgs)(nil), (*config.ComplementarityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ComplementarityArgs_To_config_ComplementarityArgs(a.(*ComplementarityArgs), b.(*config.ComplementarityArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ComplementarityArgs)(nil), (*ComplementarityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ComplementarityArgs_To_v1beta1_ComplementarityArgs(a.(*config.ComplementarityArgs), b.(*ComplementarityArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*DRFSortArgs)(nil), (*config.DRFSortArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_DRFSortArgs_To_config_DRFSortArgs(a.(*DRFSortArgs), b.(*config.DRFSortArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.DRFSortArgs)(nil), (*DRFSortArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_DRFSortArgs_To_v1beta1_DRFSortArgs(a.(*config.DRFSortArgs), b.(*DRFSortArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExpressionArgs)(nil), (*config.ExpressionArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ExpressionArgs_To_config_ExpressionArgs(a.(*ExpressionArgs), b.(*config.ExpressionArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ExpressionArgs)(nil), (*ExpressionArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ExpressionArgs_To_v1beta1_ExpressionArgs(a.(*config.ExpressionArgs), b.(*ExpressionArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Extender)(nil), (*config.Extender)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Extender_To_config_Extender(a.(*Extender), b.(*config.Extender), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Extender)(nil), (*Extender)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Extender_To_v1beta1_Extender(a.(*config.Extender), b.(*Extender), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExtenderManagedResource)(nil), (*config.ExtenderManagedResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ExtenderManagedResource_To_config_ExtenderManagedResource(a.(*ExtenderManagedResource), b.(*config.ExtenderManagedResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ExtenderManagedResource)(nil), (*ExtenderManagedResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ExtenderManagedResource_To_v1beta1_ExtenderManagedResource(a.(*config.ExtenderManagedResource), b.(*ExtenderManagedResource), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ExtenderTLSConfig)(nil), (*config.ExtenderTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ExtenderTLSConfig_To_config_ExtenderTLSConfig(a.(*ExtenderTLSConfig), b.(*config.ExtenderTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ExtenderTLSConfig)(nil), (*ExtenderTLSConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ExtenderTLSConfig_To_v1beta1_ExtenderTLSConfig(a.(*config.ExtenderTLSConfig), b.(*ExtenderTLSConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FilterExpression)(nil), (*config.FilterExpression)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_FilterExpression_To_config_FilterExpression(a.(*FilterExpression), b.(*config.FilterExpression), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.FilterExpression)(nil), (*FilterExpression)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_FilterExpression_To_v1beta1_FilterExpression(a.(*config.FilterExpression), b.(*FilterExpression), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*InterPodAffinityArgs)(nil), (*config.InterPodAffinityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_InterPodAffinityArgs_To_config_InterPodAffinityArgs(a.(*InterPodAffinityArgs), b.(*config.InterPodAffinityArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.InterPodAffinityArgs)(nil), (*InterPodAffinityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_InterPodAffinityArgs_To_v1beta1_InterPodAffinityArgs(a.(*config.InterPodAffinityArgs), b.(*InterPodAffinityArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*KubeSchedulerProfile)(nil), (*config.KubeSchedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeSchedulerProfile_To_config_KubeSchedulerProfile(a.(*KubeSchedulerProfile), b.(*config.KubeSchedulerProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.KubeSchedulerProfile)(nil), (*KubeSchedulerProfile)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_KubeSchedulerProfile_To_v1beta1_KubeSchedulerProfile(a.(*config.KubeSchedulerProfile), b.(*KubeSchedulerProfile), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LabelPreference)(nil), (*config.LabelPreference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_LabelPreference_To_config_LabelPreference(a.(*LabelPreference), b.(*config.LabelPreference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.LabelPreference)(nil), (*LabelPreference)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LabelPreference_To_v1beta1_LabelPreference(a.(*config.LabelPreference), b.(*LabelPreference), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LabelsPresence)(nil), (*config.LabelsPresence)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_LabelsPresence_To_config_LabelsPresence(a.(*LabelsPresence), b.(*config.LabelsPresence), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.LabelsPresence)(nil), (*LabelsPresence)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LabelsPresence_To_v1beta1_LabelsPresence(a.(*config.LabelsPresence), b.(*LabelsPresence), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LifecycleLabel)(nil), (*config.LifecycleLabel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_LifecycleLabel_To_config_LifecycleLabel(a.(*LifecycleLabel), b.(*config.LifecycleLabel), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.LifecycleLabel)(nil), (*LifecycleLabel)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LifecycleLabel_To_v1beta1_LifecycleLabel(a.(*config.LifecycleLabel), b.(*LifecycleLabel), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*LoadAwareArgs)(nil), (*config.LoadAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_LoadAwareArgs_To_config_LoadAwareArgs(a.(*LoadAwareArgs), b.(*config.LoadAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.LoadAwareArgs)(nil), (*LoadAwareArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_LoadAwareArgs_To_v1beta1_LoadAwareArgs(a.(*config.LoadAwareArgs), b.(*LoadAwareArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeHealthArgs)(nil), (*config.NodeHealthArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeHealthArgs_To_config_NodeHealthArgs(a.(*NodeHealthArgs), b.(*config.NodeHealthArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NodeHealthArgs)(nil), (*NodeHealthArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NodeHealthArgs_To_v1beta1_NodeHealthArgs(a.(*config.NodeHealthArgs), b.(*NodeHealthArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeLabelArgs)(nil), (*config.NodeLabelArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeLabelArgs_To_config_NodeLabelArgs(a.(*NodeLabelArgs), b.(*config.NodeLabelArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NodeLabelArgs)(nil), (*NodeLabelArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NodeLabelArgs_To_v1beta1_NodeLabelArgs(a.(*config.NodeLabelArgs), b.(*NodeLabelArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeResourcesFitArgs)(nil), (*config.NodeResourcesFitArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeResourcesFitArgs_To_config_NodeResourcesFitArgs(a.(*NodeResourcesFitArgs), b.(*config.NodeResourcesFitArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NodeResourcesFitArgs)(nil), (*NodeResourcesFitArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NodeResourcesFitArgs_To_v1beta1_NodeResourcesFitArgs(a.(*config.NodeResourcesFitArgs), b.(*NodeResourcesFitArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeResourcesLeastAllocatedArgs)(nil), (*config.NodeResourcesLeastAllocatedArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeResourcesLeastAllocatedArgs_To_config_NodeResourcesLeastAllocatedArgs(a.(*NodeResourcesLeastAllocatedArgs), b.(*config.NodeResourcesLeastAllocatedArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NodeResourcesLeastAllocatedArgs)(nil), (*NodeResourcesLeastAllocatedArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NodeResourcesLeastAllocatedArgs_To_v1beta1_NodeResourcesLeastAllocatedArgs(a.(*config.NodeResourcesLeastAllocatedArgs), b.(*NodeResourcesLeastAllocatedArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*NodeResourcesMostAllocatedArgs)(nil), (*config.NodeResourcesMostAllocatedArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_NodeResourcesMostAllocatedArgs_To_config_NodeResourcesMostAllocatedArgs(a.(*NodeResourcesMostAllocatedArgs), b.(*config.NodeResourcesMostAllocatedArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.NodeResourcesMostAllocatedArgs)(nil), (*NodeResourcesMostAllocatedArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_NodeResourcesMostAllocatedArgs_To_v1beta1_NodeResourcesMostAllocatedArgs(a.(*config.NodeResourcesMostAllocatedArgs), b.(*NodeResourcesMostAllocatedArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*OvercommitRatio)(nil), (*config.OvercommitRatio)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_OvercommitRatio_To_config_OvercommitRatio(a.(*OvercommitRatio), b.(*config.OvercommitRatio), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.OvercommitRatio)(nil), (*OvercommitRatio)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_OvercommitRatio_To_v1beta1_OvercommitRatio(a.(*config.OvercommitRatio), b.(*OvercommitRatio), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Plugin)(nil), (*config.Plugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Plugin_To_config_Plugin(a.(*Plugin), b.(*config.Plugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Plugin)(nil), (*Plugin)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Plugin_To_v1beta1_Plugin(a.(*config.Plugin), b.(*Plugin), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PluginConfig)(nil), (*config.PluginConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PluginConfig_To_config_PluginConfig(a.(*PluginConfig), b.(*config.PluginConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PluginConfig)(nil), (*PluginConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PluginConfig_To_v1beta1_PluginConfig(a.(*config.PluginConfig), b.(*PluginConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PluginSet)(nil), (*config.PluginSet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PluginSet_To_config_PluginSet(a.(*PluginSet), b.(*config.PluginSet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PluginSet)(nil), (*PluginSet)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PluginSet_To_v1beta1_PluginSet(a.(*config.PluginSet), b.(*PluginSet), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Plugins)(nil), (*config.Plugins)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Plugins_To_config_Plugins(a.(*Plugins), b.(*config.Plugins), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Plugins)(nil), (*Plugins)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Plugins_To_v1beta1_Plugins(a.(*config.Plugins), b.(*Plugins), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PodTopologySpreadArgs)(nil), (*config.PodTopologySpreadArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PodTopologySpreadArgs_To_config_PodTopologySpreadArgs(a.(*PodTopologySpreadArgs), b.(*config.PodTopologySpreadArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PodTopologySpreadArgs)(nil), (*PodTopologySpreadArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PodTopologySpreadArgs_To_v1beta1_PodTopologySpreadArgs(a.(*config.PodTopologySpreadArgs), b.(*PodTopologySpreadArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*Policy)(nil), (*config.Policy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_Policy_To_config_Policy(a.(*Policy), b.(*config.Policy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.Policy)(nil), (*Policy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_Policy_To_v1beta1_Policy(a.(*config.Policy), b.(*Policy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PredicateArgument)(nil), (*config.PredicateArgument)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PredicateArgument_To_config_PredicateArgument(a.(*PredicateArgument), b.(*config.PredicateArgument), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PredicateArgument)(nil), (*PredicateArgument)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PredicateArgument_To_v1beta1_PredicateArgument(a.(*config.PredicateArgument), b.(*PredicateArgument), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PredicatePolicy)(nil), (*config.PredicatePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PredicatePolicy_To_config_PredicatePolicy(a.(*PredicatePolicy), b.(*config.PredicatePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PredicatePolicy)(nil), (*PredicatePolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PredicatePolicy_To_v1beta1_PredicatePolicy(a.(*config.PredicatePolicy), b.(*PredicatePolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PriorityArgument)(nil), (*config.PriorityArgument)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PriorityArgument_To_config_PriorityArgument(a.(*PriorityArgument), b.(*config.PriorityArgument), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PriorityArgument)(nil), (*PriorityArgument)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PriorityArgument_To_v1beta1_PriorityArgument(a.(*config.PriorityArgument), b.(*PriorityArgument), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PriorityBackoffCap)(nil), (*config.PriorityBackoffCap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PriorityBackoffCap_To_config_PriorityBackoffCap(a.(*PriorityBackoffCap), b.(*config.PriorityBackoffCap), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PriorityBackoffCap)(nil), (*PriorityBackoffCap)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PriorityBackoffCap_To_v1beta1_PriorityBackoffCap(a.(*config.PriorityBackoffCap), b.(*PriorityBackoffCap), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*PriorityPolicy)(nil), (*config.PriorityPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_PriorityPolicy_To_config_PriorityPolicy(a.(*PriorityPolicy), b.(*config.PriorityPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.PriorityPolicy)(nil), (*PriorityPolicy)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_PriorityPolicy_To_v1beta1_PriorityPolicy(a.(*config.PriorityPolicy), b.(*PriorityPolicy), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ReasonBackoff)(nil), (*config.ReasonBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ReasonBackoff_To_config_ReasonBackoff(a.(*ReasonBackoff), b.(*config.ReasonBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ReasonBackoff)(nil), (*ReasonBackoff)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ReasonBackoff_To_v1beta1_ReasonBackoff(a.(*config.ReasonBackoff), b.(*ReasonBackoff), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RequestedToCapacityRatioArgs)(nil), (*config.RequestedToCapacityRatioArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RequestedToCapacityRatioArgs_To_config_RequestedToCapacityRatioArgs(a.(*RequestedToCapacityRatioArgs), b.(*config.RequestedToCapacityRatioArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RequestedToCapacityRatioArgs)(nil), (*RequestedToCapacityRatioArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RequestedToCapacityRatioArgs_To_v1beta1_RequestedToCapacityRatioArgs(a.(*config.RequestedToCapacityRatioArgs), b.(*RequestedToCapacityRatioArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*RequestedToCapacityRatioArguments)(nil), (*config.RequestedToCapacityRatioArguments)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_RequestedToCapacityRatioArguments_To_config_RequestedToCapacityRatioArguments(a.(*RequestedToCapacityRatioArguments), b.(*config.RequestedToCapacityRatioArguments), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.RequestedToCapacityRatioArguments)(nil), (*RequestedToCapacityRatioArguments)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_RequestedToCapacityRatioArguments_To_v1beta1_RequestedToCapacityRatioArguments(a.(*config.RequestedToCapacityRatioArguments), b.(*RequestedToCapacityRatioArguments), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ResourceSpec)(nil), (*config.ResourceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ResourceSpec_To_config_ResourceSpec(a.(*ResourceSpec), b.(*config.ResourceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ResourceSpec)(nil), (*ResourceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ResourceSpec_To_v1beta1_ResourceSpec(a.(*config.ResourceSpec), b.(*ResourceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ScoreExpression)(nil), (*config.ScoreExpression)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ScoreExpression_To_config_ScoreExpression(a.(*ScoreExpression), b.(*config.ScoreExpression), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ScoreExpression)(nil), (*ScoreExpression)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ScoreExpression_To_v1beta1_ScoreExpression(a.(*config.ScoreExpression), b.(*ScoreExpression), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAffinity)(nil), (*config.ServiceAffinity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceAffinity_To_config_ServiceAffinity(a.(*ServiceAffinity), b.(*config.ServiceAffinity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ServiceAffinity)(nil), (*ServiceAffinity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ServiceAffinity_To_v1beta1_ServiceAffinity(a.(*config.ServiceAffinity), b.(*ServiceAffinity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAffinityArgs)(nil), (*config.ServiceAffinityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceAffinityArgs_To_config_ServiceAffinityArgs(a.(*ServiceAffinityArgs), b.(*config.ServiceAffinityArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ServiceAffinityArgs)(nil), (*ServiceAffinityArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ServiceAffinityArgs_To_v1beta1_ServiceAffinityArgs(a.(*config.ServiceAffinityArgs), b.(*ServiceAffinityArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*ServiceAntiAffinity)(nil), (*config.ServiceAntiAffinity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ServiceAntiAffinity_To_config_ServiceAntiAffinity(a.(*ServiceAntiAffinity), b.(*config.ServiceAntiAffinity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.ServiceAntiAffinity)(nil), (*ServiceAntiAffinity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_ServiceAntiAffinity_To_v1beta1_ServiceAntiAffinity(a.(*config.ServiceAntiAffinity), b.(*ServiceAntiAffinity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*SpotInstanceArgs)(nil), (*config.SpotInstanceArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_SpotInstanceArgs_To_config_SpotInstanceArgs(a.(*SpotInstanceArgs), b.(*config.SpotInstanceArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.SpotInstanceArgs)(nil), (*SpotInstanceArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_SpotInstanceArgs_To_v1beta1_SpotInstanceArgs(a.(*config.SpotInstanceArgs), b.(*SpotInstanceArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TenantWeight)(nil), (*config.TenantWeight)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_TenantWeight_To_config_TenantWeight(a.(*TenantWeight), b.(*config.TenantWeight), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.TenantWeight)(nil), (*TenantWeight)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_TenantWeight_To_v1beta1_TenantWeight(a.(*config.TenantWeight), b.(*TenantWeight), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*UsageOvercommitArgs)(nil), (*config.UsageOvercommitArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_UsageOvercommitArgs_To_config_UsageOvercommitArgs(a.(*UsageOvercommitArgs), b.(*config.UsageOvercommitArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.UsageOvercommitArgs)(nil), (*UsageOvercommitArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_UsageOvercommitArgs_To_v1beta1_UsageOvercommitArgs(a.(*config.UsageOvercommitArgs), b.(*UsageOvercommitArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*UtilizationShapePoint)(nil), (*config.UtilizationShapePoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_UtilizationShapePoint_To_config_UtilizationShapePoint(a.(*UtilizationShapePoint), b.(*config.UtilizationShapePoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.UtilizationShapePoint)(nil), (*UtilizationShapePoint)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_UtilizationShapePoint_To_v1beta1_UtilizationShapePoint(a.(*config.UtilizationShapePoint), b.(*UtilizationShapePoint), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*VolumeBindingArgs)(nil), (*config.VolumeBindingArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_VolumeBindingArgs_To_config_VolumeBindingArgs(a.(*VolumeBindingArgs), b.(*config.VolumeBindingArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*config.VolumeBindingArgs)(nil), (*VolumeBindingArgs)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_VolumeBindingArgs_To_v1beta1_VolumeBindingArgs(a.(*config.VolumeBindingArgs), b.(*VolumeBindingArgs), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*config.KubeSchedulerConfiguration)(nil), (*KubeSchedulerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_config_KubeSchedulerConfiguration_To_v1beta1_KubeSchedulerConfiguration(a.(*config.KubeSchedulerConfiguration), b.(*KubeSchedulerConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*KubeSchedulerConfiguration)(nil), (*config.KubeSchedulerConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_KubeSchedulerConfiguration_To_config_KubeSchedulerConfiguration(a.(*KubeSchedulerConfiguration), b.(*config.KubeSchedulerConfiguration), scope)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+func autoConvert_v1beta1_BackoffPolicy_To_config_BackoffPolicy(in *BackoffPolicy, out *config.BackoffPolicy, s conversion.Scope) error {
+	out.Multiplier = in.Multiplier
+	out.JitterPercent = in.JitterPercent
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]config.ReasonBackoff, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ReasonBackoff_To_config_ReasonBackoff(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Reasons = nil
+	}
+	if in.PriorityCaps != nil {
+		in, out := &in.PriorityCaps, &out.PriorityCaps
+		*out = make([]config.PriorityBackoffCap, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_PriorityBackoffCap_To_config_PriorityBackoffCap(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PriorityCaps = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_BackoffPolicy_To_config_BackoffPolicy is an autogenerated conversion function.
+func Convert_v1beta1_BackoffPolicy_To_config_BackoffPolicy(in *BackoffPolicy, out *config.BackoffPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_BackoffPolicy_To_config_BackoffPolicy(in, out, s)
+}
+
+func autoConvert_config_BackoffPolicy_To_v1beta1_BackoffPolicy(in *config.BackoffPolicy, out *BackoffPolicy, s conversion.Scope) error {
+	out.Multiplier = in.Multiplier
+	out.JitterPercent = in.JitterPercent
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]ReasonBackoff, len(*in))
+		for i := range *in {
+			if err := Convert_config_ReasonBackoff_To_v1beta1_ReasonBackoff(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Reasons = nil
+	}
+	if in.PriorityCaps != nil {
+		in, out := &in.PriorityCaps, &out.PriorityCaps
+		*out = make([]PriorityBackoffCap, len(*in))
+		for i := range *in {
+			if err := Convert_config_PriorityBackoffCap_To_v1beta1_PriorityBackoffCap(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PriorityCaps = nil
+	}
+	return nil
+}
+
+// Convert_config_BackoffPolicy_To_v1beta1_BackoffPolicy is an autogenerated conversion function.
+func Convert_config_BackoffPolicy_To_v1beta1_BackoffPolicy(in *config.BackoffPolicy, out *BackoffPolicy, s conversion.Scope) error {
+	return autoConvert_config_BackoffPolicy_To_v1beta1_BackoffPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ComplementarityArgs_To_config_ComplementarityArgs(in *ComplementarityArgs, out *config.ComplementarityArgs, s conversion.Scope) error {
+	out.ProfileSource = in.ProfileSource
+	out.ProfileFile = in.ProfileFile
+	out.ProfileURL = in.ProfileURL
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.ProfileTTLSeconds, &out.ProfileTTLSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.Slots, &out.Slots, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.CPUWeight, &out.CPUWeight, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.MemoryWeight, &out.MemoryWeight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ComplementarityArgs_To_config_ComplementarityArgs is an autogenerated conversion function.
+func Convert_v1beta1_ComplementarityArgs_To_config_ComplementarityArgs(in *ComplementarityArgs, out *config.ComplementarityArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_ComplementarityArgs_To_config_ComplementarityArgs(in, out, s)
+}
+
+func autoConvert_config_ComplementarityArgs_To_v1beta1_ComplementarityArgs(in *config.ComplementarityArgs, out *ComplementarityArgs, s conversion.Scope) error {
+	out.ProfileSource = in.ProfileSource
+	out.ProfileFile = in.ProfileFile
+	out.ProfileURL = in.ProfileURL
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.ProfileTTLSeconds, &out.ProfileTTLSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.Slots, &out.Slots, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.CPUWeight, &out.CPUWeight, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.MemoryWeight, &out.MemoryWeight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_ComplementarityArgs_To_v1beta1_ComplementarityArgs is an autogenerated conversion function.
+func Convert_config_ComplementarityArgs_To_v1beta1_ComplementarityArgs(in *config.ComplementarityArgs, out *ComplementarityArgs, s conversion.Scope) error {
+	return autoConvert_config_ComplementarityArgs_To_v1beta1_ComplementarityArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_DRFSortArgs_To_config_DRFSortArgs(in *DRFSortArgs, out *config.DRFSortArgs, s conversion.Scope) error {
+	out.TenantLabel = in.TenantLabel
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.DefaultWeight, &out.DefaultWeight, s); err != nil {
+		return err
+	}
+	if in.TenantWeights != nil {
+		in, out := &in.TenantWeights, &out.TenantWeights
+		*out = make([]config.TenantWeight, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_TenantWeight_To_config_TenantWeight(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.TenantWeights = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_DRFSortArgs_To_config_DRFSortArgs is an autogenerated conversion function.
+func Convert_v1beta1_DRFSortArgs_To_config_DRFSortArgs(in *DRFSortArgs, out *config.DRFSortArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_DRFSortArgs_To_config_DRFSortArgs(in, out, s)
+}
+
+func autoConvert_config_DRFSortArgs_To_v1beta1_DRFSortArgs(in *config.DRFSortArgs, out *DRFSortArgs, s conversion.Scope) error {
+	out.TenantLabel = in.TenantLabel
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.DefaultWeight, &out.DefaultWeight, s); err != nil {
+		return err
+	}
+	if in.TenantWeights != nil {
+		in, out := &in.TenantWeights, &out.TenantWeights
+		*out = make([]TenantWeight, len(*in))
+		for i := range *in {
+			if err := Convert_config_TenantWeight_To_v1beta1_TenantWeight(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.TenantWeights = nil
+	}
+	return nil
+}
+
+// Convert_config_DRFSortArgs_To_v1beta1_DRFSortArgs is an autogenerated conversion function.
+func Convert_config_DRFSortArgs_To_v1beta1_DRFSortArgs(in *config.DRFSortArgs, out *DRFSortArgs, s conversion.Scope) error {
+	return autoConvert_config_DRFSortArgs_To_v1beta1_DRFSortArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_ExpressionArgs_To_config_ExpressionArgs(in *ExpressionArgs, out *config.ExpressionArgs, s conversion.Scope) error {
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]config.FilterExpression, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_FilterExpression_To_config_FilterExpression(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Filters = nil
+	}
+	if in.Scores != nil {
+		in, out := &in.Scores, &out.Scores
+		*out = make([]config.ScoreExpression, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ScoreExpression_To_config_ScoreExpression(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Scores = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_ExpressionArgs_To_config_ExpressionArgs is an autogenerated conversion function.
+func Convert_v1beta1_ExpressionArgs_To_config_ExpressionArgs(in *ExpressionArgs, out *config.ExpressionArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_ExpressionArgs_To_config_ExpressionArgs(in, out, s)
+}
+
+func autoConvert_config_ExpressionArgs_To_v1beta1_ExpressionArgs(in *config.ExpressionArgs, out *ExpressionArgs, s conversion.Scope) error {
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]FilterExpression, len(*in))
+		for i := range *in {
+			if err := Convert_config_FilterExpression_To_v1beta1_FilterExpression(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Filters = nil
+	}
+	if in.Scores != nil {
+		in, out := &in.Scores, &out.Scores
+		*out = make([]ScoreExpression, len(*in))
+		for i := range *in {
+			if err := Convert_config_ScoreExpression_To_v1beta1_ScoreExpression(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Scores = nil
+	}
+	return nil
+}
+
+// Convert_config_ExpressionArgs_To_v1beta1_ExpressionArgs is an autogenerated conversion function.
+func Convert_config_ExpressionArgs_To_v1beta1_ExpressionArgs(in *config.ExpressionArgs, out *ExpressionArgs, s conversion.Scope) error {
+	return autoConvert_config_ExpressionArgs_To_v1beta1_ExpressionArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_Extender_To_config_Extender(in *Extender, out *config.Extender, s conversion.Scope) error {
+	out.URLPrefix = in.URLPrefix
+	out.FilterVerb = in.FilterVerb
+	out.PreemptVerb = in.PreemptVerb
+	out.PrioritizeVerb = in.PrioritizeVerb
+	out.Weight = in.Weight
+	out.BindVerb = in.BindVerb
+	out.EnableHTTPS = in.EnableHTTPS
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(config.ExtenderTLSConfig)
+		if err := Convert_v1beta1_ExtenderTLSConfig_To_config_ExtenderTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLSConfig = nil
+	}
+	out.HTTPTimeout = in.HTTPTimeout
+	out.NodeCacheCapable = in.NodeCacheCapable
+	if in.ManagedResources != nil {
+		in, out := &in.ManagedResources, &out.ManagedResources
+		*out = make([]config.ExtenderManagedResource, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ExtenderManagedResource_To_config_ExtenderManagedResource(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ManagedResources = nil
+	}
+	out.Ignorable = in.Ignorable
+	return nil
+}
+
+// Convert_v1beta1_Extender_To_config_Extender is an autogenerated conversion function.
+func Convert_v1beta1_Extender_To_config_Extender(in *Extender, out *config.Extender, s conversion.Scope) error {
+	return autoConvert_v1beta1_Extender_To_config_Extender(in, out, s)
+}
+
+func autoConvert_config_Extender_To_v1beta1_Extender(in *config.Extender, out *Extender, s conversion.Scope) error {
+	out.URLPrefix = in.URLPrefix
+	out.FilterVerb = in.FilterVerb
+	out.PreemptVerb = in.PreemptVerb
+	out.PrioritizeVerb = in.PrioritizeVerb
+	out.Weight = in.Weight
+	out.BindVerb = in.BindVerb
+	out.EnableHTTPS = in.EnableHTTPS
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(ExtenderTLSConfig)
+		if err := Convert_config_ExtenderTLSConfig_To_v1beta1_ExtenderTLSConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TLSConfig = nil
+	}
+	out.HTTPTimeout = in.HTTPTimeout
+	out.NodeCacheCapable = in.NodeCacheCapable
+	if in.ManagedResources != nil {
+		in, out := &in.ManagedResources, &out.ManagedResources
+		*out = make([]ExtenderManagedResource, len(*in))
+		for i := range *in {
+			if err := Convert_config_ExtenderManagedResource_To_v1beta1_ExtenderManagedResource(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.ManagedResources = nil
+	}
+	out.Ignorable = in.Ignorable
+	return nil
+}
+
+// Convert_config_Extender_To_v1beta1_Extender is an autogenerated conversion function.
+func Convert_config_Extender_To_v1beta1_Extender(in *config.Extender, out *Extender, s conversion.Scope) error {
+	return autoConvert_config_Extender_To_v1beta1_Extender(in, out, s)
+}
+
+func autoConvert_v1beta1_ExtenderManagedResource_To_config_ExtenderManagedResource(in *ExtenderManagedResource, out *config.ExtenderManagedResource, s conversion.Scope) error {
+	out.Name = in.Name
+	out.IgnoredByScheduler = in.IgnoredByScheduler
+	return nil
+}
+
+// Convert_v1beta1_ExtenderManagedResource_To_config_ExtenderManagedResource is an autogenerated conversion function.
+func Convert_v1beta1_ExtenderManagedResource_To_config_ExtenderManagedResource(in *ExtenderManagedResource, out *config.ExtenderManagedResource, s conversion.Scope) error {
+	return autoConvert_v1beta1_ExtenderManagedResource_To_config_ExtenderManagedResource(in, out, s)
+}
+
+func autoConvert_config_ExtenderManagedResource_To_v1beta1_ExtenderManagedResource(in *config.ExtenderManagedResource, out *ExtenderManagedResource, s conversion.Scope) error {
+	out.Name = in.Name
+	out.IgnoredByScheduler = in.IgnoredByScheduler
+	return nil
+}
+
+// Convert_config_ExtenderManagedResource_To_v1beta1_ExtenderManagedResource is an autogenerated conversion function.
+func Convert_config_ExtenderManagedResource_To_v1beta1_ExtenderManagedResource(in *config.ExtenderManagedResource, out *ExtenderManagedResource, s conversion.Scope) error {
+	return autoConvert_config_ExtenderManagedResource_To_v1beta1_ExtenderManagedResource(in, out, s)
+}
+
+func autoConvert_v1beta1_ExtenderTLSConfig_To_config_ExtenderTLSConfig(in *ExtenderTLSConfig, out *config.ExtenderTLSConfig, s conversion.Scope) error {
+	out.Insecure = in.Insecure
+	out.ServerName = in.ServerName
+	out.CertFile = in.CertFile
+	out.KeyFile = in.KeyFile
+	out.CAFile = in.CAFile
+	out.CertData = *(*[]byte)(unsafe.Pointer(&in.CertData))
+	out.KeyData = *(*[]byte)(unsafe.Pointer(&in.KeyData))
+	out.CAData = *(*[]byte)(unsafe.Pointer(&in.CAData))
+	return nil
+}
+
+// Convert_v1beta1_ExtenderTLSConfig_To_config_ExtenderTLSConfig is an autogenerated conversion function.
+func Convert_v1beta1_ExtenderTLSConfig_To_config_ExtenderTLSConfig(in *ExtenderTLSConfig, out *config.ExtenderTLSConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ExtenderTLSConfig_To_config_ExtenderTLSConfig(in, out, s)
+}
+
+func autoConvert_config_ExtenderTLSConfig_To_v1beta1_ExtenderTLSConfig(in *config.ExtenderTLSConfig, out *ExtenderTLSConfig, s conversion.Scope) error {
+	out.Insecure = in.Insecure
+	out.ServerName = in.ServerName
+	out.CertFile = in.CertFile
+	out.KeyFile = in.KeyFile
+	out.CAFile = in.CAFile
+	out.CertData = *(*[]byte)(unsafe.Pointer(&in.CertData))
+	out.KeyData = *(*[]byte)(unsafe.Pointer(&in.KeyData))
+	out.CAData = *(*[]byte)(unsafe.Pointer(&in.CAData))
+	return nil
+}
+
+// Convert_config_ExtenderTLSConfig_To_v1beta1_ExtenderTLSConfig is an autogenerated conversion function.
+func Convert_config_ExtenderTLSConfig_To_v1beta1_ExtenderTLSConfig(in *config.ExtenderTLSConfig, out *ExtenderTLSConfig, s conversion.Scope) error {
+	return autoConvert_config_ExtenderTLSConfig_To_v1beta1_ExtenderTLSConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_FilterExpression_To_config_FilterExpression(in *FilterExpression, out *config.FilterExpression, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Expression = in.Expression
+	return nil
+}
+
+// Convert_v1beta1_FilterExpression_To_config_FilterExpression is an autogenerated conversion function.
+func Convert_v1beta1_FilterExpression_To_config_FilterExpression(in *FilterExpression, out *config.FilterExpression, s conversion.Scope) error {
+	return autoConvert_v1beta1_FilterExpression_To_config_FilterExpression(in, out, s)
+}
+
+func autoConvert_config_FilterExpression_To_v1beta1_FilterExpression(in *config.FilterExpression, out *FilterExpression, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Expression = in.Expression
+	return nil
+}
+
+// Convert_config_FilterExpression_To_v1beta1_FilterExpression is an autogenerated conversion function.
+func Convert_config_FilterExpression_To_v1beta1_FilterExpression(in *config.FilterExpression, out *FilterExpression, s conversion.Scope) error {
+	return autoConvert_config_FilterExpression_To_v1beta1_FilterExpression(in, out, s)
+}
+
+func autoConvert_v1beta1_InterPodAffinityArgs_To_config_InterPodAffinityArgs(in *InterPodAffinityArgs, out *config.InterPodAffinityArgs, s conversion.Scope) error {
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.HardPodAffinityWeight, &out.HardPodAffinityWeight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_InterPodAffinityArgs_To_config_InterPodAffinityArgs is an autogenerated conversion function.
+func Convert_v1beta1_InterPodAffinityArgs_To_config_InterPodAffinityArgs(in *InterPodAffinityArgs, out *config.InterPodAffinityArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_InterPodAffinityArgs_To_config_InterPodAffinityArgs(in, out, s)
+}
+
+func autoConvert_config_InterPodAffinityArgs_To_v1beta1_InterPodAffinityArgs(in *config.InterPodAffinityArgs, out *InterPodAffinityArgs, s conversion.Scope) error {
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.HardPodAffinityWeight, &out.HardPodAffinityWeight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_InterPodAffinityArgs_To_v1beta1_InterPodAffinityArgs is an autogenerated conversion function.
+func Convert_config_InterPodAffinityArgs_To_v1beta1_InterPodAffinityArgs(in *config.InterPodAffinityArgs, out *InterPodAffinityArgs, s conversion.Scope) error {
+	return autoConvert_config_InterPodAffinityArgs_To_v1beta1_InterPodAffinityArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_KubeSchedulerConfiguration_To_config_KubeSchedulerConfiguration(in *KubeSchedulerConfiguration, out *config.KubeSchedulerConfiguration, s conversion.Scope) error {
+	if err := metav1.Convert_Pointer_string_To_string(&in.HealthzBindAddress, &out.HealthzBindAddress, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_string_To_string(&in.MetricsBindAddress, &out.MetricsBindAddress, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.PercentageOfNodesToScore, &out.PercentageOfNodesToScore, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.PodInitialBackoffSeconds, &out.PodInitialBackoffSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.PodMaxBackoffSeconds, &out.PodMaxBackoffSeconds, s); err != nil {
+		return err
+	}
+	if in.BackoffPolicy != nil {
+		in, out := &in.BackoffPolicy, &out.BackoffPolicy
+		*out = new(config.BackoffPolicy)
+		if err := Convert_v1beta1_BackoffPolicy_To_config_BackoffPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BackoffPolicy = nil
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]config.KubeSchedulerProfile, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_KubeSchedulerProfile_To_config_KubeSchedulerProfile(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Profiles = nil
+	}
+	if in.Extenders != nil {
+		in, out := &in.Extenders, &out.Extenders
+		*out = make([]config.Extender, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Extender_To_config_Extender(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Extenders = nil
+	}
+	return nil
+}
+
+func autoConvert_config_KubeSchedulerConfiguration_To_v1beta1_KubeSchedulerConfiguration(in *config.KubeSchedulerConfiguration, out *KubeSchedulerConfiguration, s conversion.Scope) error {
+	// WARNING: in.AlgorithmSource requires manual conversion: does not exist in peer-type
+	if err := metav1.Convert_string_To_Pointer_string(&in.HealthzBindAddress, &out.HealthzBindAddress, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_string_To_Pointer_string(&in.MetricsBindAddress, &out.MetricsBindAddress, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.PercentageOfNodesToScore, &out.PercentageOfNodesToScore, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.PodInitialBackoffSeconds, &out.PodInitialBackoffSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.PodMaxBackoffSeconds, &out.PodMaxBackoffSeconds, s); err != nil {
+		return err
+	}
+	if in.BackoffPolicy != nil {
+		in, out := &in.BackoffPolicy, &out.BackoffPolicy
+		*out = new(BackoffPolicy)
+		if err := Convert_config_BackoffPolicy_To_v1beta1_BackoffPolicy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BackoffPolicy = nil
+	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]KubeSchedulerProfile, len(*in))
+		for i := range *in {
+			if err := Convert_config_KubeSchedulerProfile_To_v1beta1_KubeSchedulerProfile(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Profiles = nil
+	}
+	if in.Extenders != nil {
+		in, out := &in.Extenders, &out.Extenders
+		*out = make([]Extender, len(*in))
+		for i := range *in {
+			if err := Convert_config_Extender_To_v1beta1_Extender(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Extenders = nil
+	}
+	return nil
+}
+
+func autoConvert_v1beta1_KubeSchedulerProfile_To_config_KubeSchedulerProfile(in *KubeSchedulerProfile, out *config.KubeSchedulerProfile, s conversion.Scope) error {
+	if err := metav1.Convert_Pointer_string_To_string(&in.SchedulerName, &out.SchedulerName, s); err != nil {
+		return err
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = new(config.Plugins)
+		if err := Convert_v1beta1_Plugins_To_config_Plugins(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Plugins = nil
+	}
+	if in.PluginConfig != nil {
+		in, out := &in.PluginConfig, &out.PluginConfig
+		*out = make([]config.PluginConfig, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_PluginConfig_To_config_PluginConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PluginConfig = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_KubeSchedulerProfile_To_config_KubeSchedulerProfile is an autogenerated conversion function.
+func Convert_v1beta1_KubeSchedulerProfile_To_config_KubeSchedulerProfile(in *KubeSchedulerProfile, out *config.KubeSchedulerProfile, s conversion.Scope) error {
+	return autoConvert_v1beta1_KubeSchedulerProfile_To_config_KubeSchedulerProfile(in, out, s)
+}
+
+func autoConvert_config_KubeSchedulerProfile_To_v1beta1_KubeSchedulerProfile(in *config.KubeSchedulerProfile, out *KubeSchedulerProfile, s conversion.Scope) error {
+	if err := metav1.Convert_string_To_Pointer_string(&in.SchedulerName, &out.SchedulerName, s); err != nil {
+		return err
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = new(Plugins)
+		if err := Convert_config_Plugins_To_v1beta1_Plugins(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Plugins = nil
+	}
+	if in.PluginConfig != nil {
+		in, out := &in.PluginConfig, &out.PluginConfig
+		*out = make([]PluginConfig, len(*in))
+		for i := range *in {
+			if err := Convert_config_PluginConfig_To_v1beta1_PluginConfig(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PluginConfig = nil
+	}
+	return nil
+}
+
+// Convert_config_KubeSchedulerProfile_To_v1beta1_KubeSchedulerProfile is an autogenerated conversion function.
+func Convert_config_KubeSchedulerProfile_To_v1beta1_KubeSchedulerProfile(in *config.KubeSchedulerProfile, out *KubeSchedulerProfile, s conversion.Scope) error {
+	return autoConvert_config_KubeSchedulerProfile_To_v1beta1_KubeSchedulerProfile(in, out, s)
+}
+
+func autoConvert_v1beta1_LabelPreference_To_config_LabelPreference(in *LabelPreference, out *config.LabelPreference, s conversion.Scope) error {
+	out.Label = in.Label
+	out.Presence = in.Presence
+	return nil
+}
+
+// Convert_v1beta1_LabelPreference_To_config_LabelPreference is an autogenerated conversion function.
+func Convert_v1beta1_LabelPreference_To_config_LabelPreference(in *LabelPreference, out *config.LabelPreference, s conversion.Scope) error {
+	return autoConvert_v1beta1_LabelPreference_To_config_LabelPreference(in, out, s)
+}
+
+func autoConvert_config_LabelPreference_To_v1beta1_LabelPreference(in *config.LabelPreference, out *LabelPreference, s conversion.Scope) error {
+	out.Label = in.Label
+	out.Presence = in.Presence
+	return nil
+}
+
+// Convert_config_LabelPreference_To_v1beta1_LabelPreference is an autogenerated conversion function.
+func Convert_config_LabelPreference_To_v1beta1_LabelPreference(in *config.LabelPreference, out *LabelPreference, s conversion.Scope) error {
+	return autoConvert_config_LabelPreference_To_v1beta1_LabelPreference(in, out, s)
+}
+
+func autoConvert_v1beta1_LabelsPresence_To_config_LabelsPresence(in *LabelsPresence, out *config.LabelsPresence, s conversion.Scope) error {
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	out.Presence = in.Presence
+	return nil
+}
+
+// Convert_v1beta1_LabelsPresence_To_config_LabelsPresence is an autogenerated conversion function.
+func Convert_v1beta1_LabelsPresence_To_config_LabelsPresence(in *LabelsPresence, out *config.LabelsPresence, s conversion.Scope) error {
+	return autoConvert_v1beta1_LabelsPresence_To_config_LabelsPresence(in, out, s)
+}
+
+func autoConvert_config_LabelsPresence_To_v1beta1_LabelsPresence(in *config.LabelsPresence, out *LabelsPresence, s conversion.Scope) error {
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	out.Presence = in.Presence
+	return nil
+}
+
+// Convert_config_LabelsPresence_To_v1beta1_LabelsPresence is an autogenerated conversion function.
+func Convert_config_LabelsPresence_To_v1beta1_LabelsPresence(in *config.LabelsPresence, out *LabelsPresence, s conversion.Scope) error {
+	return autoConvert_config_LabelsPresence_To_v1beta1_LabelsPresence(in, out, s)
+}
+
+func autoConvert_v1beta1_LifecycleLabel_To_config_LifecycleLabel(in *LifecycleLabel, out *config.LifecycleLabel, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_v1beta1_LifecycleLabel_To_config_LifecycleLabel is an autogenerated conversion function.
+func Convert_v1beta1_LifecycleLabel_To_config_LifecycleLabel(in *LifecycleLabel, out *config.LifecycleLabel, s conversion.Scope) error {
+	return autoConvert_v1beta1_LifecycleLabel_To_config_LifecycleLabel(in, out, s)
+}
+
+func autoConvert_config_LifecycleLabel_To_v1beta1_LifecycleLabel(in *config.LifecycleLabel, out *LifecycleLabel, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Values = *(*[]string)(unsafe.Pointer(&in.Values))
+	return nil
+}
+
+// Convert_config_LifecycleLabel_To_v1beta1_LifecycleLabel is an autogenerated conversion function.
+func Convert_config_LifecycleLabel_To_v1beta1_LifecycleLabel(in *config.LifecycleLabel, out *LifecycleLabel, s conversion.Scope) error {
+	return autoConvert_config_LifecycleLabel_To_v1beta1_LifecycleLabel(in, out, s)
+}
+
+func autoConvert_v1beta1_LoadAwareArgs_To_config_LoadAwareArgs(in *LoadAwareArgs, out *config.LoadAwareArgs, s conversion.Scope) error {
+	out.MetricsSource = in.MetricsSource
+	out.Address = in.Address
+	out.Database = in.Database
+	out.Measurement = in.Measurement
+	if err := metav1.Convert_Pointer_string_To_string(&in.CPUQuery, &out.CPUQuery, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_string_To_string(&in.MemoryQuery, &out.MemoryQuery, s); err != nil {
+		return err
+	}
+	out.NodeLabel = in.NodeLabel
+	out.MetricsFile = in.MetricsFile
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.RefreshIntervalSeconds, &out.RefreshIntervalSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.MaxAgeSeconds, &out.MaxAgeSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.CPUWeight, &out.CPUWeight, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.MemoryWeight, &out.MemoryWeight, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.HotThreshold, &out.HotThreshold, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_LoadAwareArgs_To_config_LoadAwareArgs is an autogenerated conversion function.
+func Convert_v1beta1_LoadAwareArgs_To_config_LoadAwareArgs(in *LoadAwareArgs, out *config.LoadAwareArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_LoadAwareArgs_To_config_LoadAwareArgs(in, out, s)
+}
+
+func autoConvert_config_LoadAwareArgs_To_v1beta1_LoadAwareArgs(in *config.LoadAwareArgs, out *LoadAwareArgs, s conversion.Scope) error {
+	out.MetricsSource = in.MetricsSource
+	out.Address = in.Address
+	out.Database = in.Database
+	out.Measurement = in.Measurement
+	if err := metav1.Convert_string_To_Pointer_string(&in.CPUQuery, &out.CPUQuery, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_string_To_Pointer_string(&in.MemoryQuery, &out.MemoryQuery, s); err != nil {
+		return err
+	}
+	out.NodeLabel = in.NodeLabel
+	out.MetricsFile = in.MetricsFile
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.RefreshIntervalSeconds, &out.RefreshIntervalSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.MaxAgeSeconds, &out.MaxAgeSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.CPUWeight, &out.CPUWeight, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.MemoryWeight, &out.MemoryWeight, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.HotThreshold, &out.HotThreshold, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_LoadAwareArgs_To_v1beta1_LoadAwareArgs is an autogenerated conversion function.
+func Convert_config_LoadAwareArgs_To_v1beta1_LoadAwareArgs(in *config.LoadAwareArgs, out *LoadAwareArgs, s conversion.Scope) error {
+	return autoConvert_config_LoadAwareArgs_To_v1beta1_LoadAwareArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeHealthArgs_To_config_NodeHealthArgs(in *NodeHealthArgs, out *config.NodeHealthArgs, s conversion.Scope) error {
+	out.RiskSource = in.RiskSource
+	out.RiskFile = in.RiskFile
+	out.RiskURL = in.RiskURL
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.RiskTTLSeconds, &out.RiskTTLSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.MaxAgeSeconds, &out.MaxAgeSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.StaleRisk, &out.StaleRisk, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.CriticalThreshold, &out.CriticalThreshold, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_NodeHealthArgs_To_config_NodeHealthArgs is an autogenerated conversion function.
+func Convert_v1beta1_NodeHealthArgs_To_config_NodeHealthArgs(in *NodeHealthArgs, out *config.NodeHealthArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeHealthArgs_To_config_NodeHealthArgs(in, out, s)
+}
+
+func autoConvert_config_NodeHealthArgs_To_v1beta1_NodeHealthArgs(in *config.NodeHealthArgs, out *NodeHealthArgs, s conversion.Scope) error {
+	out.RiskSource = in.RiskSource
+	out.RiskFile = in.RiskFile
+	out.RiskURL = in.RiskURL
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.RiskTTLSeconds, &out.RiskTTLSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.MaxAgeSeconds, &out.MaxAgeSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.StaleRisk, &out.StaleRisk, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.CriticalThreshold, &out.CriticalThreshold, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_NodeHealthArgs_To_v1beta1_NodeHealthArgs is an autogenerated conversion function.
+func Convert_config_NodeHealthArgs_To_v1beta1_NodeHealthArgs(in *config.NodeHealthArgs, out *NodeHealthArgs, s conversion.Scope) error {
+	return autoConvert_config_NodeHealthArgs_To_v1beta1_NodeHealthArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeLabelArgs_To_config_NodeLabelArgs(in *NodeLabelArgs, out *config.NodeLabelArgs, s conversion.Scope) error {
+	out.PresentLabels = *(*[]string)(unsafe.Pointer(&in.PresentLabels))
+	out.AbsentLabels = *(*[]string)(unsafe.Pointer(&in.AbsentLabels))
+	out.PresentLabelsPreference = *(*[]string)(unsafe.Pointer(&in.PresentLabelsPreference))
+	out.AbsentLabelsPreference = *(*[]string)(unsafe.Pointer(&in.AbsentLabelsPreference))
+	return nil
+}
+
+// Convert_v1beta1_NodeLabelArgs_To_config_NodeLabelArgs is an autogenerated conversion function.
+func Convert_v1beta1_NodeLabelArgs_To_config_NodeLabelArgs(in *NodeLabelArgs, out *config.NodeLabelArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeLabelArgs_To_config_NodeLabelArgs(in, out, s)
+}
+
+func autoConvert_config_NodeLabelArgs_To_v1beta1_NodeLabelArgs(in *config.NodeLabelArgs, out *NodeLabelArgs, s conversion.Scope) error {
+	out.PresentLabels = *(*[]string)(unsafe.Pointer(&in.PresentLabels))
+	out.AbsentLabels = *(*[]string)(unsafe.Pointer(&in.AbsentLabels))
+	out.PresentLabelsPreference = *(*[]string)(unsafe.Pointer(&in.PresentLabelsPreference))
+	out.AbsentLabelsPreference = *(*[]string)(unsafe.Pointer(&in.AbsentLabelsPreference))
+	return nil
+}
+
+// Convert_config_NodeLabelArgs_To_v1beta1_NodeLabelArgs is an autogenerated conversion function.
+func Convert_config_NodeLabelArgs_To_v1beta1_NodeLabelArgs(in *config.NodeLabelArgs, out *NodeLabelArgs, s conversion.Scope) error {
+	return autoConvert_config_NodeLabelArgs_To_v1beta1_NodeLabelArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeResourcesFitArgs_To_config_NodeResourcesFitArgs(in *NodeResourcesFitArgs, out *config.NodeResourcesFitArgs, s conversion.Scope) error {
+	out.IgnoredResources = *(*[]string)(unsafe.Pointer(&in.IgnoredResources))
+	out.IgnoredResourceGroups = *(*[]string)(unsafe.Pointer(&in.IgnoredResourceGroups))
+	return nil
+}
+
+// Convert_v1beta1_NodeResourcesFitArgs_To_config_NodeResourcesFitArgs is an autogenerated conversion function.
+func Convert_v1beta1_NodeResourcesFitArgs_To_config_NodeResourcesFitArgs(in *NodeResourcesFitArgs, out *config.NodeResourcesFitArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeResourcesFitArgs_To_config_NodeResourcesFitArgs(in, out, s)
+}
+
+func autoConvert_config_NodeResourcesFitArgs_To_v1beta1_NodeResourcesFitArgs(in *config.NodeResourcesFitArgs, out *NodeResourcesFitArgs, s conversion.Scope) error {
+	out.IgnoredResources = *(*[]string)(unsafe.Pointer(&in.IgnoredResources))
+	out.IgnoredResourceGroups = *(*[]string)(unsafe.Pointer(&in.IgnoredResourceGroups))
+	return nil
+}
+
+// Convert_config_NodeResourcesFitArgs_To_v1beta1_NodeResourcesFitArgs is an autogenerated conversion function.
+func Convert_config_NodeResourcesFitArgs_To_v1beta1_NodeResourcesFitArgs(in *config.NodeResourcesFitArgs, out *NodeResourcesFitArgs, s conversion.Scope) error {
+	return autoConvert_config_NodeResourcesFitArgs_To_v1beta1_NodeResourcesFitArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeResourcesLeastAllocatedArgs_To_config_NodeResourcesLeastAllocatedArgs(in *NodeResourcesLeastAllocatedArgs, out *config.NodeResourcesLeastAllocatedArgs, s conversion.Scope) error {
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]config.ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ResourceSpec_To_config_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_NodeResourcesLeastAllocatedArgs_To_config_NodeResourcesLeastAllocatedArgs is an autogenerated conversion function.
+func Convert_v1beta1_NodeResourcesLeastAllocatedArgs_To_config_NodeResourcesLeastAllocatedArgs(in *NodeResourcesLeastAllocatedArgs, out *config.NodeResourcesLeastAllocatedArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeResourcesLeastAllocatedArgs_To_config_NodeResourcesLeastAllocatedArgs(in, out, s)
+}
+
+func autoConvert_config_NodeResourcesLeastAllocatedArgs_To_v1beta1_NodeResourcesLeastAllocatedArgs(in *config.NodeResourcesLeastAllocatedArgs, out *NodeResourcesLeastAllocatedArgs, s conversion.Scope) error {
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_config_ResourceSpec_To_v1beta1_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_config_NodeResourcesLeastAllocatedArgs_To_v1beta1_NodeResourcesLeastAllocatedArgs is an autogenerated conversion function.
+func Convert_config_NodeResourcesLeastAllocatedArgs_To_v1beta1_NodeResourcesLeastAllocatedArgs(in *config.NodeResourcesLeastAllocatedArgs, out *NodeResourcesLeastAllocatedArgs, s conversion.Scope) error {
+	return autoConvert_config_NodeResourcesLeastAllocatedArgs_To_v1beta1_NodeResourcesLeastAllocatedArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_NodeResourcesMostAllocatedArgs_To_config_NodeResourcesMostAllocatedArgs(in *NodeResourcesMostAllocatedArgs, out *config.NodeResourcesMostAllocatedArgs, s conversion.Scope) error {
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]config.ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ResourceSpec_To_config_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_NodeResourcesMostAllocatedArgs_To_config_NodeResourcesMostAllocatedArgs is an autogenerated conversion function.
+func Convert_v1beta1_NodeResourcesMostAllocatedArgs_To_config_NodeResourcesMostAllocatedArgs(in *NodeResourcesMostAllocatedArgs, out *config.NodeResourcesMostAllocatedArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_NodeResourcesMostAllocatedArgs_To_config_NodeResourcesMostAllocatedArgs(in, out, s)
+}
+
+func autoConvert_config_NodeResourcesMostAllocatedArgs_To_v1beta1_NodeResourcesMostAllocatedArgs(in *config.NodeResourcesMostAllocatedArgs, out *NodeResourcesMostAllocatedArgs, s conversion.Scope) error {
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_config_ResourceSpec_To_v1beta1_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_config_NodeResourcesMostAllocatedArgs_To_v1beta1_NodeResourcesMostAllocatedArgs is an autogenerated conversion function.
+func Convert_config_NodeResourcesMostAllocatedArgs_To_v1beta1_NodeResourcesMostAllocatedArgs(in *config.NodeResourcesMostAllocatedArgs, out *NodeResourcesMostAllocatedArgs, s conversion.Scope) error {
+	return autoConvert_config_NodeResourcesMostAllocatedArgs_To_v1beta1_NodeResourcesMostAllocatedArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_OvercommitRatio_To_config_OvercommitRatio(in *OvercommitRatio, out *config.OvercommitRatio, s conversion.Scope) error {
+	out.Pool = in.Pool
+	out.CPU = in.CPU
+	out.Memory = in.Memory
+	return nil
+}
+
+// Convert_v1beta1_OvercommitRatio_To_config_OvercommitRatio is an autogenerated conversion function.
+func Convert_v1beta1_OvercommitRatio_To_config_OvercommitRatio(in *OvercommitRatio, out *config.OvercommitRatio, s conversion.Scope) error {
+	return autoConvert_v1beta1_OvercommitRatio_To_config_OvercommitRatio(in, out, s)
+}
+
+func autoConvert_config_OvercommitRatio_To_v1beta1_OvercommitRatio(in *config.OvercommitRatio, out *OvercommitRatio, s conversion.Scope) error {
+	out.Pool = in.Pool
+	out.CPU = in.CPU
+	out.Memory = in.Memory
+	return nil
+}
+
+// Convert_config_OvercommitRatio_To_v1beta1_OvercommitRatio is an autogenerated conversion function.
+func Convert_config_OvercommitRatio_To_v1beta1_OvercommitRatio(in *config.OvercommitRatio, out *OvercommitRatio, s conversion.Scope) error {
+	return autoConvert_config_OvercommitRatio_To_v1beta1_OvercommitRatio(in, out, s)
+}
+
+func autoConvert_v1beta1_Plugin_To_config_Plugin(in *Plugin, out *config.Plugin, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.Weight, &out.Weight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_Plugin_To_config_Plugin is an autogenerated conversion function.
+func Convert_v1beta1_Plugin_To_config_Plugin(in *Plugin, out *config.Plugin, s conversion.Scope) error {
+	return autoConvert_v1beta1_Plugin_To_config_Plugin(in, out, s)
+}
+
+func autoConvert_config_Plugin_To_v1beta1_Plugin(in *config.Plugin, out *Plugin, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.Weight, &out.Weight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_Plugin_To_v1beta1_Plugin is an autogenerated conversion function.
+func Convert_config_Plugin_To_v1beta1_Plugin(in *config.Plugin, out *Plugin, s conversion.Scope) error {
+	return autoConvert_config_Plugin_To_v1beta1_Plugin(in, out, s)
+}
+
+func autoConvert_v1beta1_PluginConfig_To_config_PluginConfig(in *PluginConfig, out *config.PluginConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := runtime.Convert_runtime_RawExtension_To_runtime_Object(&in.Args, &out.Args, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_PluginConfig_To_config_PluginConfig is an autogenerated conversion function.
+func Convert_v1beta1_PluginConfig_To_config_PluginConfig(in *PluginConfig, out *config.PluginConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_PluginConfig_To_config_PluginConfig(in, out, s)
+}
+
+func autoConvert_config_PluginConfig_To_v1beta1_PluginConfig(in *config.PluginConfig, out *PluginConfig, s conversion.Scope) error {
+	out.Name = in.Name
+	if err := runtime.Convert_runtime_Object_To_runtime_RawExtension(&in.Args, &out.Args, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_PluginConfig_To_v1beta1_PluginConfig is an autogenerated conversion function.
+func Convert_config_PluginConfig_To_v1beta1_PluginConfig(in *config.PluginConfig, out *PluginConfig, s conversion.Scope) error {
+	return autoConvert_config_PluginConfig_To_v1beta1_PluginConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_PluginSet_To_config_PluginSet(in *PluginSet, out *config.PluginSet, s conversion.Scope) error {
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = make([]config.Plugin, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Plugin_To_config_Plugin(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Enabled = nil
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = make([]config.Plugin, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Plugin_To_config_Plugin(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Disabled = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_PluginSet_To_config_PluginSet is an autogenerated conversion function.
+func Convert_v1beta1_PluginSet_To_config_PluginSet(in *PluginSet, out *config.PluginSet, s conversion.Scope) error {
+	return autoConvert_v1beta1_PluginSet_To_config_PluginSet(in, out, s)
+}
+
+func autoConvert_config_PluginSet_To_v1beta1_PluginSet(in *config.PluginSet, out *PluginSet, s conversion.Scope) error {
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = make([]Plugin, len(*in))
+		for i := range *in {
+			if err := Convert_config_Plugin_To_v1beta1_Plugin(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Enabled = nil
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = make([]Plugin, len(*in))
+		for i := range *in {
+			if err := Convert_config_Plugin_To_v1beta1_Plugin(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Disabled = nil
+	}
+	return nil
+}
+
+// Convert_config_PluginSet_To_v1beta1_PluginSet is an autogenerated conversion function.
+func Convert_config_PluginSet_To_v1beta1_PluginSet(in *config.PluginSet, out *PluginSet, s conversion.Scope) error {
+	return autoConvert_config_PluginSet_To_v1beta1_PluginSet(in, out, s)
+}
+
+func autoConvert_v1beta1_Plugins_To_config_Plugins(in *Plugins, out *config.Plugins, s conversion.Scope) error {
+	if in.QueueSort != nil {
+		in, out := &in.QueueSort, &out.QueueSort
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.QueueSort = nil
+	}
+	if in.PreFilter != nil {
+		in, out := &in.PreFilter, &out.PreFilter
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PreFilter = nil
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Filter = nil
+	}
+	if in.PostFilter != nil {
+		in, out := &in.PostFilter, &out.PostFilter
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PostFilter = nil
+	}
+	if in.PreScore != nil {
+		in, out := &in.PreScore, &out.PreScore
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PreScore = nil
+	}
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Score = nil
+	}
+	if in.Reserve != nil {
+		in, out := &in.Reserve, &out.Reserve
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Reserve = nil
+	}
+	if in.Permit != nil {
+		in, out := &in.Permit, &out.Permit
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Permit = nil
+	}
+	if in.PreBind != nil {
+		in, out := &in.PreBind, &out.PreBind
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PreBind = nil
+	}
+	if in.Bind != nil {
+		in, out := &in.Bind, &out.Bind
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bind = nil
+	}
+	if in.PostBind != nil {
+		in, out := &in.PostBind, &out.PostBind
+		*out = new(config.PluginSet)
+		if err := Convert_v1beta1_PluginSet_To_config_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PostBind = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_Plugins_To_config_Plugins is an autogenerated conversion function.
+func Convert_v1beta1_Plugins_To_config_Plugins(in *Plugins, out *config.Plugins, s conversion.Scope) error {
+	return autoConvert_v1beta1_Plugins_To_config_Plugins(in, out, s)
+}
+
+func autoConvert_config_Plugins_To_v1beta1_Plugins(in *config.Plugins, out *Plugins, s conversion.Scope) error {
+	if in.QueueSort != nil {
+		in, out := &in.QueueSort, &out.QueueSort
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.QueueSort = nil
+	}
+	if in.PreFilter != nil {
+		in, out := &in.PreFilter, &out.PreFilter
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PreFilter = nil
+	}
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Filter = nil
+	}
+	if in.PostFilter != nil {
+		in, out := &in.PostFilter, &out.PostFilter
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PostFilter = nil
+	}
+	if in.PreScore != nil {
+		in, out := &in.PreScore, &out.PreScore
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PreScore = nil
+	}
+	if in.Score != nil {
+		in, out := &in.Score, &out.Score
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Score = nil
+	}
+	if in.Reserve != nil {
+		in, out := &in.Reserve, &out.Reserve
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Reserve = nil
+	}
+	if in.Permit != nil {
+		in, out := &in.Permit, &out.Permit
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Permit = nil
+	}
+	if in.PreBind != nil {
+		in, out := &in.PreBind, &out.PreBind
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PreBind = nil
+	}
+	if in.Bind != nil {
+		in, out := &in.Bind, &out.Bind
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Bind = nil
+	}
+	if in.PostBind != nil {
+		in, out := &in.PostBind, &out.PostBind
+		*out = new(PluginSet)
+		if err := Convert_config_PluginSet_To_v1beta1_PluginSet(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PostBind = nil
+	}
+	return nil
+}
+
+// Convert_config_Plugins_To_v1beta1_Plugins is an autogenerated conversion function.
+func Convert_config_Plugins_To_v1beta1_Plugins(in *config.Plugins, out *Plugins, s conversion.Scope) error {
+	return autoConvert_config_Plugins_To_v1beta1_Plugins(in, out, s)
+}
+
+func autoConvert_v1beta1_PodTopologySpreadArgs_To_config_PodTopologySpreadArgs(in *PodTopologySpreadArgs, out *config.PodTopologySpreadArgs, s conversion.Scope) error {
+	out.DefaultConstraints = *(*[]v1.TopologySpreadConstraint)(unsafe.Pointer(&in.DefaultConstraints))
+	return nil
+}
+
+// Convert_v1beta1_PodTopologySpreadArgs_To_config_PodTopologySpreadArgs is an autogenerated conversion function.
+func Convert_v1beta1_PodTopologySpreadArgs_To_config_PodTopologySpreadArgs(in *PodTopologySpreadArgs, out *config.PodTopologySpreadArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodTopologySpreadArgs_To_config_PodTopologySpreadArgs(in, out, s)
+}
+
+func autoConvert_config_PodTopologySpreadArgs_To_v1beta1_PodTopologySpreadArgs(in *config.PodTopologySpreadArgs, out *PodTopologySpreadArgs, s conversion.Scope) error {
+	out.DefaultConstraints = *(*[]v1.TopologySpreadConstraint)(unsafe.Pointer(&in.DefaultConstraints))
+	return nil
+}
+
+// Convert_config_PodTopologySpreadArgs_To_v1beta1_PodTopologySpreadArgs is an autogenerated conversion function.
+func Convert_config_PodTopologySpreadArgs_To_v1beta1_PodTopologySpreadArgs(in *config.PodTopologySpreadArgs, out *PodTopologySpreadArgs, s conversion.Scope) error {
+	return autoConvert_config_PodTopologySpreadArgs_To_v1beta1_PodTopologySpreadArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_Policy_To_config_Policy(in *Policy, out *config.Policy, s conversion.Scope) error {
+	if in.Predicates != nil {
+		in, out := &in.Predicates, &out.Predicates
+		*out = make([]config.PredicatePolicy, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_PredicatePolicy_To_config_PredicatePolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Predicates = nil
+	}
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make([]config.PriorityPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_PriorityPolicy_To_config_PriorityPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Priorities = nil
+	}
+	if in.Extenders != nil {
+		in, out := &in.Extenders, &out.Extenders
+		*out = make([]config.Extender, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_Extender_To_config_Extender(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Extenders = nil
+	}
+	out.HardPodAffinitySymmetricWeight = in.HardPodAffinitySymmetricWeight
+	out.AlwaysCheckAllPredicates = in.AlwaysCheckAllPredicates
+	return nil
+}
+
+// Convert_v1beta1_Policy_To_config_Policy is an autogenerated conversion function.
+func Convert_v1beta1_Policy_To_config_Policy(in *Policy, out *config.Policy, s conversion.Scope) error {
+	return autoConvert_v1beta1_Policy_To_config_Policy(in, out, s)
+}
+
+func autoConvert_config_Policy_To_v1beta1_Policy(in *config.Policy, out *Policy, s conversion.Scope) error {
+	if in.Predicates != nil {
+		in, out := &in.Predicates, &out.Predicates
+		*out = make([]PredicatePolicy, len(*in))
+		for i := range *in {
+			if err := Convert_config_PredicatePolicy_To_v1beta1_PredicatePolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Predicates = nil
+	}
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make([]PriorityPolicy, len(*in))
+		for i := range *in {
+			if err := Convert_config_PriorityPolicy_To_v1beta1_PriorityPolicy(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Priorities = nil
+	}
+	if in.Extenders != nil {
+		in, out := &in.Extenders, &out.Extenders
+		*out = make([]Extender, len(*in))
+		for i := range *in {
+			if err := Convert_config_Extender_To_v1beta1_Extender(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Extenders = nil
+	}
+	out.HardPodAffinitySymmetricWeight = in.HardPodAffinitySymmetricWeight
+	out.AlwaysCheckAllPredicates = in.AlwaysCheckAllPredicates
+	return nil
+}
+
+// Convert_config_Policy_To_v1beta1_Policy is an autogenerated conversion function.
+func Convert_config_Policy_To_v1beta1_Policy(in *config.Policy, out *Policy, s conversion.Scope) error {
+	return autoConvert_config_Policy_To_v1beta1_Policy(in, out, s)
+}
+
+func autoConvert_v1beta1_PredicateArgument_To_config_PredicateArgument(in *PredicateArgument, out *config.PredicateArgument, s conversion.Scope) error {
+	if in.ServiceAffinity != nil {
+		in, out := &in.ServiceAffinity, &out.ServiceAffinity
+		*out = new(config.ServiceAffinity)
+		if err := Convert_v1beta1_ServiceAffinity_To_config_ServiceAffinity(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ServiceAffinity = nil
+	}
+	if in.LabelsPresence != nil {
+		in, out := &in.LabelsPresence, &out.LabelsPresence
+		*out = new(config.LabelsPresence)
+		if err := Convert_v1beta1_LabelsPresence_To_config_LabelsPresence(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LabelsPresence = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_PredicateArgument_To_config_PredicateArgument is an autogenerated conversion function.
+func Convert_v1beta1_PredicateArgument_To_config_PredicateArgument(in *PredicateArgument, out *config.PredicateArgument, s conversion.Scope) error {
+	return autoConvert_v1beta1_PredicateArgument_To_config_PredicateArgument(in, out, s)
+}
+
+func autoConvert_config_PredicateArgument_To_v1beta1_PredicateArgument(in *config.PredicateArgument, out *PredicateArgument, s conversion.Scope) error {
+	if in.ServiceAffinity != nil {
+		in, out := &in.ServiceAffinity, &out.ServiceAffinity
+		*out = new(ServiceAffinity)
+		if err := Convert_config_ServiceAffinity_To_v1beta1_ServiceAffinity(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ServiceAffinity = nil
+	}
+	if in.LabelsPresence != nil {
+		in, out := &in.LabelsPresence, &out.LabelsPresence
+		*out = new(LabelsPresence)
+		if err := Convert_config_LabelsPresence_To_v1beta1_LabelsPresence(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LabelsPresence = nil
+	}
+	return nil
+}
+
+// Convert_config_PredicateArgument_To_v1beta1_PredicateArgument is an autogenerated conversion function.
+func Convert_config_PredicateArgument_To_v1beta1_PredicateArgument(in *config.PredicateArgument, out *PredicateArgument, s conversion.Scope) error {
+	return autoConvert_config_PredicateArgument_To_v1beta1_PredicateArgument(in, out, s)
+}
+
+func autoConvert_v1beta1_PredicatePolicy_To_config_PredicatePolicy(in *PredicatePolicy, out *config.PredicatePolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Argument != nil {
+		in, out := &in.Argument, &out.Argument
+		*out = new(config.PredicateArgument)
+		if err := Convert_v1beta1_PredicateArgument_To_config_PredicateArgument(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Argument = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_PredicatePolicy_To_config_PredicatePolicy is an autogenerated conversion function.
+func Convert_v1beta1_PredicatePolicy_To_config_PredicatePolicy(in *PredicatePolicy, out *config.PredicatePolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_PredicatePolicy_To_config_PredicatePolicy(in, out, s)
+}
+
+func autoConvert_config_PredicatePolicy_To_v1beta1_PredicatePolicy(in *config.PredicatePolicy, out *PredicatePolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Argument != nil {
+		in, out := &in.Argument, &out.Argument
+		*out = new(PredicateArgument)
+		if err := Convert_config_PredicateArgument_To_v1beta1_PredicateArgument(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Argument = nil
+	}
+	return nil
+}
+
+// Convert_config_PredicatePolicy_To_v1beta1_PredicatePolicy is an autogenerated conversion function.
+func Convert_config_PredicatePolicy_To_v1beta1_PredicatePolicy(in *config.PredicatePolicy, out *PredicatePolicy, s conversion.Scope) error {
+	return autoConvert_config_PredicatePolicy_To_v1beta1_PredicatePolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_PriorityArgument_To_config_PriorityArgument(in *PriorityArgument, out *config.PriorityArgument, s conversion.Scope) error {
+	if in.ServiceAntiAffinity != nil {
+		in, out := &in.ServiceAntiAffinity, &out.ServiceAntiAffinity
+		*out = new(config.ServiceAntiAffinity)
+		if err := Convert_v1beta1_ServiceAntiAffinity_To_config_ServiceAntiAffinity(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ServiceAntiAffinity = nil
+	}
+	if in.LabelPreference != nil {
+		in, out := &in.LabelPreference, &out.LabelPreference
+		*out = new(config.LabelPreference)
+		if err := Convert_v1beta1_LabelPreference_To_config_LabelPreference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LabelPreference = nil
+	}
+	if in.RequestedToCapacityRatioArguments != nil {
+		in, out := &in.RequestedToCapacityRatioArguments, &out.RequestedToCapacityRatioArguments
+		*out = new(config.RequestedToCapacityRatioArguments)
+		if err := Convert_v1beta1_RequestedToCapacityRatioArguments_To_config_RequestedToCapacityRatioArguments(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestedToCapacityRatioArguments = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_PriorityArgument_To_config_PriorityArgument is an autogenerated conversion function.
+func Convert_v1beta1_PriorityArgument_To_config_PriorityArgument(in *PriorityArgument, out *config.PriorityArgument, s conversion.Scope) error {
+	return autoConvert_v1beta1_PriorityArgument_To_config_PriorityArgument(in, out, s)
+}
+
+func autoConvert_config_PriorityArgument_To_v1beta1_PriorityArgument(in *config.PriorityArgument, out *PriorityArgument, s conversion.Scope) error {
+	if in.ServiceAntiAffinity != nil {
+		in, out := &in.ServiceAntiAffinity, &out.ServiceAntiAffinity
+		*out = new(ServiceAntiAffinity)
+		if err := Convert_config_ServiceAntiAffinity_To_v1beta1_ServiceAntiAffinity(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ServiceAntiAffinity = nil
+	}
+	if in.LabelPreference != nil {
+		in, out := &in.LabelPreference, &out.LabelPreference
+		*out = new(LabelPreference)
+		if err := Convert_config_LabelPreference_To_v1beta1_LabelPreference(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.LabelPreference = nil
+	}
+	if in.RequestedToCapacityRatioArguments != nil {
+		in, out := &in.RequestedToCapacityRatioArguments, &out.RequestedToCapacityRatioArguments
+		*out = new(RequestedToCapacityRatioArguments)
+		if err := Convert_config_RequestedToCapacityRatioArguments_To_v1beta1_RequestedToCapacityRatioArguments(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.RequestedToCapacityRatioArguments = nil
+	}
+	return nil
+}
+
+// Convert_config_PriorityArgument_To_v1beta1_PriorityArgument is an autogenerated conversion function.
+func Convert_config_PriorityArgument_To_v1beta1_PriorityArgument(in *config.PriorityArgument, out *PriorityArgument, s conversion.Scope) error {
+	return autoConvert_config_PriorityArgument_To_v1beta1_PriorityArgument(in, out, s)
+}
+
+func autoConvert_v1beta1_PriorityBackoffCap_To_config_PriorityBackoffCap(in *PriorityBackoffCap, out *config.PriorityBackoffCap, s conversion.Scope) error {
+	out.MinPriority = in.MinPriority
+	out.MaxSeconds = in.MaxSeconds
+	return nil
+}
+
+// Convert_v1beta1_PriorityBackoffCap_To_config_PriorityBackoffCap is an autogenerated conversion function.
+func Convert_v1beta1_PriorityBackoffCap_To_config_PriorityBackoffCap(in *PriorityBackoffCap, out *config.PriorityBackoffCap, s conversion.Scope) error {
+	return autoConvert_v1beta1_PriorityBackoffCap_To_config_PriorityBackoffCap(in, out, s)
+}
+
+func autoConvert_config_PriorityBackoffCap_To_v1beta1_PriorityBackoffCap(in *config.PriorityBackoffCap, out *PriorityBackoffCap, s conversion.Scope) error {
+	out.MinPriority = in.MinPriority
+	out.MaxSeconds = in.MaxSeconds
+	return nil
+}
+
+// Convert_config_PriorityBackoffCap_To_v1beta1_PriorityBackoffCap is an autogenerated conversion function.
+func Convert_config_PriorityBackoffCap_To_v1beta1_PriorityBackoffCap(in *config.PriorityBackoffCap, out *PriorityBackoffCap, s conversion.Scope) error {
+	return autoConvert_config_PriorityBackoffCap_To_v1beta1_PriorityBackoffCap(in, out, s)
+}
+
+func autoConvert_v1beta1_PriorityPolicy_To_config_PriorityPolicy(in *PriorityPolicy, out *config.PriorityPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Weight = in.Weight
+	if in.Argument != nil {
+		in, out := &in.Argument, &out.Argument
+		*out = new(config.PriorityArgument)
+		if err := Convert_v1beta1_PriorityArgument_To_config_PriorityArgument(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Argument = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_PriorityPolicy_To_config_PriorityPolicy is an autogenerated conversion function.
+func Convert_v1beta1_PriorityPolicy_To_config_PriorityPolicy(in *PriorityPolicy, out *config.PriorityPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_PriorityPolicy_To_config_PriorityPolicy(in, out, s)
+}
+
+func autoConvert_config_PriorityPolicy_To_v1beta1_PriorityPolicy(in *config.PriorityPolicy, out *PriorityPolicy, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Weight = in.Weight
+	if in.Argument != nil {
+		in, out := &in.Argument, &out.Argument
+		*out = new(PriorityArgument)
+		if err := Convert_config_PriorityArgument_To_v1beta1_PriorityArgument(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Argument = nil
+	}
+	return nil
+}
+
+// Convert_config_PriorityPolicy_To_v1beta1_PriorityPolicy is an autogenerated conversion function.
+func Convert_config_PriorityPolicy_To_v1beta1_PriorityPolicy(in *config.PriorityPolicy, out *PriorityPolicy, s conversion.Scope) error {
+	return autoConvert_config_PriorityPolicy_To_v1beta1_PriorityPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ReasonBackoff_To_config_ReasonBackoff(in *ReasonBackoff, out *config.ReasonBackoff, s conversion.Scope) error {
+	out.Reason = in.Reason
+	out.InitialSeconds = in.InitialSeconds
+	out.MaxSeconds = in.MaxSeconds
+	return nil
+}
+
+// Convert_v1beta1_ReasonBackoff_To_config_ReasonBackoff is an autogenerated conversion function.
+func Convert_v1beta1_ReasonBackoff_To_config_ReasonBackoff(in *ReasonBackoff, out *config.ReasonBackoff, s conversion.Scope) error {
+	return autoConvert_v1beta1_ReasonBackoff_To_config_ReasonBackoff(in, out, s)
+}
+
+func autoConvert_config_ReasonBackoff_To_v1beta1_ReasonBackoff(in *config.ReasonBackoff, out *ReasonBackoff, s conversion.Scope) error {
+	out.Reason = in.Reason
+	out.InitialSeconds = in.InitialSeconds
+	out.MaxSeconds = in.MaxSeconds
+	return nil
+}
+
+// Convert_config_ReasonBackoff_To_v1beta1_ReasonBackoff is an autogenerated conversion function.
+func Convert_config_ReasonBackoff_To_v1beta1_ReasonBackoff(in *config.ReasonBackoff, out *ReasonBackoff, s conversion.Scope) error {
+	return autoConvert_config_ReasonBackoff_To_v1beta1_ReasonBackoff(in, out, s)
+}
+
+func autoConvert_v1beta1_RequestedToCapacityRatioArgs_To_config_RequestedToCapacityRatioArgs(in *RequestedToCapacityRatioArgs, out *config.RequestedToCapacityRatioArgs, s conversion.Scope) error {
+	if in.Shape != nil {
+		in, out := &in.Shape, &out.Shape
+		*out = make([]config.UtilizationShapePoint, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_UtilizationShapePoint_To_config_UtilizationShapePoint(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Shape = nil
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]config.ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ResourceSpec_To_config_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_RequestedToCapacityRatioArgs_To_config_RequestedToCapacityRatioArgs is an autogenerated conversion function.
+func Convert_v1beta1_RequestedToCapacityRatioArgs_To_config_RequestedToCapacityRatioArgs(in *RequestedToCapacityRatioArgs, out *config.RequestedToCapacityRatioArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_RequestedToCapacityRatioArgs_To_config_RequestedToCapacityRatioArgs(in, out, s)
+}
+
+func autoConvert_config_RequestedToCapacityRatioArgs_To_v1beta1_RequestedToCapacityRatioArgs(in *config.RequestedToCapacityRatioArgs, out *RequestedToCapacityRatioArgs, s conversion.Scope) error {
+	if in.Shape != nil {
+		in, out := &in.Shape, &out.Shape
+		*out = make([]UtilizationShapePoint, len(*in))
+		for i := range *in {
+			if err := Convert_config_UtilizationShapePoint_To_v1beta1_UtilizationShapePoint(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Shape = nil
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_config_ResourceSpec_To_v1beta1_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_config_RequestedToCapacityRatioArgs_To_v1beta1_RequestedToCapacityRatioArgs is an autogenerated conversion function.
+func Convert_config_RequestedToCapacityRatioArgs_To_v1beta1_RequestedToCapacityRatioArgs(in *config.RequestedToCapacityRatioArgs, out *RequestedToCapacityRatioArgs, s conversion.Scope) error {
+	return autoConvert_config_RequestedToCapacityRatioArgs_To_v1beta1_RequestedToCapacityRatioArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_RequestedToCapacityRatioArguments_To_config_RequestedToCapacityRatioArguments(in *RequestedToCapacityRatioArguments, out *config.RequestedToCapacityRatioArguments, s conversion.Scope) error {
+	if in.Shape != nil {
+		in, out := &in.Shape, &out.Shape
+		*out = make([]config.UtilizationShapePoint, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_UtilizationShapePoint_To_config_UtilizationShapePoint(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Shape = nil
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]config.ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ResourceSpec_To_config_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_RequestedToCapacityRatioArguments_To_config_RequestedToCapacityRatioArguments is an autogenerated conversion function.
+func Convert_v1beta1_RequestedToCapacityRatioArguments_To_config_RequestedToCapacityRatioArguments(in *RequestedToCapacityRatioArguments, out *config.RequestedToCapacityRatioArguments, s conversion.Scope) error {
+	return autoConvert_v1beta1_RequestedToCapacityRatioArguments_To_config_RequestedToCapacityRatioArguments(in, out, s)
+}
+
+func autoConvert_config_RequestedToCapacityRatioArguments_To_v1beta1_RequestedToCapacityRatioArguments(in *config.RequestedToCapacityRatioArguments, out *RequestedToCapacityRatioArguments, s conversion.Scope) error {
+	if in.Shape != nil {
+		in, out := &in.Shape, &out.Shape
+		*out = make([]UtilizationShapePoint, len(*in))
+		for i := range *in {
+			if err := Convert_config_UtilizationShapePoint_To_v1beta1_UtilizationShapePoint(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Shape = nil
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_config_ResourceSpec_To_v1beta1_ResourceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
+	return nil
+}
+
+// Convert_config_RequestedToCapacityRatioArguments_To_v1beta1_RequestedToCapacityRatioArguments is an autogenerated conversion function.
+func Convert_config_RequestedToCapacityRatioArguments_To_v1beta1_RequestedToCapacityRatioArguments(in *config.RequestedToCapacityRatioArguments, out *RequestedToCapacityRatioArguments, s conversion.Scope) error {
+	return autoConvert_config_RequestedToCapacityRatioArguments_To_v1beta1_RequestedToCapacityRatioArguments(in, out, s)
+}
+
+func autoConvert_v1beta1_ResourceSpec_To_config_ResourceSpec(in *ResourceSpec, out *config.ResourceSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Weight = in.Weight
+	return nil
+}
+
+// Convert_v1beta1_ResourceSpec_To_config_ResourceSpec is an autogenerated conversion function.
+func Convert_v1beta1_ResourceSpec_To_config_ResourceSpec(in *ResourceSpec, out *config.ResourceSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ResourceSpec_To_config_ResourceSpec(in, out, s)
+}
+
+func autoConvert_config_ResourceSpec_To_v1beta1_ResourceSpec(in *config.ResourceSpec, out *ResourceSpec, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Weight = in.Weight
+	return nil
+}
+
+// Convert_config_ResourceSpec_To_v1beta1_ResourceSpec is an autogenerated conversion function.
+func Convert_config_ResourceSpec_To_v1beta1_ResourceSpec(in *config.ResourceSpec, out *ResourceSpec, s conversion.Scope) error {
+	return autoConvert_config_ResourceSpec_To_v1beta1_ResourceSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ScoreExpression_To_config_ScoreExpression(in *ScoreExpression, out *config.ScoreExpression, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Expression = in.Expression
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.Weight, &out.Weight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ScoreExpression_To_config_ScoreExpression is an autogenerated conversion function.
+func Convert_v1beta1_ScoreExpression_To_config_ScoreExpression(in *ScoreExpression, out *config.ScoreExpression, s conversion.Scope) error {
+	return autoConvert_v1beta1_ScoreExpression_To_config_ScoreExpression(in, out, s)
+}
+
+func autoConvert_config_ScoreExpression_To_v1beta1_ScoreExpression(in *config.ScoreExpression, out *ScoreExpression, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Expression = in.Expression
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.Weight, &out.Weight, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_ScoreExpression_To_v1beta1_ScoreExpression is an autogenerated conversion function.
+func Convert_config_ScoreExpression_To_v1beta1_ScoreExpression(in *config.ScoreExpression, out *ScoreExpression, s conversion.Scope) error {
+	return autoConvert_config_ScoreExpression_To_v1beta1_ScoreExpression(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceAffinity_To_config_ServiceAffinity(in *ServiceAffinity, out *config.ServiceAffinity, s conversion.Scope) error {
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_v1beta1_ServiceAffinity_To_config_ServiceAffinity is an autogenerated conversion function.
+func Convert_v1beta1_ServiceAffinity_To_config_ServiceAffinity(in *ServiceAffinity, out *config.ServiceAffinity, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceAffinity_To_config_ServiceAffinity(in, out, s)
+}
+
+func autoConvert_config_ServiceAffinity_To_v1beta1_ServiceAffinity(in *config.ServiceAffinity, out *ServiceAffinity, s conversion.Scope) error {
+	out.Labels = *(*[]string)(unsafe.Pointer(&in.Labels))
+	return nil
+}
+
+// Convert_config_ServiceAffinity_To_v1beta1_ServiceAffinity is an autogenerated conversion function.
+func Convert_config_ServiceAffinity_To_v1beta1_ServiceAffinity(in *config.ServiceAffinity, out *ServiceAffinity, s conversion.Scope) error {
+	return autoConvert_config_ServiceAffinity_To_v1beta1_ServiceAffinity(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceAffinityArgs_To_config_ServiceAffinityArgs(in *ServiceAffinityArgs, out *config.ServiceAffinityArgs, s conversion.Scope) error {
+	out.AffinityLabels = *(*[]string)(unsafe.Pointer(&in.AffinityLabels))
+	out.AntiAffinityLabelsPreference = *(*[]string)(unsafe.Pointer(&in.AntiAffinityLabelsPreference))
+	return nil
+}
+
+// Convert_v1beta1_ServiceAffinityArgs_To_config_ServiceAffinityArgs is an autogenerated conversion function.
+func Convert_v1beta1_ServiceAffinityArgs_To_config_ServiceAffinityArgs(in *ServiceAffinityArgs, out *config.ServiceAffinityArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceAffinityArgs_To_config_ServiceAffinityArgs(in, out, s)
+}
+
+func autoConvert_config_ServiceAffinityArgs_To_v1beta1_ServiceAffinityArgs(in *config.ServiceAffinityArgs, out *ServiceAffinityArgs, s conversion.Scope) error {
+	out.AffinityLabels = *(*[]string)(unsafe.Pointer(&in.AffinityLabels))
+	out.AntiAffinityLabelsPreference = *(*[]string)(unsafe.Pointer(&in.AntiAffinityLabelsPreference))
+	return nil
+}
+
+// Convert_config_ServiceAffinityArgs_To_v1beta1_ServiceAffinityArgs is an autogenerated conversion function.
+func Convert_config_ServiceAffinityArgs_To_v1beta1_ServiceAffinityArgs(in *config.ServiceAffinityArgs, out *ServiceAffinityArgs, s conversion.Scope) error {
+	return autoConvert_config_ServiceAffinityArgs_To_v1beta1_ServiceAffinityArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceAntiAffinity_To_config_ServiceAntiAffinity(in *ServiceAntiAffinity, out *config.ServiceAntiAffinity, s conversion.Scope) error {
+	out.Label = in.Label
+	return nil
+}
+
+// Convert_v1beta1_ServiceAntiAffinity_To_config_ServiceAntiAffinity is an autogenerated conversion function.
+func Convert_v1beta1_ServiceAntiAffinity_To_config_ServiceAntiAffinity(in *ServiceAntiAffinity, out *config.ServiceAntiAffinity, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceAntiAffinity_To_config_ServiceAntiAffinity(in, out, s)
+}
+
+func autoConvert_config_ServiceAntiAffinity_To_v1beta1_ServiceAntiAffinity(in *config.ServiceAntiAffinity, out *ServiceAntiAffinity, s conversion.Scope) error {
+	out.Label = in.Label
+	return nil
+}
+
+// Convert_config_ServiceAntiAffinity_To_v1beta1_ServiceAntiAffinity is an autogenerated conversion function.
+func Convert_config_ServiceAntiAffinity_To_v1beta1_ServiceAntiAffinity(in *config.ServiceAntiAffinity, out *ServiceAntiAffinity, s conversion.Scope) error {
+	return autoConvert_config_ServiceAntiAffinity_To_v1beta1_ServiceAntiAffinity(in, out, s)
+}
+
+func autoConvert_v1beta1_SpotInstanceArgs_To_config_SpotInstanceArgs(in *SpotInstanceArgs, out *config.SpotInstanceArgs, s conversion.Scope) error {
+	if in.LifecycleLabels != nil {
+		in, out := &in.LifecycleLabels, &out.LifecycleLabels
+		*out = make([]config.LifecycleLabel, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_LifecycleLabel_To_config_LifecycleLabel(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.LifecycleLabels = nil
+	}
+	if err := metav1.Convert_Pointer_string_To_string(&in.InterruptionRiskLabel, &out.InterruptionRiskLabel, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.MaxInterruptionRisk, &out.MaxInterruptionRisk, s); err != nil {
+		return err
+	}
+	out.InstancePoolLabels = *(*[]string)(unsafe.Pointer(&in.InstancePoolLabels))
+	if err := metav1.Convert_Pointer_int32_To_int32(&in.OnDemandPercentage, &out.OnDemandPercentage, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_SpotInstanceArgs_To_config_SpotInstanceArgs is an autogenerated conversion function.
+func Convert_v1beta1_SpotInstanceArgs_To_config_SpotInstanceArgs(in *SpotInstanceArgs, out *config.SpotInstanceArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_SpotInstanceArgs_To_config_SpotInstanceArgs(in, out, s)
+}
+
+func autoConvert_config_SpotInstanceArgs_To_v1beta1_SpotInstanceArgs(in *config.SpotInstanceArgs, out *SpotInstanceArgs, s conversion.Scope) error {
+	if in.LifecycleLabels != nil {
+		in, out := &in.LifecycleLabels, &out.LifecycleLabels
+		*out = make([]LifecycleLabel, len(*in))
+		for i := range *in {
+			if err := Convert_config_LifecycleLabel_To_v1beta1_LifecycleLabel(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.LifecycleLabels = nil
+	}
+	if err := metav1.Convert_string_To_Pointer_string(&in.InterruptionRiskLabel, &out.InterruptionRiskLabel, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.MaxInterruptionRisk, &out.MaxInterruptionRisk, s); err != nil {
+		return err
+	}
+	out.InstancePoolLabels = *(*[]string)(unsafe.Pointer(&in.InstancePoolLabels))
+	if err := metav1.Convert_int32_To_Pointer_int32(&in.OnDemandPercentage, &out.OnDemandPercentage, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_SpotInstanceArgs_To_v1beta1_SpotInstanceArgs is an autogenerated conversion function.
+func Convert_config_SpotInstanceArgs_To_v1beta1_SpotInstanceArgs(in *config.SpotInstanceArgs, out *SpotInstanceArgs, s conversion.Scope) error {
+	return autoConvert_config_SpotInstanceArgs_To_v1beta1_SpotInstanceArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_TenantWeight_To_config_TenantWeight(in *TenantWeight, out *config.TenantWeight, s conversion.Scope) error {
+	out.Tenant = in.Tenant
+	out.Weight = in.Weight
+	return nil
+}
+
+// Convert_v1beta1_TenantWeight_To_config_TenantWeight is an autogenerated conversion function.
+func Convert_v1beta1_TenantWeight_To_config_TenantWeight(in *TenantWeight, out *config.TenantWeight, s conversion.Scope) error {
+	return autoConvert_v1beta1_TenantWeight_To_config_TenantWeight(in, out, s)
+}
+
+func autoConvert_config_TenantWeight_To_v1beta1_TenantWeight(in *config.TenantWeight, out *TenantWeight, s conversion.Scope) error {
+	out.Tenant = in.Tenant
+	out.Weight = in.Weight
+	return nil
+}
+
+// Convert_config_TenantWeight_To_v1beta1_TenantWeight is an autogenerated conversion function.
+func Convert_config_TenantWeight_To_v1beta1_TenantWeight(in *config.TenantWeight, out *TenantWeight, s conversion.Scope) error {
+	return autoConvert_config_TenantWeight_To_v1beta1_TenantWeight(in, out, s)
+}
+
+func autoConvert_v1beta1_UsageOvercommitArgs_To_config_UsageOvercommitArgs(in *UsageOvercommitArgs, out *config.UsageOvercommitArgs, s conversion.Scope) error {
+	out.PredictionSource = in.PredictionSource
+	out.PredictionFile = in.PredictionFile
+	out.PredictionURL = in.PredictionURL
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.PredictionTTLSeconds, &out.PredictionTTLSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_string_To_string(&in.NodePoolLabel, &out.NodePoolLabel, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_OvercommitRatio_To_config_OvercommitRatio(&in.DefaultRatio, &out.DefaultRatio, s); err != nil {
+		return err
+	}
+	if in.PoolRatios != nil {
+		in, out := &in.PoolRatios, &out.PoolRatios
+		*out = make([]config.OvercommitRatio, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_OvercommitRatio_To_config_OvercommitRatio(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PoolRatios = nil
+	}
+	return nil
+}
+
+// Convert_v1beta1_UsageOvercommitArgs_To_config_UsageOvercommitArgs is an autogenerated conversion function.
+func Convert_v1beta1_UsageOvercommitArgs_To_config_UsageOvercommitArgs(in *UsageOvercommitArgs, out *config.UsageOvercommitArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_UsageOvercommitArgs_To_config_UsageOvercommitArgs(in, out, s)
+}
+
+func autoConvert_config_UsageOvercommitArgs_To_v1beta1_UsageOvercommitArgs(in *config.UsageOvercommitArgs, out *UsageOvercommitArgs, s conversion.Scope) error {
+	out.PredictionSource = in.PredictionSource
+	out.PredictionFile = in.PredictionFile
+	out.PredictionURL = in.PredictionURL
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.PredictionTTLSeconds, &out.PredictionTTLSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_string_To_Pointer_string(&in.NodePoolLabel, &out.NodePoolLabel, s); err != nil {
+		return err
+	}
+	if err := Convert_config_OvercommitRatio_To_v1beta1_OvercommitRatio(&in.DefaultRatio, &out.DefaultRatio, s); err != nil {
+		return err
+	}
+	if in.PoolRatios != nil {
+		in, out := &in.PoolRatios, &out.PoolRatios
+		*out = make([]OvercommitRatio, len(*in))
+		for i := range *in {
+			if err := Convert_config_OvercommitRatio_To_v1beta1_OvercommitRatio(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.PoolRatios = nil
+	}
+	return nil
+}
+
+// Convert_config_UsageOvercommitArgs_To_v1beta1_UsageOvercommitArgs is an autogenerated conversion function.
+func Convert_config_UsageOvercommitArgs_To_v1beta1_UsageOvercommitArgs(in *config.UsageOvercommitArgs, out *UsageOvercommitArgs, s conversion.Scope) error {
+	return autoConvert_config_UsageOvercommitArgs_To_v1beta1_UsageOvercommitArgs(in, out, s)
+}
+
+func autoConvert_v1beta1_UtilizationShapePoint_To_config_UtilizationShapePoint(in *UtilizationShapePoint, out *config.UtilizationShapePoint, s conversion.Scope) error {
+	out.Utilization = in.Utilization
+	out.Score = in.Score
+	return nil
+}
+
+// Convert_v1beta1_UtilizationShapePoint_To_config_UtilizationShapePoint is an autogenerated conversion function.
+func Convert_v1beta1_UtilizationShapePoint_To_config_UtilizationShapePoint(in *UtilizationShapePoint, out *config.UtilizationShapePoint, s conversion.Scope) error {
+	return autoConvert_v1beta1_UtilizationShapePoint_To_config_UtilizationShapePoint(in, out, s)
+}
+
+func autoConvert_config_UtilizationShapePoint_To_v1beta1_UtilizationShapePoint(in *config.UtilizationShapePoint, out *UtilizationShapePoint, s conversion.Scope) error {
+	out.Utilization = in.Utilization
+	out.Score = in.Score
+	return nil
+}
+
+// Convert_config_UtilizationShapePoint_To_v1beta1_UtilizationShapePoint is an autogenerated conversion function.
+func Convert_config_UtilizationShapePoint_To_v1beta1_UtilizationShapePoint(in *config.UtilizationShapePoint, out *UtilizationShapePoint, s conversion.Scope) error {
+	return autoConvert_config_UtilizationShapePoint_To_v1beta1_UtilizationShapePoint(in, out, s)
+}
+
+func autoConvert_v1beta1_VolumeBindingArgs_To_config_VolumeBindingArgs(in *VolumeBindingArgs, out *config.VolumeBindingArgs, s conversion.Scope) error {
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.BindTimeoutSeconds, &out.BindTimeoutSeconds, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_VolumeBindingArgs_To_config_VolumeBindingArgs is an autogenerated conversion function.
+func Convert_v1beta1_VolumeBindingArgs_To_config_VolumeBindingArgs(in *VolumeBindingArgs, out *config.VolumeBindingArgs, s conversion.Scope) error {
+	return autoConvert_v1beta1_VolumeBindingArgs_To_config_VolumeBindingArgs(in, out, s)
+}
+
+func autoConvert_config_VolumeBindingArgs_To_v1beta1_VolumeBindingArgs(in *config.VolumeBindingArgs, out *VolumeBindingArgs, s conversion.Scope) error {
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.BindTimeoutSeconds, &out.BindTimeoutSeconds, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_config_VolumeBindingArgs_To_v1beta1_VolumeBindingArgs is an autogenerated conversion function.
+func Convert_config_VolumeBindingArgs_To_v1beta1_VolumeBindingArgs(in *config.VolumeBindingArgs, out *VolumeBindingArgs, s conversion.Scope) error {
+	return autoConvert_config_VolumeBindingArgs_To_v1beta1_VolumeBindingArgs(in, out, s)
+}
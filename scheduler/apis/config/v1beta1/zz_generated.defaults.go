@@ -0,0 +1,83 @@
+package v1beta1
+
+import (
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// RegisterDefaults adds defaulters functions to the given scheme.
+// Public to allow building arbitrary schemes.
+// All generated defaulters are covering - they call all nested defaulters.
+func RegisterDefaults(scheme *runtime.Scheme) error {
+	scheme.AddTypeDefaultingFunc(&ComplementarityArgs{}, func(obj interface{}) { SetObjectDefaults_ComplementarityArgs(obj.(*ComplementarityArgs)) })
+	scheme.AddTypeDefaultingFunc(&DRFSortArgs{}, func(obj interface{}) { SetObjectDefaults_DRFSortArgs(obj.(*DRFSortArgs)) })
+	scheme.AddTypeDefaultingFunc(&ExpressionArgs{}, func(obj interface{}) { SetObjectDefaults_ExpressionArgs(obj.(*ExpressionArgs)) })
+	scheme.AddTypeDefaultingFunc(&InterPodAffinityArgs{}, func(obj interface{}) { SetObjectDefaults_InterPodAffinityArgs(obj.(*InterPodAffinityArgs)) })
+	scheme.AddTypeDefaultingFunc(&KubeSchedulerConfiguration{}, func(obj interface{}) { SetObjectDefaults_KubeSchedulerConfiguration(obj.(*KubeSchedulerConfiguration)) })
+	scheme.AddTypeDefaultingFunc(&LoadAwareArgs{}, func(obj interface{}) { SetObjectDefaults_LoadAwareArgs(obj.(*LoadAwareArgs)) })
+	scheme.AddTypeDefaultingFunc(&NodeHealthArgs{}, func(obj interface{}) { SetObjectDefaults_NodeHealthArgs(obj.(*NodeHealthArgs)) })
+	scheme.AddTypeDefaultingFunc(&NodeResourcesLeastAllocatedArgs{}, func(obj interface{}) {
+		SetObjectDefaults_NodeResourcesLeastAllocatedArgs(obj.(*NodeResourcesLeastAllocatedArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&NodeResourcesMostAllocatedArgs{}, func(obj interface{}) {
+		SetObjectDefaults_NodeResourcesMostAllocatedArgs(obj.(*NodeResourcesMostAllocatedArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&RequestedToCapacityRatioArgs{}, func(obj interface{}) {
+		SetObjectDefaults_RequestedToCapacityRatioArgs(obj.(*RequestedToCapacityRatioArgs))
+	})
+	scheme.AddTypeDefaultingFunc(&SpotInstanceArgs{}, func(obj interface{}) { SetObjectDefaults_SpotInstanceArgs(obj.(*SpotInstanceArgs)) })
+	scheme.AddTypeDefaultingFunc(&UsageOvercommitArgs{}, func(obj interface{}) { SetObjectDefaults_UsageOvercommitArgs(obj.(*UsageOvercommitArgs)) })
+	scheme.AddTypeDefaultingFunc(&VolumeBindingArgs{}, func(obj interface{}) { SetObjectDefaults_VolumeBindingArgs(obj.(*VolumeBindingArgs)) })
+	return nil
+}
+
+func SetObjectDefaults_ComplementarityArgs(in *ComplementarityArgs) {
+	SetDefaults_ComplementarityArgs(in)
+}
+
+func SetObjectDefaults_DRFSortArgs(in *DRFSortArgs) {
+	SetDefaults_DRFSortArgs(in)
+}
+
+func SetObjectDefaults_ExpressionArgs(in *ExpressionArgs) {
+	SetDefaults_ExpressionArgs(in)
+}
+
+func SetObjectDefaults_InterPodAffinityArgs(in *InterPodAffinityArgs) {
+	SetDefaults_InterPodAffinityArgs(in)
+}
+
+func SetObjectDefaults_KubeSchedulerConfiguration(in *KubeSchedulerConfiguration) {
+	SetDefaults_KubeSchedulerConfiguration(in)
+}
+
+func SetObjectDefaults_LoadAwareArgs(in *LoadAwareArgs) {
+	SetDefaults_LoadAwareArgs(in)
+}
+
+func SetObjectDefaults_NodeHealthArgs(in *NodeHealthArgs) {
+	SetDefaults_NodeHealthArgs(in)
+}
+
+func SetObjectDefaults_NodeResourcesLeastAllocatedArgs(in *NodeResourcesLeastAllocatedArgs) {
+	SetDefaults_NodeResourcesLeastAllocatedArgs(in)
+}
+
+func SetObjectDefaults_NodeResourcesMostAllocatedArgs(in *NodeResourcesMostAllocatedArgs) {
+	SetDefaults_NodeResourcesMostAllocatedArgs(in)
+}
+
+func SetObjectDefaults_RequestedToCapacityRatioArgs(in *RequestedToCapacityRatioArgs) {
+	SetDefaults_RequestedToCapacityRatioArgs(in)
+}
+
+func SetObjectDefaults_SpotInstanceArgs(in *SpotInstanceArgs) {
+	SetDefaults_SpotInstanceArgs(in)
+}
+
+func SetObjectDefaults_UsageOvercommitArgs(in *UsageOvercommitArgs) {
+	SetDefaults_UsageOvercommitArgs(in)
+}
+
+func SetObjectDefaults_VolumeBindingArgs(in *VolumeBindingArgs) {
+	SetDefaults_VolumeBindingArgs(in)
+}
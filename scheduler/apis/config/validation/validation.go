@@ -121,3 +121,41 @@ func validateExtendedResourceName(name v1.ResourceName) []error {
 	}
 	return validationErrors
 }
+
+// ValidateBackoffPolicy validates that a BackoffPolicy is correct.
+func ValidateBackoffPolicy(fldPath *field.Path, policy *config.BackoffPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if policy == nil {
+		return allErrs
+	}
+	if policy.Multiplier < 0 || policy.Multiplier == 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("multiplier"), policy.Multiplier, "must be 0 or greater than 1"))
+	}
+	if policy.JitterPercent < 0 || policy.JitterPercent > 100 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("jitterPercent"), policy.JitterPercent, "not in valid range [0-100]"))
+	}
+	reasons := sets.NewString(config.FailureReasonUnschedulable, config.FailureReasonInsufficientResources,
+		config.FailureReasonNoNodesAvailable, config.FailureReasonError)
+	seen := sets.NewString()
+	for i, r := range policy.Reasons {
+		path := fldPath.Child("reasons").Index(i)
+		if !reasons.Has(r.Reason) {
+			allErrs = append(allErrs, field.NotSupported(path.Child("reason"), r.Reason, reasons.List()))
+		} else if seen.Has(r.Reason) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("reason"), r.Reason))
+		}
+		seen.Insert(r.Reason)
+		if r.InitialSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("initialSeconds"), r.InitialSeconds, "must be positive"))
+		}
+		if r.MaxSeconds < r.InitialSeconds {
+			allErrs = append(allErrs, field.Invalid(path.Child("maxSeconds"), r.MaxSeconds, "must be greater than or equal to initialSeconds"))
+		}
+	}
+	for i, c := range policy.PriorityCaps {
+		if c.MaxSeconds <= 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("priorityCaps").Index(i).Child("maxSeconds"), c.MaxSeconds, "must be positive"))
+		}
+	}
+	return allErrs
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/util/expr"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -193,3 +194,264 @@ func validateResources(resources []config.ResourceSpec) error {
 	}
 	return nil
 }
+
+// ValidateSpotInstanceArgs validates that SpotInstanceArgs are correct.
+func ValidateSpotInstanceArgs(args *config.SpotInstanceArgs) error {
+	var allErrs field.ErrorList
+	path := field.NewPath("lifecycleLabels")
+	if len(args.LifecycleLabels) == 0 {
+		allErrs = append(allErrs, field.Required(path, "at least one label must be specified"))
+	}
+	for i, l := range args.LifecycleLabels {
+		if len(l.Key) == 0 {
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("key"), "can not be empty"))
+		}
+	}
+	if err := validatePercentage(field.NewPath("maxInterruptionRisk"), args.MaxInterruptionRisk); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validatePercentage(field.NewPath("onDemandPercentage"), args.OnDemandPercentage); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+func validatePercentage(path *field.Path, v int32) *field.Error {
+	if v < 0 || v > 100 {
+		return field.Invalid(path, v, "not in valid range [0-100]")
+	}
+	return nil
+}
+
+// ValidateUsageOvercommitArgs validates that UsageOvercommitArgs are correct.
+func ValidateUsageOvercommitArgs(args *config.UsageOvercommitArgs) error {
+	var allErrs field.ErrorList
+	sources := sets.NewString("annotation", "file", "http")
+	sourcePath := field.NewPath("predictionSource")
+	if !sources.Has(args.PredictionSource) {
+		allErrs = append(allErrs, field.NotSupported(sourcePath, args.PredictionSource, sources.List()))
+	}
+	if args.PredictionSource == "file" && len(args.PredictionFile) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("predictionFile"), "required by the file source"))
+	}
+	if args.PredictionSource == "http" && len(args.PredictionURL) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("predictionURL"), "required by the http source"))
+	}
+	if args.PredictionTTLSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("predictionTTLSeconds"), args.PredictionTTLSeconds, "must not be negative"))
+	}
+	allErrs = append(allErrs, validateOvercommitRatio(field.NewPath("defaultRatio"), args.DefaultRatio)...)
+	pools := sets.NewString()
+	for i, r := range args.PoolRatios {
+		path := field.NewPath("poolRatios").Index(i)
+		if len(r.Pool) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("pool"), "can not be empty"))
+		} else if pools.Has(r.Pool) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("pool"), r.Pool))
+		}
+		pools.Insert(r.Pool)
+		allErrs = append(allErrs, validateOvercommitRatio(path, r)...)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+func validateOvercommitRatio(path *field.Path, r config.OvercommitRatio) field.ErrorList {
+	const maxRatio = 1000
+	var allErrs field.ErrorList
+	if r.CPU <= 0 || r.CPU > maxRatio {
+		allErrs = append(allErrs, field.Invalid(path.Child("cpu"), r.CPU, fmt.Sprintf("not in valid range [1-%d]", maxRatio)))
+	}
+	if r.Memory <= 0 || r.Memory > maxRatio {
+		allErrs = append(allErrs, field.Invalid(path.Child("memory"), r.Memory, fmt.Sprintf("not in valid range [1-%d]", maxRatio)))
+	}
+	return allErrs
+}
+
+// ValidateLoadAwareArgs validates that LoadAwareArgs are correct.
+func ValidateLoadAwareArgs(args *config.LoadAwareArgs) error {
+	var allErrs field.ErrorList
+	sources := sets.NewString("influxdb", "prometheus", "file")
+	if !sources.Has(args.MetricsSource) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("metricsSource"), args.MetricsSource, sources.List()))
+	}
+	switch args.MetricsSource {
+	case "influxdb":
+		if len(args.Address) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("address"), "required by the influxdb source"))
+		}
+		if len(args.Measurement) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("measurement"), "required by the influxdb source"))
+		}
+	case "prometheus":
+		if len(args.Address) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("address"), "required by the prometheus source"))
+		}
+		if len(args.CPUQuery) == 0 && len(args.MemoryQuery) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("cpuQuery"), "a cpu or memory query is required by the prometheus source"))
+		}
+		if len(args.NodeLabel) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("nodeLabel"), "required by the prometheus source"))
+		}
+	case "file":
+		if len(args.MetricsFile) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("metricsFile"), "required by the file source"))
+		}
+	}
+	if args.RefreshIntervalSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("refreshIntervalSeconds"), args.RefreshIntervalSeconds, "must be positive"))
+	}
+	if args.MaxAgeSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxAgeSeconds"), args.MaxAgeSeconds, "must be positive"))
+	}
+	if args.CPUWeight < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("cpuWeight"), args.CPUWeight, "must not be negative"))
+	}
+	if args.MemoryWeight < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("memoryWeight"), args.MemoryWeight, "must not be negative"))
+	}
+	if args.CPUWeight+args.MemoryWeight == 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("cpuWeight"), args.CPUWeight, "cpu and memory weights can not both be zero"))
+	}
+	if err := validatePercentage(field.NewPath("hotThreshold"), args.HotThreshold); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateNodeHealthArgs validates that NodeHealthArgs are correct.
+func ValidateNodeHealthArgs(args *config.NodeHealthArgs) error {
+	var allErrs field.ErrorList
+	sources := sets.NewString("annotation", "file", "http")
+	if !sources.Has(args.RiskSource) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("riskSource"), args.RiskSource, sources.List()))
+	}
+	if args.RiskSource == "file" && len(args.RiskFile) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("riskFile"), "required by the file source"))
+	}
+	if args.RiskSource == "http" && len(args.RiskURL) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("riskURL"), "required by the http source"))
+	}
+	if args.RiskTTLSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("riskTTLSeconds"), args.RiskTTLSeconds, "must not be negative"))
+	}
+	if args.MaxAgeSeconds <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("maxAgeSeconds"), args.MaxAgeSeconds, "must be positive"))
+	}
+	if err := validatePercentage(field.NewPath("staleRisk"), args.StaleRisk); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if err := validatePercentage(field.NewPath("criticalThreshold"), args.CriticalThreshold); err != nil {
+		allErrs = append(allErrs, err)
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateComplementarityArgs validates that ComplementarityArgs are correct.
+func ValidateComplementarityArgs(args *config.ComplementarityArgs) error {
+	var allErrs field.ErrorList
+	sources := sets.NewString("file", "http")
+	if !sources.Has(args.ProfileSource) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("profileSource"), args.ProfileSource, sources.List()))
+	}
+	if args.ProfileSource == "file" && len(args.ProfileFile) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("profileFile"), "required by the file source"))
+	}
+	if args.ProfileSource == "http" && len(args.ProfileURL) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("profileURL"), "required by the http source"))
+	}
+	if args.ProfileTTLSeconds < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("profileTTLSeconds"), args.ProfileTTLSeconds, "must not be negative"))
+	}
+	if args.Slots <= 0 || args.Slots > 1440 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("slots"), args.Slots, "not in valid range [1-1440]"))
+	}
+	if args.CPUWeight < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("cpuWeight"), args.CPUWeight, "must not be negative"))
+	}
+	if args.MemoryWeight < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("memoryWeight"), args.MemoryWeight, "must not be negative"))
+	}
+	if args.CPUWeight+args.MemoryWeight == 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("cpuWeight"), args.CPUWeight, "cpu and memory weights can not both be zero"))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateDRFSortArgs validates that DRFSortArgs are correct.
+func ValidateDRFSortArgs(args *config.DRFSortArgs) error {
+	var allErrs field.ErrorList
+	if args.DefaultWeight <= 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("defaultWeight"), args.DefaultWeight, "must be positive"))
+	}
+	tenants := sets.NewString()
+	for i, w := range args.TenantWeights {
+		path := field.NewPath("tenantWeights").Index(i)
+		if len(w.Tenant) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("tenant"), "can not be empty"))
+		} else if tenants.Has(w.Tenant) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("tenant"), w.Tenant))
+		}
+		tenants.Insert(w.Tenant)
+		if w.Weight <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("weight"), w.Weight, "must be positive"))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
+
+// ValidateExpressionArgs validates that ExpressionArgs are correct: names are
+// unique, filters compile to booleans and scores to numbers.
+func ValidateExpressionArgs(args *config.ExpressionArgs) error {
+	var allErrs field.ErrorList
+	names := sets.NewString()
+	for i, f := range args.Filters {
+		path := field.NewPath("filters").Index(i)
+		if len(f.Name) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "can not be empty"))
+		} else if names.Has(f.Name) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), f.Name))
+		}
+		names.Insert(f.Name)
+		if _, err := expr.CompileAs(f.Expression, expr.Bool); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("expression"), f.Expression, err.Error()))
+		}
+	}
+	names = sets.NewString()
+	for i, s := range args.Scores {
+		path := field.NewPath("scores").Index(i)
+		if len(s.Name) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("name"), "can not be empty"))
+		} else if names.Has(s.Name) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), s.Name))
+		}
+		names.Insert(s.Name)
+		if _, err := expr.CompileAs(s.Expression, expr.Number); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("expression"), s.Expression, err.Error()))
+		}
+		if s.Weight <= 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("weight"), s.Weight, "must be positive"))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs.ToAggregate()
+}
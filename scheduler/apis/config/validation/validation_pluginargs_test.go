@@ -0,0 +1,109 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+)
+
+func TestValidateExpressionArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    config.ExpressionArgs
+		wantErr []string
+	}{
+		{
+			name: "valid",
+			args: config.ExpressionArgs{
+				Filters: []config.FilterExpression{{Name: "gpu", Expression: `node.labels.pool == "gpu"`}},
+				// Filters and scores may share names.
+				Scores: []config.ScoreExpression{
+					{Name: "gpu", Expression: `node.allocatable.cpu / 1000`, Weight: 1},
+					{Name: "priority", Expression: `pod.priority`, Weight: 2},
+				},
+			},
+		},
+		{
+			name: "no expressions",
+		},
+		{
+			name: "duplicate filter names",
+			args: config.ExpressionArgs{
+				Filters: []config.FilterExpression{
+					{Name: "gpu", Expression: `true`},
+					{Name: "gpu", Expression: `false`},
+				},
+			},
+			wantErr: []string{`filters[1].name: Duplicate value: "gpu"`},
+		},
+		{
+			name: "duplicate score names",
+			args: config.ExpressionArgs{
+				Scores: []config.ScoreExpression{
+					{Name: "cpu", Expression: `1`, Weight: 1},
+					{Name: "cpu", Expression: `2`, Weight: 1},
+				},
+			},
+			wantErr: []string{`scores[1].name: Duplicate value: "cpu"`},
+		},
+		{
+			name: "empty names",
+			args: config.ExpressionArgs{
+				Filters: []config.FilterExpression{{Expression: `true`}},
+				Scores:  []config.ScoreExpression{{Expression: `1`, Weight: 1}},
+			},
+			wantErr: []string{"filters[0].name: Required value", "scores[0].name: Required value"},
+		},
+		{
+			name: "filter of a number",
+			args: config.ExpressionArgs{
+				Filters: []config.FilterExpression{{Name: "cpu", Expression: `node.allocatable.cpu`}},
+			},
+			wantErr: []string{"filters[0].expression: Invalid value", "is a number, want a bool"},
+		},
+		{
+			name: "score of a bool",
+			args: config.ExpressionArgs{
+				Scores: []config.ScoreExpression{{Name: "gpu", Expression: `has(node.labels.gpu)`, Weight: 1}},
+			},
+			wantErr: []string{"scores[0].expression: Invalid value", "is a bool, want a number"},
+		},
+		{
+			name: "syntax error",
+			args: config.ExpressionArgs{
+				Filters: []config.FilterExpression{{Name: "gpu", Expression: `node.labels.pool ==`}},
+			},
+			wantErr: []string{"filters[0].expression: Invalid value", "unexpected end of expression"},
+		},
+		{
+			name: "weights",
+			args: config.ExpressionArgs{
+				Scores: []config.ScoreExpression{
+					{Name: "zero", Expression: `1`},
+					{Name: "negative", Expression: `1`, Weight: -1},
+				},
+			},
+			wantErr: []string{"scores[0].weight: Invalid value: 0", "scores[1].weight: Invalid value: -1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateExpressionArgs(&test.args)
+			if len(test.wantErr) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("got no error, want %q", test.wantErr)
+			}
+			for _, want := range test.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("got error %q, want it to contain %q", err, want)
+				}
+			}
+		})
+	}
+}
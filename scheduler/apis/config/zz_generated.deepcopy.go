@@ -5,6 +5,122 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackoffPolicy) DeepCopyInto(out *BackoffPolicy) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]ReasonBackoff, len(*in))
+		copy(*out, *in)
+	}
+	if in.PriorityCaps != nil {
+		in, out := &in.PriorityCaps, &out.PriorityCaps
+		*out = make([]PriorityBackoffCap, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackoffPolicy.
+func (in *BackoffPolicy) DeepCopy() *BackoffPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(BackoffPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplementarityArgs) DeepCopyInto(out *ComplementarityArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplementarityArgs.
+func (in *ComplementarityArgs) DeepCopy() *ComplementarityArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplementarityArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComplementarityArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DRFSortArgs) DeepCopyInto(out *DRFSortArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.TenantWeights != nil {
+		in, out := &in.TenantWeights, &out.TenantWeights
+		*out = make([]TenantWeight, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DRFSortArgs.
+func (in *DRFSortArgs) DeepCopy() *DRFSortArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(DRFSortArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DRFSortArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressionArgs) DeepCopyInto(out *ExpressionArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]FilterExpression, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scores != nil {
+		in, out := &in.Scores, &out.Scores
+		*out = make([]ScoreExpression, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionArgs.
+func (in *ExpressionArgs) DeepCopy() *ExpressionArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExpressionArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Extender) DeepCopyInto(out *Extender) {
 	*out = *in
@@ -79,6 +195,22 @@ func (in *ExtenderTLSConfig) DeepCopy() *ExtenderTLSConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FilterExpression) DeepCopyInto(out *FilterExpression) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FilterExpression.
+func (in *FilterExpression) DeepCopy() *FilterExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(FilterExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InterPodAffinityArgs) DeepCopyInto(out *InterPodAffinityArgs) {
 	*out = *in
@@ -109,6 +241,11 @@ func (in *KubeSchedulerConfiguration) DeepCopyInto(out *KubeSchedulerConfigurati
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.AlgorithmSource.DeepCopyInto(&out.AlgorithmSource)
+	if in.BackoffPolicy != nil {
+		in, out := &in.BackoffPolicy, &out.BackoffPolicy
+		*out = new(BackoffPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Profiles != nil {
 		in, out := &in.Profiles, &out.Profiles
 		*out = make([]KubeSchedulerProfile, len(*in))
@@ -209,6 +346,77 @@ func (in *LabelsPresence) DeepCopy() *LabelsPresence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LifecycleLabel) DeepCopyInto(out *LifecycleLabel) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LifecycleLabel.
+func (in *LifecycleLabel) DeepCopy() *LifecycleLabel {
+	if in == nil {
+		return nil
+	}
+	out := new(LifecycleLabel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadAwareArgs) DeepCopyInto(out *LoadAwareArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadAwareArgs.
+func (in *LoadAwareArgs) DeepCopy() *LoadAwareArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadAwareArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LoadAwareArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeHealthArgs) DeepCopyInto(out *NodeHealthArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeHealthArgs.
+func (in *NodeHealthArgs) DeepCopy() *NodeHealthArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeHealthArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NodeHealthArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeLabelArgs) DeepCopyInto(out *NodeLabelArgs) {
 	*out = *in
@@ -349,6 +557,22 @@ func (in *NodeResourcesMostAllocatedArgs) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OvercommitRatio) DeepCopyInto(out *OvercommitRatio) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvercommitRatio.
+func (in *OvercommitRatio) DeepCopy() *OvercommitRatio {
+	if in == nil {
+		return nil
+	}
+	out := new(OvercommitRatio)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Plugin) DeepCopyInto(out *Plugin) {
 	*out = *in
@@ -637,6 +861,22 @@ func (in *PriorityArgument) DeepCopy() *PriorityArgument {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PriorityBackoffCap) DeepCopyInto(out *PriorityBackoffCap) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PriorityBackoffCap.
+func (in *PriorityBackoffCap) DeepCopy() *PriorityBackoffCap {
+	if in == nil {
+		return nil
+	}
+	out := new(PriorityBackoffCap)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PriorityPolicy) DeepCopyInto(out *PriorityPolicy) {
 	*out = *in
@@ -658,6 +898,22 @@ func (in *PriorityPolicy) DeepCopy() *PriorityPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReasonBackoff) DeepCopyInto(out *ReasonBackoff) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReasonBackoff.
+func (in *ReasonBackoff) DeepCopy() *ReasonBackoff {
+	if in == nil {
+		return nil
+	}
+	out := new(ReasonBackoff)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RequestedToCapacityRatioArgs) DeepCopyInto(out *RequestedToCapacityRatioArgs) {
 	*out = *in
@@ -819,6 +1075,22 @@ func (in *SchedulerPolicySource) DeepCopy() *SchedulerPolicySource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScoreExpression) DeepCopyInto(out *ScoreExpression) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScoreExpression.
+func (in *ScoreExpression) DeepCopy() *ScoreExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(ScoreExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAffinity) DeepCopyInto(out *ServiceAffinity) {
 	*out = *in
@@ -891,6 +1163,90 @@ func (in *ServiceAntiAffinity) DeepCopy() *ServiceAntiAffinity {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpotInstanceArgs) DeepCopyInto(out *SpotInstanceArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.LifecycleLabels != nil {
+		in, out := &in.LifecycleLabels, &out.LifecycleLabels
+		*out = make([]LifecycleLabel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstancePoolLabels != nil {
+		in, out := &in.InstancePoolLabels, &out.InstancePoolLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotInstanceArgs.
+func (in *SpotInstanceArgs) DeepCopy() *SpotInstanceArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(SpotInstanceArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *SpotInstanceArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantWeight) DeepCopyInto(out *TenantWeight) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantWeight.
+func (in *TenantWeight) DeepCopy() *TenantWeight {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantWeight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageOvercommitArgs) DeepCopyInto(out *UsageOvercommitArgs) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.DefaultRatio = in.DefaultRatio
+	if in.PoolRatios != nil {
+		in, out := &in.PoolRatios, &out.PoolRatios
+		*out = make([]OvercommitRatio, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageOvercommitArgs.
+func (in *UsageOvercommitArgs) DeepCopy() *UsageOvercommitArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageOvercommitArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UsageOvercommitArgs) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UtilizationShapePoint) DeepCopyInto(out *UtilizationShapePoint) {
 	*out = *in
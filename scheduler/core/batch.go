@@ -0,0 +1,207 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/turtacn/cloud-prophet/scheduler/explain"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/binpack"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/parallelize"
+	"github.com/turtacn/cloud-prophet/scheduler/profile"
+	v1 "k8s.io/api/core/v1"
+	utiltrace "k8s.io/utils/trace"
+)
+
+// BatchScheduler is implemented by the algorithms that can schedule several
+// pods at once.
+type BatchScheduler interface {
+	// ScheduleBatch assigns pods to nodes jointly, packing as many pods as
+	// possible on as few nodes as possible. states are the cycle states of
+	// the pods. The result of a pod that is not placed has an empty
+	// SuggestedHost; such pods are to be scheduled one by one.
+	ScheduleBatch(ctx context.Context, prof *profile.Profile, states []*framework.CycleState, pods []*v1.Pod) ([]ScheduleResult, error)
+}
+
+var _ BatchScheduler = &genericScheduler{}
+
+// ScheduleBatch implements BatchScheduler. Only the pods whose filter results
+// only depend on the node they run on are placed, see
+// framework.CacheableFilterPlugin, so that the solver can place them on any
+// node that fits them independently of the others. Every placement is checked
+// again with the filters on the node holding the pods placed before it; if a
+// placement is rejected the whole batch is rolled back, as the assignment of
+// the other pods assumed it, and no pod is placed.
+// Scoring plugins are not run: the objective of the batch replaces them.
+func (g *genericScheduler) ScheduleBatch(ctx context.Context, prof *profile.Profile, states []*framework.CycleState, pods []*v1.Pod) ([]ScheduleResult, error) {
+	trace := utiltrace.New("Scheduling batch", utiltrace.Field{Key: "pods", Value: len(pods)})
+	defer trace.LogIfLong(100 * time.Millisecond)
+
+	results := make([]ScheduleResult, len(pods))
+	if err := g.snapshot(); err != nil {
+		return nil, err
+	}
+	if g.nodeInfoSnapshot.NumNodes() == 0 {
+		return nil, ErrNoNodesAvailable
+	}
+	allNodes, err := g.nodeInfoSnapshot.NodeInfos().List()
+	if err != nil {
+		return nil, err
+	}
+	nodeIndex := make(map[string]int, len(allNodes))
+	for i, n := range allNodes {
+		nodeIndex[n.Node().Name] = i
+	}
+
+	dims := resourceDimensions(allNodes)
+	items := make([]binpack.Item, len(pods))
+	for i, pod := range pods {
+		items[i].Size = podRequestVector(pod, dims)
+		if err := podPassesBasicChecks(pod, g.pvcLister); err != nil {
+			continue
+		}
+		if s := prof.RunPreFilterPlugins(ctx, states[i], pod); !s.IsSuccess() {
+			if !s.IsUnschedulable() {
+				return nil, s.AsError()
+			}
+			continue
+		}
+		if !prof.FilterCacheable(states[i], pod) {
+			continue
+		}
+		feasibleNodes, err := g.findAllNodesThatPassFilters(ctx, prof, states[i], pod, allNodes)
+		if err != nil {
+			return nil, err
+		}
+		explain.FromState(states[i]).RecordFeasible(feasibleNodes, nil)
+		for _, n := range feasibleNodes {
+			items[i].Bins = append(items[i].Bins, nodeIndex[n.Name])
+		}
+		results[i].EvaluatedNodes = len(allNodes)
+		results[i].FeasibleNodes = len(feasibleNodes)
+	}
+	trace.Step("Computing predicates done")
+
+	bins := make([]binpack.Bin, len(allNodes))
+	for i, n := range allNodes {
+		bins[i] = binpack.Bin{Free: nodeFreeVector(n, dims), Used: len(n.Pods) > 0}
+	}
+	assignment := binpack.Solve(items, bins)
+	trace.Step("Solving assignment done")
+
+	// Check the assignment on copies of the nodes, adding the pods one by
+	// one, in case the solver does not capture a filter.
+	nodeInfos := make(map[int]*framework.NodeInfo)
+	ph := prof.PreemptHandle()
+	for i, b := range assignment {
+		if b < 0 {
+			continue
+		}
+		nodeInfo, ok := nodeInfos[b]
+		if !ok {
+			nodeInfo = allNodes[b].Clone()
+			nodeInfos[b] = nodeInfo
+		}
+		fits, _, err := PodPassesFiltersOnNode(ctx, ph, states[i], pods[i], nodeInfo)
+		if err != nil {
+			return nil, err
+		}
+		if !fits {
+			klog.V(4).Infof("Batch placement of pod %s/%s on node %s rejected by filters, rolling back the batch", pods[i].Namespace, pods[i].Name, nodeInfo.Node().Name)
+			for j := range results {
+				results[j].SuggestedHost = ""
+			}
+			return results, nil
+		}
+		nodeInfo.AddPod(pods[i])
+		results[i].SuggestedHost = nodeInfo.Node().Name
+	}
+	return results, nil
+}
+
+// findAllNodesThatPassFilters returns all the nodes that pass the filter
+// plugins and extenders for pod, unlike findNodesThatPassFilters, which stops
+// after numFeasibleNodesToFind nodes. PreFilter plugins must have run.
+func (g *genericScheduler) findAllNodesThatPassFilters(ctx context.Context, prof *profile.Profile, state *framework.CycleState, pod *v1.Pod, allNodes []*framework.NodeInfo) ([]*v1.Node, error) {
+	errCh := parallelize.NewErrorChannel()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	class, cacheable := g.equivalenceClass(prof, state, pod)
+	fits := make([]bool, len(allNodes))
+	parallelize.Until(ctx, len(allNodes), func(i int) {
+		ok, _, err := g.podPassesFiltersOnNode(ctx, prof, state, pod, allNodes[i], class, cacheable)
+		if err != nil {
+			errCh.SendErrorWithCancel(err, cancel)
+			return
+		}
+		fits[i] = ok
+	})
+	if err := errCh.ReceiveError(); err != nil {
+		return nil, err
+	}
+	var feasibleNodes []*v1.Node
+	for i, ok := range fits {
+		if ok {
+			feasibleNodes = append(feasibleNodes, allNodes[i].Node())
+		}
+	}
+	return g.findNodesThatPassExtenders(pod, feasibleNodes, make(framework.NodeToStatusMap))
+}
+
+// resourceDimensions returns the scalar resources allocatable on nodes. They
+// follow cpu, memory, ephemeral storage and pods in resource vectors.
+func resourceDimensions(nodes []*framework.NodeInfo) []v1.ResourceName {
+	var dims []v1.ResourceName
+	seen := make(map[v1.ResourceName]bool)
+	for _, n := range nodes {
+		for name := range n.Allocatable.ScalarResources {
+			if !seen[name] {
+				seen[name] = true
+				dims = append(dims, name)
+			}
+		}
+	}
+	return dims
+}
+
+// podRequestVector returns the resource vector of the request of pod:
+// max(sum(containers), init containers) + overhead, like NodeResourcesFit.
+func podRequestVector(pod *v1.Pod, dims []v1.ResourceName) []int64 {
+	var res framework.Resource
+	for _, c := range pod.Spec.Containers {
+		res.Add(c.Resources.Requests)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		res.SetMaxResource(c.Resources.Requests)
+	}
+	if pod.Spec.Overhead != nil {
+		res.Add(pod.Spec.Overhead)
+	}
+	v := []int64{res.MilliCPU, res.Memory, res.EphemeralStorage, 1}
+	for _, name := range dims {
+		v = append(v, res.ScalarResources[name])
+	}
+	return v
+}
+
+// nodeFreeVector returns the resource vector of the free capacity of n.
+func nodeFreeVector(n *framework.NodeInfo, dims []v1.ResourceName) []int64 {
+	a, r := n.Allocatable, n.Requested
+	v := []int64{
+		a.MilliCPU - r.MilliCPU,
+		a.Memory - r.Memory,
+		a.EphemeralStorage - r.EphemeralStorage,
+		int64(a.AllowedPodNumber - len(n.Pods)),
+	}
+	for _, name := range dims {
+		v = append(v, a.ScalarResources[name]-r.ScalarResources[name])
+	}
+	for d := range v {
+		if v[d] < 0 {
+			v[d] = 0
+		}
+	}
+	return v
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/defaultbinder"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/queuesort"
+	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	internalcache "github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	internalqueue "github.com/turtacn/cloud-prophet/scheduler/internal/queue"
+	"github.com/turtacn/cloud-prophet/scheduler/profile"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// spreadFilter rejects nodes running a pod of the same app. It claims to be
+// cacheable although it depends on the pods of the node, which the solver
+// does not capture.
+type spreadFilter struct{}
+
+var _ framework.CacheableFilterPlugin = spreadFilter{}
+
+func (spreadFilter) Name() string { return "SpreadFilter" }
+
+func (spreadFilter) Filter(_ context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	for _, p := range nodeInfo.Pods {
+		if p.Pod.Labels["app"] == pod.Labels["app"] {
+			return framework.NewStatus(framework.Unschedulable, "app already on the node")
+		}
+	}
+	return nil
+}
+
+func (spreadFilter) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool { return true }
+
+func newBatchProfile(t *testing.T) *profile.Profile {
+	registry := frameworkruntime.Registry{
+		queuesort.Name:     queuesort.New,
+		defaultbinder.Name: defaultbinder.New,
+		"SpreadFilter": func(_ runtime.Object, _ framework.FrameworkHandle) (framework.Plugin, error) {
+			return spreadFilter{}, nil
+		},
+	}
+	pls := &config.Plugins{
+		QueueSort: &config.PluginSet{Enabled: []config.Plugin{{Name: queuesort.Name}}},
+		Filter:    &config.PluginSet{Enabled: []config.Plugin{{Name: "SpreadFilter"}}},
+		Bind:      &config.PluginSet{Enabled: []config.Plugin{{Name: defaultbinder.Name}}},
+	}
+	fwk, err := frameworkruntime.NewFramework(registry, pls, nil, frameworkruntime.WithPodNominator(internalqueue.NewPodNominator()))
+	if err != nil {
+		t.Fatalf("creating framework: %v", err)
+	}
+	return &profile.Profile{Framework: fwk, Name: "default-scheduler"}
+}
+
+func makeBatchPod(name, app, cpu string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name), Labels: map[string]string{"app": app}},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "c",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestScheduleBatch(t *testing.T) {
+	tests := []struct {
+		name string
+		pods []*v1.Pod
+		want []string
+	}{
+		{
+			name: "every pod placed on one node",
+			pods: []*v1.Pod{
+				makeBatchPod("a", "a", "1"),
+				makeBatchPod("b", "b", "1"),
+				makeBatchPod("c", "c", "1"),
+			},
+			want: []string{"node", "node", "node"},
+		},
+		{
+			name: "pod the solver cannot place",
+			pods: []*v1.Pod{
+				makeBatchPod("a", "a", "1"),
+				makeBatchPod("big", "big", "8"),
+				makeBatchPod("c", "c", "1"),
+			},
+			want: []string{"node", "", "node"},
+		},
+		{
+			// The second pod of the app is rejected once the first one is
+			// on the node, which rolls back the placement of all pods.
+			name: "rejected placement rolls back the batch",
+			pods: []*v1.Pod{
+				makeBatchPod("a", "a", "1"),
+				makeBatchPod("web-1", "web", "1"),
+				makeBatchPod("web-2", "web", "1"),
+			},
+			want: []string{"", "", ""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stop := make(chan struct{})
+			defer close(stop)
+			cache := internalcache.New(time.Minute, stop)
+			node := &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node"},
+				Status: v1.NodeStatus{
+					Allocatable: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse("4"),
+						v1.ResourceMemory: resource.MustParse("8Gi"),
+						v1.ResourcePods:   resource.MustParse("110"),
+					},
+				},
+			}
+			if err := cache.AddNode(node); err != nil {
+				t.Fatal(err)
+			}
+			g := NewGenericScheduler(cache, internalcache.NewEmptySnapshot(), nil, nil, true, 100, nil).(*genericScheduler)
+			states := make([]*framework.CycleState, len(test.pods))
+			for i := range states {
+				states[i] = framework.NewCycleState()
+			}
+
+			results, err := g.ScheduleBatch(context.Background(), newBatchProfile(t), states, test.pods)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, r := range results {
+				got = append(got, r.SuggestedHost)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got hosts %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScheduleBatchNoNodes(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	g := NewGenericScheduler(internalcache.New(time.Minute, stop), internalcache.NewEmptySnapshot(), nil, nil, true, 100, nil).(*genericScheduler)
+	pods := []*v1.Pod{makeBatchPod("a", "a", "1"), makeBatchPod("b", "b", "1")}
+	states := []*framework.CycleState{framework.NewCycleState(), framework.NewCycleState()}
+	if _, err := g.ScheduleBatch(context.Background(), newBatchProfile(t), states, pods); err != ErrNoNodesAvailable {
+		t.Errorf("got error %v, want %v", err, ErrNoNodesAvailable)
+	}
+}
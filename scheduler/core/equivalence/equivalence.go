@@ -0,0 +1,175 @@
+// Package equivalence caches the filter results of equivalent pods, such as
+// the replicas of a Deployment, so that they are not recomputed on every
+// node for every replica.
+package equivalence
+
+import (
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+// maxClassesPerNode bounds the results kept per node. The results of a node
+// are dropped when it is reached, like when the node changes.
+const maxClassesPerNode = 256
+
+// Class identifies the pods with the same scheduling-relevant fields.
+type Class uint64
+
+// equivalencePod holds the fields of a pod that filter plugins look at.
+type equivalencePod struct {
+	Namespace                 string
+	OwnerReferences           []metav1.OwnerReference
+	Labels                    map[string]string
+	Annotations               map[string]string
+	Containers                []equivalenceContainer
+	InitContainers            []equivalenceContainer
+	Overhead                  v1.ResourceList
+	NodeName                  string
+	NodeSelector              map[string]string
+	Affinity                  *v1.Affinity
+	Tolerations               []v1.Toleration
+	Volumes                   []v1.Volume
+	TopologySpreadConstraints []v1.TopologySpreadConstraint
+}
+
+// equivalenceContainer holds the fields of a container that filter plugins
+// look at.
+type equivalenceContainer struct {
+	Requests v1.ResourceList
+	Limits   v1.ResourceList
+	Ports    []v1.ContainerPort
+}
+
+func containers(cs []v1.Container) []equivalenceContainer {
+	res := make([]equivalenceContainer, len(cs))
+	for i := range cs {
+		res[i] = equivalenceContainer{
+			Requests: cs[i].Resources.Requests,
+			Limits:   cs[i].Resources.Limits,
+			Ports:    cs[i].Ports,
+		}
+	}
+	return res
+}
+
+// GetClass returns the equivalence class of pod: a hash of its owners,
+// requests, ports, selectors, affinity, tolerations, volumes, topology spread
+// constraints, labels and annotations. Pods of the same class get the same
+// filter results on a node. ok is false if the class cannot be computed.
+func GetClass(pod *v1.Pod) (c Class, ok bool) {
+	e := equivalencePod{
+		Namespace:                 pod.Namespace,
+		OwnerReferences:           pod.OwnerReferences,
+		Labels:                    pod.Labels,
+		Annotations:               pod.Annotations,
+		Containers:                containers(pod.Spec.Containers),
+		InitContainers:            containers(pod.Spec.InitContainers),
+		Overhead:                  pod.Spec.Overhead,
+		NodeName:                  pod.Spec.NodeName,
+		NodeSelector:              pod.Spec.NodeSelector,
+		Affinity:                  pod.Spec.Affinity,
+		Tolerations:               pod.Spec.Tolerations,
+		Volumes:                   pod.Spec.Volumes,
+		TopologySpreadConstraints: pod.Spec.TopologySpreadConstraints,
+	}
+	// encoding/json sorts map keys, so equal pods encode equally.
+	data, err := json.Marshal(e)
+	if err != nil {
+		klog.V(4).Infof("Cannot compute equivalence class of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return 0, false
+	}
+	h := fnv.New64a()
+	h.Write(data)
+	return Class(h.Sum64()), true
+}
+
+// Cache holds the filter results of equivalence classes per node. Results
+// are keyed by profile too, as profiles configure different filters, and
+// are valid as long as the generation of their node does not change.
+type Cache struct {
+	mu    sync.RWMutex
+	nodes map[string]*nodeCache
+}
+
+type nodeCache struct {
+	generation int64
+	results    map[classKey]result
+}
+
+type classKey struct {
+	profile string
+	class   Class
+}
+
+type result struct {
+	fits   bool
+	status *framework.Status
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{nodes: make(map[string]*nodeCache)}
+}
+
+// Lookup returns the cached filter result of class on nodeInfo, false if
+// there is none for the current generation of the node.
+func (c *Cache) Lookup(profile string, class Class, nodeInfo *framework.NodeInfo) (fits bool, status *framework.Status, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	n, found := c.nodes[nodeInfo.Node().Name]
+	if !found || n.generation != nodeInfo.Generation {
+		return false, nil, false
+	}
+	r, ok := n.results[classKey{profile: profile, class: class}]
+	return r.fits, r.status, ok
+}
+
+// Update records the filter result of class on nodeInfo. Results of older
+// generations of the node are dropped, results computed on an older
+// generation than the cached ones are ignored.
+func (c *Cache) Update(profile string, class Class, nodeInfo *framework.NodeInfo, fits bool, status *framework.Status) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	name := nodeInfo.Node().Name
+	n, found := c.nodes[name]
+	switch {
+	case found && n.generation > nodeInfo.Generation:
+		return
+	case !found || n.generation < nodeInfo.Generation || len(n.results) >= maxClassesPerNode:
+		n = &nodeCache{generation: nodeInfo.Generation, results: make(map[classKey]result)}
+		c.nodes[name] = n
+	}
+	n.results[classKey{profile: profile, class: class}] = result{fits: fits, status: status}
+}
+
+// RemoveStaleNodes drops the results of the nodes not in nodeInfos, e.g.
+// deleted ones.
+func (c *Cache) RemoveStaleNodes(nodeInfos []*framework.NodeInfo) {
+	if c.Len() <= len(nodeInfos) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current := make(map[string]bool, len(nodeInfos))
+	for _, n := range nodeInfos {
+		current[n.Node().Name] = true
+	}
+	for name := range c.nodes {
+		if !current[name] {
+			delete(c.nodes, name)
+		}
+	}
+}
+
+// Len returns the number of nodes with cached results.
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.nodes)
+}
@@ -0,0 +1,306 @@
+package equivalence
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/noderesources"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func makePod(name, cpu string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			Labels:    map[string]string{"app": "web"},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name:  "web",
+				Image: "nginx:" + name,
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+	}
+}
+
+func makeNodeInfo(name, cpu string, pods ...*v1.Pod) *framework.NodeInfo {
+	n := framework.NewNodeInfo(pods...)
+	n.SetNode(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	})
+	return n
+}
+
+func mustClass(t testing.TB, pod *v1.Pod) Class {
+	c, ok := GetClass(pod)
+	if !ok {
+		t.Fatalf("no class for pod %s", pod.Name)
+	}
+	return c
+}
+
+func TestGetClass(t *testing.T) {
+	base := makePod("web-1", "1")
+	tests := []struct {
+		name   string
+		mutate func(*v1.Pod)
+		equal  bool
+	}{
+		{
+			name: "replica with another name, uid and image",
+			mutate: func(p *v1.Pod) {
+				p.Name, p.UID = "web-2", "web-2"
+				p.Spec.Containers[0].Image = "nginx:other"
+			},
+			equal: true,
+		},
+		{
+			name:   "equal quantity written differently",
+			mutate: func(p *v1.Pod) { p.Spec.Containers[0].Resources.Requests[v1.ResourceCPU] = resource.MustParse("1000m") },
+			equal:  true,
+		},
+		{
+			name:   "other requests",
+			mutate: func(p *v1.Pod) { p.Spec.Containers[0].Resources.Requests[v1.ResourceCPU] = resource.MustParse("2") },
+		},
+		{
+			name:   "other namespace",
+			mutate: func(p *v1.Pod) { p.Namespace = "other" },
+		},
+		{
+			name:   "node selector",
+			mutate: func(p *v1.Pod) { p.Spec.NodeSelector = map[string]string{"zone": "a"} },
+		},
+		{
+			name: "tolerations",
+			mutate: func(p *v1.Pod) {
+				p.Spec.Tolerations = []v1.Toleration{{Key: "spot", Operator: v1.TolerationOpExists}}
+			},
+		},
+		{
+			name: "affinity",
+			mutate: func(p *v1.Pod) {
+				p.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+						NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchFields: []v1.NodeSelectorRequirement{{
+							Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"node-1"},
+						}}}},
+					},
+				}}
+			},
+		},
+		{
+			name: "host port",
+			mutate: func(p *v1.Pod) {
+				p.Spec.Containers[0].Ports = []v1.ContainerPort{{ContainerPort: 80, HostPort: 80}}
+			},
+		},
+		{
+			name: "volumes",
+			mutate: func(p *v1.Pod) {
+				p.Spec.Volumes = []v1.Volume{{Name: "data", VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+				}}}
+			},
+		},
+		{
+			name:   "labels",
+			mutate: func(p *v1.Pod) { p.Labels = map[string]string{"app": "db"} },
+		},
+		{
+			name: "other owner",
+			mutate: func(p *v1.Pod) {
+				p.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "db-1", Controller: new(bool)}}
+			},
+		},
+		{
+			name:   "node name",
+			mutate: func(p *v1.Pod) { p.Spec.NodeName = "node-1" },
+		},
+	}
+	want := mustClass(t, base)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := base.DeepCopy()
+			tt.mutate(pod)
+			if got := mustClass(t, pod); (got == want) != tt.equal {
+				t.Errorf("class equal = %v, want %v", got == want, tt.equal)
+			}
+		})
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache()
+	class := mustClass(t, makePod("web-1", "1"))
+	node := makeNodeInfo("node-1", "4")
+	unschedulable := framework.NewStatus(framework.Unschedulable, "Insufficient cpu")
+
+	if _, _, ok := c.Lookup("default", class, node); ok {
+		t.Fatal("lookup in empty cache hit")
+	}
+	c.Update("default", class, node, false, unschedulable)
+	fits, status, ok := c.Lookup("default", class, node)
+	if !ok || fits || status != unschedulable {
+		t.Errorf("Lookup() = %v, %v, %v, want false, %v, true", fits, status, ok, unschedulable)
+	}
+	if _, _, ok := c.Lookup("other-profile", class, node); ok {
+		t.Error("lookup of another profile hit")
+	}
+	if _, _, ok := c.Lookup("default", class+1, node); ok {
+		t.Error("lookup of another class hit")
+	}
+
+	// A new generation of the node invalidates its results.
+	old := node.Clone()
+	node.AddPod(makePod("web-0", "1"))
+	if _, _, ok := c.Lookup("default", class, node); ok {
+		t.Error("lookup after the node changed hit")
+	}
+	c.Update("default", class, node, true, nil)
+	// Results computed on an older generation are ignored.
+	c.Update("default", class, old, false, unschedulable)
+	if fits, _, ok := c.Lookup("default", class, node); !ok || !fits {
+		t.Errorf("Lookup() = %v, %v, want true, true", fits, ok)
+	}
+	if _, _, ok := c.Lookup("default", class, old); ok {
+		t.Error("lookup of an older generation hit")
+	}
+}
+
+func TestCacheBoundsClassesPerNode(t *testing.T) {
+	c := NewCache()
+	node := makeNodeInfo("node-1", "4")
+	for i := 0; i < maxClassesPerNode; i++ {
+		c.Update("default", Class(i), node, true, nil)
+	}
+	if _, _, ok := c.Lookup("default", 0, node); !ok {
+		t.Fatal("lookup before reaching the bound missed")
+	}
+	c.Update("default", Class(maxClassesPerNode), node, true, nil)
+	if _, _, ok := c.Lookup("default", 0, node); ok {
+		t.Error("results were not dropped after reaching the bound")
+	}
+	if _, _, ok := c.Lookup("default", Class(maxClassesPerNode), node); !ok {
+		t.Error("lookup of the last result missed")
+	}
+}
+
+func TestRemoveStaleNodes(t *testing.T) {
+	c := NewCache()
+	node1, node2 := makeNodeInfo("node-1", "4"), makeNodeInfo("node-2", "4")
+	c.Update("default", 1, node1, true, nil)
+	c.Update("default", 1, node2, true, nil)
+	c.RemoveStaleNodes([]*framework.NodeInfo{node1})
+	if c.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", c.Len())
+	}
+	if _, _, ok := c.Lookup("default", 1, node1); !ok {
+		t.Error("lookup on a remaining node missed")
+	}
+}
+
+// TestCachedResultMatchesFilter checks that cached results equal the results
+// of the filter for equivalent pods, as long as the node does not change.
+func TestCachedResultMatchesFilter(t *testing.T) {
+	pl, err := noderesources.NewFit(&config.NodeResourcesFitArgs{}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fit := pl.(*noderesources.Fit)
+	run := func(pod *v1.Pod, node *framework.NodeInfo) bool {
+		state := framework.NewCycleState()
+		if s := fit.PreFilter(context.Background(), state, pod); !s.IsSuccess() {
+			t.Fatal(s.Message())
+		}
+		return fit.Filter(context.Background(), state, pod, node).IsSuccess()
+	}
+
+	c := NewCache()
+	node := makeNodeInfo("node-1", "2")
+	for i := 0; i < 4; i++ {
+		pod := makePod(fmt.Sprintf("web-%d", i), "1")
+		class := mustClass(t, pod)
+		want := run(pod, node)
+		if fits, _, ok := c.Lookup("default", class, node); ok && fits != want {
+			t.Errorf("pod %d: cached result %v, filter %v", i, fits, want)
+		}
+		c.Update("default", class, node, want, nil)
+		if want {
+			node.AddPod(pod)
+		}
+	}
+	if len(node.Pods) != 2 {
+		t.Errorf("%d pods placed on the node, want 2", len(node.Pods))
+	}
+}
+
+func BenchmarkGetClass(b *testing.B) {
+	pod := makePod("web-1", "1")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GetClass(pod)
+	}
+}
+
+// BenchmarkFilter compares running NodeResourcesFit on nodes with many pods
+// with looking up the cached results.
+func BenchmarkFilter(b *testing.B) {
+	pl, err := noderesources.NewFit(&config.NodeResourcesFitArgs{}, nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	fit := pl.(*noderesources.Fit)
+	const numNodes = 1000
+	nodes := make([]*framework.NodeInfo, numNodes)
+	for i := range nodes {
+		var pods []*v1.Pod
+		for j := 0; j < 50; j++ {
+			pods = append(pods, makePod(fmt.Sprintf("pod-%d-%d", i, j), "100m"))
+		}
+		nodes[i] = makeNodeInfo(fmt.Sprintf("node-%d", i), "32", pods...)
+	}
+	pod := makePod("web-1", "1")
+	state := framework.NewCycleState()
+	fit.PreFilter(context.Background(), state, pod)
+
+	b.Run("filter", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, n := range nodes {
+				fit.Filter(context.Background(), state, pod, n)
+			}
+		}
+	})
+	b.Run("cache", func(b *testing.B) {
+		c := NewCache()
+		class := mustClass(b, pod)
+		for _, n := range nodes {
+			c.Update("default", class, n, fit.Filter(context.Background(), state, pod, n).IsSuccess(), nil)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			class := mustClass(b, pod)
+			for _, n := range nodes {
+				c.Lookup("default", class, n)
+			}
+		}
+	})
+}
@@ -12,6 +12,8 @@ import (
 
 	"k8s.io/klog/v2"
 
+	"github.com/turtacn/cloud-prophet/scheduler/core/equivalence"
+	"github.com/turtacn/cloud-prophet/scheduler/explain"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
 	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
 	podutil "github.com/turtacn/cloud-prophet/scheduler/helper"
@@ -22,6 +24,7 @@ import (
 	"github.com/turtacn/cloud-prophet/scheduler/util"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	corelisters "k8s.io/client-go/listers/core/v1"
 	extenderv1 "k8s.io/kube-scheduler/extender/v1"
 	utiltrace "k8s.io/utils/trace"
@@ -55,6 +58,19 @@ const (
 	NoNodeAvailableMsg = "0/%v nodes are available"
 )
 
+// UnschedulablePlugins returns the plugins that rejected the pod, nil if
+// some node was rejected by something else, e.g. an extender.
+func (f *FitError) UnschedulablePlugins() sets.String {
+	plugins := sets.NewString()
+	for _, status := range f.FilteredNodesStatuses {
+		if len(status.FailedPlugins()) == 0 {
+			return nil
+		}
+		plugins.Insert(status.FailedPlugins()...)
+	}
+	return plugins
+}
+
 // Error returns detailed information of why the pod failed to fit on each node
 func (f *FitError) Error() string {
 	reasons := make(map[string]int)
@@ -105,6 +121,9 @@ type genericScheduler struct {
 	disablePreemption        bool
 	percentageOfNodesToScore int32
 	nextStartNodeIndex       int
+	// equivalenceCache holds the filter results of equivalent pods, nil if
+	// disabled.
+	equivalenceCache *equivalence.Cache
 }
 
 // snapshot snapshots scheduler cache and node infos for all fit and priority
@@ -140,6 +159,7 @@ func (g *genericScheduler) Schedule(ctx context.Context, prof *profile.Profile,
 	if err != nil {
 		return result, err
 	}
+	explain.FromState(state).RecordFeasible(feasibleNodes, filteredNodesStatuses)
 	trace.Step("Computing predicates done")
 
 	if len(feasibleNodes) == 0 {
@@ -297,11 +317,12 @@ func (g *genericScheduler) findNodesThatPassFilters(ctx context.Context, prof *p
 	var statusesLock sync.Mutex
 	var feasibleNodesLen int32
 	ctx, cancel := context.WithCancel(ctx)
+	class, cacheable := g.equivalenceClass(prof, state, pod)
 	checkNode := func(i int) {
 		// We check the nodes starting from where we left off in the previous scheduling cycle,
 		// this is to make sure all nodes have the same chance of being examined across pods.
 		nodeInfo := allNodes[(g.nextStartNodeIndex+i)%len(allNodes)]
-		fits, status, err := PodPassesFiltersOnNode(ctx, prof.PreemptHandle(), state, pod, nodeInfo)
+		fits, status, err := g.podPassesFiltersOnNode(ctx, prof, state, pod, nodeInfo, class, cacheable)
 		if err != nil {
 			errCh.SendErrorWithCancel(err, cancel)
 			return
@@ -335,6 +356,9 @@ func (g *genericScheduler) findNodesThatPassFilters(ctx context.Context, prof *p
 	// Stops searching for more nodes once the configured number of feasible nodes
 	// are found.
 	parallelize.Until(ctx, len(allNodes), checkNode)
+	if g.equivalenceCache != nil {
+		g.equivalenceCache.RemoveStaleNodes(allNodes)
+	}
 	processedNodes := int(feasibleNodesLen) + len(statuses)
 	g.nextStartNodeIndex = (g.nextStartNodeIndex + processedNodes) % len(allNodes)
 
@@ -346,6 +370,58 @@ func (g *genericScheduler) findNodesThatPassFilters(ctx context.Context, prof *p
 	return feasibleNodes, nil
 }
 
+// equivalenceClass returns the equivalence class of pod, false if the
+// filter results of prof for pod cannot be cached.
+func (g *genericScheduler) equivalenceClass(prof *profile.Profile, state *framework.CycleState, pod *v1.Pod) (equivalence.Class, bool) {
+	if g.equivalenceCache == nil || !prof.FilterCacheable(state, pod) || g.ownsReservation(pod) {
+		return 0, false
+	}
+	return equivalence.GetClass(pod)
+}
+
+// ownsReservation returns whether a reservation is owned by pod itself
+// rather than by its workload. The class of a pod does not hold its name, so
+// the results of a pod that may consume such a reservation are not the ones
+// of its class.
+func (g *genericScheduler) ownsReservation(pod *v1.Pod) bool {
+	key := pod.Namespace + "/" + pod.Name
+	for _, r := range g.cache.Reservations() {
+		if r.Owner == key {
+			return true
+		}
+	}
+	return false
+}
+
+// podPassesFiltersOnNode runs PodPassesFiltersOnNode, reusing the result of
+// the pods of class on nodeInfo if cacheable.
+func (g *genericScheduler) podPassesFiltersOnNode(
+	ctx context.Context,
+	prof *profile.Profile,
+	state *framework.CycleState,
+	pod *v1.Pod,
+	nodeInfo *framework.NodeInfo,
+	class equivalence.Class,
+	cacheable bool,
+) (bool, *framework.Status, error) {
+	ph := prof.PreemptHandle()
+	// Nominated pods are added to the node before running the filters, so
+	// the node alone does not determine the result.
+	if !cacheable || len(ph.NominatedPodsForNode(nodeInfo.Node().Name)) > 0 {
+		return PodPassesFiltersOnNode(ctx, ph, state, pod, nodeInfo)
+	}
+	if fits, status, ok := g.equivalenceCache.Lookup(prof.Name, class, nodeInfo); ok {
+		metrics.EquivalenceCacheLookups.WithLabelValues("hit").Inc()
+		return fits, status, nil
+	}
+	metrics.EquivalenceCacheLookups.WithLabelValues("miss").Inc()
+	fits, status, err := PodPassesFiltersOnNode(ctx, ph, state, pod, nodeInfo)
+	if err == nil {
+		g.equivalenceCache.Update(prof.Name, class, nodeInfo, fits, status)
+	}
+	return fits, status, err
+}
+
 func (g *genericScheduler) findNodesThatPassExtenders(pod *v1.Pod, feasibleNodes []*v1.Node, statuses framework.NodeToStatusMap) ([]*v1.Node, error) {
 	for _, extender := range g.extenders {
 		if len(feasibleNodes) == 0 {
@@ -564,6 +640,7 @@ func (g *genericScheduler) prioritizeNodes(
 			klog.Infof("Host %s => Score %d", result[i].Name, result[i].Score)
 		}
 	}
+	explain.FromState(state).RecordTotals(result)
 	return result, nil
 }
 
@@ -612,7 +689,8 @@ func NewGenericScheduler(
 	extenders []framework.Extender,
 	pvcLister corelisters.PersistentVolumeClaimLister,
 	disablePreemption bool,
-	percentageOfNodesToScore int32) ScheduleAlgorithm {
+	percentageOfNodesToScore int32,
+	equivalenceCache *equivalence.Cache) ScheduleAlgorithm {
 	return &genericScheduler{
 		cache:                    cache,
 		extenders:                extenders,
@@ -620,5 +698,6 @@ func NewGenericScheduler(
 		pvcLister:                pvcLister,
 		disablePreemption:        disablePreemption,
 		percentageOfNodesToScore: percentageOfNodesToScore,
+		equivalenceCache:         equivalenceCache,
 	}
 }
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/core/equivalence"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	internalcache "github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestFitErrorUnschedulablePlugins(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses framework.NodeToStatusMap
+		want     sets.String
+	}{
+		{
+			name:     "no nodes",
+			statuses: framework.NodeToStatusMap{},
+			want:     sets.NewString(),
+		},
+		{
+			name: "plugins of every node",
+			statuses: framework.NodeToStatusMap{
+				"a": framework.NewStatus(framework.Unschedulable, "too many pods").WithFailedPlugin("NodeResourcesFit"),
+				"b": framework.NewStatus(framework.UnschedulableAndUnresolvable, "taint").WithFailedPlugin("TaintToleration"),
+				"c": framework.NewStatus(framework.Unschedulable, "too many pods").WithFailedPlugin("NodeResourcesFit"),
+			},
+			want: sets.NewString("NodeResourcesFit", "TaintToleration"),
+		},
+		{
+			// Extenders do not record plugins, so any event may help.
+			name: "node rejected by something else",
+			statuses: framework.NodeToStatusMap{
+				"a": framework.NewStatus(framework.Unschedulable, "too many pods").WithFailedPlugin("NodeResourcesFit"),
+				"b": framework.NewStatus(framework.Unschedulable, "rejected by extender"),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := &FitError{NumAllNodes: len(test.statuses), FilteredNodesStatuses: test.statuses}
+			if got := err.UnschedulablePlugins(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// TestEquivalenceClassOfReservationOwners checks that the filter results of
+// a pod owning a reservation by its name are not shared with its class.
+func TestEquivalenceClassOfReservationOwners(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	cache := internalcache.New(time.Minute, stop)
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:  resource.MustParse("4"),
+				v1.ResourcePods: resource.MustParse("110"),
+			},
+		},
+	}
+	if err := cache.AddNode(node); err != nil {
+		t.Fatal(err)
+	}
+	for name, owner := range map[string]string{"pod": "default/web-1", "workload": "default/web"} {
+		r := &framework.Reservation{
+			Name:      name,
+			Owner:     owner,
+			Resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			Expires:   time.Now().Add(time.Hour),
+		}
+		if err := cache.AddReservation(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	g := NewGenericScheduler(cache, internalcache.NewEmptySnapshot(), nil, nil, true, 100, equivalence.NewCache()).(*genericScheduler)
+	prof := newBatchProfile(t)
+
+	for name, want := range map[string]bool{"web-1": false, "web-2": true} {
+		pod := makeBatchPod(name, "web", "1")
+		if _, got := g.equivalenceClass(prof, framework.NewCycleState(), pod); got != want {
+			t.Errorf("pod %s: got cacheable %v, want %v", name, got, want)
+		}
+	}
+}
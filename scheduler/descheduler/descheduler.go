@@ -0,0 +1,375 @@
+// Package descheduler evicts pods whose placement no longer suits the
+// cluster, so that the scheduler places them again.
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/defaultbinder"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/interpodaffinity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeaffinity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/noderesources"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeunschedulable"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/podtopologyspread"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/queuesort"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/tainttoleration"
+	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	"github.com/turtacn/cloud-prophet/scheduler/rebalance"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// Config limits the evictions of a Descheduler. Zero limits mean
+// unbounded.
+type Config struct {
+	MaxPodsToEvict             int
+	MaxPodsToEvictPerNode      int
+	MaxPodsToEvictPerNamespace int
+	// EvictLocalStorage allows evicting pods with emptyDir or hostPath volumes.
+	EvictLocalStorage bool
+	// NodeFit only evicts pods that fit on another node.
+	NodeFit bool
+	// ExcludeNamespaces are never touched.
+	ExcludeNamespaces []string
+}
+
+// Eviction records a pod evicted, or meant to be in dry run.
+type Eviction struct {
+	Strategy  string `json:"strategy"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Node      string `json:"node"`
+	Reason    string `json:"reason"`
+	Error     string `json:"error,omitempty"`
+}
+
+// WriteEvictions writes evictions in a human readable form.
+func WriteEvictions(w io.Writer, evictions []Eviction) error {
+	for _, e := range evictions {
+		line := fmt.Sprintf("evict %s/%s from %s by %s: %s", e.Namespace, e.Name, e.Node, e.Strategy, e.Reason)
+		if e.Error != "" {
+			line += " (failed: " + e.Error + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Strategy selects pods to evict.
+type Strategy interface {
+	Name() string
+	// Run calls h.Evict for each pod to evict.
+	Run(ctx context.Context, h *Handle)
+}
+
+// Descheduler runs strategies against snapshots of the cluster.
+type Descheduler struct {
+	client     kubernetes.Interface
+	config     Config
+	evictor    rebalance.Evictor
+	strategies []Strategy
+}
+
+// New returns a descheduler evicting pods with evictor.
+func New(client kubernetes.Interface, config Config, evictor rebalance.Evictor, strategies ...Strategy) *Descheduler {
+	return &Descheduler{client: client, config: config, evictor: evictor, strategies: strategies}
+}
+
+// RunOnce runs every strategy once against the current state of the
+// cluster and returns the evictions.
+func (d *Descheduler) RunOnce(ctx context.Context) ([]Eviction, error) {
+	snapshot, pdbs, err := d.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fwk, err := NewFramework(d.client, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return d.Deschedule(ctx, fwk, snapshot, pdbs), nil
+}
+
+// Rebalance moves pods off the nodes hotter than the thresholds of config
+// with the evictor of the descheduler and returns the plan carried out.
+// usage is keyed by node name or address; nodes without usage are assumed
+// to use what their pods request.
+func (d *Descheduler) Rebalance(ctx context.Context, config rebalance.Config, usage map[string]rebalance.Usage) (*rebalance.Plan, error) {
+	snapshot, pdbs, err := d.snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fwk, err := NewFramework(d.client, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := rebalance.NewPlanner(fwk, snapshot, config).Plan(ctx, nodeUsage(snapshot, usage), pdbs)
+	if err != nil {
+		return nil, err
+	}
+	return plan, rebalance.Execute(ctx, plan, d.evictor)
+}
+
+// snapshot lists the nodes, bound pods and PodDisruptionBudgets of the
+// cluster.
+func (d *Descheduler) snapshot(ctx context.Context) (*cache.Snapshot, []*policy.PodDisruptionBudget, error) {
+	nodeList, err := d.client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	podList, err := d.client.CoreV1().Pods(v1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	pdbList, err := d.client.PolicyV1beta1().PodDisruptionBudgets(v1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	nodes := make([]*v1.Node, 0, len(nodeList.Items))
+	for i := range nodeList.Items {
+		nodes = append(nodes, &nodeList.Items[i])
+	}
+	var pods []*v1.Pod
+	for i := range podList.Items {
+		if p := &podList.Items[i]; p.Spec.NodeName != "" {
+			pods = append(pods, p)
+		}
+	}
+	pdbs := make([]*policy.PodDisruptionBudget, 0, len(pdbList.Items))
+	for i := range pdbList.Items {
+		pdbs = append(pdbs, &pdbList.Items[i])
+	}
+	return cache.NewSnapshot(pods, nodes), pdbs, nil
+}
+
+// nodeUsage keys usage by the name of the nodes of snapshot, looking nodes
+// up by name and then by address.
+func nodeUsage(snapshot *cache.Snapshot, usage map[string]rebalance.Usage) map[string]rebalance.Usage {
+	infos, err := snapshot.NodeInfos().List()
+	if err != nil {
+		return usage
+	}
+	res := make(map[string]rebalance.Usage, len(usage))
+	for _, info := range infos {
+		node := info.Node()
+		if node == nil {
+			continue
+		}
+		u, ok := usage[node.Name]
+		for i := 0; !ok && i < len(node.Status.Addresses); i++ {
+			u, ok = usage[node.Status.Addresses[i].Address]
+		}
+		if ok {
+			res[node.Name] = u
+		}
+	}
+	return res
+}
+
+// NewFramework returns a framework running the filters the descheduler
+// checks placements with against snapshot. All filters run, so that the
+// strategies can tell the failing ones apart.
+func NewFramework(client kubernetes.Interface, snapshot *cache.Snapshot) (framework.Framework, error) {
+	set := func(names ...string) *config.PluginSet {
+		s := &config.PluginSet{}
+		for _, n := range names {
+			s.Enabled = append(s.Enabled, config.Plugin{Name: n})
+		}
+		return s
+	}
+	pls := &config.Plugins{
+		QueueSort: set(queuesort.Name),
+		PreFilter: set(noderesources.FitName, podtopologyspread.Name, interpodaffinity.Name),
+		Filter: set(nodeunschedulable.Name, noderesources.FitName, nodeaffinity.Name, tainttoleration.Name,
+			podtopologyspread.Name, interpodaffinity.Name),
+		Bind: set(defaultbinder.Name),
+	}
+	args := []config.PluginConfig{
+		{Name: noderesources.FitName, Args: &config.NodeResourcesFitArgs{}},
+		{Name: podtopologyspread.Name, Args: &config.PodTopologySpreadArgs{}},
+		{Name: interpodaffinity.Name, Args: &config.InterPodAffinityArgs{HardPodAffinityWeight: 1}},
+	}
+	return frameworkruntime.NewFramework(plugins.NewInTreeRegistry(), pls, args,
+		frameworkruntime.WithClientSet(client),
+		frameworkruntime.WithInformerFactory(informers.NewSharedInformerFactory(client, 0)),
+		frameworkruntime.WithSnapshotSharedLister(snapshot),
+		frameworkruntime.WithRunAllFilters(true),
+	)
+}
+
+// Deschedule runs every strategy once against snapshot, checking
+// placements with fwk, which must read snapshot and run all filters.
+// Evicted pods are removed from the snapshot as they go.
+func (d *Descheduler) Deschedule(ctx context.Context, fwk framework.Framework, snapshot *cache.Snapshot, pdbs []*policy.PodDisruptionBudget) []Eviction {
+	h := &Handle{
+		d:            d,
+		fwk:          fwk,
+		snapshot:     snapshot,
+		budget:       rebalance.NewDisruptionBudget(pdbs),
+		excluded:     sets.NewString(d.config.ExcludeNamespaces...),
+		evicted:      make(map[types.UID]bool),
+		perNode:      make(map[string]int),
+		perNamespace: make(map[string]int),
+	}
+	for _, s := range d.strategies {
+		if ctx.Err() != nil {
+			break
+		}
+		h.strategy = s.Name()
+		s.Run(ctx, h)
+	}
+	return h.evictions
+}
+
+// Handle gives strategies access to the snapshot and evicts pods within
+// the limits of the descheduler.
+type Handle struct {
+	d            *Descheduler
+	fwk          framework.Framework
+	snapshot     *cache.Snapshot
+	budget       *rebalance.DisruptionBudget
+	excluded     sets.String
+	strategy     string
+	evicted      map[types.UID]bool
+	perNode      map[string]int
+	perNamespace map[string]int
+	evictions    []Eviction
+}
+
+// Nodes returns the nodes of the snapshot.
+func (h *Handle) Nodes() []*framework.NodeInfo {
+	nodes, err := h.snapshot.NodeInfos().List()
+	if err != nil {
+		klog.Errorf("Cannot list nodes of the snapshot: %v", err)
+		return nil
+	}
+	return nodes
+}
+
+// Pods returns a copy of the pods of nodeInfo, safe to range over while
+// evicting.
+func (h *Handle) Pods(nodeInfo *framework.NodeInfo) []*v1.Pod {
+	pods := make([]*v1.Pod, 0, len(nodeInfo.Pods))
+	for _, p := range nodeInfo.Pods {
+		pods = append(pods, p.Pod)
+	}
+	return pods
+}
+
+// Evictable reports whether pod may be evicted.
+func (h *Handle) Evictable(pod *v1.Pod) bool {
+	return !h.evicted[pod.UID] && !h.excluded.Has(pod.Namespace) && rebalance.Evictable(pod, h.d.config.EvictLocalStorage)
+}
+
+// limited reports whether a limit forbids evicting pod.
+func (h *Handle) limited(pod *v1.Pod) bool {
+	c := h.d.config
+	return (c.MaxPodsToEvict > 0 && len(h.evictions) >= c.MaxPodsToEvict) ||
+		(c.MaxPodsToEvictPerNode > 0 && h.perNode[pod.Spec.NodeName] >= c.MaxPodsToEvictPerNode) ||
+		(c.MaxPodsToEvictPerNamespace > 0 && h.perNamespace[pod.Namespace] >= c.MaxPodsToEvictPerNamespace)
+}
+
+// Evict evicts pod for reason unless it is not evictable, a limit or a
+// PodDisruptionBudget forbids it, or, with NodeFit, it fits on no other
+// node. It reports whether the pod was evicted.
+func (h *Handle) Evict(ctx context.Context, pod *v1.Pod, reason string) bool {
+	if !h.Evictable(pod) || h.limited(pod) {
+		return false
+	}
+	if !h.budget.Allows(pod) {
+		klog.V(3).Infof("Not evicting pod %s/%s: it would violate a PodDisruptionBudget", pod.Namespace, pod.Name)
+		return false
+	}
+	if h.d.config.NodeFit && !h.FitsElsewhere(ctx, pod) {
+		klog.V(3).Infof("Not evicting pod %s/%s: it fits on no other node", pod.Namespace, pod.Name)
+		return false
+	}
+	h.evicted[pod.UID] = true
+	e := Eviction{Strategy: h.strategy, Namespace: pod.Namespace, Name: pod.Name, Node: pod.Spec.NodeName, Reason: reason}
+	if err := h.d.evictor.Evict(ctx, pod, ""); err != nil {
+		klog.Errorf("Cannot evict pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		e.Error = err.Error()
+		h.evictions = append(h.evictions, e)
+		return false
+	}
+	h.evictions = append(h.evictions, e)
+	h.budget.Take(pod)
+	h.perNode[pod.Spec.NodeName]++
+	h.perNamespace[pod.Namespace]++
+	if info, err := h.snapshot.Get(pod.Spec.NodeName); err == nil {
+		if err := info.RemovePod(pod); err != nil {
+			klog.Warningf("Cannot remove pod %s/%s from the snapshot: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	return true
+}
+
+// unbound runs f with pod taken off its node in the snapshot, as a pending
+// replacement of pod together with the cycle state of its PreFilter.
+func (h *Handle) unbound(ctx context.Context, pod *v1.Pod, f func(pending *v1.Pod, state *framework.CycleState, own *framework.NodeInfo) bool) bool {
+	info, err := h.snapshot.Get(pod.Spec.NodeName)
+	if err != nil {
+		return false
+	}
+	if err := info.RemovePod(pod); err != nil {
+		return false
+	}
+	defer info.AddPod(pod)
+	pending := pod.DeepCopy()
+	pending.Spec.NodeName = ""
+	state := framework.NewCycleState()
+	if s := h.fwk.RunPreFilterPlugins(ctx, state, pending); !s.IsSuccess() {
+		klog.V(4).Infof("PreFilter of pod %s/%s failed: %v", pod.Namespace, pod.Name, s.Message())
+		return false
+	}
+	return f(pending, state, info)
+}
+
+// FitsElsewhere reports whether pod passes the filters of a node other than
+// its own.
+func (h *Handle) FitsElsewhere(ctx context.Context, pod *v1.Pod) bool {
+	return h.unbound(ctx, pod, func(pending *v1.Pod, state *framework.CycleState, own *framework.NodeInfo) bool {
+		for _, info := range h.Nodes() {
+			if info == own || info.Node() == nil {
+				continue
+			}
+			if h.fwk.RunFilterPlugins(ctx, state, pending, info).Merge().IsSuccess() {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Violates returns the reason the filter plugin would reject pod on its
+// own node. Only the given reasons count, any if reasons is nil.
+func (h *Handle) Violates(ctx context.Context, pod *v1.Pod, plugin string, reasons sets.String) (string, bool) {
+	var reason string
+	ok := h.unbound(ctx, pod, func(pending *v1.Pod, state *framework.CycleState, own *framework.NodeInfo) bool {
+		s := h.fwk.RunFilterPlugins(ctx, state, pending, own)[plugin]
+		if s.IsSuccess() {
+			return false
+		}
+		for _, r := range s.Reasons() {
+			if reasons == nil || reasons.Has(r) {
+				reason = r
+				return true
+			}
+		}
+		return false
+	})
+	return reason, ok
+}
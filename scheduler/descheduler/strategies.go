@@ -0,0 +1,342 @@
+package descheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/interpodaffinity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeaffinity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/podtopologyspread"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	schedutil "github.com/turtacn/cloud-prophet/scheduler/util"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// Names of the strategies.
+const (
+	RemoveDuplicatesName                            = "RemoveDuplicates"
+	LowNodeUtilizationName                          = "LowNodeUtilization"
+	RemovePodsViolatingInterPodAntiAffinityName     = "RemovePodsViolatingInterPodAntiAffinity"
+	RemovePodsViolatingNodeAffinityName             = "RemovePodsViolatingNodeAffinity"
+	RemovePodsViolatingNodeTaintsName               = "RemovePodsViolatingNodeTaints"
+	RemovePodsViolatingTopologySpreadConstraintName = "RemovePodsViolatingTopologySpreadConstraint"
+)
+
+// RemoveDuplicates evicts pods of the same controller with the same
+// containers sharing a node, keeping the oldest, so that replicas spread
+// over nodes.
+func RemoveDuplicates() Strategy {
+	return removeDuplicates{}
+}
+
+type removeDuplicates struct{}
+
+func (removeDuplicates) Name() string {
+	return RemoveDuplicatesName
+}
+
+func (removeDuplicates) Run(ctx context.Context, h *Handle) {
+	nodes := h.Nodes()
+	if len(nodes) < 2 {
+		return
+	}
+	for _, info := range nodes {
+		groups := make(map[string][]*v1.Pod)
+		var keys []string
+		for _, pod := range h.Pods(info) {
+			owner := metav1.GetControllerOf(pod)
+			if owner == nil {
+				continue
+			}
+			images := make([]string, 0, len(pod.Spec.Containers))
+			for _, c := range pod.Spec.Containers {
+				images = append(images, c.Image)
+			}
+			sort.Strings(images)
+			key := strings.Join([]string{pod.Namespace, owner.Kind, owner.Name, strings.Join(images, ",")}, "/")
+			if _, ok := groups[key]; !ok {
+				keys = append(keys, key)
+			}
+			groups[key] = append(groups[key], pod)
+		}
+		for _, key := range keys {
+			pods := groups[key]
+			if len(pods) < 2 {
+				continue
+			}
+			sort.SliceStable(pods, func(i, j int) bool {
+				return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+			})
+			for _, pod := range pods[1:] {
+				h.Evict(ctx, pod, fmt.Sprintf("duplicate of %s/%s", pods[0].Namespace, pods[0].Name))
+			}
+		}
+	}
+}
+
+// Thresholds are utilisations in percent of the allocatable resources of a
+// node, computed from the requests of its pods.
+type Thresholds struct {
+	CPU    float64
+	Memory float64
+	Pods   float64
+}
+
+// LowNodeUtilization evicts pods from nodes above the target thresholds as
+// long as the nodes below the low thresholds can take them without going
+// above the target thresholds themselves.
+func LowNodeUtilization(low, target Thresholds) Strategy {
+	return &lowNodeUtilization{low: low, target: target}
+}
+
+type lowNodeUtilization struct {
+	low, target Thresholds
+}
+
+func (s *lowNodeUtilization) Name() string {
+	return LowNodeUtilizationName
+}
+
+// requested is the absolute requests of a node or of a pod.
+type requested struct {
+	milliCPU, memory, pods float64
+}
+
+func nodeRequested(info *framework.NodeInfo) requested {
+	return requested{
+		milliCPU: float64(info.NonZeroRequested.MilliCPU),
+		memory:   float64(info.NonZeroRequested.Memory),
+		pods:     float64(len(info.Pods)),
+	}
+}
+
+func podRequested(pod *v1.Pod) requested {
+	r := requested{pods: 1}
+	for i := range pod.Spec.Containers {
+		c, m := schedutil.GetNonzeroRequests(&pod.Spec.Containers[i].Resources.Requests)
+		r.milliCPU += float64(c)
+		r.memory += float64(m)
+	}
+	return r
+}
+
+// usage returns r in percent of the allocatable resources of info.
+func usage(r requested, info *framework.NodeInfo) Thresholds {
+	return Thresholds{
+		CPU:    percent(r.milliCPU, float64(info.Allocatable.MilliCPU)),
+		Memory: percent(r.memory, float64(info.Allocatable.Memory)),
+		Pods:   percent(r.pods, float64(info.Allocatable.AllowedPodNumber)),
+	}
+}
+
+func percent(v, capacity float64) float64 {
+	if capacity <= 0 {
+		return 100
+	}
+	return v * 100 / capacity
+}
+
+func (u Thresholds) below(t Thresholds) bool {
+	return u.CPU < t.CPU && u.Memory < t.Memory && u.Pods < t.Pods
+}
+
+func (u Thresholds) above(t Thresholds) bool {
+	return u.CPU > t.CPU || u.Memory > t.Memory || u.Pods > t.Pods
+}
+
+func (s *lowNodeUtilization) Run(ctx context.Context, h *Handle) {
+	type overNode struct {
+		info *framework.NodeInfo
+		used requested
+	}
+	var over []overNode
+	// room is what the under utilised nodes can take before reaching the
+	// target thresholds.
+	var room requested
+	under := 0
+	for _, info := range h.Nodes() {
+		if info.Node() == nil || info.Node().Spec.Unschedulable {
+			continue
+		}
+		used := nodeRequested(info)
+		u := usage(used, info)
+		switch {
+		case u.below(s.low):
+			under++
+			room.milliCPU += float64(info.Allocatable.MilliCPU)*s.target.CPU/100 - used.milliCPU
+			room.memory += float64(info.Allocatable.Memory)*s.target.Memory/100 - used.memory
+			room.pods += float64(info.Allocatable.AllowedPodNumber)*s.target.Pods/100 - used.pods
+		case u.above(s.target):
+			over = append(over, overNode{info: info, used: used})
+		}
+	}
+	if under == 0 || len(over) == 0 {
+		return
+	}
+	sort.SliceStable(over, func(i, j int) bool {
+		a, b := usage(over[i].used, over[i].info), usage(over[j].used, over[j].info)
+		return a.CPU+a.Memory > b.CPU+b.Memory
+	})
+	for _, n := range over {
+		pods := h.Pods(n.info)
+		// Lower priority pods go first, bigger ones first among equals.
+		sort.SliceStable(pods, func(i, j int) bool {
+			pi, pj := priority(pods[i]), priority(pods[j])
+			if pi != pj {
+				return pi < pj
+			}
+			return podRequested(pods[i]).milliCPU > podRequested(pods[j]).milliCPU
+		})
+		for _, pod := range pods {
+			if !usage(n.used, n.info).above(s.target) {
+				break
+			}
+			r := podRequested(pod)
+			if r.milliCPU > room.milliCPU || r.memory > room.memory || r.pods > room.pods {
+				continue
+			}
+			if !h.Evict(ctx, pod, fmt.Sprintf("node %s is over utilised", n.info.Node().Name)) {
+				continue
+			}
+			n.used.milliCPU -= r.milliCPU
+			n.used.memory -= r.memory
+			n.used.pods -= r.pods
+			room.milliCPU -= r.milliCPU
+			room.memory -= r.memory
+			room.pods -= r.pods
+		}
+	}
+}
+
+func priority(pod *v1.Pod) int32 {
+	if pod.Spec.Priority != nil {
+		return *pod.Spec.Priority
+	}
+	return 0
+}
+
+// filterStrategy evicts pods that a filter plugin would no longer admit on
+// their node.
+type filterStrategy struct {
+	name    string
+	plugin  string
+	reasons sets.String
+}
+
+// RemovePodsViolatingInterPodAntiAffinity evicts pods sharing a topology
+// domain with pods their anti-affinity, or the anti-affinity of the others,
+// rules out.
+func RemovePodsViolatingInterPodAntiAffinity() Strategy {
+	return &filterStrategy{
+		name:    RemovePodsViolatingInterPodAntiAffinityName,
+		plugin:  interpodaffinity.Name,
+		reasons: sets.NewString(interpodaffinity.ErrReasonAntiAffinityRulesNotMatch, interpodaffinity.ErrReasonExistingAntiAffinityRulesNotMatch),
+	}
+}
+
+// RemovePodsViolatingNodeAffinity evicts pods whose node no longer matches
+// their node selector or required node affinity.
+func RemovePodsViolatingNodeAffinity() Strategy {
+	return &filterStrategy{name: RemovePodsViolatingNodeAffinityName, plugin: nodeaffinity.Name}
+}
+
+// RemovePodsViolatingNodeTaints evicts pods not tolerating a NoSchedule or
+// NoExecute taint added to their node.
+func RemovePodsViolatingNodeTaints() Strategy {
+	return removePodsViolatingNodeTaints{}
+}
+
+type removePodsViolatingNodeTaints struct{}
+
+func (removePodsViolatingNodeTaints) Name() string {
+	return RemovePodsViolatingNodeTaintsName
+}
+
+func (removePodsViolatingNodeTaints) Run(ctx context.Context, h *Handle) {
+	for _, info := range h.Nodes() {
+		node := info.Node()
+		if node == nil {
+			continue
+		}
+		for _, pod := range h.Pods(info) {
+			if ctx.Err() != nil {
+				return
+			}
+			if taint := untoleratedTaint(pod.Spec.Tolerations, node.Spec.Taints); taint != nil {
+				h.Evict(ctx, pod, fmt.Sprintf("node %s has taint %s that the pod does not tolerate", node.Name, taint.ToString()))
+			}
+		}
+	}
+}
+
+// untoleratedTaint returns the first NoSchedule or NoExecute taint of
+// taints not tolerated by tolerations, nil if there is none.
+func untoleratedTaint(tolerations []v1.Toleration, taints []v1.Taint) *v1.Taint {
+	for i := range taints {
+		taint := &taints[i]
+		if taint.Effect != v1.TaintEffectNoSchedule && taint.Effect != v1.TaintEffectNoExecute {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return taint
+		}
+	}
+	return nil
+}
+
+// RemovePodsViolatingTopologySpreadConstraint evicts pods that make their
+// topology domain exceed the maximum skew of a DoNotSchedule constraint.
+func RemovePodsViolatingTopologySpreadConstraint() Strategy {
+	return &filterStrategy{name: RemovePodsViolatingTopologySpreadConstraintName, plugin: podtopologyspread.Name}
+}
+
+func (s *filterStrategy) Name() string {
+	return s.name
+}
+
+func (s *filterStrategy) Run(ctx context.Context, h *Handle) {
+	for _, info := range h.Nodes() {
+		for _, pod := range h.Pods(info) {
+			if ctx.Err() != nil {
+				return
+			}
+			if !h.Evictable(pod) {
+				continue
+			}
+			if reason, ok := h.Violates(ctx, pod, s.plugin, s.reasons); ok {
+				h.Evict(ctx, pod, reason)
+			}
+		}
+	}
+}
+
+// NewStrategy returns the strategy called name. LowNodeUtilization uses
+// low and target.
+func NewStrategy(name string, low, target Thresholds) (Strategy, error) {
+	switch name {
+	case RemoveDuplicatesName:
+		return RemoveDuplicates(), nil
+	case LowNodeUtilizationName:
+		return LowNodeUtilization(low, target), nil
+	case RemovePodsViolatingInterPodAntiAffinityName:
+		return RemovePodsViolatingInterPodAntiAffinity(), nil
+	case RemovePodsViolatingNodeAffinityName:
+		return RemovePodsViolatingNodeAffinity(), nil
+	case RemovePodsViolatingNodeTaintsName:
+		return RemovePodsViolatingNodeTaints(), nil
+	case RemovePodsViolatingTopologySpreadConstraintName:
+		return RemovePodsViolatingTopologySpreadConstraint(), nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q", name)
+}
@@ -0,0 +1,310 @@
+package descheduler
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeEvictor struct {
+	evicted []string
+}
+
+func (e *fakeEvictor) Evict(_ context.Context, pod *v1.Pod, _ string) error {
+	e.evicted = append(e.evicted, pod.Namespace+"/"+pod.Name)
+	return nil
+}
+
+func makeNode(name string, taints ...v1.Taint) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"zone": name}},
+		Spec:       v1.NodeSpec{Taints: taints},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+var created = time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+
+// makePod returns a pod of the ReplicaSet owner on nodeName requesting cpu.
+func makePod(name, owner, nodeName, cpu string, opts ...func(*v1.Pod)) *v1.Pod {
+	controller := true
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			UID:               types.UID(name),
+			Labels:            map[string]string{"app": owner},
+			CreationTimestamp: metav1.NewTime(created),
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: owner, Controller: &controller},
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name:  "c",
+				Image: "nginx:1",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			}},
+		},
+	}
+	for _, opt := range opts {
+		opt(pod)
+	}
+	return pod
+}
+
+func createdAt(offset time.Duration) func(*v1.Pod) {
+	return func(p *v1.Pod) {
+		p.CreationTimestamp = metav1.NewTime(created.Add(offset))
+	}
+}
+
+func image(name string) func(*v1.Pod) {
+	return func(p *v1.Pod) {
+		p.Spec.Containers[0].Image = name
+	}
+}
+
+func tolerates(key string) func(*v1.Pod) {
+	return func(p *v1.Pod) {
+		p.Spec.Tolerations = append(p.Spec.Tolerations, v1.Toleration{Key: key, Operator: v1.TolerationOpExists})
+	}
+}
+
+var noSchedule = v1.Taint{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}
+
+func TestStrategies(t *testing.T) {
+	lowNodeUtilization := LowNodeUtilization(
+		Thresholds{CPU: 20, Memory: 20, Pods: 100},
+		Thresholds{CPU: 50, Memory: 50, Pods: 100},
+	)
+	tests := []struct {
+		name     string
+		strategy Strategy
+		config   Config
+		nodes    []*v1.Node
+		pods     []*v1.Pod
+		pdbs     []*policy.PodDisruptionBudget
+		want     []string
+	}{
+		{
+			name:     "duplicates keep the oldest pod",
+			strategy: RemoveDuplicates(),
+			nodes:    []*v1.Node{makeNode("a"), makeNode("b")},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "a", "100m"),
+				makePod("web-2", "web", "a", "100m", createdAt(-time.Hour)),
+				makePod("web-3", "web", "a", "100m", createdAt(time.Hour)),
+				makePod("web-4", "web", "b", "100m"),
+				makePod("api-1", "api", "a", "100m"),
+			},
+			want: []string{"default/web-1", "default/web-3"},
+		},
+		{
+			name:     "pods with other images are not duplicates",
+			strategy: RemoveDuplicates(),
+			nodes:    []*v1.Node{makeNode("a"), makeNode("b")},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "a", "100m"),
+				makePod("web-2", "web", "a", "100m", image("nginx:2")),
+			},
+		},
+		{
+			name:     "duplicates on a single node stay",
+			strategy: RemoveDuplicates(),
+			nodes:    []*v1.Node{makeNode("a")},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "a", "100m"),
+				makePod("web-2", "web", "a", "100m"),
+			},
+		},
+		{
+			// The idle node is at 17.5% cpu and can take 1300m before
+			// reaching 50%: p1 does not fit, p2 does, then p3 no longer
+			// fits.
+			name:     "low node utilization evicts what the idle nodes can take",
+			strategy: lowNodeUtilization,
+			nodes:    []*v1.Node{makeNode("busy"), makeNode("idle")},
+			pods: []*v1.Pod{
+				makePod("p1", "p1", "busy", "1500m"),
+				makePod("p2", "p2", "busy", "1"),
+				makePod("p3", "p3", "busy", "1"),
+				makePod("idle-1", "idle-1", "idle", "700m"),
+			},
+			want: []string{"default/p2"},
+		},
+		{
+			name:     "low node utilization stops under the target",
+			strategy: lowNodeUtilization,
+			nodes:    []*v1.Node{makeNode("busy"), makeNode("idle")},
+			pods: []*v1.Pod{
+				makePod("p1", "p1", "busy", "1500m"),
+				makePod("p2", "p2", "busy", "1"),
+				makePod("p3", "p3", "busy", "1"),
+			},
+			// Evicting p1 takes the busy node down to the 50% target.
+			want: []string{"default/p1"},
+		},
+		{
+			name:     "low node utilization needs an under utilised node",
+			strategy: lowNodeUtilization,
+			nodes:    []*v1.Node{makeNode("busy"), makeNode("warm")},
+			pods: []*v1.Pod{
+				makePod("p1", "p1", "busy", "3"),
+				makePod("warm-1", "warm-1", "warm", "1"),
+			},
+		},
+		{
+			name:     "untolerated NoSchedule taint",
+			strategy: RemovePodsViolatingNodeTaints(),
+			nodes: []*v1.Node{
+				makeNode("gpu", noSchedule),
+				makeNode("spot", v1.Taint{Key: "spot", Effect: v1.TaintEffectPreferNoSchedule}),
+			},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "gpu", "100m"),
+				makePod("train-1", "train", "gpu", "100m", tolerates("dedicated")),
+				makePod("web-2", "web", "spot", "100m"),
+			},
+			want: []string{"default/web-1"},
+		},
+		{
+			name:     "untolerated NoExecute taint",
+			strategy: RemovePodsViolatingNodeTaints(),
+			nodes:    []*v1.Node{makeNode("a", v1.Taint{Key: "maintenance", Effect: v1.TaintEffectNoExecute})},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "a", "100m"),
+				makePod("web-2", "web", "a", "100m", tolerates("maintenance")),
+			},
+			want: []string{"default/web-1"},
+		},
+		{
+			name:     "node affinity",
+			strategy: RemovePodsViolatingNodeAffinity(),
+			nodes:    []*v1.Node{makeNode("a")},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "a", "100m", func(p *v1.Pod) { p.Spec.NodeSelector = map[string]string{"zone": "b"} }),
+				makePod("web-2", "web", "a", "100m", func(p *v1.Pod) { p.Spec.NodeSelector = map[string]string{"zone": "a"} }),
+			},
+			want: []string{"default/web-1"},
+		},
+		{
+			name:     "per node limit",
+			strategy: RemovePodsViolatingNodeTaints(),
+			config:   Config{MaxPodsToEvictPerNode: 1},
+			nodes:    []*v1.Node{makeNode("gpu", noSchedule)},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "gpu", "100m"),
+				makePod("web-2", "web", "gpu", "100m"),
+			},
+			want: []string{"default/web-1"},
+		},
+		{
+			name:     "excluded namespace",
+			strategy: RemovePodsViolatingNodeTaints(),
+			config:   Config{ExcludeNamespaces: []string{"default"}},
+			nodes:    []*v1.Node{makeNode("gpu", noSchedule)},
+			pods:     []*v1.Pod{makePod("web-1", "web", "gpu", "100m")},
+		},
+		{
+			name:     "disruption budget",
+			strategy: RemovePodsViolatingNodeTaints(),
+			nodes:    []*v1.Node{makeNode("gpu", noSchedule)},
+			pods: []*v1.Pod{
+				makePod("web-1", "web", "gpu", "100m"),
+				makePod("web-2", "web", "gpu", "100m"),
+			},
+			pdbs: []*policy.PodDisruptionBudget{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+				Spec:       policy.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+				Status:     policy.PodDisruptionBudgetStatus{DisruptionsAllowed: 1},
+			}},
+			want: []string{"default/web-1"},
+		},
+		{
+			name:     "node fit without another node",
+			strategy: RemovePodsViolatingNodeTaints(),
+			config:   Config{NodeFit: true},
+			nodes:    []*v1.Node{makeNode("gpu", noSchedule)},
+			pods:     []*v1.Pod{makePod("web-1", "web", "gpu", "100m")},
+		},
+		{
+			name:     "node fit with another node",
+			strategy: RemovePodsViolatingNodeTaints(),
+			config:   Config{NodeFit: true},
+			nodes:    []*v1.Node{makeNode("gpu", noSchedule), makeNode("a")},
+			pods:     []*v1.Pod{makePod("web-1", "web", "gpu", "100m")},
+			want:     []string{"default/web-1"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := cache.NewSnapshot(test.pods, test.nodes)
+			client := fake.NewSimpleClientset()
+			fwk, err := NewFramework(client, snapshot)
+			if err != nil {
+				t.Fatalf("creating framework: %v", err)
+			}
+			evictor := &fakeEvictor{}
+			d := New(client, test.config, evictor, test.strategy)
+			evictions := d.Deschedule(context.Background(), fwk, snapshot, test.pdbs)
+
+			var got []string
+			for _, e := range evictions {
+				if e.Strategy != test.strategy.Name() || e.Error != "" {
+					t.Errorf("unexpected eviction %+v", e)
+				}
+				got = append(got, e.Namespace+"/"+e.Name)
+			}
+			// Nodes are visited in no particular order.
+			sort.Strings(got)
+			sort.Strings(evictor.evicted)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got evictions %v, want %v", got, test.want)
+			}
+			if !reflect.DeepEqual(evictor.evicted, test.want) {
+				t.Errorf("got evicted pods %v, want %v", evictor.evicted, test.want)
+			}
+		})
+	}
+}
+
+func TestUntoleratedTaint(t *testing.T) {
+	taints := []v1.Taint{
+		{Key: "soft", Effect: v1.TaintEffectPreferNoSchedule},
+		noSchedule,
+	}
+	if taint := untoleratedTaint(nil, taints); taint == nil || taint.Key != "dedicated" {
+		t.Errorf("got %v, want the dedicated taint", taint)
+	}
+	tolerations := []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule}}
+	if taint := untoleratedTaint(tolerations, taints); taint != nil {
+		t.Errorf("got %v, want no taint", taint)
+	}
+	tolerations[0].Value = "fpga"
+	if taint := untoleratedTaint(tolerations, taints); taint == nil {
+		t.Errorf("expected a toleration of another value not to tolerate the taint")
+	}
+}
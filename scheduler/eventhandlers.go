@@ -6,6 +6,7 @@ import (
 
 	"k8s.io/klog/v2"
 
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
 	"github.com/turtacn/cloud-prophet/scheduler/internal/queue"
 	"github.com/turtacn/cloud-prophet/scheduler/profile"
 	v1 "k8s.io/api/core/v1"
@@ -110,7 +111,7 @@ func (sched *Scheduler) updateNodeInCache(oldObj, newObj interface{}) {
 	// pod to be reevaluated when a change in the cluster happens.
 	if sched.SchedulingQueue.NumUnschedulablePods() == 0 {
 		sched.SchedulingQueue.MoveAllToActiveOrBackoffQueue(queue.Unknown)
-	} else if event := nodeSchedulingPropertiesChange(newNode, oldNode); event != "" {
+	} else if event, changed := nodeSchedulingPropertiesChange(newNode, oldNode); changed {
 		sched.SchedulingQueue.MoveAllToActiveOrBackoffQueue(event)
 	}
 }
@@ -448,24 +449,33 @@ func addAllEventHandlers(
 	)
 }
 
-func nodeSchedulingPropertiesChange(newNode *v1.Node, oldNode *v1.Node) string {
-	if nodeSpecUnschedulableChanged(newNode, oldNode) {
-		return queue.NodeSpecUnschedulableChange
-	}
-	if nodeAllocatableChanged(newNode, oldNode) {
-		return queue.NodeAllocatableChange
-	}
-	if nodeLabelsChanged(newNode, oldNode) {
-		return queue.NodeLabelChange
-	}
-	if nodeTaintsChanged(newNode, oldNode) {
-		return queue.NodeTaintChange
-	}
-	if nodeConditionsChanged(newNode, oldNode) {
-		return queue.NodeConditionChange
+// nodeSchedulingPropertiesChange returns the event of a node update, false if
+// it cannot make pods more schedulable. The event is labelled with the first
+// change and carries the action types of all of them, so that pods waiting
+// for any of them are moved.
+func nodeSchedulingPropertiesChange(newNode *v1.Node, oldNode *v1.Node) (framework.ClusterEvent, bool) {
+	changes := []struct {
+		changed bool
+		event   framework.ClusterEvent
+	}{
+		{nodeSpecUnschedulableChanged(newNode, oldNode), queue.NodeSpecUnschedulableChange},
+		{nodeAllocatableChanged(newNode, oldNode), queue.NodeAllocatableChange},
+		{nodeLabelsChanged(newNode, oldNode), queue.NodeLabelChange},
+		{nodeTaintsChanged(newNode, oldNode), queue.NodeTaintChange},
+		{nodeConditionsChanged(newNode, oldNode), queue.NodeConditionChange},
+	}
+	var event framework.ClusterEvent
+	for _, c := range changes {
+		if !c.changed {
+			continue
+		}
+		if event.ActionType == 0 {
+			event = c.event
+		} else {
+			event.ActionType |= c.event.ActionType
+		}
 	}
-
-	return ""
+	return event, event.ActionType != 0
 }
 
 func nodeAllocatableChanged(newNode *v1.Node, oldNode *v1.Node) bool {
@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"testing"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/queue"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeSchedulingPropertiesChange(t *testing.T) {
+	node := func(opts ...func(*v1.Node)) *v1.Node {
+		n := &v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node", Labels: map[string]string{"zone": "a"}},
+			Spec:       v1.NodeSpec{Taints: []v1.Taint{{Key: "dedicated", Effect: v1.TaintEffectNoSchedule}}},
+			Status: v1.NodeStatus{
+				Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+				Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+			},
+		}
+		for _, opt := range opts {
+			opt(n)
+		}
+		return n
+	}
+	unschedulable := func(n *v1.Node) { n.Spec.Unschedulable = true }
+	relabelled := func(n *v1.Node) { n.Labels["zone"] = "b" }
+	untainted := func(n *v1.Node) { n.Spec.Taints = nil }
+	resized := func(n *v1.Node) { n.Status.Allocatable[v1.ResourceCPU] = resource.MustParse("8") }
+	notReady := func(n *v1.Node) { n.Status.Conditions[0].Status = v1.ConditionFalse }
+	heartbeat := func(n *v1.Node) { n.Status.Conditions[0].LastHeartbeatTime = metav1.Now() }
+	annotated := func(n *v1.Node) { n.Annotations = map[string]string{"note": "x"} }
+
+	tests := []struct {
+		name      string
+		oldNode   *v1.Node
+		newNode   *v1.Node
+		wantEvent framework.ClusterEvent
+		want      bool
+	}{
+		{
+			name:    "no change",
+			oldNode: node(),
+			newNode: node(annotated, heartbeat),
+		},
+		{
+			name:      "made schedulable",
+			oldNode:   node(unschedulable),
+			newNode:   node(),
+			wantEvent: queue.NodeSpecUnschedulableChange,
+			want:      true,
+		},
+		{
+			name:    "made unschedulable",
+			oldNode: node(),
+			newNode: node(unschedulable),
+		},
+		{
+			name:      "allocatable",
+			oldNode:   node(),
+			newNode:   node(resized),
+			wantEvent: queue.NodeAllocatableChange,
+			want:      true,
+		},
+		{
+			name:      "labels",
+			oldNode:   node(),
+			newNode:   node(relabelled),
+			wantEvent: queue.NodeLabelChange,
+			want:      true,
+		},
+		{
+			name:      "taints",
+			oldNode:   node(),
+			newNode:   node(untainted),
+			wantEvent: queue.NodeTaintChange,
+			want:      true,
+		},
+		{
+			name:      "conditions",
+			oldNode:   node(),
+			newNode:   node(notReady),
+			wantEvent: queue.NodeConditionChange,
+			want:      true,
+		},
+		{
+			name:    "several changes",
+			oldNode: node(),
+			newNode: node(resized, relabelled, notReady),
+			wantEvent: framework.ClusterEvent{
+				Resource:   framework.Node,
+				ActionType: framework.UpdateNodeAllocatable | framework.UpdateNodeLabel | framework.UpdateNodeCondition,
+				Label:      queue.NodeAllocatableChange.Label,
+			},
+			want: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event, ok := nodeSchedulingPropertiesChange(test.newNode, test.oldNode)
+			if ok != test.want {
+				t.Fatalf("got change %v, want %v", ok, test.want)
+			}
+			if event != test.wantEvent {
+				t.Errorf("got event %+v, want %+v", event, test.wantEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+// Package explain records why the scheduler placed a pod where it did, or
+// nowhere: the filter statuses of every node, the scores of every plugin
+// and the selected host of each scheduling attempt.
+package explain
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// StateKey is the key of the Attempt in the CycleState of a scheduling
+// cycle. The framework only records when it is present.
+const StateKey framework.StateKey = "Explain"
+
+// Status is the status a plugin returned.
+type Status struct {
+	Plugin  string   `json:"plugin,omitempty"`
+	Code    string   `json:"code"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+func newStatus(plugin string, s *framework.Status) Status {
+	return Status{Plugin: plugin, Code: s.Code().String(), Reasons: append([]string(nil), s.Reasons()...)}
+}
+
+// Score is the score a plugin gave a node at each step.
+type Score struct {
+	Raw        int64 `json:"raw"`
+	Normalized int64 `json:"normalized"`
+	Weighted   int64 `json:"weighted"`
+}
+
+// Node is what happened to a node in an attempt.
+type Node struct {
+	Feasible bool `json:"feasible"`
+	// Status is the merged status of an infeasible node, including the
+	// rejections of extenders.
+	Status *Status `json:"status,omitempty"`
+	// Filters are the statuses of the filter plugins that rejected the node.
+	Filters []Status          `json:"filters,omitempty"`
+	Scores  map[string]*Score `json:"scores,omitempty"`
+	// Total is the sum of the weighted scores and of the extender scores.
+	Total int64 `json:"total"`
+}
+
+// Attempt is a scheduling attempt of a pod.
+type Attempt struct {
+	Time      time.Time `json:"time"`
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	UID       types.UID `json:"uid"`
+	Profile   string    `json:"profile"`
+	// PreFilter is the status of the PreFilter plugin that rejected the pod.
+	PreFilter    *Status          `json:"preFilter,omitempty"`
+	Nodes        map[string]*Node `json:"nodes"`
+	SelectedHost string           `json:"selectedHost,omitempty"`
+	Error        string           `json:"error,omitempty"`
+
+	mu sync.Mutex
+	// done stops recording once the attempt is finished, e.g. while
+	// preemption reruns the filters.
+	done bool
+}
+
+// NewAttempt returns an attempt to schedule pod with profile.
+func NewAttempt(pod *v1.Pod, profile string) *Attempt {
+	return &Attempt{
+		Time:      time.Now(),
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		UID:       pod.UID,
+		Profile:   profile,
+		Nodes:     make(map[string]*Node),
+	}
+}
+
+// Clone shares the attempt between clones of the cycle state, so that the
+// filters run with nominated pods are recorded too.
+func (a *Attempt) Clone() framework.StateData {
+	return a
+}
+
+// FromState returns the attempt recorded in state, nil if there is none.
+// Every method of Attempt is a no-op on nil.
+func FromState(state *framework.CycleState) *Attempt {
+	if state == nil {
+		return nil
+	}
+	c, err := state.Read(StateKey)
+	if err != nil {
+		return nil
+	}
+	a, _ := c.(*Attempt)
+	return a
+}
+
+// record runs f under the lock unless the attempt is nil or done.
+func (a *Attempt) record(f func()) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.done {
+		f()
+	}
+}
+
+func (a *Attempt) node(name string) *Node {
+	n, ok := a.Nodes[name]
+	if !ok {
+		n = &Node{}
+		a.Nodes[name] = n
+	}
+	return n
+}
+
+// RecordPreFilter records the PreFilter plugin rejecting the pod.
+func (a *Attempt) RecordPreFilter(plugin string, s *framework.Status) {
+	a.record(func() {
+		st := newStatus(plugin, s)
+		a.PreFilter = &st
+	})
+}
+
+// RecordFilter records the statuses of the filter plugins on node.
+func (a *Attempt) RecordFilter(node string, statuses framework.PluginToStatus) {
+	a.record(func() {
+		n := a.node(node)
+		n.Filters = n.Filters[:0]
+		for plugin, s := range statuses {
+			n.Filters = append(n.Filters, newStatus(plugin, s))
+		}
+		sort.Slice(n.Filters, func(i, j int) bool { return n.Filters[i].Plugin < n.Filters[j].Plugin })
+	})
+}
+
+// RecordFeasible records the outcome of filtering: the feasible nodes and
+// the merged statuses of the others.
+func (a *Attempt) RecordFeasible(feasible []*v1.Node, statuses framework.NodeToStatusMap) {
+	a.record(func() {
+		for _, node := range feasible {
+			n := a.node(node.Name)
+			n.Feasible = true
+			n.Filters = nil
+		}
+		for name, s := range statuses {
+			st := newStatus("", s)
+			a.node(name).Status = &st
+		}
+	})
+}
+
+// Step is a step of scoring.
+type Step int
+
+const (
+	// Raw scores returned by Score.
+	Raw Step = iota
+	// Normalized scores after NormalizeScore.
+	Normalized
+	// Weighted scores after applying the weight of the plugin.
+	Weighted
+)
+
+// RecordScores records the scores of plugin at step.
+func (a *Attempt) RecordScores(plugin string, step Step, scores framework.NodeScoreList) {
+	a.record(func() {
+		for _, s := range scores {
+			n := a.node(s.Name)
+			if n.Scores == nil {
+				n.Scores = make(map[string]*Score)
+			}
+			sc, ok := n.Scores[plugin]
+			if !ok {
+				sc = &Score{}
+				n.Scores[plugin] = sc
+			}
+			switch step {
+			case Raw:
+				sc.Raw = s.Score
+			case Normalized:
+				sc.Normalized = s.Score
+			case Weighted:
+				sc.Weighted = s.Score
+			}
+		}
+	})
+}
+
+// RecordTotals records the total scores of the feasible nodes.
+func (a *Attempt) RecordTotals(totals framework.NodeScoreList) {
+	a.record(func() {
+		for _, s := range totals {
+			a.node(s.Name).Total = s.Score
+		}
+	})
+}
+
+// Finish records the outcome of the attempt and stops recording.
+func (a *Attempt) Finish(host string, err error) {
+	a.record(func() {
+		a.SelectedHost = host
+		if err != nil {
+			a.Error = err.Error()
+		}
+		a.done = true
+	})
+}
@@ -0,0 +1,68 @@
+package explain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAttempt(t *testing.T) {
+	a := attempt("default", "web")
+	state := framework.NewCycleState()
+	state.Write(StateKey, a)
+	if got := FromState(state.Clone()); got != a {
+		t.Fatalf("expected clones of the state to share the attempt")
+	}
+
+	a.RecordFilter("node-1", framework.PluginToStatus{
+		"NodeResourcesFit": framework.NewStatus(framework.Unschedulable, "Insufficient cpu"),
+		"NodeAffinity":     framework.NewStatus(framework.UnschedulableAndUnresolvable, "node(s) didn't match node selector"),
+	})
+	a.RecordFeasible([]*v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}}}, framework.NodeToStatusMap{
+		"node-1": framework.NewStatus(framework.UnschedulableAndUnresolvable, "node(s) didn't match node selector", "Insufficient cpu"),
+	})
+	a.RecordScores("LoadAware", Raw, framework.NodeScoreList{{Name: "node-2", Score: 40}})
+	a.RecordScores("LoadAware", Normalized, framework.NodeScoreList{{Name: "node-2", Score: 80}})
+	a.RecordScores("LoadAware", Weighted, framework.NodeScoreList{{Name: "node-2", Score: 160}})
+	a.RecordTotals(framework.NodeScoreList{{Name: "node-2", Score: 160}})
+	a.Finish("node-2", nil)
+	// Nothing is recorded once finished, e.g. by preemption.
+	a.RecordFilter("node-2", framework.PluginToStatus{"NodeResourcesFit": framework.NewStatus(framework.Unschedulable, "Insufficient cpu")})
+	a.Finish("", errors.New("preempted"))
+
+	want := map[string]*Node{
+		"node-1": {
+			Status: &Status{Code: "UnschedulableAndUnresolvable", Reasons: []string{"node(s) didn't match node selector", "Insufficient cpu"}},
+			Filters: []Status{
+				{Plugin: "NodeAffinity", Code: "UnschedulableAndUnresolvable", Reasons: []string{"node(s) didn't match node selector"}},
+				{Plugin: "NodeResourcesFit", Code: "Unschedulable", Reasons: []string{"Insufficient cpu"}},
+			},
+		},
+		"node-2": {
+			Feasible: true,
+			Scores:   map[string]*Score{"LoadAware": {Raw: 40, Normalized: 80, Weighted: 160}},
+			Total:    160,
+		},
+	}
+	if !reflect.DeepEqual(a.Nodes, want) {
+		t.Errorf("got nodes %+v, want %+v", a.Nodes, want)
+	}
+	if a.SelectedHost != "node-2" || a.Error != "" {
+		t.Errorf("got host %q and error %q, want node-2 and no error", a.SelectedHost, a.Error)
+	}
+}
+
+func TestNilAttempt(t *testing.T) {
+	a := FromState(framework.NewCycleState())
+	if a != nil {
+		t.Fatalf("expected no attempt, got %v", a)
+	}
+	// Recording without an attempt is a no-op.
+	a.RecordPreFilter("NodeResourcesFit", framework.NewStatus(framework.Unschedulable))
+	a.RecordTotals(framework.NodeScoreList{{Name: "node-1", Score: 1}})
+	a.Finish("node-1", nil)
+}
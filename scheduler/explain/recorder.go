@@ -0,0 +1,106 @@
+package explain
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+// DefaultSize is the default number of attempts a Recorder keeps.
+const DefaultSize = 1000
+
+// Recorder keeps the last attempts in a ring buffer.
+type Recorder struct {
+	mu       sync.RWMutex
+	attempts []*Attempt
+	next     int
+	full     bool
+}
+
+// NewRecorder returns a recorder keeping the last size attempts.
+func NewRecorder(size int) *Recorder {
+	if size <= 0 {
+		size = DefaultSize
+	}
+	return &Recorder{attempts: make([]*Attempt, size)}
+}
+
+// Add records a finished attempt, dropping the oldest one when full.
+func (r *Recorder) Add(a *Attempt) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts[r.next] = a
+	r.next = (r.next + 1) % len(r.attempts)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// List returns the attempts matching match, newest first, at most limit of
+// them if limit is positive.
+func (r *Recorder) List(match func(*Attempt) bool, limit int) []*Attempt {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n := r.next
+	if r.full {
+		n = len(r.attempts)
+	}
+	var res []*Attempt
+	for i := 1; i <= n; i++ {
+		a := r.attempts[(r.next-i+len(r.attempts))%len(r.attempts)]
+		if match == nil || match(a) {
+			res = append(res, a)
+			if limit > 0 && len(res) == limit {
+				break
+			}
+		}
+	}
+	return res
+}
+
+// ForPod returns the attempts of the pod namespace/name, newest first.
+func (r *Recorder) ForPod(namespace, name string) []*Attempt {
+	return r.List(func(a *Attempt) bool { return a.Namespace == namespace && a.Name == name }, 0)
+}
+
+// ServeHTTP returns the attempts of a pod as JSON, newest first. The pod
+// is selected by the namespace and name, or uid, query parameters; without
+// them the last attempts of every pod are returned. limit bounds the
+// number of attempts.
+func (r *Recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	q := req.URL.Query()
+	limit := 0
+	if l := q.Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+	namespace, name, uid := q.Get("namespace"), q.Get("name"), types.UID(q.Get("uid"))
+	if namespace == "" {
+		namespace = "default"
+	}
+	match := func(a *Attempt) bool {
+		switch {
+		case uid != "":
+			return a.UID == uid
+		case name != "":
+			return a.Namespace == namespace && a.Name == name
+		}
+		return true
+	}
+	if uid == "" && name == "" && limit <= 0 {
+		limit = 100
+	}
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r.List(match, limit)); err != nil {
+		klog.Errorf("Cannot write scheduling attempts: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package explain
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func attempt(namespace, name string) *Attempt {
+	return NewAttempt(&v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: namespace,
+		Name:      name,
+		UID:       types.UID(namespace + "-" + name),
+	}}, "default-scheduler")
+}
+
+func names(attempts []*Attempt) []string {
+	var res []string
+	for _, a := range attempts {
+		res = append(res, a.Namespace+"/"+a.Name)
+	}
+	return res
+}
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder(3)
+	if got := r.List(nil, 0); len(got) != 0 {
+		t.Errorf("expected no attempts, got %v", names(got))
+	}
+	r.Add(attempt("default", "a"))
+	r.Add(attempt("default", "b"))
+	if got, want := names(r.List(nil, 0)), []string{"default/b", "default/a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// The oldest attempts are dropped once full.
+	r.Add(attempt("default", "a"))
+	r.Add(attempt("kube-system", "c"))
+	r.Add(attempt("default", "d"))
+	if got, want := names(r.List(nil, 0)), []string{"default/d", "kube-system/c", "default/a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := names(r.List(nil, 2)), []string{"default/d", "kube-system/c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := names(r.ForPod("default", "a")), []string{"default/a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := r.ForPod("default", "b"); len(got) != 0 {
+		t.Errorf("expected the attempt of b to be dropped, got %v", names(got))
+	}
+	if n := len(NewRecorder(0).attempts); n != DefaultSize {
+		t.Errorf("got size %d, want %d", n, DefaultSize)
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	r := NewRecorder(200)
+	for i := 0; i < 150; i++ {
+		r.Add(attempt("default", fmt.Sprintf("pod-%d", i)))
+	}
+	r.Add(attempt("kube-system", "pod-1"))
+	r.Add(attempt("default", "pod-1"))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantCount  int
+		wantFirst  string
+	}{
+		{
+			name:       "last attempts of every pod",
+			wantStatus: http.StatusOK,
+			wantCount:  100,
+			wantFirst:  "default/pod-1",
+		},
+		{
+			name:       "limit",
+			query:      "limit=2",
+			wantStatus: http.StatusOK,
+			wantCount:  2,
+			wantFirst:  "default/pod-1",
+		},
+		{
+			name:       "pod in the default namespace",
+			query:      "name=pod-1",
+			wantStatus: http.StatusOK,
+			wantCount:  2,
+			wantFirst:  "default/pod-1",
+		},
+		{
+			name:       "pod in another namespace",
+			query:      "namespace=kube-system&name=pod-1",
+			wantStatus: http.StatusOK,
+			wantCount:  1,
+			wantFirst:  "kube-system/pod-1",
+		},
+		{
+			name:       "uid",
+			query:      "uid=kube-system-pod-1",
+			wantStatus: http.StatusOK,
+			wantCount:  1,
+			wantFirst:  "kube-system/pod-1",
+		},
+		{
+			name:       "unknown pod",
+			query:      "name=missing",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "invalid limit",
+			query:      "limit=ten",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/explain?"+test.query, nil))
+			if w.Code != test.wantStatus {
+				t.Fatalf("got status %d, want %d", w.Code, test.wantStatus)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+			var got []Attempt
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decoding response: %v", err)
+			}
+			if len(got) != test.wantCount {
+				t.Errorf("got %d attempts, want %d", len(got), test.wantCount)
+			}
+			if len(got) > 0 && got[0].Namespace+"/"+got[0].Name != test.wantFirst {
+				t.Errorf("got first attempt %s/%s, want %s", got[0].Namespace, got[0].Name, test.wantFirst)
+			}
+		})
+	}
+}
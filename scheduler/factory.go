@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
@@ -12,6 +13,7 @@ import (
 	schedulerapi "github.com/turtacn/cloud-prophet/scheduler/apis/config"
 	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
 	"github.com/turtacn/cloud-prophet/scheduler/core"
+	"github.com/turtacn/cloud-prophet/scheduler/core/equivalence"
 	frameworkplugins "github.com/turtacn/cloud-prophet/scheduler/framework/plugins"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/defaultbinder"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/noderesources"
@@ -67,11 +69,17 @@ type Configurator struct {
 
 	podMaxBackoffSeconds int64
 
+	// backoffPolicy refines the backoff of unschedulable pods, if set.
+	backoffPolicy *schedulerapi.BackoffPolicy
+
 	profiles          []schedulerapi.KubeSchedulerProfile
 	registry          frameworkruntime.Registry
 	nodeInfoSnapshot  *internalcache.Snapshot
 	extenders         []schedulerapi.Extender
 	frameworkCapturer FrameworkCapturer
+
+	// equivalenceCache holds the filter results of equivalent pods, if set.
+	equivalenceCache *equivalence.Cache
 }
 
 func (c *Configurator) buildFramework(p schedulerapi.KubeSchedulerProfile, opts ...frameworkruntime.Option) (framework.Framework, error) {
@@ -139,8 +147,12 @@ func (c *Configurator) create() (*Scheduler, error) {
 
 	// The nominator will be passed all the way to framework instantiation.
 	nominator := internalqueue.NewPodNominator()
+	// The frameworks fill clusterEventMap with the plugins interested in each
+	// event, the queue uses it to only move the pods those events may help.
+	clusterEventMap := make(map[framework.ClusterEvent]sets.String)
 	profiles, err := profile.NewMap(c.profiles, c.buildFramework,
-		frameworkruntime.WithPodNominator(nominator))
+		frameworkruntime.WithPodNominator(nominator),
+		frameworkruntime.WithClusterEventMap(clusterEventMap))
 	if err != nil {
 		return nil, fmt.Errorf("initializing profiles: %v", err)
 	}
@@ -149,11 +161,15 @@ func (c *Configurator) create() (*Scheduler, error) {
 	}
 	// Profiles are required to have equivalent queue sort plugins.
 	lessFn := profiles[c.profiles[0].SchedulerName].Framework.QueueSortFunc()
+	podInitialBackoff := time.Duration(c.podInitialBackoffSeconds) * time.Second
+	podMaxBackoff := time.Duration(c.podMaxBackoffSeconds) * time.Second
 	podQueue := internalqueue.NewSchedulingQueue(
 		lessFn,
-		internalqueue.WithPodInitialBackoffDuration(time.Duration(c.podInitialBackoffSeconds)*time.Second),
-		internalqueue.WithPodMaxBackoffDuration(time.Duration(c.podMaxBackoffSeconds)*time.Second),
+		internalqueue.WithPodInitialBackoffDuration(podInitialBackoff),
+		internalqueue.WithPodMaxBackoffDuration(podMaxBackoff),
+		internalqueue.WithBackoffPolicy(internalqueue.NewBackoffPolicy(c.backoffPolicy, podInitialBackoff, podMaxBackoff)),
 		internalqueue.WithPodNominator(nominator),
+		internalqueue.WithClusterEventMap(clusterEventMap),
 	)
 
 	algo := core.NewGenericScheduler(
@@ -163,6 +179,7 @@ func (c *Configurator) create() (*Scheduler, error) {
 		c.informerFactory.Core().V1().PersistentVolumeClaims().Lister(),
 		c.disablePreemption,
 		c.percentageOfNodesToScore,
+		c.equivalenceCache,
 	)
 
 	return &Scheduler{
@@ -198,14 +215,41 @@ func (c *Configurator) createFromProvider(providerName string) (*Scheduler, erro
 // createFromConfig creates a scheduler from the configuration file
 // Only reachable when using v1alpha1 component config
 func (c *Configurator) createFromConfig(policy schedulerapi.Policy) (*Scheduler, error) {
+	klog.V(2).Infof("Creating scheduler from configuration: %v", policy)
+
+	defPlugins, defPluginConfig, err := PluginsFromPolicy(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	// When AlwaysCheckAllPredicates is set to true, scheduler checks all the configured
+	// predicates even after one or more of them fails.
+	if policy.AlwaysCheckAllPredicates {
+		c.alwaysCheckAllPredicates = policy.AlwaysCheckAllPredicates
+	}
+
+	for i := range c.profiles {
+		prof := &c.profiles[i]
+		// Plugins are empty when using Policy.
+		prof.Plugins = &schedulerapi.Plugins{}
+		prof.Plugins.Append(defPlugins)
+
+		// PluginConfig is ignored when using Policy.
+		prof.PluginConfig = defPluginConfig
+	}
+
+	return c.create()
+}
+
+// PluginsFromPolicy translates the predicates and priorities of a Policy through the
+// legacy registry into the plugins and plugin configs of the equivalent profile.
+func PluginsFromPolicy(policy schedulerapi.Policy) (*schedulerapi.Plugins, []schedulerapi.PluginConfig, error) {
 	lr := frameworkplugins.NewLegacyRegistry()
 	args := &frameworkplugins.ConfigProducerArgs{}
 
-	klog.V(2).Infof("Creating scheduler from configuration: %v", policy)
-
 	// validate the policy configuration
 	if err := validation.ValidatePolicy(policy); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	predicateKeys := sets.NewString()
@@ -242,22 +286,16 @@ func (c *Configurator) createFromConfig(policy schedulerapi.Policy) (*Scheduler,
 		}
 	}
 
-	// When AlwaysCheckAllPredicates is set to true, scheduler checks all the configured
-	// predicates even after one or more of them fails.
-	if policy.AlwaysCheckAllPredicates {
-		c.alwaysCheckAllPredicates = policy.AlwaysCheckAllPredicates
-	}
-
 	klog.V(2).Infof("Creating scheduler with fit predicates '%v' and priority functions '%v'", predicateKeys, priorityKeys)
 
 	pluginsForPredicates, pluginConfigForPredicates, err := getPredicateConfigs(predicateKeys, lr, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	pluginsForPriorities, pluginConfigForPriorities, err := getPriorityConfigs(priorityKeys, lr, args)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// Combine all framework configurations. If this results in any duplication, framework
 	// instantiation should fail.
@@ -276,19 +314,9 @@ func (c *Configurator) createFromConfig(policy schedulerapi.Policy) (*Scheduler,
 	defPlugins.Append(pluginsForPriorities)
 	defPluginConfig, err := mergePluginConfigsFromPolicy(pluginConfigForPredicates, pluginConfigForPriorities)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	for i := range c.profiles {
-		prof := &c.profiles[i]
-		// Plugins are empty when using Policy.
-		prof.Plugins = &schedulerapi.Plugins{}
-		prof.Plugins.Append(&defPlugins)
-
-		// PluginConfig is ignored when using Policy.
-		prof.PluginConfig = defPluginConfig
-	}
-
-	return c.create()
+	return &defPlugins, defPluginConfig, nil
 }
 
 // mergePluginConfigsFromPolicy merges the giving plugin configs ensuring that,
@@ -305,11 +333,17 @@ func mergePluginConfigsFromPolicy(pc1, pc2 []schedulerapi.PluginConfig) ([]sched
 		}
 		args[c.Name] = c.Args
 	}
+	// Sort the names so that the merged configs are stable.
+	names := make([]string, 0, len(args))
+	for k := range args {
+		names = append(names, k)
+	}
+	sort.Strings(names)
 	pc := make([]schedulerapi.PluginConfig, 0, len(args))
-	for k, v := range args {
+	for _, k := range names {
 		pc = append(pc, schedulerapi.PluginConfig{
 			Name: k,
-			Args: v,
+			Args: args[k],
 		})
 	}
 	return pc, nil
@@ -370,8 +404,8 @@ func getPredicateConfigs(keys sets.String, lr *frameworkplugins.LegacyRegistry,
 		}
 	}
 
-	// Third, add the rest in no specific order.
-	for predicateKey := range allPredicates {
+	// Third, add the rest in alphabetical order.
+	for _, predicateKey := range allPredicates.List() {
 		producer, exist := lr.PredicateToConfigProducer[predicateKey]
 		if !exist {
 			return nil, nil, fmt.Errorf("no framework config producer registered for %q", predicateKey)
@@ -433,6 +467,12 @@ func MakeDefaultErrorFunc(client clientset.Interface, podLister corelisters.PodL
 			klog.ErrorS(err, "Error scheduling pod; retrying", "pod", klog.KObj(pod))
 		}
 
+		podInfo.FailureReason = failureReason(err)
+		podInfo.UnschedulablePlugins = nil
+		if fitErr, ok := err.(*core.FitError); ok {
+			podInfo.UnschedulablePlugins = fitErr.UnschedulablePlugins()
+		}
+
 		// Check if the Pod exists in informer cache.
 		cachedPod, err := podLister.Pods(pod.Namespace).Get(pod.Name)
 		if err != nil {
@@ -446,3 +486,23 @@ func MakeDefaultErrorFunc(client clientset.Interface, podLister corelisters.PodL
 		}
 	}
 }
+
+// failureReason classifies the error of a scheduling attempt for the backoff
+// policy.
+func failureReason(err error) string {
+	if err == core.ErrNoNodesAvailable {
+		return schedulerapi.FailureReasonNoNodesAvailable
+	}
+	fitErr, ok := err.(*core.FitError)
+	if !ok {
+		return schedulerapi.FailureReasonError
+	}
+	for _, status := range fitErr.FilteredNodesStatuses {
+		for _, reason := range status.Reasons() {
+			if strings.Contains(strings.ToLower(reason), "insufficient") || reason == "Too many pods" {
+				return schedulerapi.FailureReasonInsufficientResources
+			}
+		}
+	}
+	return schedulerapi.FailureReasonUnschedulable
+}
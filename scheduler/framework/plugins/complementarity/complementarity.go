@@ -0,0 +1,230 @@
+package complementarity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	schedutil "github.com/turtacn/cloud-prophet/scheduler/util"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "Complementarity"
+	// DefaultProfileFile is the default ProfileFile.
+	DefaultProfileFile = "/etc/prophet/profiles.yaml"
+
+	// preScoreStateKey is the key in CycleState to Complementarity pre-computed data.
+	preScoreStateKey = "PreScore" + Name
+)
+
+var _ framework.PreScorePlugin = &Complementarity{}
+var _ framework.ScorePlugin = &Complementarity{}
+
+// Complementarity scores nodes by their predicted peak usage over a day
+// once the pod is placed, given the usage profiles of workloads. Summing
+// profiles rather than peaks favours nodes whose pods peak when the
+// incoming pod does not, so that workloads with anti-correlated patterns
+// share nodes. Pods without a profile count with a flat profile at their
+// requests; nodes are not scored for such pods.
+type Complementarity struct {
+	handle framework.FrameworkHandle
+	args   *config.ComplementarityArgs
+	source ProfileSource
+}
+
+// preScoreState computed at PreScore and used at Score.
+type preScoreState struct {
+	// skip is set when the pod has no profile.
+	skip        bool
+	cpu, memory []float64
+}
+
+// Clone the prescore state.
+func (s *preScoreState) Clone() framework.StateData {
+	return s
+}
+
+// DefaultArgs returns the args used when none are configured.
+func DefaultArgs() *config.ComplementarityArgs {
+	return &config.ComplementarityArgs{
+		ProfileSource:     "file",
+		ProfileFile:       DefaultProfileFile,
+		ProfileTTLSeconds: 300,
+		Slots:             24,
+		CPUWeight:         1,
+		MemoryWeight:      1,
+	}
+}
+
+func getArgs(obj runtime.Object) (*config.ComplementarityArgs, error) {
+	if obj == nil {
+		return DefaultArgs(), nil
+	}
+	ptr, ok := obj.(*config.ComplementarityArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type ComplementarityArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateComplementarityArgs(args); err != nil {
+		return nil, err
+	}
+	ttl := time.Duration(args.ProfileTTLSeconds) * time.Second
+	var source ProfileSource
+	switch args.ProfileSource {
+	case "http":
+		source, err = NewHTTPSource(args.ProfileURL, ttl)
+	default:
+		source, err = NewFileSource(args.ProfileFile, ttl)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewWithSource(h, args, source), nil
+}
+
+// NewWithSource returns the plugin with a custom profile source.
+func NewWithSource(h framework.FrameworkHandle, args *config.ComplementarityArgs, source ProfileSource) *Complementarity {
+	return &Complementarity{handle: h, args: args, source: source}
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *Complementarity) Name() string {
+	return Name
+}
+
+// PreScore invoked at the prescore extension point. It resamples the
+// profile of the pod.
+func (pl *Complementarity) PreScore(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodes []*v1.Node) *framework.Status {
+	s := &preScoreState{}
+	var ok bool
+	s.cpu, s.memory, ok = pl.profile(pod)
+	s.skip = !ok
+	cycleState.Write(preScoreStateKey, s)
+	return nil
+}
+
+func getPreScoreState(cycleState *framework.CycleState) (*preScoreState, error) {
+	c, err := cycleState.Read(preScoreStateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %q from cycleState: %v", preScoreStateKey, err)
+	}
+	s, ok := c.(*preScoreState)
+	if !ok {
+		return nil, fmt.Errorf("%+v  convert to complementarity.preScoreState error", c)
+	}
+	return s, nil
+}
+
+// Score invoked at the score extension point. The score is the idle
+// fraction of the node at the predicted peak of its pods and of the
+// incoming pod, weighted between cpu and memory.
+func (pl *Complementarity) Score(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	s, err := getPreScoreState(cycleState)
+	if err != nil {
+		return 0, framework.NewStatus(framework.Error, err.Error())
+	}
+	if s.skip {
+		return 0, nil
+	}
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil || nodeInfo.Node() == nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+
+	cpu := append([]float64(nil), s.cpu...)
+	memory := append([]float64(nil), s.memory...)
+	for _, p := range nodeInfo.Pods {
+		c, m, _ := pl.profile(p.Pod)
+		add(cpu, c)
+		add(memory, m)
+	}
+	cpuPeak := percent(peak(cpu), nodeInfo.Allocatable.MilliCPU)
+	memoryPeak := percent(peak(memory), nodeInfo.Allocatable.Memory)
+	load := (float64(pl.args.CPUWeight)*cpuPeak + float64(pl.args.MemoryWeight)*memoryPeak) /
+		float64(pl.args.CPUWeight+pl.args.MemoryWeight)
+	if load >= 100 {
+		return 0, nil
+	}
+	if load < 0 {
+		load = 0
+	}
+	return int64((100 - load) * float64(framework.MaxNodeScore) / 100), nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *Complementarity) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// profile returns the cpu and memory profiles of pod over Slots slots, and
+// whether the pod has a profile. A resource without a profile is flat at
+// the requests of the pod.
+func (pl *Complementarity) profile(pod *v1.Pod) (cpu, memory []float64, ok bool) {
+	var milliCPU, bytes int64
+	for i := range pod.Spec.Containers {
+		c, m := schedutil.GetNonzeroRequests(&pod.Spec.Containers[i].Resources.Requests)
+		milliCPU += c
+		bytes += m
+	}
+	p, ok := pl.source.Profile(pod)
+	slots := int(pl.args.Slots)
+	return resample(p.CPU, slots, float64(milliCPU)), resample(p.Memory, slots, float64(bytes)), ok
+}
+
+// resample returns v over n slots. When downsampling each slot takes the
+// peak of the values it covers, so that no peak is lost; when upsampling it
+// takes the value covering its start. An empty v is flat at def.
+func resample(v []float64, n int, def float64) []float64 {
+	out := make([]float64, n)
+	for i := range out {
+		if len(v) == 0 {
+			out[i] = def
+			continue
+		}
+		lo, hi := i*len(v)/n, (i+1)*len(v)/n
+		out[i] = v[lo]
+		for _, x := range v[lo:hi] {
+			if x > out[i] {
+				out[i] = x
+			}
+		}
+	}
+	return out
+}
+
+func add(sum, v []float64) {
+	for i := range sum {
+		sum[i] += v[i]
+	}
+}
+
+func peak(v []float64) float64 {
+	var max float64
+	for _, x := range v {
+		if x > max {
+			max = x
+		}
+	}
+	return max
+}
+
+func percent(v float64, capacity int64) float64 {
+	if capacity <= 0 {
+		return 100
+	}
+	return v * 100 / float64(capacity)
+}
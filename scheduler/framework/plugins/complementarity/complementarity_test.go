@@ -0,0 +1,150 @@
+package complementarity
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// mapSource returns profiles by pod name.
+type mapSource map[string]Profile
+
+func (s mapSource) Profile(pod *v1.Pod) (Profile, bool) {
+	p, ok := s[pod.Name]
+	return p, ok
+}
+
+func makeNode(name string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func makePod(name, nodeName, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "c",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestScore(t *testing.T) {
+	nodes := []*v1.Node{makeNode("day"), makeNode("night"), makeNode("empty"), makeNode("unprofiled")}
+	pods := []*v1.Pod{
+		makePod("web", "day", "1", "1Gi"),
+		makePod("etl", "night", "1", "2Gi"),
+		makePod("plain", "unprofiled", "1", "1Gi"),
+	}
+	source := mapSource{
+		"batch": {CPU: []float64{2000, 2000, 0, 0}},
+		"web":   {CPU: []float64{2000, 2000, 0, 0}},
+		// Resampled to 4 slots: 0, 0, 2000, 2000.
+		"etl": {CPU: []float64{0, 2000}},
+	}
+	args := DefaultArgs()
+	args.Slots = 4
+
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		wantScores map[string]int64
+	}{
+		{
+			// The batch pod uses 2000m in the first half of the day and
+			// 1Gi of memory all day.
+			name: "profiled pod",
+			pod:  makePod("batch", "", "100m", "1Gi"),
+			wantScores: map[string]int64{
+				// Same peaks: cpu 100%, memory 25%.
+				"day": 37,
+				// Complementary peaks: cpu 50%, memory 37.5%.
+				"night": 56,
+				// cpu 50%, memory 12.5%.
+				"empty": 68,
+				// Flat at requests: cpu 75%, memory 25%.
+				"unprofiled": 50,
+			},
+		},
+		{
+			name:       "pod without profile",
+			pod:        makePod("other", "", "100m", "1Gi"),
+			wantScores: map[string]int64{"day": 0, "night": 0, "empty": 0, "unprofiled": 0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := cache.NewSnapshot(pods, nodes)
+			fh, _ := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(snapshot))
+			pl := NewWithSource(fh, args, source)
+			ctx := context.Background()
+			state := framework.NewCycleState()
+			if status := pl.PreScore(ctx, state, test.pod, nodes); !status.IsSuccess() {
+				t.Fatalf("unexpected PreScore status: %v", status)
+			}
+			gotScores := make(map[string]int64, len(nodes))
+			for _, n := range nodes {
+				score, status := pl.Score(ctx, state, test.pod, n.Name)
+				if !status.IsSuccess() {
+					t.Fatalf("unexpected Score status: %v", status)
+				}
+				gotScores[n.Name] = score
+			}
+			if !reflect.DeepEqual(gotScores, test.wantScores) {
+				t.Errorf("got scores %v, want %v", gotScores, test.wantScores)
+			}
+		})
+	}
+}
+
+func TestScoreWithoutPreScore(t *testing.T) {
+	snapshot := cache.NewSnapshot(nil, []*v1.Node{makeNode("empty")})
+	fh, _ := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(snapshot))
+	pl := NewWithSource(fh, DefaultArgs(), mapSource{})
+	if _, status := pl.Score(context.Background(), framework.NewCycleState(), makePod("web", "", "1", "1Gi"), "empty"); status.Code() != framework.Error {
+		t.Errorf("expected an error without PreScore state, got %v", status)
+	}
+}
+
+func TestResample(t *testing.T) {
+	tests := []struct {
+		v    []float64
+		n    int
+		want []float64
+	}{
+		{v: nil, n: 3, want: []float64{5, 5, 5}},
+		{v: []float64{1, 2}, n: 4, want: []float64{1, 1, 2, 2}},
+		{v: []float64{1, 2, 3, 4}, n: 2, want: []float64{2, 4}},
+		// The peaks are not at the start of their slot.
+		{v: []float64{1, 9, 1, 1, 1, 1}, n: 3, want: []float64{9, 1, 1}},
+		{v: []float64{0, 0, 0, 7, 0}, n: 2, want: []float64{0, 7}},
+	}
+	for _, test := range tests {
+		if got := resample(test.v, test.n, 5); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("resample(%v, %d) = %v, want %v", test.v, test.n, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package complementarity
+
+import (
+	"time"
+
+	pluginhelper "github.com/turtacn/cloud-prophet/scheduler/framework/plugins/helper"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Profile is the predicted usage of a workload over a day, split in equal
+// slots starting at midnight UTC, e.g. 24 hourly values.
+type Profile struct {
+	// CPU in millicores.
+	CPU []float64 `json:"cpu,omitempty"`
+	// Memory in bytes.
+	Memory []float64 `json:"memory,omitempty"`
+}
+
+// ProfileSource returns the usage profiles of pods.
+type ProfileSource interface {
+	// Profile returns the profile of pod, false if there is none.
+	Profile(pod *v1.Pod) (Profile, bool)
+}
+
+// ProfileFile is the content of a profile file, and the answer of the
+// profile endpoint.
+type ProfileFile struct {
+	// Profiles are keyed by "namespace/name" of a pod, its controller or
+	// its deployment.
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+func decodeProfileFile(data []byte) (map[string]interface{}, error) {
+	file := &ProfileFile{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(file.Profiles))
+	for k, v := range file.Profiles {
+		values[k] = v
+	}
+	return values, nil
+}
+
+// NewFileSource returns a ProfileSource reading a YAML or JSON ProfileFile,
+// reloaded in the background when modified at most every ttl.
+func NewFileSource(path string, ttl time.Duration) (ProfileSource, error) {
+	source, err := pluginhelper.NewFileWorkloadSource(path, ttl, decodeProfileFile)
+	if err != nil {
+		return nil, err
+	}
+	return &workloadSource{source: source}, nil
+}
+
+// NewHTTPSource returns a ProfileSource getting a JSON ProfileFile from
+// endpoint every ttl. The endpoint is read in the background, so that
+// scheduling never waits for it; pods have no profile until the first
+// answer.
+func NewHTTPSource(endpoint string, ttl time.Duration) (ProfileSource, error) {
+	source, err := pluginhelper.NewHTTPWorkloadSource(endpoint, ttl, decodeProfileFile)
+	if err != nil {
+		return nil, err
+	}
+	return &workloadSource{source: source}, nil
+}
+
+type workloadSource struct {
+	source *pluginhelper.WorkloadSource
+}
+
+func (s *workloadSource) Profile(pod *v1.Pod) (Profile, bool) {
+	v, ok := s.source.Get(pod)
+	if !ok {
+		return Profile{}, false
+	}
+	return v.(Profile), true
+}
@@ -0,0 +1,106 @@
+package complementarity
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func replica(name string) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{"pod-template-hash": "5d8f"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "web-5d8f", Controller: &controller},
+			},
+		},
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "complementarity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "profiles.yaml")
+	if err := ioutil.WriteFile(path, []byte(`
+profiles:
+  default/web:
+    cpu: [100, 200]
+  default/web-1:
+    memory: [1024]
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileSource(filepath.Join(dir, "missing.yaml"), time.Minute); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+	s, err := NewFileSource(path, time.Minute)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		pod    string
+		want   Profile
+		wantOK bool
+	}{
+		// The profile of the pod wins over the one of its deployment.
+		{pod: "web-1", want: Profile{Memory: []float64{1024}}, wantOK: true},
+		{pod: "web-2", want: Profile{CPU: []float64{100, 200}}, wantOK: true},
+	}
+	for _, test := range tests {
+		got, ok := s.Profile(replica(test.pod))
+		if ok != test.wantOK || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Profile(%s) = %+v, %v; want %+v, %v", test.pod, got, ok, test.want, test.wantOK)
+		}
+	}
+	other := replica("api-1")
+	other.OwnerReferences[0].Name = "api-7c9b"
+	other.Labels["pod-template-hash"] = "7c9b"
+	if _, ok := s.Profile(other); ok {
+		t.Errorf("expected no profile for another workload")
+	}
+}
+
+func TestHTTPSource(t *testing.T) {
+	requests := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+		w.Write([]byte(`{"profiles": {"default/web": {"cpu": [100, 200], "memory": [1024]}}}`))
+	}))
+	defer server.Close()
+
+	s, err := NewHTTPSource(server.URL, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := replica("web-1")
+	want := Profile{CPU: []float64{100, 200}, Memory: []float64{1024}}
+	if err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		got, ok := s.Profile(pod)
+		return ok && reflect.DeepEqual(got, want), nil
+	}); err != nil {
+		t.Fatalf("waiting for the profile: %v", err)
+	}
+	// Profiles are read from memory until the ttl expires.
+	for i := 0; i < 5; i++ {
+		s.Profile(replica("web-2"))
+	}
+	if n := len(requests); n != 1 {
+		t.Errorf("expected a single request, got %d", n)
+	}
+}
@@ -0,0 +1,124 @@
+package drf
+
+import (
+	"fmt"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
+	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	podutil "github.com/turtacn/cloud-prophet/scheduler/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = "DRFSort"
+
+var _ framework.QueueSortPlugin = &DRFSort{}
+
+// DRFSort sorts pods by priority and, among pods of equal priority, by the
+// weighted dominant share of their tenant, so that a tenant flooding the
+// queue does not starve the others (Dominant Resource Fairness). Ties are
+// broken by timestamp. The dominant share of a tenant is the largest
+// fraction of the allocatable cpu or memory of the cluster requested by its
+// pods in the scheduler cache, including the pods assumed but not bound yet.
+//
+// The share of a pod is taken when it is queued and kept while it waits,
+// so that the order of the active queue stays consistent as shares change.
+type DRFSort struct {
+	args    *config.DRFSortArgs
+	weights map[string]int64
+	tracker *tracker
+}
+
+// DefaultArgs returns the args used when none are configured.
+func DefaultArgs() *config.DRFSortArgs {
+	return &config.DRFSortArgs{DefaultWeight: 1}
+}
+
+func getArgs(obj runtime.Object) (*config.DRFSortArgs, error) {
+	if obj == nil {
+		return DefaultArgs(), nil
+	}
+	ptr, ok := obj.(*config.DRFSortArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type DRFSortArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// NewFactory returns the factory of the plugin reading the pods allocated
+// to tenants from store, the scheduler cache.
+func NewFactory(store Store) frameworkruntime.PluginFactory {
+	return func(plArgs runtime.Object, _ framework.FrameworkHandle) (framework.Plugin, error) {
+		args, err := getArgs(plArgs)
+		if err != nil {
+			return nil, err
+		}
+		if err := validation.ValidateDRFSortArgs(args); err != nil {
+			return nil, err
+		}
+		return newDRFSort(args, store), nil
+	}
+}
+
+func newDRFSort(args *config.DRFSortArgs, store Store) *DRFSort {
+	pl := &DRFSort{args: args, weights: make(map[string]int64, len(args.TenantWeights))}
+	for _, w := range args.TenantWeights {
+		pl.weights[w.Tenant] = w.Weight
+	}
+	pl.tracker = newTracker(store, pl.tenant)
+	return pl
+}
+
+// Name returns name of the plugin.
+func (pl *DRFSort) Name() string {
+	return Name
+}
+
+// tenant returns the tenant of pod.
+func (pl *DRFSort) tenant(pod *v1.Pod) string {
+	if pl.args.TenantLabel != "" {
+		if t, ok := pod.Labels[pl.args.TenantLabel]; ok {
+			return t
+		}
+	}
+	return pod.Namespace
+}
+
+// share returns the weighted dominant share of the tenant of pod.
+func (pl *DRFSort) share(pod *v1.Pod) float64 {
+	tenant := pl.tenant(pod)
+	weight, ok := pl.weights[tenant]
+	if !ok {
+		weight = pl.args.DefaultWeight
+	}
+	return pl.tracker.share(tenant) / float64(weight)
+}
+
+// Less is the function used by the activeQ heap algorithm to sort pods.
+func (pl *DRFSort) Less(pInfo1, pInfo2 *framework.QueuedPodInfo) bool {
+	p1 := podutil.GetPodPriority(pInfo1.Pod)
+	p2 := podutil.GetPodPriority(pInfo2.Pod)
+	if p1 != p2 {
+		return p1 > p2
+	}
+	if t1, t2 := pl.tenant(pInfo1.Pod), pl.tenant(pInfo2.Pod); t1 != t2 {
+		if s1, s2 := pl.queuedShare(pInfo1), pl.queuedShare(pInfo2); s1 != s2 {
+			return s1 < s2
+		}
+	}
+	return pInfo1.Timestamp.Before(pInfo2.Timestamp)
+}
+
+// queuedShare returns the share of the tenant of the pod of pInfo when it
+// was queued, computed on the first comparison after being queued and kept
+// as the sort key of pInfo.
+func (pl *DRFSort) queuedShare(pInfo *framework.QueuedPodInfo) float64 {
+	if pInfo.SortKeyTimestamp.IsZero() || !pInfo.SortKeyTimestamp.Equal(pInfo.Timestamp) {
+		pInfo.SortKey = pl.share(pInfo.Pod)
+		pInfo.SortKeyTimestamp = pInfo.Timestamp
+	}
+	return pInfo.SortKey
+}
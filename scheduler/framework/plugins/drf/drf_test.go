@@ -0,0 +1,230 @@
+package drf
+
+import (
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var queued = time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+
+func makeNode(name string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func makePod(namespace, name, nodeName, cpu string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(namespace + "/" + name)},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "c",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func withPriority(pod *v1.Pod, priority int32) *v1.Pod {
+	pod.Spec.Priority = &priority
+	return pod
+}
+
+func withTenant(pod *v1.Pod, tenant string) *v1.Pod {
+	pod.Labels = map[string]string{"tenant": tenant}
+	return pod
+}
+
+func queuedPod(pod *v1.Pod, offset time.Duration) *framework.QueuedPodInfo {
+	return &framework.QueuedPodInfo{Pod: pod, Timestamp: queued.Add(offset)}
+}
+
+// newCache returns a cache of a node with the pods bound and the pods
+// assumed on it.
+func newCache(t *testing.T, stop <-chan struct{}, bound, assumed []*v1.Pod) cache.Cache {
+	c := cache.New(time.Minute, stop)
+	if err := c.AddNode(makeNode("node")); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range bound {
+		if err := c.AddPod(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, p := range assumed {
+		if err := c.AssumePod(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return c
+}
+
+func TestLess(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	// Tenant a has half of the cpu bound, tenant b a quarter assumed.
+	c := newCache(t, stop,
+		[]*v1.Pod{makePod("a", "a-1", "node", "2")},
+		[]*v1.Pod{makePod("b", "b-1", "node", "1")},
+	)
+
+	tests := []struct {
+		name   string
+		args   config.DRFSortArgs
+		pInfo1 *framework.QueuedPodInfo
+		pInfo2 *framework.QueuedPodInfo
+		want   bool
+	}{
+		{
+			name:   "lower share first",
+			args:   config.DRFSortArgs{DefaultWeight: 1},
+			pInfo1: queuedPod(makePod("b", "b-2", "", "100m"), time.Second),
+			pInfo2: queuedPod(makePod("a", "a-2", "", "100m"), 0),
+			want:   true,
+		},
+		{
+			name:   "higher share later",
+			args:   config.DRFSortArgs{DefaultWeight: 1},
+			pInfo1: queuedPod(makePod("a", "a-2", "", "100m"), 0),
+			pInfo2: queuedPod(makePod("b", "b-2", "", "100m"), time.Second),
+			want:   false,
+		},
+		{
+			name:   "higher priority first",
+			args:   config.DRFSortArgs{DefaultWeight: 1},
+			pInfo1: queuedPod(withPriority(makePod("a", "a-2", "", "100m"), 10), time.Second),
+			pInfo2: queuedPod(makePod("b", "b-2", "", "100m"), 0),
+			want:   true,
+		},
+		{
+			name:   "same tenant by timestamp",
+			args:   config.DRFSortArgs{DefaultWeight: 1},
+			pInfo1: queuedPod(makePod("a", "a-2", "", "100m"), 0),
+			pInfo2: queuedPod(makePod("a", "a-3", "", "100m"), time.Second),
+			want:   true,
+		},
+		{
+			name:   "tenant without usage first",
+			args:   config.DRFSortArgs{DefaultWeight: 1},
+			pInfo1: queuedPod(makePod("c", "c-1", "", "100m"), time.Second),
+			pInfo2: queuedPod(makePod("b", "b-2", "", "100m"), 0),
+			want:   true,
+		},
+		{
+			// The pod of namespace a belongs to tenant c, without usage.
+			name:   "tenant label",
+			args:   config.DRFSortArgs{DefaultWeight: 1, TenantLabel: "tenant"},
+			pInfo1: queuedPod(withTenant(makePod("a", "a-2", "", "100m"), "c"), time.Second),
+			pInfo2: queuedPod(makePod("b", "b-2", "", "100m"), 0),
+			want:   true,
+		},
+		{
+			// Half of the cpu at weight 4 is less than a quarter at weight 1.
+			name:   "tenant weights",
+			args:   config.DRFSortArgs{DefaultWeight: 1, TenantWeights: []config.TenantWeight{{Tenant: "a", Weight: 4}}},
+			pInfo1: queuedPod(makePod("a", "a-2", "", "100m"), time.Second),
+			pInfo2: queuedPod(makePod("b", "b-2", "", "100m"), 0),
+			want:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := test.args
+			pl := newDRFSort(&args, c)
+			if got := pl.Less(test.pInfo1, test.pInfo2); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestShareCountsAssumedPods(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	c := newCache(t, stop,
+		[]*v1.Pod{makePod("a", "a-1", "node", "1")},
+		[]*v1.Pod{makePod("b", "b-1", "node", "2"), makePod("b", "b-2", "node", "1")},
+	)
+	pl := newDRFSort(DefaultArgs(), c)
+	for tenant, want := range map[string]float64{"a": 0.25, "b": 0.75, "c": 0} {
+		if got := pl.tracker.share(tenant); got != want {
+			t.Errorf("tenant %s: got share %v, want %v", tenant, got, want)
+		}
+	}
+}
+
+func TestShareKeptWhileQueued(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	c := newCache(t, stop, nil, nil)
+	pl := newDRFSort(DefaultArgs(), c)
+	now := queued
+	pl.tracker.now = func() time.Time { return now }
+
+	pInfo := queuedPod(makePod("a", "a-2", "", "100m"), 0)
+	if got := pl.queuedShare(pInfo); got != 0 {
+		t.Fatalf("got share %v, want 0", got)
+	}
+
+	// The tenant gets half of the cpu while the pod waits.
+	if err := c.AddPod(makePod("a", "a-1", "node", "2")); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * refreshInterval)
+	if got := pl.tracker.share("a"); got != 0.5 {
+		t.Fatalf("got tracked share %v, want 0.5", got)
+	}
+	if got := pl.queuedShare(pInfo); got != 0 {
+		t.Errorf("got share %v while queued, want the share when queued 0", got)
+	}
+
+	// Queued again, the pod takes the current share.
+	pInfo.Timestamp = pInfo.Timestamp.Add(time.Minute)
+	if got := pl.queuedShare(pInfo); got != 0.5 {
+		t.Errorf("got share %v once queued again, want 0.5", got)
+	}
+}
+
+func TestShareRefreshInterval(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	c := newCache(t, stop, nil, nil)
+	pl := newDRFSort(DefaultArgs(), c)
+	now := queued
+	pl.tracker.now = func() time.Time { return now }
+
+	if got := pl.tracker.share("a"); got != 0 {
+		t.Fatalf("got share %v, want 0", got)
+	}
+	if err := c.AddPod(makePod("a", "a-1", "node", "2")); err != nil {
+		t.Fatal(err)
+	}
+	if got := pl.tracker.share("a"); got != 0 {
+		t.Errorf("got share %v before the refresh interval, want 0", got)
+	}
+	now = now.Add(refreshInterval)
+	if got := pl.tracker.share("a"); got != 0.5 {
+		t.Errorf("got share %v after the refresh interval, want 0.5", got)
+	}
+}
@@ -0,0 +1,122 @@
+package drf
+
+import (
+	"sync"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	schedutil "github.com/turtacn/cloud-prophet/scheduler/util"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// refreshInterval bounds how often the allocations are read from the store.
+const refreshInterval = time.Second
+
+// Store is where the plugin reads the pods allocated to tenants from: the
+// scheduler cache, which holds the pods assumed but not bound yet.
+type Store interface {
+	UpdateSnapshot(nodeSnapshot *cache.Snapshot) error
+}
+
+// resources are the cpu and memory tracked for fairness.
+type resources struct {
+	milliCPU int64
+	memory   int64
+}
+
+func (r *resources) add(o resources) {
+	r.milliCPU += o.milliCPU
+	r.memory += o.memory
+}
+
+// podResources returns the non-zero requests of pod, as the scheduler
+// cache accounts them.
+func podResources(pod *v1.Pod) resources {
+	var r resources
+	for i := range pod.Spec.Containers {
+		c, m := schedutil.GetNonzeroRequests(&pod.Spec.Containers[i].Resources.Requests)
+		r.milliCPU += c
+		r.memory += m
+	}
+	return r
+}
+
+// tracker keeps the resources allocated to each tenant and the allocatable
+// resources of the cluster, read from a snapshot of the store refreshed at
+// most every refreshInterval.
+type tracker struct {
+	store    Store
+	tenantOf func(*v1.Pod) string
+	now      func() time.Time
+
+	mu          sync.Mutex
+	snapshot    *cache.Snapshot
+	refreshed   time.Time
+	usage       map[string]resources
+	allocatable resources
+}
+
+func newTracker(store Store, tenantOf func(*v1.Pod) string) *tracker {
+	return &tracker{
+		store:    store,
+		tenantOf: tenantOf,
+		now:      time.Now,
+		snapshot: cache.NewEmptySnapshot(),
+	}
+}
+
+// share returns the dominant share of tenant: the largest fraction of the
+// allocatable cpu or memory of the cluster allocated to it.
+func (t *tracker) share(tenant string) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now := t.now(); t.usage == nil || now.Sub(t.refreshed) >= refreshInterval {
+		t.refreshLocked()
+		t.refreshed = now
+	}
+	u := t.usage[tenant]
+	var share float64
+	if t.allocatable.milliCPU > 0 {
+		share = float64(u.milliCPU) / float64(t.allocatable.milliCPU)
+	}
+	if t.allocatable.memory > 0 {
+		if s := float64(u.memory) / float64(t.allocatable.memory); s > share {
+			share = s
+		}
+	}
+	return share
+}
+
+// refreshLocked sums the requests of the pods of each tenant and the
+// allocatable resources of the nodes. The snapshot is updated
+// incrementally, only the nodes changed since the last refresh are copied.
+func (t *tracker) refreshLocked() {
+	if err := t.store.UpdateSnapshot(t.snapshot); err != nil {
+		klog.Errorf("Cannot update the snapshot of tenant allocations: %v", err)
+		return
+	}
+	infos, err := t.snapshot.NodeInfos().List()
+	if err != nil {
+		klog.Errorf("Cannot list nodes of the snapshot of tenant allocations: %v", err)
+		return
+	}
+	usage := make(map[string]resources)
+	var allocatable resources
+	for _, info := range infos {
+		if info.Node() == nil {
+			continue
+		}
+		allocatable.add(resources{milliCPU: info.Allocatable.MilliCPU, memory: info.Allocatable.Memory})
+		for _, p := range info.Pods {
+			if p.Pod.Status.Phase == v1.PodSucceeded || p.Pod.Status.Phase == v1.PodFailed {
+				continue
+			}
+			tenant := t.tenantOf(p.Pod)
+			u := usage[tenant]
+			u.add(podResources(p.Pod))
+			usage[tenant] = u
+		}
+	}
+	t.usage, t.allocatable = usage, allocatable
+}
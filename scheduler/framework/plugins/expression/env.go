@@ -0,0 +1,98 @@
+package expression
+
+import (
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/util/expr"
+	v1 "k8s.io/api/core/v1"
+)
+
+// env provides the attributes of a pod and a node to expressions.
+type env struct {
+	pod      *v1.Pod
+	nodeInfo *framework.NodeInfo
+	// requests of the pod, computed when first used.
+	requests *framework.Resource
+}
+
+var _ expr.Env = &env{}
+
+func newEnv(pod *v1.Pod, nodeInfo *framework.NodeInfo) *env {
+	return &env{pod: pod, nodeInfo: nodeInfo}
+}
+
+// String returns string attributes.
+func (e *env) String(attr, key string) (string, bool) {
+	var m map[string]string
+	switch attr {
+	case expr.PodName:
+		return e.pod.Name, true
+	case expr.PodNamespace:
+		return e.pod.Namespace, true
+	case expr.NodeName:
+		return e.nodeInfo.Node().Name, true
+	case expr.PodLabels:
+		m = e.pod.Labels
+	case expr.PodAnnotations:
+		m = e.pod.Annotations
+	case expr.NodeLabels:
+		m = e.nodeInfo.Node().Labels
+	case expr.NodeAnnotations:
+		m = e.nodeInfo.Node().Annotations
+	}
+	v, ok := m[key]
+	return v, ok
+}
+
+// Number returns number attributes.
+func (e *env) Number(attr, key string) (float64, bool) {
+	switch attr {
+	case expr.PodPriority:
+		if e.pod.Spec.Priority == nil {
+			return 0, true
+		}
+		return float64(*e.pod.Spec.Priority), true
+	case expr.PodRequests:
+		if e.requests == nil {
+			e.requests = podRequests(e.pod)
+		}
+		return resource(e.requests, key, 1)
+	case expr.NodeAllocatable:
+		return resource(e.nodeInfo.Allocatable, key, e.nodeInfo.Allocatable.AllowedPodNumber)
+	case expr.NodeRequested:
+		return resource(e.nodeInfo.Requested, key, len(e.nodeInfo.Pods))
+	}
+	return 0, false
+}
+
+// resource returns the quantity of the resource name in r, pods being the
+// number of pods.
+func resource(r *framework.Resource, name string, pods int) (float64, bool) {
+	switch v1.ResourceName(name) {
+	case v1.ResourceCPU:
+		return float64(r.MilliCPU), true
+	case v1.ResourceMemory:
+		return float64(r.Memory), true
+	case v1.ResourceEphemeralStorage:
+		return float64(r.EphemeralStorage), true
+	case v1.ResourcePods:
+		return float64(pods), true
+	}
+	v, ok := r.ScalarResources[v1.ResourceName(name)]
+	return float64(v), ok
+}
+
+// podRequests returns the resources requested by pod, as the Fit plugin
+// computes them.
+func podRequests(pod *v1.Pod) *framework.Resource {
+	result := &framework.Resource{}
+	for _, container := range pod.Spec.Containers {
+		result.Add(container.Resources.Requests)
+	}
+	for _, container := range pod.Spec.InitContainers {
+		result.SetMaxResource(container.Resources.Requests)
+	}
+	if pod.Spec.Overhead != nil {
+		result.Add(pod.Spec.Overhead)
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+package expression
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/util/expr"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = "Expression"
+
+var _ framework.FilterPlugin = &Expression{}
+var _ framework.ScorePlugin = &Expression{}
+var _ framework.EnqueueExtensions = &Expression{}
+
+// Expression filters and scores nodes with the expressions of its args,
+// compiled when the plugin is built. A node fits a pod if all the filters
+// are true, and scores the weighted average of the scores, each clamped to
+// [0, MaxNodeScore].
+type Expression struct {
+	handle  framework.FrameworkHandle
+	filters []filter
+	scores  []score
+}
+
+type filter struct {
+	name string
+	expr *expr.Expression
+	// resolvable filters refer to the requests of the pods on the node,
+	// which preemption can lower.
+	resolvable bool
+}
+
+type score struct {
+	name   string
+	expr   *expr.Expression
+	weight int64
+}
+
+// DefaultArgs returns the args used when none are configured.
+func DefaultArgs() *config.ExpressionArgs {
+	return &config.ExpressionArgs{}
+}
+
+func getArgs(obj runtime.Object) (*config.ExpressionArgs, error) {
+	if obj == nil {
+		return DefaultArgs(), nil
+	}
+	ptr, ok := obj.(*config.ExpressionArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type ExpressionArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateExpressionArgs(args); err != nil {
+		return nil, err
+	}
+	pl := &Expression{handle: h}
+	for _, f := range args.Filters {
+		e, err := expr.CompileAs(f.Expression, expr.Bool)
+		if err != nil {
+			return nil, err
+		}
+		resolvable := false
+		for _, a := range e.Attributes() {
+			if a == expr.NodeRequested {
+				resolvable = true
+			}
+		}
+		pl.filters = append(pl.filters, filter{name: f.Name, expr: e, resolvable: resolvable})
+	}
+	for _, s := range args.Scores {
+		e, err := expr.CompileAs(s.Expression, expr.Number)
+		if err != nil {
+			return nil, err
+		}
+		pl.scores = append(pl.scores, score{name: s.Name, expr: e, weight: s.Weight})
+	}
+	return pl, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *Expression) Name() string {
+	return Name
+}
+
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *Expression) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Node, ActionType: framework.Add | framework.Update},
+		{Resource: framework.Pod, ActionType: framework.Delete},
+	}
+}
+
+// Filter invoked at the filter extension point.
+func (pl *Expression) Filter(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if len(pl.filters) == 0 {
+		return nil
+	}
+	if nodeInfo.Node() == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+	env := newEnv(pod, nodeInfo)
+	for _, f := range pl.filters {
+		if f.expr.Bool(env) {
+			continue
+		}
+		code := framework.UnschedulableAndUnresolvable
+		if f.resolvable {
+			code = framework.Unschedulable
+		}
+		return framework.NewStatus(code, fmt.Sprintf("node(s) didn't match expression %s", f.name))
+	}
+	return nil
+}
+
+// Score invoked at the score extension point.
+func (pl *Expression) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	if len(pl.scores) == 0 {
+		return 0, nil
+	}
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil || nodeInfo.Node() == nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+	env := newEnv(pod, nodeInfo)
+	var sum float64
+	var weights int64
+	for _, s := range pl.scores {
+		v := math.Max(0, math.Min(float64(framework.MaxNodeScore), s.expr.Number(env)))
+		if math.IsNaN(v) {
+			v = 0
+		}
+		sum += v * float64(s.weight)
+		weights += s.weight
+	}
+	return int64(math.Round(sum / float64(weights))), nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *Expression) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
@@ -0,0 +1,231 @@
+package expression
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func makeNode(name string, labels, annotations map[string]string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func makePod(name, nodeName, cpu, memory string, annotations map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "c",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+	}
+}
+
+// newPlugin returns the plugin of args on a snapshot of nodes and pods.
+func newPlugin(t *testing.T, args *config.ExpressionArgs, nodes []*v1.Node, pods []*v1.Pod) (*Expression, framework.FrameworkHandle) {
+	fh, err := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(cache.NewSnapshot(pods, nodes)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl, err := New(args, fh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pl.(*Expression), fh
+}
+
+func TestFilter(t *testing.T) {
+	args := &config.ExpressionArgs{
+		Filters: []config.FilterExpression{
+			{Name: "gpu", Expression: `!has(pod.annotations["prophet.io/gpu"]) || node.labels.pool == "gpu"`},
+			{Name: "cpu", Expression: `node.requested.cpu + pod.requests.cpu <= node.allocatable.cpu`},
+		},
+	}
+	gpu := map[string]string{"prophet.io/gpu": "true"}
+	nodes := []*v1.Node{
+		makeNode("gpu", map[string]string{"pool": "gpu"}, nil),
+		makeNode("cpu", nil, nil),
+	}
+	pods := []*v1.Pod{makePod("busy", "cpu", "3", "1Gi", nil)}
+
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		node       string
+		wantCode   framework.Code
+		wantReason string
+	}{
+		{
+			name: "fits",
+			pod:  makePod("web", "", "1", "1Gi", nil),
+			node: "cpu",
+		},
+		{
+			name: "gpu pod on a gpu node",
+			pod:  makePod("train", "", "2", "1Gi", gpu),
+			node: "gpu",
+		},
+		{
+			name:       "gpu pod on another node",
+			pod:        makePod("train", "", "1", "1Gi", gpu),
+			node:       "cpu",
+			wantCode:   framework.UnschedulableAndUnresolvable,
+			wantReason: "node(s) didn't match expression gpu",
+		},
+		{
+			// Preempting busy would free the cpu the filter requires.
+			name:       "requested cpu",
+			pod:        makePod("web", "", "2", "1Gi", nil),
+			node:       "cpu",
+			wantCode:   framework.Unschedulable,
+			wantReason: "node(s) didn't match expression cpu",
+		},
+	}
+	pl, fh := newPlugin(t, args, nodes, pods)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nodeInfo, err := fh.SnapshotSharedLister().NodeInfos().Get(test.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+			status := pl.Filter(context.Background(), nil, test.pod, nodeInfo)
+			if status.Code() != test.wantCode {
+				t.Fatalf("got status %v, want code %v", status, test.wantCode)
+			}
+			if test.wantReason != "" && status.Reasons()[0] != test.wantReason {
+				t.Errorf("got reasons %v, want %q", status.Reasons(), test.wantReason)
+			}
+		})
+	}
+
+	if status := pl.Filter(context.Background(), nil, tests[0].pod, framework.NewNodeInfo()); status.Code() != framework.Error {
+		t.Errorf("got status %v without node, want an error", status)
+	}
+}
+
+func TestFilterWithoutFilters(t *testing.T) {
+	pl, _ := newPlugin(t, &config.ExpressionArgs{}, nil, nil)
+	if status := pl.Filter(context.Background(), nil, &v1.Pod{}, framework.NewNodeInfo()); !status.IsSuccess() {
+		t.Errorf("got status %v, want success", status)
+	}
+}
+
+func TestScore(t *testing.T) {
+	args := &config.ExpressionArgs{
+		Scores: []config.ScoreExpression{
+			{Name: "a", Expression: `number(node.annotations.a)`, Weight: 1},
+			{Name: "b", Expression: `number(node.annotations.b)`, Weight: 3},
+		},
+	}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        int64
+	}{
+		{
+			name:        "weighted average",
+			annotations: map[string]string{"a": "40", "b": "80"},
+			want:        70,
+		},
+		{
+			name:        "rounded",
+			annotations: map[string]string{"b": "50"},
+			want:        38,
+		},
+		{
+			name:        "clamped",
+			annotations: map[string]string{"a": "150", "b": "-20"},
+			want:        25,
+		},
+		{
+			name:        "infinite",
+			annotations: map[string]string{"a": "-Inf", "b": "+Inf"},
+			want:        75,
+		},
+		{
+			name:        "not a number",
+			annotations: map[string]string{"a": "NaN", "b": "100"},
+			want:        75,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pl, _ := newPlugin(t, args, []*v1.Node{makeNode("node", nil, test.annotations)}, nil)
+			got, status := pl.Score(context.Background(), nil, &v1.Pod{}, "node")
+			if !status.IsSuccess() {
+				t.Fatalf("unexpected status: %v", status)
+			}
+			if got != test.want {
+				t.Errorf("got score %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestScoreResources(t *testing.T) {
+	args := &config.ExpressionArgs{
+		Scores: []config.ScoreExpression{{
+			Name:       "free-memory",
+			Expression: `(node.allocatable.memory - node.requested.memory - pod.requests.memory) / node.allocatable.memory * 100`,
+			Weight:     1,
+		}},
+	}
+	pl, _ := newPlugin(t, args, []*v1.Node{makeNode("node", nil, nil)}, []*v1.Pod{makePod("busy", "node", "1", "2Gi", nil)})
+	got, status := pl.Score(context.Background(), nil, makePod("web", "", "1", "4Gi", nil), "node")
+	if !status.IsSuccess() {
+		t.Fatalf("unexpected status: %v", status)
+	}
+	if got != 25 {
+		t.Errorf("got score %d, want 25", got)
+	}
+
+	if _, status := pl.Score(context.Background(), nil, &v1.Pod{}, "missing"); status.Code() != framework.Error {
+		t.Errorf("got status %v for an unknown node, want an error", status)
+	}
+}
+
+func TestScoreWithoutScores(t *testing.T) {
+	pl, _ := newPlugin(t, &config.ExpressionArgs{}, nil, nil)
+	if got, status := pl.Score(context.Background(), nil, &v1.Pod{}, "missing"); got != 0 || !status.IsSuccess() {
+		t.Errorf("got score %d, %v, want 0", got, status)
+	}
+}
+
+func TestNew(t *testing.T) {
+	fh, err := runtime.NewFramework(nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(&config.NodeHealthArgs{}, fh); err == nil {
+		t.Errorf("expected an error for args of another plugin")
+	}
+	args := &config.ExpressionArgs{
+		Filters: []config.FilterExpression{{Name: "cpu", Expression: `node.allocatable.cpu`}},
+	}
+	if _, err := New(args, fh); err == nil {
+		t.Errorf("expected an error for a filter that is not a bool")
+	}
+}
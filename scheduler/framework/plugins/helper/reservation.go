@@ -0,0 +1,29 @@
+package helper
+
+import (
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// OwnedReservation returns the placeholder pod of the reservation on
+// nodeInfo that pod may consume, the one with the smallest name if there
+// are several, nil if there is none.
+func OwnedReservation(nodeInfo *framework.NodeInfo, pod *v1.Pod) *v1.Pod {
+	var res *v1.Pod
+	var keys []string
+	for _, pi := range nodeInfo.Pods {
+		owner, ok := pi.Pod.Annotations[framework.ReservationOwnerAnnotation]
+		if !ok || !framework.IsReservationPod(pi.Pod) {
+			continue
+		}
+		if keys == nil {
+			keys = WorkloadKeys(pod)
+		}
+		for _, key := range keys {
+			if key == owner && (res == nil || pi.Pod.Name < res.Name) {
+				res = pi.Pod
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,24 @@
+package helper
+
+import (
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadKeys returns the "namespace/name" keys data about pod, such as
+// predictions, may be stored under, most specific first: the pod, its
+// controller and the deployment owning its replica set.
+func WorkloadKeys(pod *v1.Pod) []string {
+	keys := []string{pod.Namespace + "/" + pod.Name}
+	ref := metav1.GetControllerOf(pod)
+	if ref == nil {
+		return keys
+	}
+	keys = append(keys, pod.Namespace+"/"+ref.Name)
+	if hash, ok := pod.Labels["pod-template-hash"]; ok && ref.Kind == "ReplicaSet" && strings.HasSuffix(ref.Name, "-"+hash) {
+		keys = append(keys, pod.Namespace+"/"+strings.TrimSuffix(ref.Name, "-"+hash))
+	}
+	return keys
+}
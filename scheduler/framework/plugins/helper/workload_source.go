@@ -0,0 +1,201 @@
+package helper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// DecodeFunc turns a YAML or JSON document into values keyed by the
+// "namespace/name" keys of WorkloadKeys.
+type DecodeFunc func(data []byte) (map[string]interface{}, error)
+
+// LoadFunc returns all the values of a custom source, keyed by workload
+// key, node name or anything else the plugin looks up.
+type LoadFunc func() (map[string]interface{}, error)
+
+// WorkloadSource holds per workload or per node data, such as usage
+// predictions, read from a file, an HTTP endpoint or a custom loader. The
+// data is reloaded in the background at most every ttl, so that plugins
+// only read memory while scheduling; failed reloads keep the last data.
+type WorkloadSource struct {
+	location string
+	ttl      time.Duration
+	decode   DecodeFunc
+	// load returns the decoded document and when it was produced, or nil
+	// if it did not change.
+	load func() (map[string]interface{}, time.Time, error)
+
+	mu      sync.RWMutex
+	values  map[string]interface{}
+	loaded  time.Time
+	checked time.Time
+	loading bool
+	// modified is the modification time of the file last loaded. It is
+	// only accessed by load, which never runs concurrently.
+	modified time.Time
+}
+
+// NewFileWorkloadSource returns a WorkloadSource reading the YAML or JSON
+// file at path, reloaded when modified. The file is read once before
+// returning, so that a missing or invalid file is reported right away.
+func NewFileWorkloadSource(path string, ttl time.Duration, decode DecodeFunc) (*WorkloadSource, error) {
+	s := &WorkloadSource{location: path, ttl: ttl, decode: decode}
+	s.load = s.loadFile
+	values, loaded, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	s.values, s.loaded, s.checked = values, loaded, time.Now()
+	return s, nil
+}
+
+// NewHTTPWorkloadSource returns a WorkloadSource getting a JSON document
+// from endpoint. The first read starts in the background, pods have no data
+// until it completes.
+func NewHTTPWorkloadSource(endpoint string, ttl time.Duration, decode DecodeFunc) (*WorkloadSource, error) {
+	if _, err := url.Parse(endpoint); err != nil {
+		return nil, err
+	}
+	s := &WorkloadSource{location: endpoint, ttl: ttl, decode: decode}
+	client := &http.Client{Timeout: 10 * time.Second}
+	s.load = func() (map[string]interface{}, time.Time, error) {
+		return s.loadHTTP(client)
+	}
+	s.refresh()
+	return s, nil
+}
+
+// NewWorkloadSource returns a WorkloadSource calling load, e.g. to query a
+// metrics store. location names the source in logs. The first load starts
+// in the background, there is no data until it completes.
+func NewWorkloadSource(location string, ttl time.Duration, load LoadFunc) *WorkloadSource {
+	s := &WorkloadSource{location: location, ttl: ttl}
+	s.load = func() (map[string]interface{}, time.Time, error) {
+		values, err := load()
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return nonNil(values), time.Now(), nil
+	}
+	s.refresh()
+	return s
+}
+
+// Get returns the data of the most specific workload key of pod. It never
+// blocks on the file or endpoint.
+func (s *WorkloadSource) Get(pod *v1.Pod) (interface{}, bool) {
+	values, _ := s.current()
+	for _, key := range WorkloadKeys(pod) {
+		if v, ok := values[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// GetKey returns the data of key, e.g. a node name, and when the data was
+// produced: the modification time of the file, or the time the endpoint or
+// loader answered. It never blocks on the source.
+func (s *WorkloadSource) GetKey(key string) (interface{}, time.Time, bool) {
+	values, loaded := s.current()
+	v, ok := values[key]
+	return v, loaded, ok
+}
+
+// current returns the data, starting a reload when it is older than ttl.
+func (s *WorkloadSource) current() (map[string]interface{}, time.Time) {
+	s.mu.RLock()
+	values, loaded, stale := s.values, s.loaded, time.Since(s.checked) >= s.ttl
+	s.mu.RUnlock()
+	if stale {
+		s.refresh()
+	}
+	return values, loaded
+}
+
+// HasSynced returns whether the data was loaded at least once.
+func (s *WorkloadSource) HasSynced() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values != nil
+}
+
+// refresh starts a reload unless one is already running.
+func (s *WorkloadSource) refresh() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loading {
+		return
+	}
+	s.loading = true
+	go func() {
+		values, loaded, err := s.load()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.loading = false
+		s.checked = time.Now()
+		if err != nil {
+			klog.Warningf("Keeping the data of %s: %v", s.location, err)
+			return
+		}
+		if values != nil {
+			s.values, s.loaded = values, loaded
+		}
+	}()
+}
+
+func (s *WorkloadSource) loadFile() (map[string]interface{}, time.Time, error) {
+	info, err := os.Stat(s.location)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if !info.ModTime().After(s.modified) && !s.modified.IsZero() {
+		return nil, time.Time{}, nil
+	}
+	data, err := ioutil.ReadFile(s.location)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	values, err := s.decode(data)
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("%s: %v", s.location, err)
+	}
+	s.modified = info.ModTime()
+	return nonNil(values), s.modified, nil
+}
+
+func (s *WorkloadSource) loadHTTP(client *http.Client) (map[string]interface{}, time.Time, error) {
+	resp, err := client.Get(s.location)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, time.Time{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	values, err := s.decode(data)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return nonNil(values), time.Now(), nil
+}
+
+// nonNil tells an empty document from an unchanged one.
+func nonNil(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return map[string]interface{}{}
+	}
+	return values
+}
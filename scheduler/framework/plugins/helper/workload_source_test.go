@@ -0,0 +1,196 @@
+package helper
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func decodeStrings(data []byte) (map[string]interface{}, error) {
+	var doc map[string]string
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(doc))
+	for k, v := range doc {
+		values[k] = v
+	}
+	return values, nil
+}
+
+func replicaSetPod(name, replicaSet, hash string) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{"pod-template-hash": hash},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: replicaSet, Controller: &controller},
+			},
+		},
+	}
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	if err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) { return cond(), nil }); err != nil {
+		t.Fatalf("waiting for %s: %v", what, err)
+	}
+}
+
+func TestFileWorkloadSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "workload-source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "data.json")
+	if err := ioutil.WriteFile(path, []byte(`{"default/web": "deployment", "default/web-1": "pod"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileWorkloadSource(filepath.Join(dir, "missing.json"), time.Minute, decodeStrings); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+	s, err := NewFileWorkloadSource(path, 0, decodeStrings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !s.HasSynced() {
+		t.Errorf("expected the file to be loaded on creation")
+	}
+	for name, want := range map[string]interface{}{"web-1": "pod", "web-2": "deployment"} {
+		if v, ok := s.Get(replicaSetPod(name, "web-5d8f", "5d8f")); !ok || v != want {
+			t.Errorf("Get(%s) = %v, %v; want %v", name, v, ok, want)
+		}
+	}
+	if _, ok := s.Get(replicaSetPod("api-1", "api-7c9b", "7c9b")); ok {
+		t.Errorf("expected no data for another workload")
+	}
+
+	// Invalid content is ignored, the last data is kept.
+	if err := ioutil.WriteFile(path, []byte(`{`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	s.Get(replicaSetPod("web-1", "web-5d8f", "5d8f"))
+	waitFor(t, "the invalid file to be read", func() bool {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		return !s.loading && s.checked.After(later.Add(-time.Minute))
+	})
+	if v, ok := s.Get(replicaSetPod("web-1", "web-5d8f", "5d8f")); !ok || v != "pod" {
+		t.Errorf("expected the last data to be kept, got %v, %v", v, ok)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"default/web": "updated"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later = later.Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the file to be reloaded", func() bool {
+		v, _ := s.Get(replicaSetPod("web-1", "web-5d8f", "5d8f"))
+		return v == "updated"
+	})
+}
+
+func TestHTTPWorkloadSourceNeverBlocks(t *testing.T) {
+	release := make(chan struct{})
+	var requests, status int32 = 0, http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			<-release
+		}
+		if code := atomic.LoadInt32(&status); code != http.StatusOK {
+			w.WriteHeader(int(code))
+			return
+		}
+		w.Write([]byte(`{"default/web": "deployment"}`))
+	}))
+	defer server.Close()
+
+	s, err := NewHTTPWorkloadSource(server.URL, 0, decodeStrings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := replicaSetPod("web-1", "web-5d8f", "5d8f")
+	// The first answer is held back by the server: Get must not wait for it.
+	done := make(chan struct{})
+	go func() {
+		if _, ok := s.Get(pod); ok {
+			t.Errorf("expected no data before the first answer")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("Get blocked on the endpoint")
+	}
+	if s.HasSynced() {
+		t.Errorf("expected the source not to be synced before the first answer")
+	}
+	close(release)
+	waitFor(t, "the first answer", s.HasSynced)
+	if v, ok := s.Get(pod); !ok || v != "deployment" {
+		t.Errorf("Get = %v, %v; want deployment", v, ok)
+	}
+
+	// Failed reloads keep the last data.
+	atomic.StoreInt32(&status, http.StatusInternalServerError)
+	seen := atomic.LoadInt32(&requests)
+	waitFor(t, "a failed reload", func() bool {
+		s.Get(pod)
+		return atomic.LoadInt32(&requests) > seen+1
+	})
+	if v, ok := s.Get(pod); !ok || v != "deployment" {
+		t.Errorf("expected the last data to be kept, got %v, %v", v, ok)
+	}
+}
+
+func TestWorkloadSourceGetKey(t *testing.T) {
+	var loads int32
+	s := NewWorkloadSource("test", 0, func() (map[string]interface{}, error) {
+		if atomic.AddInt32(&loads, 1) > 1 {
+			return nil, errors.New("unavailable")
+		}
+		return map[string]interface{}{"node-1": 42}, nil
+	})
+	start := time.Now()
+	waitFor(t, "the first load", s.HasSynced)
+	v, loaded, ok := s.GetKey("node-1")
+	if !ok || v != 42 {
+		t.Errorf("GetKey(node-1) = %v, %v; want 42", v, ok)
+	}
+	if loaded.Before(start) {
+		t.Errorf("got load time %v, want the time of the load", loaded)
+	}
+	if _, _, ok := s.GetKey("node-2"); ok {
+		t.Errorf("expected no data for another key")
+	}
+
+	// Failed loads keep the last data and its time.
+	waitFor(t, "a failed load", func() bool {
+		s.GetKey("node-1")
+		return atomic.LoadInt32(&loads) > 2
+	})
+	if v, got, ok := s.GetKey("node-1"); !ok || v != 42 || !got.Equal(loaded) {
+		t.Errorf("GetKey(node-1) = %v, %v, %v; want 42 loaded at %v", v, got, ok, loaded)
+	}
+}
@@ -355,6 +355,19 @@ func satisfyPodAffinity(state *preFilterState, nodeInfo *framework.NodeInfo) boo
 	return true
 }
 
+// FilterCacheable returns true if the pod has no required affinity terms and
+// no existing pod has anti-affinity terms matching it. Otherwise Filter
+// depends on the pods of the other nodes of the topology domains.
+func (pl *InterPodAffinity) FilterCacheable(cycleState *framework.CycleState, pod *v1.Pod) bool {
+	s, err := getPreFilterState(cycleState)
+	if err != nil {
+		return false
+	}
+	return len(s.podInfo.RequiredAffinityTerms) == 0 &&
+		len(s.podInfo.RequiredAntiAffinityTerms) == 0 &&
+		len(s.topologyToMatchedExistingAntiAffinityTerms) == 0
+}
+
 // Filter invoked at the filter extension point.
 // It checks if a pod can be scheduled on the specified node with pod affinity/anti-affinity configuration.
 func (pl *InterPodAffinity) Filter(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
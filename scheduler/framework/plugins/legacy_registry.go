@@ -1,6 +1,8 @@
 package plugins
 
 import (
+	"fmt"
+
 	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/imagelocality"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/interpodaffinity"
@@ -583,7 +585,49 @@ func (lr *LegacyRegistry) ProcessPriorityPolicy(policy config.PriorityPolicy, co
 	return priorityName
 }
 
+// CheckPredicatePolicy returns an error if ProcessPredicatePolicy would reject the given PredicatePolicy.
+func (lr *LegacyRegistry) CheckPredicatePolicy(policy config.PredicatePolicy) error {
+	if err := validatePredicate(policy); err != nil {
+		return err
+	}
+	predicateName := policy.Name
+	if policy.Name == "PodFitsPorts" {
+		predicateName = PodFitsHostPortsPred
+	}
+	if _, ok := lr.PredicateToConfigProducer[predicateName]; !ok && policy.Argument == nil {
+		return fmt.Errorf("predicate type not found for %q", policy.Name)
+	}
+	return nil
+}
+
+// CheckPriorityPolicy returns an error if ProcessPriorityPolicy would reject the given PriorityPolicy.
+func (lr *LegacyRegistry) CheckPriorityPolicy(policy config.PriorityPolicy) error {
+	if err := validatePriority(policy); err != nil {
+		return err
+	}
+	priorityName := policy.Name
+	if policy.Name == ServiceSpreadingPriority {
+		priorityName = SelectorSpreadPriority
+	}
+	if _, ok := lr.PriorityToConfigProducer[priorityName]; !ok && policy.Argument == nil {
+		return fmt.Errorf("priority type not found for %q", priorityName)
+	}
+	return nil
+}
+
 func validatePredicateOrDie(predicate config.PredicatePolicy) {
+	if err := validatePredicate(predicate); err != nil {
+		klog.Fatal(err)
+	}
+}
+
+func validatePriorityOrDie(priority config.PriorityPolicy) {
+	if err := validatePriority(priority); err != nil {
+		klog.Fatal(err)
+	}
+}
+
+func validatePredicate(predicate config.PredicatePolicy) error {
 	if predicate.Argument != nil {
 		numArgs := 0
 		if predicate.Argument.ServiceAffinity != nil {
@@ -593,12 +637,13 @@ func validatePredicateOrDie(predicate config.PredicatePolicy) {
 			numArgs++
 		}
 		if numArgs != 1 {
-			klog.Fatalf("Exactly 1 predicate argument is required, numArgs: %v, Predicate: %s", numArgs, predicate.Name)
+			return fmt.Errorf("exactly 1 predicate argument is required, numArgs: %v, Predicate: %s", numArgs, predicate.Name)
 		}
 	}
+	return nil
 }
 
-func validatePriorityOrDie(priority config.PriorityPolicy) {
+func validatePriority(priority config.PriorityPolicy) error {
 	if priority.Argument != nil {
 		numArgs := 0
 		if priority.Argument.ServiceAntiAffinity != nil {
@@ -611,7 +656,8 @@ func validatePriorityOrDie(priority config.PriorityPolicy) {
 			numArgs++
 		}
 		if numArgs != 1 {
-			klog.Fatalf("Exactly 1 priority argument is required, numArgs: %v, Priority: %s", numArgs, priority.Name)
+			return fmt.Errorf("exactly 1 priority argument is required, numArgs: %v, Priority: %s", numArgs, priority.Name)
 		}
 	}
+	return nil
 }
@@ -0,0 +1,76 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+)
+
+func TestCheckPredicatePolicy(t *testing.T) {
+	labels := &config.LabelsPresence{Labels: []string{"zone"}}
+	tests := []struct {
+		name    string
+		policy  config.PredicatePolicy
+		wantErr bool
+	}{
+		{name: "registered", policy: config.PredicatePolicy{Name: PodFitsResourcesPred}},
+		{name: "legacy name", policy: config.PredicatePolicy{Name: "PodFitsPorts"}},
+		{name: "unknown", policy: config.PredicatePolicy{Name: "PodFitsGPU"}, wantErr: true},
+		{
+			name:   "custom",
+			policy: config.PredicatePolicy{Name: "ZonePresent", Argument: &config.PredicateArgument{LabelsPresence: labels}},
+		},
+		{
+			name:    "custom without argument",
+			policy:  config.PredicatePolicy{Name: "ZonePresent", Argument: &config.PredicateArgument{}},
+			wantErr: true,
+		},
+		{
+			name: "custom with two arguments",
+			policy: config.PredicatePolicy{Name: "ZonePresent", Argument: &config.PredicateArgument{
+				LabelsPresence:  labels,
+				ServiceAffinity: &config.ServiceAffinity{Labels: []string{"zone"}},
+			}},
+			wantErr: true,
+		},
+	}
+	lr := NewLegacyRegistry()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := lr.CheckPredicatePolicy(test.policy); (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckPriorityPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  config.PriorityPolicy
+		wantErr bool
+	}{
+		{name: "registered", policy: config.PriorityPolicy{Name: LeastRequestedPriority, Weight: 1}},
+		{name: "legacy name", policy: config.PriorityPolicy{Name: ServiceSpreadingPriority, Weight: 1}},
+		{name: "unknown", policy: config.PriorityPolicy{Name: "GPUPriority", Weight: 1}, wantErr: true},
+		{
+			name: "custom",
+			policy: config.PriorityPolicy{Name: "ZonePreferred", Weight: 1, Argument: &config.PriorityArgument{
+				LabelPreference: &config.LabelPreference{Label: "zone", Presence: true},
+			}},
+		},
+		{
+			name:    "custom without argument",
+			policy:  config.PriorityPolicy{Name: "ZonePreferred", Weight: 1, Argument: &config.PriorityArgument{}},
+			wantErr: true,
+		},
+	}
+	lr := NewLegacyRegistry()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := lr.CheckPriorityPolicy(test.policy); (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
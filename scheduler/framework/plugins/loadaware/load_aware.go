@@ -0,0 +1,194 @@
+package loadaware
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
+	pluginhelper "github.com/turtacn/cloud-prophet/scheduler/framework/plugins/helper"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	schedutil "github.com/turtacn/cloud-prophet/scheduler/util"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "LoadAware"
+	// DefaultMeasurement is the default InfluxDB measurement of node utilisation.
+	DefaultMeasurement = "node_usage"
+	// DefaultCPUQuery is the default Prometheus query of node CPU utilisation.
+	DefaultCPUQuery = `100 * (1 - avg by (instance) (rate(node_cpu_seconds_total{mode="idle"}[5m])))`
+	// DefaultMemoryQuery is the default Prometheus query of node memory utilisation.
+	DefaultMemoryQuery = `100 * (1 - node_memory_MemAvailable_bytes / node_memory_MemTotal_bytes)`
+	// DefaultNodeLabel is the default Prometheus label naming the node of a sample.
+	DefaultNodeLabel = "instance"
+)
+
+var _ framework.ScorePlugin = &LoadAware{}
+
+// LoadAware scores nodes by their actual utilisation, read from a metrics
+// source, rather than by the requests of their pods. Nodes without recent
+// metrics are scored by their requests.
+type LoadAware struct {
+	handle framework.FrameworkHandle
+	args   *config.LoadAwareArgs
+	usage  *pluginhelper.WorkloadSource
+	now    func() time.Time
+}
+
+// DefaultArgs returns the args used when none are configured.
+func DefaultArgs() *config.LoadAwareArgs {
+	return &config.LoadAwareArgs{
+		MetricsSource:          "influxdb",
+		Address:                "http://localhost:8086",
+		Measurement:            DefaultMeasurement,
+		CPUQuery:               DefaultCPUQuery,
+		MemoryQuery:            DefaultMemoryQuery,
+		NodeLabel:              DefaultNodeLabel,
+		RefreshIntervalSeconds: 30,
+		MaxAgeSeconds:          180,
+		CPUWeight:              1,
+		MemoryWeight:           1,
+		HotThreshold:           80,
+	}
+}
+
+func getArgs(obj runtime.Object) (*config.LoadAwareArgs, error) {
+	if obj == nil {
+		return DefaultArgs(), nil
+	}
+	ptr, ok := obj.(*config.LoadAwareArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type LoadAwareArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateLoadAwareArgs(args); err != nil {
+		return nil, err
+	}
+	var source MetricsSource
+	switch args.MetricsSource {
+	case "prometheus":
+		source = NewPrometheusSource(args.Address, args.CPUQuery, args.MemoryQuery, args.NodeLabel)
+	case "file":
+		source = NewFileSource(args.MetricsFile)
+	default:
+		source, err = NewInfluxSourceFromAddress(args.Address, args.Database, args.Measurement, time.Duration(args.MaxAgeSeconds)*time.Second)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return NewWithSource(h, args, source), nil
+}
+
+// NewWithSource returns the plugin with a custom metrics source.
+func NewWithSource(h framework.FrameworkHandle, args *config.LoadAwareArgs, source MetricsSource) *LoadAware {
+	// The utilisation is read in the background so that scoring never
+	// waits for the source.
+	usage := pluginhelper.NewWorkloadSource("node utilisation", time.Duration(args.RefreshIntervalSeconds)*time.Second, func() (map[string]interface{}, error) {
+		usage, err := source.Fetch()
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, len(usage))
+		for node, u := range usage {
+			values[node] = u
+		}
+		return values, nil
+	})
+	return &LoadAware{handle: h, args: args, usage: usage, now: time.Now}
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *LoadAware) Name() string {
+	return Name
+}
+
+// Score invoked at the score extension point. The score is the idle
+// fraction of the node after placing the pod, weighted between cpu and
+// memory. Nodes at or above the hot threshold score zero.
+func (pl *LoadAware) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil || nodeInfo.Node() == nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+	usage, ok := pl.nodeUsage(nodeInfo.Node())
+	if !ok {
+		usage = requestedUsage(nodeInfo)
+	}
+	hot := float64(pl.args.HotThreshold)
+	if usage.CPU >= hot || usage.Memory >= hot {
+		return 0, nil
+	}
+
+	cpu, memory := podUsage(pod, nodeInfo)
+	load := (float64(pl.args.CPUWeight)*(usage.CPU+cpu) + float64(pl.args.MemoryWeight)*(usage.Memory+memory)) /
+		float64(pl.args.CPUWeight+pl.args.MemoryWeight)
+	if load >= 100 {
+		return 0, nil
+	}
+	if load < 0 {
+		load = 0
+	}
+	return int64((100 - load) * float64(framework.MaxNodeScore) / 100), nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *LoadAware) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
+
+// nodeUsage returns the recent utilisation of node, looked up by name and
+// then by address. Utilisation older than MaxAgeSeconds is ignored.
+func (pl *LoadAware) nodeUsage(node *v1.Node) (NodeUsage, bool) {
+	v, _, ok := pl.usage.GetKey(node.Name)
+	for i := 0; !ok && i < len(node.Status.Addresses); i++ {
+		v, _, ok = pl.usage.GetKey(node.Status.Addresses[i].Address)
+	}
+	if !ok {
+		return NodeUsage{}, false
+	}
+	u := v.(NodeUsage)
+	if pl.now().Sub(u.Time) > time.Duration(pl.args.MaxAgeSeconds)*time.Second {
+		return NodeUsage{}, false
+	}
+	return u, true
+}
+
+// requestedUsage returns the requests of the pods of nodeInfo in percent of
+// its allocatable resources.
+func requestedUsage(nodeInfo *framework.NodeInfo) NodeUsage {
+	return NodeUsage{
+		CPU:    percent(nodeInfo.NonZeroRequested.MilliCPU, nodeInfo.Allocatable.MilliCPU),
+		Memory: percent(nodeInfo.NonZeroRequested.Memory, nodeInfo.Allocatable.Memory),
+	}
+}
+
+// podUsage returns the requests of pod in percent of the allocatable
+// resources of nodeInfo.
+func podUsage(pod *v1.Pod, nodeInfo *framework.NodeInfo) (cpu, memory float64) {
+	var milliCPU, bytes int64
+	for i := range pod.Spec.Containers {
+		c, m := schedutil.GetNonzeroRequests(&pod.Spec.Containers[i].Resources.Requests)
+		milliCPU += c
+		bytes += m
+	}
+	return percent(milliCPU, nodeInfo.Allocatable.MilliCPU), percent(bytes, nodeInfo.Allocatable.Memory)
+}
+
+func percent(v, capacity int64) float64 {
+	if capacity <= 0 {
+		return 100
+	}
+	return float64(v) * 100 / float64(capacity)
+}
@@ -0,0 +1,197 @@
+package loadaware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var now = time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+
+type fakeSource struct {
+	mu    sync.Mutex
+	usage map[string]NodeUsage
+	err   error
+	calls int
+}
+
+func (s *fakeSource) Fetch() (map[string]NodeUsage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	return s.usage, s.err
+}
+
+func makeNode(name, address string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: address}},
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("10Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func makePod(name, nodeName, cpu, memory string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "c",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+	}
+}
+
+// newPlugin returns the plugin once the first read of source completed.
+func newPlugin(t *testing.T, args *config.LoadAwareArgs, source MetricsSource, pods []*v1.Pod, nodes []*v1.Node) *LoadAware {
+	snapshot := cache.NewSnapshot(pods, nodes)
+	fh, _ := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(snapshot))
+	pl := NewWithSource(fh, args, source)
+	pl.now = func() time.Time { return now }
+	if err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		return pl.usage.HasSynced(), nil
+	}); err != nil {
+		t.Fatalf("waiting for the first read: %v", err)
+	}
+	return pl
+}
+
+func TestScore(t *testing.T) {
+	nodes := []*v1.Node{
+		makeNode("idle", "10.0.0.1"),
+		makeNode("hot", "10.0.0.2"),
+		makeNode("stale", "10.0.0.3"),
+		makeNode("by-address", "10.0.0.4"),
+		makeNode("unknown", "10.0.0.5"),
+	}
+	pods := []*v1.Pod{makePod("big", "stale", "2", "5Gi")}
+	source := &fakeSource{usage: map[string]NodeUsage{
+		"idle":     {CPU: 20, Memory: 30, Time: now.Add(-time.Minute)},
+		"hot":      {CPU: 85, Memory: 10, Time: now.Add(-time.Minute)},
+		"stale":    {CPU: 1, Memory: 1, Time: now.Add(-time.Hour)},
+		"10.0.0.4": {CPU: 40, Memory: 40, Time: now},
+	}}
+	// The pod asks for a tenth of every node.
+	pod := makePod("new", "", "400m", "1Gi")
+
+	tests := []struct {
+		name       string
+		args       func(*config.LoadAwareArgs)
+		wantScores map[string]int64
+	}{
+		{
+			name: "equal weights",
+			wantScores: map[string]int64{
+				// (20+10 + 30+10) / 2 = 35% load.
+				"idle": 65,
+				// Above the hot threshold.
+				"hot": 0,
+				// Stale metrics, scored by requests: (50+10 + 50+10) / 2.
+				"stale": 40,
+				// Looked up by address: (40+10 + 40+10) / 2.
+				"by-address": 50,
+				// No metrics and no pods: (0+10 + 0+10) / 2.
+				"unknown": 90,
+			},
+		},
+		{
+			name: "cpu weighs more",
+			args: func(args *config.LoadAwareArgs) {
+				args.CPUWeight = 3
+			},
+			wantScores: map[string]int64{
+				// (3*30 + 40) / 4 = 32.5% load.
+				"idle":       67,
+				"hot":        0,
+				"stale":      40,
+				"by-address": 50,
+				"unknown":    90,
+			},
+		},
+		{
+			name: "lower hot threshold",
+			args: func(args *config.LoadAwareArgs) {
+				args.HotThreshold = 30
+			},
+			wantScores: map[string]int64{
+				"idle":       0,
+				"hot":        0,
+				"stale":      0,
+				"by-address": 0,
+				"unknown":    90,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			args := DefaultArgs()
+			if test.args != nil {
+				test.args(args)
+			}
+			pl := newPlugin(t, args, source, pods, nodes)
+			gotScores := make(map[string]int64, len(nodes))
+			for _, n := range nodes {
+				score, status := pl.Score(context.Background(), framework.NewCycleState(), pod, n.Name)
+				if !status.IsSuccess() {
+					t.Fatalf("unexpected status: %v", status)
+				}
+				gotScores[n.Name] = score
+			}
+			if !reflect.DeepEqual(gotScores, test.wantScores) {
+				t.Errorf("got scores %v, want %v", gotScores, test.wantScores)
+			}
+		})
+	}
+}
+
+func TestFailedReadKeepsLastUsage(t *testing.T) {
+	source := &fakeSource{usage: map[string]NodeUsage{"idle": {CPU: 20, Memory: 30, Time: now}}}
+	args := DefaultArgs()
+	// Every lookup reads the source again in the background.
+	args.RefreshIntervalSeconds = 0
+	node := makeNode("idle", "10.0.0.1")
+	pl := newPlugin(t, args, source, nil, []*v1.Node{node})
+
+	source.mu.Lock()
+	source.usage, source.err = nil, errors.New("unavailable")
+	source.mu.Unlock()
+	// A third read only starts once the failed second one completed.
+	if err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		if u, ok := pl.nodeUsage(node); !ok || u.CPU != 20 {
+			return false, fmt.Errorf("got usage %v, %v, want the last usage", u, ok)
+		}
+		source.mu.Lock()
+		defer source.mu.Unlock()
+		return source.calls > 2, nil
+	}); err != nil {
+		t.Fatalf("waiting for a failed read: %v", err)
+	}
+	if u, ok := pl.nodeUsage(node); !ok || u.CPU != 20 {
+		t.Errorf("expected a failed read to keep the usage, got %v, %v", u, ok)
+	}
+}
@@ -0,0 +1,247 @@
+package loadaware
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	client "github.com/influxdata/influxdb1-client/v2"
+	"github.com/turtacn/cloud-prophet/model"
+	"github.com/turtacn/cloud-prophet/profil"
+	"sigs.k8s.io/yaml"
+)
+
+// NodeUsage is the observed utilisation of a node in percent of its
+// capacity.
+type NodeUsage struct {
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+	// Time is when the utilisation was observed.
+	Time time.Time `json:"time,omitempty"`
+}
+
+// MetricsSource reads the utilisation of nodes.
+type MetricsSource interface {
+	// Fetch returns the latest utilisation of nodes, keyed by node name or
+	// address.
+	Fetch() (map[string]NodeUsage, error)
+}
+
+// NewInfluxSource returns a MetricsSource reading the last cpu and memory
+// fields of measurement, tagged by node, written within window.
+func NewInfluxSource(store profil.Store, measurement string, window time.Duration) MetricsSource {
+	return &influxSource{store: store, measurement: measurement, window: window}
+}
+
+type influxSource struct {
+	store       profil.Store
+	measurement string
+	window      time.Duration
+}
+
+type influxUsage struct {
+	Node  string    `influx:"node"`
+	Time  time.Time `influx:"time"`
+	Value float64   `influx:"value"`
+}
+
+func (s *influxSource) Fetch() (map[string]NodeUsage, error) {
+	res := make(map[string]NodeUsage)
+	// Selectors are queried one at a time: with several of them InfluxDB
+	// drops the timestamp of the points.
+	for _, field := range []string{"cpu", "memory"} {
+		cmd, err := profil.Select(profil.Last(field).As("value")).From(s.measurement).Since(s.window).GroupBy("node").Build()
+		if err != nil {
+			return nil, err
+		}
+		results, err := s.store.Query(cmd)
+		if err != nil {
+			return nil, err
+		}
+		var rows []influxUsage
+		if err := profil.DecodeSeries(results, &rows); err != nil {
+			return nil, err
+		}
+		for _, r := range rows {
+			u := res[r.Node]
+			if field == "cpu" {
+				u.CPU = r.Value
+			} else {
+				u.Memory = r.Value
+			}
+			if u.Time.IsZero() || r.Time.Before(u.Time) {
+				u.Time = r.Time
+			}
+			res[r.Node] = u
+		}
+	}
+	return res, nil
+}
+
+// NewInfluxSourceFromAddress returns an InfluxDB source connecting to
+// address with the default credentials.
+func NewInfluxSourceFromAddress(address, database, measurement string, window time.Duration) (MetricsSource, error) {
+	c, err := client.NewHTTPClient(client.HTTPConfig{
+		Addr:     address,
+		Username: model.UserName,
+		Password: model.PassWord,
+	})
+	if err != nil {
+		return nil, err
+	}
+	config := profil.DefaultConfig()
+	if database != "" {
+		config.Database = database
+	}
+	return NewInfluxSource(profil.NewStore(c, config), measurement, window), nil
+}
+
+// NewPrometheusSource returns a MetricsSource running instant queries
+// against the Prometheus HTTP API at address. Samples are keyed by the
+// value of nodeLabel, without a port.
+func NewPrometheusSource(address, cpuQuery, memoryQuery, nodeLabel string) MetricsSource {
+	return &prometheusSource{
+		address:     strings.TrimSuffix(address, "/"),
+		cpuQuery:    cpuQuery,
+		memoryQuery: memoryQuery,
+		nodeLabel:   nodeLabel,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type prometheusSource struct {
+	address     string
+	cpuQuery    string
+	memoryQuery string
+	nodeLabel   string
+	client      *http.Client
+}
+
+type prometheusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (s *prometheusSource) Fetch() (map[string]NodeUsage, error) {
+	res := make(map[string]NodeUsage)
+	for _, q := range []struct {
+		query string
+		set   func(u *NodeUsage, v float64)
+	}{
+		{s.cpuQuery, func(u *NodeUsage, v float64) { u.CPU = v }},
+		{s.memoryQuery, func(u *NodeUsage, v float64) { u.Memory = v }},
+	} {
+		if q.query == "" {
+			continue
+		}
+		if err := s.query(q.query, res, q.set); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (s *prometheusSource) query(query string, res map[string]NodeUsage, set func(u *NodeUsage, v float64)) error {
+	resp, err := s.client.Get(s.address + "/api/v1/query?" + url.Values{"query": {query}}.Encode())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("query %q: unexpected status %s", query, resp.Status)
+	}
+	var body prometheusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("decoding response of %q: %v", query, err)
+	}
+	if body.Status != "success" {
+		return fmt.Errorf("query %q failed: %s", query, body.Error)
+	}
+	if body.Data.ResultType != "vector" {
+		return fmt.Errorf("query %q returned a %s, want a vector", query, body.Data.ResultType)
+	}
+	for _, sample := range body.Data.Result {
+		node := sample.Metric[s.nodeLabel]
+		if node == "" || len(sample.Value) != 2 {
+			continue
+		}
+		if host, _, err := net.SplitHostPort(node); err == nil {
+			node = host
+		}
+		ts, ok := sample.Value[0].(float64)
+		if !ok {
+			continue
+		}
+		raw, ok := sample.Value[1].(string)
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			continue
+		}
+		u := res[node]
+		set(&u, v)
+		t := time.Unix(0, int64(ts*float64(time.Second)))
+		if u.Time.IsZero() || t.Before(u.Time) {
+			u.Time = t
+		}
+		res[node] = u
+	}
+	return nil
+}
+
+// NewFileSource returns a MetricsSource reading a YAML or JSON file of the
+// form
+//
+//	nodes:
+//	  node-1: {cpu: 42, memory: 63, time: "2020-10-01T10:00:00Z"}
+//
+// Entries without a time are as old as the file.
+func NewFileSource(path string) MetricsSource {
+	return &fileSource{path: path}
+}
+
+type fileSource struct {
+	path string
+}
+
+type usageFile struct {
+	Nodes map[string]NodeUsage `json:"nodes"`
+}
+
+func (s *fileSource) Fetch() (map[string]NodeUsage, error) {
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	var f usageFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("%s: %v", s.path, err)
+	}
+	for node, u := range f.Nodes {
+		if u.Time.IsZero() {
+			u.Time = info.ModTime()
+			f.Nodes[node] = u
+		}
+	}
+	return f.Nodes, nil
+}
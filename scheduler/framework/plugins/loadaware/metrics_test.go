@@ -0,0 +1,138 @@
+package loadaware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusSource(t *testing.T) {
+	responses := map[string]string{
+		"cpu": `{"status": "success", "data": {"resultType": "vector", "result": [
+			{"metric": {"instance": "10.0.0.1:9100"}, "value": [1601546400, "42.5"]},
+			{"metric": {"instance": "node-2"}, "value": [1601546400, "10"]},
+			{"metric": {"job": "node"}, "value": [1601546400, "99"]},
+			{"metric": {"instance": "node-3"}, "value": [1601546400, "NaN?"]}
+		]}}`,
+		"memory": `{"status": "success", "data": {"resultType": "vector", "result": [
+			{"metric": {"instance": "10.0.0.1:9100"}, "value": [1601546340, "63"]}
+		]}}`,
+		"matrix": `{"status": "success", "data": {"resultType": "matrix", "result": []}}`,
+		"failed": `{"status": "error", "error": "bad query"}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(responses[r.URL.Query().Get("query")]))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		cpuQuery    string
+		memoryQuery string
+		want        map[string]NodeUsage
+		wantErr     bool
+	}{
+		{
+			name:        "cpu and memory",
+			cpuQuery:    "cpu",
+			memoryQuery: "memory",
+			want: map[string]NodeUsage{
+				// The port is stripped and the oldest sample wins.
+				"10.0.0.1": {CPU: 42.5, Memory: 63, Time: time.Unix(1601546340, 0)},
+				"node-2":   {CPU: 10, Time: time.Unix(1601546400, 0)},
+			},
+		},
+		{
+			name:     "memory query disabled",
+			cpuQuery: "cpu",
+			want: map[string]NodeUsage{
+				"10.0.0.1": {CPU: 42.5, Time: time.Unix(1601546400, 0)},
+				"node-2":   {CPU: 10, Time: time.Unix(1601546400, 0)},
+			},
+		},
+		{
+			name:     "not a vector",
+			cpuQuery: "matrix",
+			wantErr:  true,
+		},
+		{
+			name:     "failed query",
+			cpuQuery: "failed",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NewPrometheusSource(server.URL+"/", test.cpuQuery, test.memoryQuery, "instance").Fetch()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if !test.wantErr && !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPrometheusSourceUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "no healthy upstream", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	_, err := NewPrometheusSource(server.URL, "cpu", "memory", "instance").Fetch()
+	if err == nil || !strings.Contains(err.Error(), "503 Service Unavailable") {
+		t.Errorf("got error %v, want the status of the answer", err)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "loadaware")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "usage.yaml")
+	if err := ioutil.WriteFile(path, []byte(`
+nodes:
+  node-1: {cpu: 42, memory: 63, time: "2020-10-01T10:00:00Z"}
+  10.0.0.2: {cpu: 5, memory: 7}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	modified := time.Date(2020, 10, 1, 9, 0, 0, 0, time.UTC)
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := NewFileSource(path).Fetch()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]NodeUsage{
+		"node-1": {CPU: 42, Memory: 63, Time: time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)},
+		// Entries without a time are as old as the file.
+		"10.0.0.2": {CPU: 5, Memory: 7, Time: modified},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for node, w := range want {
+		if g := got[node]; g.CPU != w.CPU || g.Memory != w.Memory || !g.Time.Equal(w.Time) {
+			t.Errorf("node %s: got %v, want %v", node, g, w)
+		}
+	}
+
+	if _, err := NewFileSource(filepath.Join(dir, "missing.yaml")).Fetch(); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
@@ -17,6 +17,8 @@ type NodeAffinity struct {
 
 var _ framework.FilterPlugin = &NodeAffinity{}
 var _ framework.ScorePlugin = &NodeAffinity{}
+var _ framework.EnqueueExtensions = &NodeAffinity{}
+var _ framework.CacheableFilterPlugin = &NodeAffinity{}
 
 const (
 	// Name is the name of the plugin used in the plugin registry and configurations.
@@ -31,6 +33,20 @@ func (pl *NodeAffinity) Name() string {
 	return Name
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *NodeAffinity) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeLabel},
+	}
+}
+
+// FilterCacheable returns true: the result of Filter only depends on the
+// node selector and affinity of the pod and the labels of the node.
+func (pl *NodeAffinity) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return true
+}
+
 // Filter invoked at the filter extension point.
 func (pl *NodeAffinity) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
 	node := nodeInfo.Node()
@@ -0,0 +1,150 @@
+package nodehealth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "NodeHealth"
+
+	// ErrReasonRisk is the Filter reason status when the failure risk of the node is critical.
+	ErrReasonRisk = "node(s) had a critical failure risk"
+)
+
+var _ framework.FilterPlugin = &NodeHealth{}
+var _ framework.ScorePlugin = &NodeHealth{}
+var _ framework.EnqueueExtensions = &NodeHealth{}
+
+// NodeHealth keeps pods off nodes likely to fail, as predicted by the
+// remedy controller. Nodes whose failure risk reaches the critical threshold
+// are filtered out, others score proportionally to 100 minus their risk.
+// Risks older than MaxAgeSeconds, and missing ones, count as StaleRisk.
+type NodeHealth struct {
+	handle framework.FrameworkHandle
+	args   *config.NodeHealthArgs
+	source RiskSource
+	now    func() time.Time
+}
+
+// DefaultArgs returns the args used when none are configured.
+func DefaultArgs() *config.NodeHealthArgs {
+	return &config.NodeHealthArgs{
+		RiskSource:        "annotation",
+		RiskTTLSeconds:    30,
+		MaxAgeSeconds:     600,
+		StaleRisk:         0,
+		CriticalThreshold: 90,
+	}
+}
+
+func getArgs(obj runtime.Object) (*config.NodeHealthArgs, error) {
+	if obj == nil {
+		return DefaultArgs(), nil
+	}
+	ptr, ok := obj.(*config.NodeHealthArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type NodeHealthArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateNodeHealthArgs(args); err != nil {
+		return nil, err
+	}
+	ttl := time.Duration(args.RiskTTLSeconds) * time.Second
+	var source RiskSource
+	switch args.RiskSource {
+	case "file":
+		source, err = NewFileSource(args.RiskFile, ttl)
+	case "http":
+		source, err = NewHTTPSource(args.RiskURL, ttl)
+	default:
+		source = NewAnnotationSource()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewWithSource(h, args, source), nil
+}
+
+// NewWithSource returns the plugin with a custom risk source.
+func NewWithSource(h framework.FrameworkHandle, args *config.NodeHealthArgs, source RiskSource) *NodeHealth {
+	return &NodeHealth{handle: h, args: args, source: source, now: time.Now}
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *NodeHealth) Name() string {
+	return Name
+}
+
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable. Risk annotations are not node
+// scheduling properties, so lowered risks are only noticed when the
+// unschedulable pods are moved periodically.
+func (pl *NodeHealth) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Node, ActionType: framework.Add},
+	}
+}
+
+// risk returns the failure risk of node, StaleRisk if it is unknown or
+// older than MaxAgeSeconds.
+func (pl *NodeHealth) risk(node *v1.Node) float64 {
+	r, ok := pl.source.Risk(node)
+	if !ok {
+		return float64(pl.args.StaleRisk)
+	}
+	if age := pl.now().Sub(r.Time); !r.Time.IsZero() && age > time.Duration(pl.args.MaxAgeSeconds)*time.Second {
+		klog.V(5).Infof("Risk of node %s is stale: predicted %v ago", node.Name, age)
+		return float64(pl.args.StaleRisk)
+	}
+	return r.Value
+}
+
+// Filter invoked at the filter extension point.
+func (pl *NodeHealth) Filter(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if nodeInfo.Node() == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+	if pl.risk(nodeInfo.Node()) >= float64(pl.args.CriticalThreshold) {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonRisk)
+	}
+	return nil
+}
+
+// Score invoked at the score extension point.
+func (pl *NodeHealth) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil || nodeInfo.Node() == nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+	risk := pl.risk(nodeInfo.Node())
+	if risk >= 100 {
+		return 0, nil
+	}
+	if risk < 0 {
+		risk = 0
+	}
+	return int64((100 - risk) * float64(framework.MaxNodeScore) / 100), nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *NodeHealth) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
@@ -0,0 +1,173 @@
+package nodehealth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var now = time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+
+func makeNode(name string, annotations map[string]string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: annotations}}
+}
+
+func risk(value string, predicted time.Time) map[string]string {
+	return map[string]string{RiskAnnotation: value, RiskTimeAnnotation: predicted.Format(time.RFC3339)}
+}
+
+func TestNodeHealth(t *testing.T) {
+	args := DefaultArgs()
+	staleArgs := DefaultArgs()
+	staleArgs.StaleRisk = 40
+
+	tests := []struct {
+		name       string
+		args       *config.NodeHealthArgs
+		node       *v1.Node
+		wantFilter framework.Code
+		wantScore  int64
+	}{
+		{
+			name:      "low risk",
+			args:      args,
+			node:      makeNode("n", risk("20", now.Add(-time.Minute))),
+			wantScore: 80,
+		},
+		{
+			name:      "risk without time",
+			args:      args,
+			node:      makeNode("n", map[string]string{RiskAnnotation: "35.5"}),
+			wantScore: 64,
+		},
+		{
+			name:       "critical risk",
+			args:       args,
+			node:       makeNode("n", risk("95", now)),
+			wantFilter: framework.UnschedulableAndUnresolvable,
+			wantScore:  5,
+		},
+		{
+			name:       "risk at the critical threshold",
+			args:       args,
+			node:       makeNode("n", risk("90", now)),
+			wantFilter: framework.UnschedulableAndUnresolvable,
+			wantScore:  10,
+		},
+		{
+			name:       "risk above 100",
+			args:       args,
+			node:       makeNode("n", risk("120", now)),
+			wantFilter: framework.UnschedulableAndUnresolvable,
+			wantScore:  0,
+		},
+		{
+			name:      "negative risk",
+			args:      args,
+			node:      makeNode("n", risk("-5", now)),
+			wantScore: 100,
+		},
+		{
+			name:      "unknown risk",
+			args:      args,
+			node:      makeNode("n", nil),
+			wantScore: 100,
+		},
+		{
+			name:      "unknown risk of a configured stale risk",
+			args:      staleArgs,
+			node:      makeNode("n", nil),
+			wantScore: 60,
+		},
+		{
+			name:      "stale critical risk",
+			args:      staleArgs,
+			node:      makeNode("n", risk("95", now.Add(-time.Hour))),
+			wantScore: 60,
+		},
+		{
+			name:      "invalid risk",
+			args:      staleArgs,
+			node:      makeNode("n", map[string]string{RiskAnnotation: "high"}),
+			wantScore: 60,
+		},
+		{
+			name:      "invalid time",
+			args:      staleArgs,
+			node:      makeNode("n", map[string]string{RiskAnnotation: "95", RiskTimeAnnotation: "yesterday"}),
+			wantScore: 60,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fh, err := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(cache.NewSnapshot(nil, []*v1.Node{test.node})))
+			if err != nil {
+				t.Fatal(err)
+			}
+			pl := NewWithSource(fh, test.args, NewAnnotationSource())
+			pl.now = func() time.Time { return now }
+
+			nodeInfo := framework.NewNodeInfo()
+			nodeInfo.SetNode(test.node)
+			status := pl.Filter(context.Background(), nil, &v1.Pod{}, nodeInfo)
+			if status.Code() != test.wantFilter {
+				t.Errorf("got filter status %v, want code %v", status, test.wantFilter)
+			}
+			if status != nil && status.Reasons()[0] != ErrReasonRisk {
+				t.Errorf("got reasons %v, want %q", status.Reasons(), ErrReasonRisk)
+			}
+			score, status := pl.Score(context.Background(), nil, &v1.Pod{}, test.node.Name)
+			if !status.IsSuccess() {
+				t.Fatalf("unexpected score status: %v", status)
+			}
+			if score != test.wantScore {
+				t.Errorf("got score %d, want %d", score, test.wantScore)
+			}
+		})
+	}
+}
+
+func TestUnknownNode(t *testing.T) {
+	fh, err := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(cache.NewSnapshot(nil, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl := NewWithSource(fh, DefaultArgs(), NewAnnotationSource())
+	if status := pl.Filter(context.Background(), nil, &v1.Pod{}, framework.NewNodeInfo()); status.Code() != framework.Error {
+		t.Errorf("got filter status %v, want an error", status)
+	}
+	if _, status := pl.Score(context.Background(), nil, &v1.Pod{}, "n"); status.Code() != framework.Error {
+		t.Errorf("got score status %v, want an error", status)
+	}
+}
+
+func TestNew(t *testing.T) {
+	fh, err := runtime.NewFramework(nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(nil, fh); err != nil {
+		t.Errorf("unexpected error with the default args: %v", err)
+	}
+	if _, err := New(&config.NodeResourcesFitArgs{}, fh); err == nil {
+		t.Errorf("expected an error for args of another plugin")
+	}
+	args := DefaultArgs()
+	args.RiskSource = "file"
+	args.RiskFile = "/nonexistent/risks.yaml"
+	if _, err := New(args, fh); err == nil {
+		t.Errorf("expected an error for a missing risk file")
+	}
+	args = DefaultArgs()
+	args.CriticalThreshold = 101
+	if _, err := New(args, fh); err == nil {
+		t.Errorf("expected an error for invalid args")
+	}
+}
@@ -0,0 +1,121 @@
+package nodehealth
+
+import (
+	"strconv"
+	"time"
+
+	pluginhelper "github.com/turtacn/cloud-prophet/scheduler/framework/plugins/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// RiskAnnotation holds the predicted failure risk of a node, from 0 to
+	// 100, e.g. written by the remedy controller.
+	RiskAnnotation = "prophet.io/failure-risk"
+	// RiskTimeAnnotation holds the RFC 3339 time the risk was predicted at.
+	RiskTimeAnnotation = "prophet.io/failure-risk-time"
+)
+
+// Risk is the predicted failure risk of a node.
+type Risk struct {
+	// Value from 0 to 100.
+	Value float64 `json:"risk"`
+	// Time is when the risk was predicted, zero if unknown.
+	Time time.Time `json:"time,omitempty"`
+}
+
+// RiskSource returns the failure risks of nodes.
+type RiskSource interface {
+	// Risk returns the risk of node, false if there is none.
+	Risk(node *v1.Node) (Risk, bool)
+}
+
+// NewAnnotationSource returns a RiskSource reading the risk annotations of
+// nodes. Risks without a time annotation never get stale.
+func NewAnnotationSource() RiskSource {
+	return annotationSource{}
+}
+
+type annotationSource struct{}
+
+func (annotationSource) Risk(node *v1.Node) (Risk, bool) {
+	value, ok := node.Annotations[RiskAnnotation]
+	if !ok {
+		return Risk{}, false
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		klog.V(4).Infof("Ignoring risk of node %s: invalid %s %q: %v", node.Name, RiskAnnotation, value, err)
+		return Risk{}, false
+	}
+	r := Risk{Value: v}
+	if t, ok := node.Annotations[RiskTimeAnnotation]; ok {
+		if r.Time, err = time.Parse(time.RFC3339, t); err != nil {
+			klog.V(4).Infof("Ignoring risk of node %s: invalid %s %q: %v", node.Name, RiskTimeAnnotation, t, err)
+			return Risk{}, false
+		}
+	}
+	return r, true
+}
+
+// RiskFile is the content of a risk file, and the answer of the risk
+// endpoint.
+type RiskFile struct {
+	// Nodes are keyed by node name.
+	Nodes map[string]Risk `json:"nodes"`
+}
+
+// NewFileSource returns a RiskSource reading a YAML or JSON RiskFile,
+// reloaded in the background when modified at most every ttl. Risks
+// without a time are as old as the file.
+func NewFileSource(path string, ttl time.Duration) (RiskSource, error) {
+	source, err := pluginhelper.NewFileWorkloadSource(path, ttl, decodeRiskFile)
+	if err != nil {
+		return nil, err
+	}
+	return &keyedSource{source: source}, nil
+}
+
+// NewHTTPSource returns a RiskSource getting a JSON RiskFile from endpoint
+// every ttl. The endpoint is read in the background, so that scheduling
+// never waits for it; failed reads keep the last risks, which get stale.
+// Risks without a time are as old as the answer.
+func NewHTTPSource(endpoint string, ttl time.Duration) (RiskSource, error) {
+	source, err := pluginhelper.NewHTTPWorkloadSource(endpoint, ttl, decodeRiskFile)
+	if err != nil {
+		return nil, err
+	}
+	return &keyedSource{source: source}, nil
+}
+
+// keyedSource looks up the risks of a background-refreshed source by node
+// name.
+type keyedSource struct {
+	source *pluginhelper.WorkloadSource
+}
+
+func (s *keyedSource) Risk(node *v1.Node) (Risk, bool) {
+	v, loaded, ok := s.source.GetKey(node.Name)
+	if !ok {
+		return Risk{}, false
+	}
+	r := v.(Risk)
+	if r.Time.IsZero() {
+		r.Time = loaded
+	}
+	return r, true
+}
+
+func decodeRiskFile(data []byte) (map[string]interface{}, error) {
+	file := &RiskFile{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(file.Nodes))
+	for node, r := range file.Nodes {
+		values[node] = r
+	}
+	return values, nil
+}
@@ -0,0 +1,227 @@
+package nodehealth
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestAnnotationSource(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        Risk
+		wantOK      bool
+	}{
+		{
+			name:        "risk and time",
+			annotations: risk("42", now),
+			want:        Risk{Value: 42, Time: now},
+			wantOK:      true,
+		},
+		{
+			name:        "risk without time",
+			annotations: map[string]string{RiskAnnotation: "7.5"},
+			want:        Risk{Value: 7.5},
+			wantOK:      true,
+		},
+		{
+			name: "no risk",
+		},
+		{
+			name:        "invalid risk",
+			annotations: map[string]string{RiskAnnotation: "high"},
+		},
+		{
+			name:        "invalid time",
+			annotations: map[string]string{RiskAnnotation: "42", RiskTimeAnnotation: "yesterday"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := NewAnnotationSource().Risk(makeNode("n", test.annotations))
+			if ok != test.wantOK || got.Value != test.want.Value || !got.Time.Equal(test.want.Time) {
+				t.Errorf("got %+v, %v, want %+v, %v", got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, path, content string, modified time.Time) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodehealth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "risks.yaml")
+
+	if _, err := NewFileSource(path, 0); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+	writeFile(t, path, `nodes: [`, now)
+	if _, err := NewFileSource(path, 0); err == nil {
+		t.Errorf("expected an error for an invalid file")
+	}
+
+	writeFile(t, path, `
+nodes:
+  a: {risk: 20}
+  b: {risk: 95, time: "2020-10-01T09:00:00Z"}
+`, now)
+	s, err := NewFileSource(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := s.Risk(makeNode("a", nil)); !ok || r.Value != 20 || !r.Time.Equal(now) {
+		t.Errorf("got risk %+v, %v of a, want 20 as old as the file", r, ok)
+	}
+	if r, ok := s.Risk(makeNode("b", nil)); !ok || r.Value != 95 || !r.Time.Equal(now.Add(-time.Hour)) {
+		t.Errorf("got risk %+v, %v of b, want 95 predicted an hour earlier", r, ok)
+	}
+	if r, ok := s.Risk(makeNode("c", nil)); ok {
+		t.Errorf("got risk %+v of c, want none", r)
+	}
+
+	// The modified file is reloaded in the background, an invalid one
+	// keeps the last risks.
+	writeFile(t, path, `nodes: {c: {risk: 50}}`, now.Add(time.Minute))
+	if r := waitRisk(t, s, "c", 50); !r.Time.Equal(now.Add(time.Minute)) {
+		t.Errorf("got risk time %v of c, want the time of the file", r.Time)
+	}
+	if _, ok := s.Risk(makeNode("a", nil)); ok {
+		t.Errorf("expected the risk of a to leave with the reload")
+	}
+	writeFile(t, path, `nodes: [`, now.Add(2*time.Minute))
+	for i := 0; i < 10; i++ {
+		if r, ok := s.Risk(makeNode("c", nil)); !ok || r.Value != 50 {
+			t.Fatalf("got risk %+v, %v of c, want 50 kept", r, ok)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFileSourceTTL(t *testing.T) {
+	dir, err := ioutil.TempDir("", "nodehealth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "risks.yaml")
+
+	writeFile(t, path, `nodes: {a: {risk: 20}}`, now)
+	s, err := NewFileSource(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, `nodes: {a: {risk: 80}}`, now.Add(time.Minute))
+	if r, _ := s.Risk(makeNode("a", nil)); r.Value != 20 {
+		t.Errorf("got risk %v of a, want 20 until the ttl passed", r.Value)
+	}
+}
+
+// riskServer answers the risks set by set, or the status set by fail.
+type riskServer struct {
+	mu     sync.Mutex
+	body   string
+	status int
+}
+
+func (s *riskServer) set(body string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.body, s.status = body, http.StatusOK
+}
+
+func (s *riskServer) fail(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = status
+}
+
+func (s *riskServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.WriteHeader(s.status)
+	w.Write([]byte(s.body))
+}
+
+// waitRisk waits until the risk of node is value.
+func waitRisk(t *testing.T, s RiskSource, node string, value float64) Risk {
+	var r Risk
+	if err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		r, _ = s.Risk(makeNode(node, nil))
+		return r.Value == value, nil
+	}); err != nil {
+		t.Fatalf("got risk %+v of %s, want %v", r, node, value)
+	}
+	return r
+}
+
+func TestHTTPSource(t *testing.T) {
+	rs := &riskServer{}
+	rs.set(`{"nodes": {"a": {"risk": 20}, "b": {"risk": 95, "time": "2020-10-01T09:00:00Z"}}}`)
+	server := httptest.NewServer(rs)
+	defer server.Close()
+
+	start := time.Now()
+	s, err := NewHTTPSource(server.URL, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := waitRisk(t, s, "a", 20); r.Time.Before(start) {
+		t.Errorf("got risk time %v, want the time of the answer", r.Time)
+	}
+	if r, ok := s.Risk(makeNode("b", nil)); !ok || !r.Time.Equal(now.Add(-time.Hour)) {
+		t.Errorf("got risk %+v, %v of b, want 95 predicted an hour earlier", r, ok)
+	}
+
+	rs.set(`{"nodes": {"a": {"risk": 60}}}`)
+	waitRisk(t, s, "a", 60)
+
+	// Failed reads keep the last risks.
+	rs.fail(http.StatusInternalServerError)
+	for i := 0; i < 10; i++ {
+		if r, _ := s.Risk(makeNode("a", nil)); r.Value != 60 {
+			t.Fatalf("got risk %v of a, want 60 kept", r.Value)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	rs.set(`{"nodes": {"a": {"risk": 70}}}`)
+	waitRisk(t, s, "a", 70)
+}
+
+func TestHTTPSourceInvalidURL(t *testing.T) {
+	if _, err := NewHTTPSource("http://[::1", time.Second); err == nil {
+		t.Errorf("expected an error for an invalid url")
+	}
+}
+
+func TestHTTPSourceNoRisks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	s, err := NewHTTPSource(server.URL, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := s.Risk(makeNode("a", nil)); ok {
+		t.Errorf("got risk %+v of a, want none", r)
+	}
+}
@@ -53,12 +53,28 @@ type NodeLabel struct {
 
 var _ framework.FilterPlugin = &NodeLabel{}
 var _ framework.ScorePlugin = &NodeLabel{}
+var _ framework.EnqueueExtensions = &NodeLabel{}
+var _ framework.CacheableFilterPlugin = &NodeLabel{}
 
 // Name returns name of the plugin. It is used in logs, etc.
 func (pl *NodeLabel) Name() string {
 	return Name
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *NodeLabel) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeLabel},
+	}
+}
+
+// FilterCacheable returns true: the result of Filter only depends on the
+// labels of the node.
+func (pl *NodeLabel) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return true
+}
+
 // Filter invoked at the filter extension point.
 // It checks whether all of the specified labels exists on a node or not, regardless of their value
 //
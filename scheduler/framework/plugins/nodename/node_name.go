@@ -12,6 +12,8 @@ import (
 type NodeName struct{}
 
 var _ framework.FilterPlugin = &NodeName{}
+var _ framework.EnqueueExtensions = &NodeName{}
+var _ framework.CacheableFilterPlugin = &NodeName{}
 
 const (
 	// Name is the name of the plugin used in the plugin registry and configurations.
@@ -26,6 +28,20 @@ func (pl *NodeName) Name() string {
 	return Name
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *NodeName) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Node, ActionType: framework.Add},
+	}
+}
+
+// FilterCacheable returns true: the result of Filter only depends on the
+// node name of the pod.
+func (pl *NodeName) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return true
+}
+
 // Filter invoked at the filter extension point.
 func (pl *NodeName) Filter(ctx context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
 	if nodeInfo.Node() == nil {
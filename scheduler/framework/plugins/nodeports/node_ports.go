@@ -14,6 +14,8 @@ type NodePorts struct{}
 
 var _ framework.PreFilterPlugin = &NodePorts{}
 var _ framework.FilterPlugin = &NodePorts{}
+var _ framework.EnqueueExtensions = &NodePorts{}
+var _ framework.CacheableFilterPlugin = &NodePorts{}
 
 const (
 	// Name is the name of the plugin used in the plugin registry and configurations.
@@ -40,6 +42,21 @@ func (pl *NodePorts) Name() string {
 	return Name
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *NodePorts) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Pod, ActionType: framework.Delete},
+		{Resource: framework.Node, ActionType: framework.Add},
+	}
+}
+
+// FilterCacheable returns true: the result of Filter only depends on the
+// ports of the pod and of the pods on the node.
+func (pl *NodePorts) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return true
+}
+
 // getContainerPorts returns the used host ports of Pods: if 'port' was used, a 'port:true' pair
 // will be in the result; but it does not resolve port conflict.
 func getContainerPorts(pods ...*v1.Pod) []*v1.ContainerPort {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	pluginhelper "github.com/turtacn/cloud-prophet/scheduler/framework/plugins/helper"
 	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -15,6 +16,8 @@ import (
 
 var _ framework.PreFilterPlugin = &Fit{}
 var _ framework.FilterPlugin = &Fit{}
+var _ framework.EnqueueExtensions = &Fit{}
+var _ framework.CacheableFilterPlugin = &Fit{}
 
 const (
 	// FitName is the name of the plugin used in the plugin registry and configurations.
@@ -46,6 +49,22 @@ func (f *Fit) Name() string {
 	return FitName
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (f *Fit) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Pod, ActionType: framework.Delete},
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeAllocatable},
+	}
+}
+
+// FilterCacheable returns true: the result of Filter only depends on the
+// requests and owner of the pod and the pods and allocatable resources of
+// the node.
+func (f *Fit) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return true
+}
+
 func validateFitArgs(args config.NodeResourcesFitArgs) error {
 	var allErrs field.ErrorList
 	resPath := field.NewPath("ignoredResources")
@@ -172,6 +191,13 @@ func (f *Fit) Filter(ctx context.Context, cycleState *framework.CycleState, pod
 		return framework.NewStatus(framework.Error, err.Error())
 	}
 
+	// The capacity reserved for the workload of the pod is available to it.
+	if reserved := pluginhelper.OwnedReservation(nodeInfo, pod); reserved != nil {
+		nodeInfo = nodeInfo.Clone()
+		if err := nodeInfo.RemovePod(reserved); err != nil {
+			return framework.NewStatus(framework.Error, err.Error())
+		}
+	}
 	insufficientResources := fitsRequest(s, nodeInfo, f.ignoredResources, f.ignoredResourceGroups)
 
 	if len(insufficientResources) != 0 {
@@ -0,0 +1,266 @@
+package overcommit
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "UsageOvercommit"
+	// DefaultNodePoolLabel is the default NodePoolLabel.
+	DefaultNodePoolLabel = "prophet.io/node-pool"
+
+	// preFilterStateKey is the key in CycleState to UsageOvercommit pre-computed data.
+	preFilterStateKey = "PreFilter" + Name
+
+	// ErrReasonCPU is the Filter reason status when the predicted CPU usage does not fit.
+	ErrReasonCPU = "node(s) had insufficient predicted cpu"
+	// ErrReasonMemory is the Filter reason status when the predicted memory usage does not fit.
+	ErrReasonMemory = "node(s) had insufficient predicted memory"
+)
+
+var _ framework.PreFilterPlugin = &UsageOvercommit{}
+var _ framework.FilterPlugin = &UsageOvercommit{}
+var _ framework.ScorePlugin = &UsageOvercommit{}
+var _ framework.EnqueueExtensions = &UsageOvercommit{}
+
+// UsageOvercommit admits pods onto a node as long as the predicted peak
+// usage of its pods stays under its allocatable resources times the
+// overcommit ratio of its node pool. Pods without a prediction count with
+// their requests. It is meant to replace the cpu and memory checks of
+// NodeResourcesFit on overcommitted pools.
+type UsageOvercommit struct {
+	handle    framework.FrameworkHandle
+	args      *config.UsageOvercommitArgs
+	predictor Predictor
+	ratios    map[string]config.OvercommitRatio
+}
+
+// preFilterState computed at PreFilter and used at Filter and Score.
+type preFilterState struct {
+	// Prediction of the incoming pod.
+	Prediction
+
+	mu sync.Mutex
+	// used is the predicted usage of the pods of each node, computed once
+	// per cycle by the first Filter or Score of the node.
+	used map[string]nodeUsage
+}
+
+// nodeUsage is the predicted usage of the pods of a NodeInfo generation.
+// Preemption filters copies of NodeInfo with pods removed, which have
+// another generation.
+type nodeUsage struct {
+	generation int64
+	used       Prediction
+}
+
+// Clone the prefilter state.
+func (s *preFilterState) Clone() framework.StateData {
+	return s
+}
+
+// DefaultArgs returns the args used when none are configured.
+func DefaultArgs() *config.UsageOvercommitArgs {
+	return &config.UsageOvercommitArgs{
+		PredictionSource:     "annotation",
+		PredictionTTLSeconds: 60,
+		NodePoolLabel:        DefaultNodePoolLabel,
+		DefaultRatio:         config.OvercommitRatio{CPU: 100, Memory: 100},
+	}
+}
+
+func getArgs(obj runtime.Object) (*config.UsageOvercommitArgs, error) {
+	if obj == nil {
+		return DefaultArgs(), nil
+	}
+	ptr, ok := obj.(*config.UsageOvercommitArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type UsageOvercommitArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateUsageOvercommitArgs(args); err != nil {
+		return nil, err
+	}
+	ttl := time.Duration(args.PredictionTTLSeconds) * time.Second
+	var predictor Predictor
+	switch args.PredictionSource {
+	case "file":
+		predictor, err = NewFilePredictor(args.PredictionFile, ttl)
+	case "http":
+		predictor, err = NewHTTPPredictor(args.PredictionURL, ttl)
+	default:
+		predictor = NewAnnotationPredictor()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewWithPredictor(h, args, predictor), nil
+}
+
+// NewWithPredictor returns the plugin with a custom prediction source.
+func NewWithPredictor(h framework.FrameworkHandle, args *config.UsageOvercommitArgs, predictor Predictor) *UsageOvercommit {
+	ratios := make(map[string]config.OvercommitRatio, len(args.PoolRatios))
+	for _, r := range args.PoolRatios {
+		ratios[r.Pool] = r
+	}
+	return &UsageOvercommit{handle: h, args: args, predictor: predictor, ratios: ratios}
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *UsageOvercommit) Name() string {
+	return Name
+}
+
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *UsageOvercommit) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Pod, ActionType: framework.Delete},
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeAllocatable | framework.UpdateNodeLabel},
+	}
+}
+
+func (pl *UsageOvercommit) predict(pod *v1.Pod) Prediction {
+	if p, ok := pl.predictor.Predict(pod); ok {
+		return p
+	}
+	return podRequests(pod)
+}
+
+// ratio returns the overcommit ratio of node.
+func (pl *UsageOvercommit) ratio(node *v1.Node) config.OvercommitRatio {
+	if r, ok := pl.ratios[node.Labels[pl.args.NodePoolLabel]]; ok {
+		return r
+	}
+	return pl.args.DefaultRatio
+}
+
+// podsUsage returns the predicted usage of the pods of nodeInfo.
+func (pl *UsageOvercommit) podsUsage(nodeInfo *framework.NodeInfo) (used Prediction) {
+	for _, p := range nodeInfo.Pods {
+		prediction := pl.predict(p.Pod)
+		used.MilliCPU += prediction.MilliCPU
+		used.Memory += prediction.Memory
+	}
+	return used
+}
+
+// usage returns the predicted usage of the pods of nodeInfo, computed once
+// per cycle in s, and its overcommitted capacity.
+func (pl *UsageOvercommit) usage(s *preFilterState, nodeInfo *framework.NodeInfo) (used, capacity Prediction) {
+	name := nodeInfo.Node().Name
+	s.mu.Lock()
+	u, ok := s.used[name]
+	s.mu.Unlock()
+	if ok && u.generation == nodeInfo.Generation {
+		used = u.used
+	} else {
+		used = pl.podsUsage(nodeInfo)
+		s.mu.Lock()
+		s.used[name] = nodeUsage{generation: nodeInfo.Generation, used: used}
+		s.mu.Unlock()
+	}
+	r := pl.ratio(nodeInfo.Node())
+	capacity.MilliCPU = nodeInfo.Allocatable.MilliCPU * int64(r.CPU) / 100
+	capacity.Memory = nodeInfo.Allocatable.Memory * int64(r.Memory) / 100
+	return used, capacity
+}
+
+func (pl *UsageOvercommit) newPreFilterState(pod *v1.Pod) *preFilterState {
+	return &preFilterState{Prediction: pl.predict(pod), used: make(map[string]nodeUsage)}
+}
+
+// PreFilter invoked at the prefilter extension point.
+func (pl *UsageOvercommit) PreFilter(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod) *framework.Status {
+	cycleState.Write(preFilterStateKey, pl.newPreFilterState(pod))
+	return nil
+}
+
+// PreFilterExtensions returns prefilter extensions, pod add and remove.
+func (pl *UsageOvercommit) PreFilterExtensions() framework.PreFilterExtensions {
+	return nil
+}
+
+func getPreFilterState(cycleState *framework.CycleState) (*preFilterState, error) {
+	c, err := cycleState.Read(preFilterStateKey)
+	if err != nil {
+		// preFilterState doesn't exist, likely PreFilter wasn't invoked.
+		return nil, fmt.Errorf("error reading %q from cycleState: %v", preFilterStateKey, err)
+	}
+	s, ok := c.(*preFilterState)
+	if !ok {
+		return nil, fmt.Errorf("%+v  convert to overcommit.preFilterState error", c)
+	}
+	return s, nil
+}
+
+// Filter invoked at the filter extension point.
+func (pl *UsageOvercommit) Filter(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if nodeInfo.Node() == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+	s, err := getPreFilterState(cycleState)
+	if err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	used, capacity := pl.usage(s, nodeInfo)
+	var reasons []string
+	if s.MilliCPU > 0 && used.MilliCPU+s.MilliCPU > capacity.MilliCPU {
+		reasons = append(reasons, ErrReasonCPU)
+	}
+	if s.Memory > 0 && used.Memory+s.Memory > capacity.Memory {
+		reasons = append(reasons, ErrReasonMemory)
+	}
+	if len(reasons) > 0 {
+		return framework.NewStatus(framework.Unschedulable, reasons...)
+	}
+	return nil
+}
+
+// Score invoked at the score extension point. Nodes with more predicted
+// headroom after placing the pod score higher.
+func (pl *UsageOvercommit) Score(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil || nodeInfo.Node() == nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+	s, err := getPreFilterState(cycleState)
+	if err != nil {
+		// The plugin may be enabled at Score only.
+		s = pl.newPreFilterState(pod)
+	}
+	used, capacity := pl.usage(s, nodeInfo)
+	cpu := headroomScore(used.MilliCPU+s.MilliCPU, capacity.MilliCPU)
+	memory := headroomScore(used.Memory+s.Memory, capacity.Memory)
+	return (cpu + memory) / 2, nil
+}
+
+func headroomScore(used, capacity int64) int64 {
+	if capacity <= 0 || used >= capacity {
+		return 0
+	}
+	return (capacity - used) * framework.MaxNodeScore / capacity
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *UsageOvercommit) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
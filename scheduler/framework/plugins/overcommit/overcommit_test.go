@@ -0,0 +1,105 @@
+package overcommit
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// countingPredictor predicts the requests of pods and counts the calls.
+type countingPredictor struct {
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (p *countingPredictor) Predict(pod *v1.Pod) (Prediction, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls[pod.Name]++
+	return podRequests(pod), true
+}
+
+// placedPod returns a pod without prediction annotations on nodeName.
+func placedPod(name, nodeName, cpu string) *v1.Pod {
+	pod := makePod(name, nil, cpu, "0")
+	pod.UID = types.UID(name)
+	pod.Spec.NodeName = nodeName
+	return pod
+}
+
+func makeNode(name, cpu string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+			},
+		},
+	}
+}
+
+// TestUsageOncePerCycle checks that the pods of a node are predicted once
+// per cycle, and again for a NodeInfo with pods removed by preemption.
+func TestUsageOncePerCycle(t *testing.T) {
+	nodes := []*v1.Node{makeNode("a", "4"), makeNode("b", "4")}
+	pods := []*v1.Pod{placedPod("a-1", "a", "1"), placedPod("a-2", "a", "2"), placedPod("b-1", "b", "1")}
+	fh, err := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(cache.NewSnapshot(pods, nodes)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	predictor := &countingPredictor{calls: make(map[string]int)}
+	pl := NewWithPredictor(fh, DefaultArgs(), predictor)
+
+	pod := placedPod("web", "", "2")
+	state := framework.NewCycleState()
+	if status := pl.PreFilter(context.Background(), state, pod); !status.IsSuccess() {
+		t.Fatal(status)
+	}
+	want := map[string]framework.Code{"a": framework.Unschedulable, "b": framework.Success}
+	for _, node := range nodes {
+		nodeInfo, err := fh.SnapshotSharedLister().NodeInfos().Get(node.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status := pl.Filter(context.Background(), state, pod, nodeInfo); status.Code() != want[node.Name] {
+			t.Errorf("node %s: got status %v, want %v", node.Name, status, want[node.Name])
+		}
+	}
+	score, status := pl.Score(context.Background(), state, pod, "b")
+	if !status.IsSuccess() {
+		t.Fatal(status)
+	}
+	// 3 of 4 cpus and 0 of 8Gi used.
+	if score != 62 {
+		t.Errorf("got score %d, want 62", score)
+	}
+	for _, name := range []string{"web", "a-1", "a-2", "b-1"} {
+		if predictor.calls[name] != 1 {
+			t.Errorf("got %d predictions of %s, want 1", predictor.calls[name], name)
+		}
+	}
+
+	nodeInfo, err := fh.SnapshotSharedLister().NodeInfos().Get("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeInfo = nodeInfo.Clone()
+	if err := nodeInfo.RemovePod(pods[1]); err != nil {
+		t.Fatal(err)
+	}
+	if status := pl.Filter(context.Background(), state, pod, nodeInfo); !status.IsSuccess() {
+		t.Errorf("got status %v once a-2 is removed, want success", status)
+	}
+	if predictor.calls["a-1"] != 2 {
+		t.Errorf("got %d predictions of a-1, want 2", predictor.calls["a-1"])
+	}
+}
@@ -0,0 +1,157 @@
+package overcommit
+
+import (
+	"fmt"
+	"time"
+
+	pluginhelper "github.com/turtacn/cloud-prophet/scheduler/framework/plugins/helper"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// PredictedCPUAnnotation holds the predicted peak CPU usage of a pod, e.g.
+	// the sum of the recommender targets of its containers.
+	PredictedCPUAnnotation = "prophet.io/predicted-cpu"
+	// PredictedMemoryAnnotation holds the predicted peak memory usage of a pod.
+	PredictedMemoryAnnotation = "prophet.io/predicted-memory"
+)
+
+// Prediction is the predicted peak usage of a pod.
+type Prediction struct {
+	MilliCPU int64
+	Memory   int64
+}
+
+// Predictor predicts the peak usage of pods.
+type Predictor interface {
+	// Predict returns the prediction for pod, false if there is none.
+	Predict(pod *v1.Pod) (Prediction, bool)
+}
+
+// NewAnnotationPredictor returns a Predictor reading the predicted usage
+// annotations of pods.
+func NewAnnotationPredictor() Predictor {
+	return annotationPredictor{}
+}
+
+type annotationPredictor struct{}
+
+func (annotationPredictor) Predict(pod *v1.Pod) (Prediction, bool) {
+	cpu, hasCPU := pod.Annotations[PredictedCPUAnnotation]
+	memory, hasMemory := pod.Annotations[PredictedMemoryAnnotation]
+	if !hasCPU && !hasMemory {
+		return Prediction{}, false
+	}
+	p, err := parsePrediction(cpu, memory, pod)
+	if err != nil {
+		klog.V(4).Infof("Ignoring prediction of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return Prediction{}, false
+	}
+	return p, true
+}
+
+// parsePrediction parses CPU and memory quantities. A missing quantity is
+// taken from the requests of pod.
+func parsePrediction(cpu, memory string, pod *v1.Pod) (Prediction, error) {
+	p := podRequests(pod)
+	if cpu != "" {
+		q, err := resource.ParseQuantity(cpu)
+		if err != nil {
+			return Prediction{}, fmt.Errorf("invalid cpu %q: %v", cpu, err)
+		}
+		p.MilliCPU = q.MilliValue()
+	}
+	if memory != "" {
+		q, err := resource.ParseQuantity(memory)
+		if err != nil {
+			return Prediction{}, fmt.Errorf("invalid memory %q: %v", memory, err)
+		}
+		p.Memory = q.Value()
+	}
+	return p, nil
+}
+
+// podRequests returns the requests of pod as a prediction.
+func podRequests(pod *v1.Pod) Prediction {
+	var p Prediction
+	if pod == nil {
+		return p
+	}
+	for i := range pod.Spec.Containers {
+		requests := pod.Spec.Containers[i].Resources.Requests
+		p.MilliCPU += requests.Cpu().MilliValue()
+		p.Memory += requests.Memory().Value()
+	}
+	return p
+}
+
+// PredictionFile is the content of a prediction file, and the answer of
+// the prediction endpoint.
+type PredictionFile struct {
+	// Predictions are keyed by "namespace/name" of a pod, its controller or
+	// its deployment.
+	Predictions map[string]PredictionQuantities `json:"predictions"`
+}
+
+// PredictionQuantities are the predicted CPU and memory quantities of a
+// workload. A missing quantity is taken from the requests of a pod.
+type PredictionQuantities struct {
+	CPU    string `json:"cpu,omitempty"`
+	Memory string `json:"memory,omitempty"`
+}
+
+func decodePredictionFile(data []byte) (map[string]interface{}, error) {
+	file := &PredictionFile{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{}, len(file.Predictions))
+	for k, v := range file.Predictions {
+		values[k] = v
+	}
+	return values, nil
+}
+
+// NewFilePredictor returns a Predictor reading a YAML or JSON
+// PredictionFile, reloaded in the background when modified at most every
+// ttl.
+func NewFilePredictor(path string, ttl time.Duration) (Predictor, error) {
+	source, err := pluginhelper.NewFileWorkloadSource(path, ttl, decodePredictionFile)
+	if err != nil {
+		return nil, err
+	}
+	return &sourcePredictor{source: source}, nil
+}
+
+// NewHTTPPredictor returns a Predictor getting a JSON PredictionFile from
+// endpoint every ttl. The endpoint is read in the background, so that
+// scheduling never waits for it; pods count with their requests until the
+// first answer.
+func NewHTTPPredictor(endpoint string, ttl time.Duration) (Predictor, error) {
+	source, err := pluginhelper.NewHTTPWorkloadSource(endpoint, ttl, decodePredictionFile)
+	if err != nil {
+		return nil, err
+	}
+	return &sourcePredictor{source: source}, nil
+}
+
+type sourcePredictor struct {
+	source *pluginhelper.WorkloadSource
+}
+
+func (p *sourcePredictor) Predict(pod *v1.Pod) (Prediction, bool) {
+	v, ok := p.source.Get(pod)
+	if !ok {
+		return Prediction{}, false
+	}
+	q := v.(PredictionQuantities)
+	prediction, err := parsePrediction(q.CPU, q.Memory, pod)
+	if err != nil {
+		klog.V(4).Infof("Ignoring prediction of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return Prediction{}, false
+	}
+	return prediction, true
+}
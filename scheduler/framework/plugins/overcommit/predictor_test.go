@@ -0,0 +1,152 @@
+package overcommit
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func makePod(name string, annotations map[string]string, cpu, memory string) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "default",
+			Name:        name,
+			Annotations: annotations,
+			Labels:      map[string]string{"pod-template-hash": "5d8f"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "web-5d8f", Controller: &controller},
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{{
+				Name: "web",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse(memory),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestAnnotationPredictor(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        Prediction
+		wantOK      bool
+	}{
+		{
+			name: "no annotations",
+		},
+		{
+			name:        "both quantities",
+			annotations: map[string]string{PredictedCPUAnnotation: "250m", PredictedMemoryAnnotation: "256Mi"},
+			want:        Prediction{MilliCPU: 250, Memory: 256 << 20},
+			wantOK:      true,
+		},
+		{
+			name:        "missing memory falls back to requests",
+			annotations: map[string]string{PredictedCPUAnnotation: "1500m"},
+			want:        Prediction{MilliCPU: 1500, Memory: 1 << 30},
+			wantOK:      true,
+		},
+		{
+			name:        "invalid quantity",
+			annotations: map[string]string{PredictedCPUAnnotation: "lots"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := NewAnnotationPredictor().Predict(makePod("web-1", test.annotations, "1", "1Gi"))
+			if ok != test.wantOK || got != test.want {
+				t.Errorf("Predict() = %+v, %v; want %+v, %v", got, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestFilePredictor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "overcommit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "predictions.yaml")
+	if err := ioutil.WriteFile(path, []byte(`
+predictions:
+  default/web:
+    cpu: 300m
+  default/web-2:
+    cpu: "2"
+    memory: 2Gi
+  default/web-3:
+    cpu: bogus
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := NewFilePredictor(path, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		pod    string
+		want   Prediction
+		wantOK bool
+	}{
+		{pod: "web-1", want: Prediction{MilliCPU: 300, Memory: 1 << 30}, wantOK: true},
+		{pod: "web-2", want: Prediction{MilliCPU: 2000, Memory: 2 << 30}, wantOK: true},
+		{pod: "web-3"},
+	}
+	for _, test := range tests {
+		got, ok := p.Predict(makePod(test.pod, nil, "1", "1Gi"))
+		if ok != test.wantOK || got != test.want {
+			t.Errorf("Predict(%s) = %+v, %v; want %+v, %v", test.pod, got, ok, test.want, test.wantOK)
+		}
+	}
+
+	if _, err := NewFilePredictor(filepath.Join(dir, "missing.yaml"), time.Hour); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestHTTPPredictor(t *testing.T) {
+	requests := make(chan struct{}, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+		w.Write([]byte(`{"predictions": {"default/web": {"cpu": "500m", "memory": "128Mi"}}}`))
+	}))
+	defer server.Close()
+
+	p, err := NewHTTPPredictor(server.URL, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := makePod("web-1", nil, "1", "1Gi")
+	want := Prediction{MilliCPU: 500, Memory: 128 << 20}
+	if err := wait.Poll(10*time.Millisecond, 5*time.Second, func() (bool, error) {
+		got, ok := p.Predict(pod)
+		return ok && got == want, nil
+	}); err != nil {
+		t.Fatalf("waiting for the prediction: %v", err)
+	}
+	// Predictions are read from memory until the ttl expires.
+	for i := 0; i < 5; i++ {
+		p.Predict(pod)
+	}
+	if n := len(requests); n != 1 {
+		t.Errorf("expected a single request, got %d", n)
+	}
+}
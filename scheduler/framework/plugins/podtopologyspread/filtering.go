@@ -256,6 +256,17 @@ func (pl *PodTopologySpread) calPreFilterState(pod *v1.Pod) (*preFilterState, er
 	return &s, nil
 }
 
+// FilterCacheable returns true if no hard constraint applies to the pod.
+// Otherwise Filter depends on the pods of the other nodes of the topology
+// domains.
+func (pl *PodTopologySpread) FilterCacheable(cycleState *framework.CycleState, pod *v1.Pod) bool {
+	s, err := getPreFilterState(cycleState)
+	if err != nil {
+		return false
+	}
+	return len(s.Constraints) == 0
+}
+
 // Filter invoked at the filter extension point.
 func (pl *PodTopologySpread) Filter(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
 	node := nodeInfo.Node()
@@ -1,20 +1,26 @@
 package plugins
 
 import (
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/complementarity"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/defaultbinder"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/defaultpreemption"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/expression"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/imagelocality"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/interpodaffinity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/loadaware"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeaffinity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodehealth"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodelabel"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodename"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeports"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/noderesources"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeunschedulable"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/overcommit"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/podtopologyspread"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/queuesort"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/selectorspread"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/serviceaffinity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/spotinstance"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/tainttoleration"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/volumerestrictions"
 	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/volumezone"
@@ -47,5 +53,12 @@ func NewInTreeRegistry() runtime.Registry {
 		queuesort.Name:                             queuesort.New,
 		defaultbinder.Name:                         defaultbinder.New,
 		defaultpreemption.Name:                     defaultpreemption.New,
+		spotinstance.FilterName:                    spotinstance.NewFilter,
+		spotinstance.ScoreName:                     spotinstance.NewScore,
+		overcommit.Name:                            overcommit.New,
+		loadaware.Name:                             loadaware.New,
+		complementarity.Name:                       complementarity.New,
+		nodehealth.Name:                            nodehealth.New,
+		expression.Name:                            expression.New,
 	}
 }
@@ -0,0 +1,44 @@
+package plugins
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/scheme"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/v1beta1"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/complementarity"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/drf"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/expression"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/loadaware"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodehealth"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/overcommit"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/spotinstance"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// TestDefaultArgs checks that the args plugins default to without args match
+// the defaults of the v1beta1 API, which the framework decodes args from.
+func TestDefaultArgs(t *testing.T) {
+	tests := map[string]runtime.Object{
+		spotinstance.FilterName: spotinstance.DefaultArgs(),
+		spotinstance.ScoreName:  spotinstance.DefaultArgs(),
+		overcommit.Name:         overcommit.DefaultArgs(),
+		loadaware.Name:          loadaware.DefaultArgs(),
+		nodehealth.Name:         nodehealth.DefaultArgs(),
+		complementarity.Name:    complementarity.DefaultArgs(),
+		drf.Name:                drf.DefaultArgs(),
+		expression.Name:         expression.DefaultArgs(),
+	}
+	for name, want := range tests {
+		t.Run(name, func(t *testing.T) {
+			gvk := v1beta1.SchemeGroupVersion.WithKind(v1beta1.PluginArgsKind(name))
+			got, _, err := scheme.Codecs.UniversalDecoder().Decode(nil, &gvk, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("unexpected default args (-DefaultArgs, +v1beta1):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package reservation
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/yaml"
+)
+
+// File is the content of a reservation file.
+type File struct {
+	Reservations []Spec `json:"reservations"`
+}
+
+// Spec describes reservations of capacity for the pods of a workload.
+type Spec struct {
+	// Name identifies the reservations, named Name-0, Name-1... if
+	// Replicas is greater than 1.
+	Name string `json:"name"`
+	// Owner is the "namespace/name" key of the workload allowed to use the
+	// capacity, e.g. "default/web" for a deployment.
+	Owner string `json:"owner"`
+	// Replicas is the number of pods to hold capacity for, 1 by default.
+	Replicas int `json:"replicas,omitempty"`
+	// Resources is the capacity held for one pod.
+	Resources v1.ResourceList `json:"resources"`
+	// NodeSelector restricts the nodes the capacity may be held on.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// TTL is how long the capacity is held once placed.
+	TTL metav1.Duration `json:"ttl"`
+}
+
+// Reservations returns the reservations described by s, expiring ttl after
+// now.
+func (s *Spec) Reservations(now time.Time) []*framework.Reservation {
+	if s.Replicas <= 1 {
+		return []*framework.Reservation{s.reservation(s.Name, now)}
+	}
+	res := make([]*framework.Reservation, s.Replicas)
+	for i := range res {
+		res[i] = s.reservation(fmt.Sprintf("%s-%d", s.Name, i), now)
+	}
+	return res
+}
+
+func (s *Spec) reservation(name string, now time.Time) *framework.Reservation {
+	return &framework.Reservation{
+		Name:         name,
+		Owner:        s.Owner,
+		Resources:    s.Resources,
+		NodeSelector: s.NodeSelector,
+		Expires:      now.Add(s.TTL.Duration),
+	}
+}
+
+func (s *Spec) validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("reservation without name")
+	}
+	if parts := strings.Split(s.Owner, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("reservation %s: owner %q is not namespace/name", s.Name, s.Owner)
+	}
+	if len(s.Resources) == 0 {
+		return fmt.Errorf("reservation %s: no resources", s.Name)
+	}
+	if s.TTL.Duration <= 0 {
+		return fmt.Errorf("reservation %s: ttl must be positive", s.Name)
+	}
+	return nil
+}
+
+// LoadFile reads a YAML or JSON File.
+func LoadFile(path string) ([]Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	file := &File{}
+	if err := yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	names := make(map[string]bool)
+	for i := range file.Reservations {
+		s := &file.Reservations[i]
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		if names[s.Name] {
+			return nil, fmt.Errorf("%s: duplicate reservation %s", path, s.Name)
+		}
+		names[s.Name] = true
+	}
+	return file.Reservations, nil
+}
+
+// Syncer places the reservations of a file in a Store. A reservation is
+// placed once: after it is consumed or expires it is only placed again if
+// it is removed from the file and added back. Reservations removed from the
+// file are released.
+type Syncer struct {
+	path  string
+	store Store
+	// onRelease is called after reservations are released or left the
+	// store, e.g. to retry the pods they kept out.
+	onRelease func()
+
+	// placed are the names of the reservations placed from the file.
+	placed map[string]bool
+	// held are the names of the reservations in the store at the last sync.
+	held map[string]bool
+}
+
+// NewSyncer returns a Syncer of the reservations of the file at path.
+func NewSyncer(path string, store Store, onRelease func()) *Syncer {
+	return &Syncer{
+		path:      path,
+		store:     store,
+		onRelease: onRelease,
+		placed:    make(map[string]bool),
+		held:      make(map[string]bool),
+	}
+}
+
+// Sync places the new reservations of the file and releases the removed
+// ones. The store is left as is if the file cannot be read.
+func (s *Syncer) Sync() {
+	s.sync(time.Now())
+}
+
+func (s *Syncer) sync(now time.Time) {
+	specs, err := LoadFile(s.path)
+	if err != nil {
+		klog.Errorf("Keeping reservations of %s: %v", s.path, err)
+		return
+	}
+	desired := make(map[string]*framework.Reservation)
+	for i := range specs {
+		for _, r := range specs[i].Reservations(now) {
+			desired[r.Name] = r
+		}
+	}
+	held := make(map[string]bool)
+	for _, r := range s.store.Reservations() {
+		held[r.Name] = true
+	}
+
+	released := false
+	for name := range s.placed {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		delete(s.placed, name)
+		if held[name] {
+			if _, err := s.store.RemoveReservation(name); err != nil {
+				klog.Errorf("Releasing reservation %s failed: %v", name, err)
+				continue
+			}
+			delete(held, name)
+			released = true
+		}
+	}
+	// Reservations gone from the store expired or were consumed.
+	for name := range s.held {
+		if !held[name] && s.placed[name] {
+			released = true
+		}
+	}
+	for name, r := range desired {
+		if s.placed[name] {
+			continue
+		}
+		if err := s.store.AddReservation(r); err != nil {
+			klog.V(3).Infof("Cannot place reservation %s yet: %v", name, err)
+			continue
+		}
+		s.placed[name] = true
+		held[name] = true
+	}
+	s.held = held
+	if released && s.onRelease != nil {
+		s.onRelease()
+	}
+}
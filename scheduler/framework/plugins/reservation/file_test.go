@@ -0,0 +1,313 @@
+package reservation
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var now = time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+
+// fakeStore places reservations on node, except the full ones.
+type fakeStore struct {
+	reservations map[string]*framework.Reservation
+	full         map[string]bool
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{reservations: make(map[string]*framework.Reservation), full: make(map[string]bool)}
+}
+
+func (s *fakeStore) AddReservation(r *framework.Reservation) error {
+	if s.full[r.Name] {
+		return fmt.Errorf("no node has room for reservation %v", r.Name)
+	}
+	if _, ok := s.reservations[r.Name]; ok {
+		return fmt.Errorf("reservation %v is in the cache", r.Name)
+	}
+	r = r.DeepCopy()
+	if r.NodeName == "" {
+		r.NodeName = "node"
+	}
+	s.reservations[r.Name] = r
+	return nil
+}
+
+func (s *fakeStore) RemoveReservation(name string) (*framework.Reservation, error) {
+	r, ok := s.reservations[name]
+	if !ok {
+		return nil, fmt.Errorf("reservation %v is not found", name)
+	}
+	delete(s.reservations, name)
+	return r, nil
+}
+
+func (s *fakeStore) Reservations() []*framework.Reservation {
+	res := make([]*framework.Reservation, 0, len(s.reservations))
+	for _, r := range s.reservations {
+		res = append(res, r.DeepCopy())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func (s *fakeStore) names() []string {
+	var names []string
+	for _, r := range s.Reservations() {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func writeFile(t *testing.T, path, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reservation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		content string
+		want    []Spec
+		wantErr bool
+	}{
+		{
+			name: "reservations",
+			content: `
+reservations:
+- name: web-rollout
+  owner: default/web
+  replicas: 3
+  resources: {cpu: "1", memory: 2Gi}
+  nodeSelector: {zone: a}
+  ttl: 10m
+- name: api
+  owner: prod/api
+  resources: {cpu: 500m}
+  ttl: 30s
+`,
+			want: []Spec{
+				{
+					Name:         "web-rollout",
+					Owner:        "default/web",
+					Replicas:     3,
+					Resources:    v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("2Gi")},
+					NodeSelector: map[string]string{"zone": "a"},
+					TTL:          metav1.Duration{Duration: 10 * time.Minute},
+				},
+				{
+					Name:      "api",
+					Owner:     "prod/api",
+					Resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m")},
+					TTL:       metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+		},
+		{
+			name:    "json",
+			content: `{"reservations": [{"name": "api", "owner": "prod/api", "resources": {"cpu": "1"}, "ttl": "1m"}]}`,
+			want: []Spec{{
+				Name:      "api",
+				Owner:     "prod/api",
+				Resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+				TTL:       metav1.Duration{Duration: time.Minute},
+			}},
+		},
+		{
+			name:    "no reservations",
+			content: `reservations: []`,
+			want:    []Spec{},
+		},
+		{
+			name:    "without name",
+			content: `{"reservations": [{"owner": "prod/api", "resources": {"cpu": "1"}, "ttl": "1m"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "owner without namespace",
+			content: `{"reservations": [{"name": "api", "owner": "api", "resources": {"cpu": "1"}, "ttl": "1m"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "without resources",
+			content: `{"reservations": [{"name": "api", "owner": "prod/api", "ttl": "1m"}]}`,
+			wantErr: true,
+		},
+		{
+			name:    "without ttl",
+			content: `{"reservations": [{"name": "api", "owner": "prod/api", "resources": {"cpu": "1"}}]}`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			content: `{"reservations": [
+				{"name": "api", "owner": "prod/api", "resources": {"cpu": "1"}, "ttl": "1m"},
+				{"name": "api", "owner": "prod/web", "resources": {"cpu": "1"}, "ttl": "1m"}
+			]}`,
+			wantErr: true,
+		},
+		{
+			name:    "not yaml",
+			content: `reservations: [`,
+			wantErr: true,
+		},
+	}
+	for i, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("reservations-%d.yaml", i))
+			writeFile(t, path, test.content)
+			got, err := LoadFile(path)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if !test.wantErr && !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+
+	if _, err := LoadFile(filepath.Join(dir, "missing.yaml")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+func TestSpecReservations(t *testing.T) {
+	spec := Spec{
+		Name:      "web",
+		Owner:     "default/web",
+		Resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		TTL:       metav1.Duration{Duration: time.Minute},
+	}
+	for replicas, want := range map[int][]string{
+		0: {"web"},
+		1: {"web"},
+		3: {"web-0", "web-1", "web-2"},
+	} {
+		spec.Replicas = replicas
+		var got []string
+		for _, r := range spec.Reservations(now) {
+			got = append(got, r.Name)
+			if r.Owner != spec.Owner || !r.Expires.Equal(now.Add(time.Minute)) || r.NodeName != "" {
+				t.Errorf("replicas %d: got reservation %+v", replicas, r)
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("replicas %d: got %v, want %v", replicas, got, want)
+		}
+	}
+}
+
+func TestSyncer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reservation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "reservations.yaml")
+	spec := func(name string, replicas int) string {
+		return fmt.Sprintf(`{"name": %q, "owner": "default/%s", "replicas": %d, "resources": {"cpu": "1"}, "ttl": "10m"}`, name, name, replicas)
+	}
+	file := func(specs ...string) string {
+		content := `{"reservations": [`
+		for i, s := range specs {
+			if i > 0 {
+				content += ","
+			}
+			content += s
+		}
+		return content + `]}`
+	}
+
+	store := newFakeStore()
+	released := 0
+	s := NewSyncer(path, store, func() { released++ })
+
+	steps := []struct {
+		name         string
+		file         string
+		change       func()
+		want         []string
+		wantReleased int
+	}{
+		{
+			name: "placed",
+			file: file(spec("web", 2)),
+			want: []string{"web-0", "web-1"},
+		},
+		{
+			name: "placed once",
+			file: file(spec("web", 2)),
+			want: []string{"web-0", "web-1"},
+		},
+		{
+			// web-0 was consumed or expired: it is not placed again.
+			name:         "gone from the store",
+			file:         file(spec("web", 2)),
+			change:       func() { store.RemoveReservation("web-0") },
+			want:         []string{"web-1"},
+			wantReleased: 1,
+		},
+		{
+			name:         "removed from the file",
+			file:         file(spec("api", 1)),
+			want:         []string{"api"},
+			wantReleased: 2,
+		},
+		{
+			name:         "added back to the file",
+			file:         file(spec("api", 1), spec("web", 1)),
+			want:         []string{"api", "web"},
+			wantReleased: 2,
+		},
+		{
+			name:         "no room",
+			file:         file(spec("api", 1), spec("web", 1), spec("db", 1)),
+			change:       func() { store.full["db"] = true },
+			want:         []string{"api", "web"},
+			wantReleased: 2,
+		},
+		{
+			name:         "room later",
+			file:         file(spec("api", 1), spec("web", 1), spec("db", 1)),
+			change:       func() { delete(store.full, "db") },
+			want:         []string{"api", "db", "web"},
+			wantReleased: 2,
+		},
+		{
+			name:         "unreadable file",
+			file:         `{"reservations": [`,
+			want:         []string{"api", "db", "web"},
+			wantReleased: 2,
+		},
+	}
+	for _, step := range steps {
+		writeFile(t, path, step.file)
+		if step.change != nil {
+			step.change()
+		}
+		s.sync(now)
+		if got := store.names(); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("%s: got reservations %v, want %v", step.name, got, step.want)
+		}
+		if released != step.wantReleased {
+			t.Errorf("%s: got %d releases, want %d", step.name, released, step.wantReleased)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package reservation
+
+import (
+	"context"
+
+	pluginhelper "github.com/turtacn/cloud-prophet/scheduler/framework/plugins/helper"
+	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Name is the name of the plugin used in the plugin registry and configurations.
+	Name = "Reservation"
+
+	// stateKey is the key in CycleState to the reservation consumed by the pod.
+	stateKey = "Reserve" + Name
+)
+
+// Store holds the reservations, e.g. the scheduler cache.
+type Store interface {
+	AddReservation(r *framework.Reservation) error
+	RemoveReservation(name string) (*framework.Reservation, error)
+	Reservations() []*framework.Reservation
+}
+
+var _ framework.ReservePlugin = &Reservation{}
+
+// Reservation consumes the reservation held for the workload of a pod on
+// the node it is assumed on, see framework.Reservation. NodeResourcesFit
+// already counts the reserved capacity as available to the pod; releasing
+// the reservation keeps the pod from using it twice.
+type Reservation struct {
+	handle framework.FrameworkHandle
+	store  Store
+}
+
+// consumed is the reservation consumed by the pod, restored on Unreserve.
+type consumed struct {
+	reservation *framework.Reservation
+}
+
+// Clone the consumed reservation.
+func (c *consumed) Clone() framework.StateData {
+	return c
+}
+
+// NewFactory returns the factory of the plugin consuming the reservations
+// of store.
+func NewFactory(store Store) frameworkruntime.PluginFactory {
+	return func(_ runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+		return &Reservation{handle: h, store: store}, nil
+	}
+}
+
+// Name returns name of the plugin.
+func (pl *Reservation) Name() string {
+	return Name
+}
+
+// Reserve releases the reservation of the workload of the pod on the node,
+// if any.
+func (pl *Reservation) Reserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) *framework.Status {
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil {
+		return framework.NewStatus(framework.Error, err.Error())
+	}
+	placeholder := pluginhelper.OwnedReservation(nodeInfo, pod)
+	if placeholder == nil {
+		return nil
+	}
+	r, err := pl.store.RemoveReservation(placeholder.Annotations[framework.ReservationAnnotation])
+	if err != nil {
+		// The reservation expired since the snapshot was taken; the pod
+		// fits anyway, as it is assumed already.
+		klog.V(4).Infof("Cannot consume reservation of pod %s/%s on node %s: %v", pod.Namespace, pod.Name, nodeName, err)
+		return nil
+	}
+	klog.V(3).Infof("Pod %s/%s consumed reservation %s on node %s", pod.Namespace, pod.Name, r.Name, nodeName)
+	state.Write(stateKey, &consumed{reservation: r})
+	return nil
+}
+
+// Unreserve restores the reservation consumed by the pod.
+func (pl *Reservation) Unreserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) {
+	data, err := state.Read(stateKey)
+	if err != nil {
+		return
+	}
+	state.Delete(stateKey)
+	r := data.(*consumed).reservation
+	if err := pl.store.AddReservation(r); err != nil {
+		klog.Errorf("Restoring reservation %s unreserved by pod %s/%s failed: %v", r.Name, pod.Namespace, pod.Name, err)
+	}
+}
@@ -0,0 +1,186 @@
+package reservation
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// makePod returns a pod of the deployment owner.
+func makePod(owner string) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      owner + "-5d8f-x1",
+			Labels:    map[string]string{"pod-template-hash": "5d8f"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: owner + "-5d8f", Controller: &controller},
+			},
+		},
+	}
+}
+
+// newPlugin returns the plugin consuming the reservations of store, placed
+// on node.
+func newPlugin(t *testing.T, store *fakeStore) *Reservation {
+	node := &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4")},
+		},
+	}
+	var pods []*v1.Pod
+	for _, r := range store.Reservations() {
+		pods = append(pods, r.Pod())
+	}
+	fh, err := frameworkruntime.NewFramework(nil, nil, nil, frameworkruntime.WithSnapshotSharedLister(cache.NewSnapshot(pods, []*v1.Node{node})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pl, err := NewFactory(store)(nil, fh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pl.(*Reservation)
+}
+
+func newStore(t *testing.T, reservations ...*framework.Reservation) *fakeStore {
+	store := newFakeStore()
+	for _, r := range reservations {
+		if err := store.AddReservation(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func reservation(name, owner string) *framework.Reservation {
+	return &framework.Reservation{
+		Name:      name,
+		Owner:     owner,
+		Resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+		NodeName:  "node",
+		Expires:   now,
+	}
+}
+
+func TestReserve(t *testing.T) {
+	tests := []struct {
+		name         string
+		reservations []*framework.Reservation
+		pod          *v1.Pod
+		nodeName     string
+		want         []string
+		wantConsumed string
+		wantErr      bool
+	}{
+		{
+			name:         "reservation of the deployment",
+			reservations: []*framework.Reservation{reservation("web-1", "default/web"), reservation("web-0", "default/web")},
+			pod:          makePod("web"),
+			nodeName:     "node",
+			want:         []string{"web-1"},
+			wantConsumed: "web-0",
+		},
+		{
+			name:         "reservation of the pod",
+			reservations: []*framework.Reservation{reservation("web-x1", "default/web-5d8f-x1"), reservation("api-0", "default/api")},
+			pod:          makePod("web"),
+			nodeName:     "node",
+			want:         []string{"api-0"},
+			wantConsumed: "web-x1",
+		},
+		{
+			name:         "reservation of another workload",
+			reservations: []*framework.Reservation{reservation("api-0", "default/api")},
+			pod:          makePod("web"),
+			nodeName:     "node",
+			want:         []string{"api-0"},
+		},
+		{
+			name:     "unknown node",
+			pod:      makePod("web"),
+			nodeName: "other",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := newStore(t, test.reservations...)
+			pl := newPlugin(t, store)
+			state := framework.NewCycleState()
+			status := pl.Reserve(context.Background(), state, test.pod, test.nodeName)
+			if status.IsSuccess() == test.wantErr {
+				t.Fatalf("got status %v, want error %v", status, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if got := store.names(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got reservations %v, want %v", got, test.want)
+			}
+			data, err := state.Read(stateKey)
+			if test.wantConsumed == "" {
+				if err == nil {
+					t.Errorf("got consumed reservation %v, want none", data.(*consumed).reservation.Name)
+				}
+				return
+			}
+			if err != nil || data.(*consumed).reservation.Name != test.wantConsumed {
+				t.Errorf("got consumed reservation %v (%v), want %s", data, err, test.wantConsumed)
+			}
+		})
+	}
+}
+
+// TestReserveExpired checks that a pod fits although the reservation in
+// the snapshot expired before Reserve.
+func TestReserveExpired(t *testing.T) {
+	store := newStore(t, reservation("web-0", "default/web"))
+	pl := newPlugin(t, store)
+	if _, err := store.RemoveReservation("web-0"); err != nil {
+		t.Fatal(err)
+	}
+	state := framework.NewCycleState()
+	if status := pl.Reserve(context.Background(), state, makePod("web"), "node"); !status.IsSuccess() {
+		t.Errorf("got status %v, want success", status)
+	}
+	if _, err := state.Read(stateKey); err == nil {
+		t.Errorf("expected no consumed reservation")
+	}
+}
+
+func TestUnreserve(t *testing.T) {
+	store := newStore(t, reservation("web-0", "default/web"))
+	pl := newPlugin(t, store)
+	state := framework.NewCycleState()
+	pod := makePod("web")
+	if status := pl.Reserve(context.Background(), state, pod, "node"); !status.IsSuccess() {
+		t.Fatalf("got status %v, want success", status)
+	}
+	if got := store.names(); len(got) != 0 {
+		t.Fatalf("got reservations %v, want none once consumed", got)
+	}
+
+	pl.Unreserve(context.Background(), state, pod, "node")
+	if res := store.Reservations(); len(res) != 1 || res[0].Name != "web-0" || res[0].NodeName != "node" {
+		t.Errorf("got reservations %v, want web-0 restored on node", res)
+	}
+	if _, err := state.Read(stateKey); err == nil {
+		t.Errorf("expected the consumed reservation to leave the cycle state")
+	}
+
+	// A second Unreserve has nothing to restore.
+	pl.Unreserve(context.Background(), state, pod, "node")
+	if got := store.names(); !reflect.DeepEqual(got, []string{"web-0"}) {
+		t.Errorf("got reservations %v, want web-0", got)
+	}
+}
@@ -79,12 +79,30 @@ type ServiceAffinity struct {
 var _ framework.PreFilterPlugin = &ServiceAffinity{}
 var _ framework.FilterPlugin = &ServiceAffinity{}
 var _ framework.ScorePlugin = &ServiceAffinity{}
+var _ framework.EnqueueExtensions = &ServiceAffinity{}
+var _ framework.CacheableFilterPlugin = &ServiceAffinity{}
 
 // Name returns name of the plugin. It is used in logs, etc.
 func (pl *ServiceAffinity) Name() string {
 	return Name
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *ServiceAffinity) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Pod, ActionType: framework.All},
+		{Resource: framework.Service, ActionType: framework.All},
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeLabel},
+	}
+}
+
+// FilterCacheable returns true if no affinity labels are configured. Otherwise
+// Filter depends on the nodes of the pods of the services of pod.
+func (pl *ServiceAffinity) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return len(pl.args.AffinityLabels) == 0
+}
+
 func (pl *ServiceAffinity) createPreFilterState(pod *v1.Pod) (*preFilterState, error) {
 	if pod == nil {
 		return nil, fmt.Errorf("a pod is required to calculate service affinity preFilterState")
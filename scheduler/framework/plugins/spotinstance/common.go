@@ -0,0 +1,135 @@
+package spotinstance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// AllowedAnnotation set to "false" keeps a pod off spot nodes.
+	AllowedAnnotation = "spot.prophet.io/allowed"
+	// MaxInterruptionRiskAnnotation overrides MaxInterruptionRisk for a pod.
+	MaxInterruptionRiskAnnotation = "spot.prophet.io/max-interruption-risk"
+	// OnDemandPercentageAnnotation overrides OnDemandPercentage for the
+	// workload of a pod.
+	OnDemandPercentageAnnotation = "spot.prophet.io/on-demand-percentage"
+	// DefaultInterruptionRiskLabel is the default InterruptionRiskLabel.
+	DefaultInterruptionRiskLabel = "spot.prophet.io/interruption-risk"
+)
+
+// DefaultArgs returns the args used when none are configured: the
+// lifecycle labels set by the major cloud providers, instance pools made
+// of instance type and zone, and a fifth of every workload on on-demand
+// nodes.
+func DefaultArgs() *config.SpotInstanceArgs {
+	return &config.SpotInstanceArgs{
+		LifecycleLabels: []config.LifecycleLabel{
+			{Key: "node.kubernetes.io/lifecycle", Values: []string{"spot", "preemptible"}},
+			{Key: "eks.amazonaws.com/capacityType", Values: []string{"SPOT"}},
+			{Key: "cloud.google.com/gke-preemptible", Values: []string{"true"}},
+			{Key: "cloud.google.com/gke-spot", Values: []string{"true"}},
+			{Key: "kubernetes.azure.com/scalesetpriority", Values: []string{"spot"}},
+		},
+		InterruptionRiskLabel: DefaultInterruptionRiskLabel,
+		MaxInterruptionRisk:   100,
+		InstancePoolLabels:    []string{v1.LabelInstanceTypeStable, v1.LabelZoneFailureDomainStable},
+		OnDemandPercentage:    20,
+	}
+}
+
+func getArgs(obj runtime.Object) (*config.SpotInstanceArgs, error) {
+	if obj == nil {
+		return DefaultArgs(), nil
+	}
+	ptr, ok := obj.(*config.SpotInstanceArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type SpotInstanceArgs, got %T", obj)
+	}
+	return ptr, nil
+}
+
+// classifier tells spot nodes from on-demand ones.
+type classifier struct {
+	args *config.SpotInstanceArgs
+}
+
+// isSpot returns whether node is a spot, low-priority or preemptible
+// instance.
+func (c *classifier) isSpot(node *v1.Node) bool {
+	for _, l := range c.args.LifecycleLabels {
+		value, ok := node.Labels[l.Key]
+		if !ok {
+			continue
+		}
+		if len(l.Values) == 0 {
+			return true
+		}
+		for _, v := range l.Values {
+			if v == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// risk returns the interruption risk of node in percent, 0 if unknown.
+func (c *classifier) risk(node *v1.Node) int64 {
+	if c.args.InterruptionRiskLabel == "" {
+		return 0
+	}
+	return parseRisk(node.Labels[c.args.InterruptionRiskLabel])
+}
+
+func parseRisk(s string) int64 {
+	switch strings.ToLower(s) {
+	case "", "low":
+		return 0
+	case "medium":
+		return 50
+	case "high":
+		return 90
+	}
+	v, err := strconv.ParseInt(strings.TrimSuffix(s, "%"), 10, 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+	if v > 100 {
+		return 100
+	}
+	return v
+}
+
+// pool returns the instance pool of node.
+func (c *classifier) pool(node *v1.Node) string {
+	values := make([]string, len(c.args.InstancePoolLabels))
+	for i, key := range c.args.InstancePoolLabels {
+		values[i] = node.Labels[key]
+	}
+	return strings.Join(values, "/")
+}
+
+// podPercentage returns the value of a percentage annotation of pod, or def.
+func podPercentage(pod *v1.Pod, annotation string, def int32) int64 {
+	if v, ok := pod.Annotations[annotation]; ok {
+		if p, err := strconv.ParseInt(v, 10, 32); err == nil && p >= 0 && p <= 100 {
+			return p
+		}
+	}
+	return int64(def)
+}
+
+// workload returns the UID of the controller of pod, or of pod itself.
+func workload(pod *v1.Pod) types.UID {
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		return ref.UID
+	}
+	return pod.UID
+}
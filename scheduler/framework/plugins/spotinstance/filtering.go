@@ -0,0 +1,39 @@
+package spotinstance
+
+import (
+	"context"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ErrReasonOptedOut is the Filter reason status for pods that opted out of spot instances.
+	ErrReasonOptedOut = "node(s) were spot instances the pod opted out of"
+	// ErrReasonRisk is the Filter reason status for spot instances too likely to be interrupted.
+	ErrReasonRisk = "node(s) had an interruption risk the pod didn't accept"
+)
+
+// Filter invoked at the filter extension point.
+func (pl *Filter) Filter(ctx context.Context, _ *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+	if !pl.isSpot(node) {
+		return nil
+	}
+	if pod.Annotations[AllowedAnnotation] == "false" {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonOptedOut)
+	}
+	if pl.risk(node) > podPercentage(pod, MaxInterruptionRiskAnnotation, pl.args.MaxInterruptionRisk) {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonRisk)
+	}
+	return nil
+}
+
+// FilterCacheable returns true: the result of Filter only depends on the pod
+// and the labels of the node.
+func (pl *Filter) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return true
+}
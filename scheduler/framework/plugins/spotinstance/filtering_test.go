@@ -0,0 +1,124 @@
+package spotinstance
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func makeNode(name string, labels map[string]string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func podWithAnnotations(annotations map[string]string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", Annotations: annotations}}
+}
+
+func TestFilter(t *testing.T) {
+	onDemand := makeNode("on-demand", nil)
+	spot := makeNode("spot", map[string]string{"node.kubernetes.io/lifecycle": "spot"})
+	preemptible := makeNode("preemptible", map[string]string{"cloud.google.com/gke-preemptible": "true", DefaultInterruptionRiskLabel: "high"})
+	lowRisk := makeNode("low-risk", map[string]string{"eks.amazonaws.com/capacityType": "SPOT", DefaultInterruptionRiskLabel: "10%"})
+	notSpot := makeNode("not-spot", map[string]string{"eks.amazonaws.com/capacityType": "ON_DEMAND", DefaultInterruptionRiskLabel: "high"})
+
+	tests := []struct {
+		name       string
+		args       *config.SpotInstanceArgs
+		pod        *v1.Pod
+		node       *v1.Node
+		wantStatus *framework.Status
+	}{
+		{
+			name: "on-demand node accepts pods that opted out",
+			pod:  podWithAnnotations(map[string]string{AllowedAnnotation: "false"}),
+			node: onDemand,
+		},
+		{
+			name: "on-demand lifecycle value is not spot",
+			pod:  podWithAnnotations(map[string]string{AllowedAnnotation: "false"}),
+			node: notSpot,
+		},
+		{
+			name: "spot node accepts pods by default",
+			pod:  podWithAnnotations(nil),
+			node: preemptible,
+		},
+		{
+			name:       "spot node rejects pods that opted out",
+			pod:        podWithAnnotations(map[string]string{AllowedAnnotation: "false"}),
+			node:       spot,
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonOptedOut),
+		},
+		{
+			name:       "pod annotation lowers the accepted risk",
+			pod:        podWithAnnotations(map[string]string{MaxInterruptionRiskAnnotation: "50"}),
+			node:       preemptible,
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonRisk),
+		},
+		{
+			name: "percentage risk below the accepted risk",
+			pod:  podWithAnnotations(map[string]string{MaxInterruptionRiskAnnotation: "50"}),
+			node: lowRisk,
+		},
+		{
+			name: "invalid pod annotation falls back to the args",
+			args: func() *config.SpotInstanceArgs {
+				args := DefaultArgs()
+				args.MaxInterruptionRisk = 5
+				return args
+			}(),
+			pod:        podWithAnnotations(map[string]string{MaxInterruptionRiskAnnotation: "200"}),
+			node:       lowRisk,
+			wantStatus: framework.NewStatus(framework.UnschedulableAndUnresolvable, ErrReasonRisk),
+		},
+		{
+			name:       "missing node",
+			pod:        podWithAnnotations(nil),
+			wantStatus: framework.NewStatus(framework.Error, "node not found"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var args runtime.Object
+			if test.args != nil {
+				args = test.args
+			}
+			p, err := NewFilter(args, nil)
+			if err != nil {
+				t.Fatalf("creating plugin: %v", err)
+			}
+			nodeInfo := framework.NewNodeInfo()
+			if test.node != nil {
+				nodeInfo.SetNode(test.node)
+			}
+			gotStatus := p.(*Filter).Filter(context.Background(), nil, test.pod, nodeInfo)
+			if !reflect.DeepEqual(gotStatus, test.wantStatus) {
+				t.Errorf("status does not match: %v, want: %v", gotStatus, test.wantStatus)
+			}
+		})
+	}
+}
+
+func TestParseRisk(t *testing.T) {
+	for s, want := range map[string]int64{
+		"":       0,
+		"low":    0,
+		"Medium": 50,
+		"HIGH":   90,
+		"35":     35,
+		"35%":    35,
+		"250":    100,
+		"-5":     0,
+		"bogus":  0,
+	} {
+		if got := parseRisk(s); got != want {
+			t.Errorf("parseRisk(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package spotinstance
+
+import (
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	// FilterName is the name of the filter plugin used in the plugin registry and configurations.
+	FilterName = "SpotInstanceFilter"
+	// ScoreName is the name of the score plugin used in the plugin registry and configurations.
+	ScoreName = "SpotInstanceScore"
+)
+
+var _ framework.FilterPlugin = &Filter{}
+var _ framework.EnqueueExtensions = &Filter{}
+var _ framework.CacheableFilterPlugin = &Filter{}
+var _ framework.PreScorePlugin = &Score{}
+var _ framework.ScorePlugin = &Score{}
+
+// Filter keeps pods that opted out of spot instances, or that do not
+// accept their interruption risk, off spot nodes.
+type Filter struct {
+	classifier
+}
+
+// Score keeps a share of the replicas of every workload on on-demand nodes
+// and spreads the others across spot instance pools.
+type Score struct {
+	classifier
+	handle framework.FrameworkHandle
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *Filter) Name() string {
+	return FilterName
+}
+
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *Filter) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeLabel},
+	}
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *Score) Name() string {
+	return ScoreName
+}
+
+// NewFilter initializes a new filter plugin and returns it.
+func NewFilter(plArgs runtime.Object, _ framework.FrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateSpotInstanceArgs(args); err != nil {
+		return nil, err
+	}
+	return &Filter{classifier{args: args}}, nil
+}
+
+// NewScore initializes a new score plugin and returns it.
+func NewScore(plArgs runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+	args, err := getArgs(plArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validation.ValidateSpotInstanceArgs(args); err != nil {
+		return nil, err
+	}
+	return &Score{classifier: classifier{args: args}, handle: h}, nil
+}
@@ -0,0 +1,116 @@
+package spotinstance
+
+import (
+	"context"
+	"fmt"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// preScoreStateKey is the key in CycleState to SpotInstanceScore pre-computed data for Scoring.
+const preScoreStateKey = "PreScore" + ScoreName
+
+// preScoreState computed at PreScore and used at Score.
+type preScoreState struct {
+	// needOnDemand is set if the workload has fewer replicas on on-demand
+	// nodes than it should have once the pod is placed.
+	needOnDemand bool
+	// spot is the number of replicas on spot nodes, pools their number per
+	// instance pool.
+	spot  int64
+	pools map[string]int64
+}
+
+// Clone implements the mandatory Clone interface. We don't really copy the data since
+// there is no need for that.
+func (s *preScoreState) Clone() framework.StateData {
+	return s
+}
+
+// PreScore counts the replicas of the workload of pod on on-demand nodes
+// and on every spot instance pool.
+func (pl *Score) PreScore(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodes []*v1.Node) *framework.Status {
+	nodeInfos, err := pl.handle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return framework.NewStatus(framework.Error, fmt.Sprintf("listing nodes from Snapshot: %v", err))
+	}
+	owner := workload(pod)
+	state := &preScoreState{pools: make(map[string]int64)}
+	var total, onDemand int64
+	for _, nodeInfo := range nodeInfos {
+		node := nodeInfo.Node()
+		if node == nil {
+			continue
+		}
+		var replicas int64
+		for _, p := range nodeInfo.Pods {
+			if p.Pod.Namespace == pod.Namespace && workload(p.Pod) == owner && p.Pod.DeletionTimestamp == nil {
+				replicas++
+			}
+		}
+		if replicas == 0 {
+			continue
+		}
+		total += replicas
+		if pl.isSpot(node) {
+			state.spot += replicas
+			state.pools[pl.pool(node)] += replicas
+		} else {
+			onDemand += replicas
+		}
+	}
+	percentage := podPercentage(pod, OnDemandPercentageAnnotation, pl.args.OnDemandPercentage)
+	// Round up so that a workload keeps at least one on-demand replica as
+	// soon as the percentage is positive.
+	desired := (percentage*(total+1) + 99) / 100
+	state.needOnDemand = onDemand < desired
+	cycleState.Write(preScoreStateKey, state)
+	return nil
+}
+
+func getPreScoreState(cycleState *framework.CycleState) (*preScoreState, error) {
+	c, err := cycleState.Read(preScoreStateKey)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading %q from cycleState: %v", preScoreStateKey, err)
+	}
+	s, ok := c.(*preScoreState)
+	if !ok {
+		return nil, fmt.Errorf("%+v convert to spotinstance.preScoreState error", c)
+	}
+	return s, nil
+}
+
+// Score invoked at the Score extension point. On-demand nodes score
+// MaxNodeScore while the workload needs more on-demand replicas and 0
+// afterwards. Spot nodes score the other way round, scaled down by the
+// share of the spot replicas already in their instance pool and by their
+// interruption risk.
+func (pl *Score) Score(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
+	nodeInfo, err := pl.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+	if err != nil || nodeInfo.Node() == nil {
+		return 0, framework.NewStatus(framework.Error, fmt.Sprintf("getting node %q from Snapshot: %v", nodeName, err))
+	}
+	node := nodeInfo.Node()
+	s, err := getPreScoreState(state)
+	if err != nil {
+		return 0, framework.NewStatus(framework.Error, err.Error())
+	}
+
+	if !pl.isSpot(node) {
+		if s.needOnDemand {
+			return framework.MaxNodeScore, nil
+		}
+		return 0, nil
+	}
+	if s.needOnDemand {
+		return 0, nil
+	}
+	spread := framework.MaxNodeScore * (s.spot + 1 - s.pools[pl.pool(node)]) / (s.spot + 1)
+	return spread * (100 - pl.risk(node)) / 100, nil
+}
+
+// ScoreExtensions of the Score plugin.
+func (pl *Score) ScoreExtensions() framework.ScoreExtensions {
+	return nil
+}
@@ -0,0 +1,136 @@
+package spotinstance
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func replica(name, owner, nodeName string) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: owner, UID: types.UID(owner), Controller: &controller},
+			},
+		},
+		Spec: v1.PodSpec{NodeName: nodeName},
+	}
+}
+
+func spotNode(name, zone, risk string) *v1.Node {
+	return makeNode(name, map[string]string{
+		"node.kubernetes.io/lifecycle":  "spot",
+		v1.LabelInstanceTypeStable:      "m5.large",
+		v1.LabelZoneFailureDomainStable: zone,
+		DefaultInterruptionRiskLabel:    risk,
+	})
+}
+
+func TestScore(t *testing.T) {
+	nodes := []*v1.Node{
+		makeNode("on-demand", map[string]string{v1.LabelInstanceTypeStable: "m5.large", v1.LabelZoneFailureDomainStable: "a"}),
+		spotNode("spot-a", "a", "low"),
+		spotNode("spot-b", "b", "low"),
+		spotNode("spot-c", "c", "medium"),
+	}
+	tests := []struct {
+		name       string
+		pod        *v1.Pod
+		pods       []*v1.Pod
+		wantScores map[string]int64
+	}{
+		{
+			name: "first replica goes on-demand",
+			pod:  replica("web-new", "web", ""),
+			pods: []*v1.Pod{
+				// Another workload does not count towards the on-demand share.
+				replica("api-1", "api", "on-demand"),
+			},
+			wantScores: map[string]int64{"on-demand": 100, "spot-a": 0, "spot-b": 0, "spot-c": 0},
+		},
+		{
+			name: "replicas spread across spot pools once the on-demand share is met",
+			pod:  replica("web-new", "web", ""),
+			pods: []*v1.Pod{
+				replica("web-1", "web", "on-demand"),
+				replica("web-2", "web", "spot-a"),
+				replica("web-3", "web", "spot-a"),
+			},
+			// spot-c has no replica but a medium interruption risk.
+			wantScores: map[string]int64{"on-demand": 0, "spot-a": 33, "spot-b": 100, "spot-c": 50},
+		},
+		{
+			name: "terminating replicas are ignored",
+			pod:  replica("web-new", "web", ""),
+			pods: []*v1.Pod{
+				func() *v1.Pod {
+					p := replica("web-1", "web", "on-demand")
+					now := metav1.Now()
+					p.DeletionTimestamp = &now
+					return p
+				}(),
+				replica("web-2", "web", "spot-a"),
+			},
+			wantScores: map[string]int64{"on-demand": 100, "spot-a": 0, "spot-b": 0, "spot-c": 0},
+		},
+		{
+			name: "pod annotation disables the on-demand share",
+			pod: func() *v1.Pod {
+				p := replica("web-new", "web", "")
+				p.Annotations = map[string]string{OnDemandPercentageAnnotation: "0"}
+				return p
+			}(),
+			wantScores: map[string]int64{"on-demand": 0, "spot-a": 100, "spot-b": 100, "spot-c": 50},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := cache.NewSnapshot(test.pods, nodes)
+			fh, _ := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(snapshot))
+			p, err := NewScore(nil, fh)
+			if err != nil {
+				t.Fatalf("creating plugin: %v", err)
+			}
+			pl := p.(*Score)
+			ctx := context.Background()
+			state := framework.NewCycleState()
+			if status := pl.PreScore(ctx, state, test.pod, nodes); !status.IsSuccess() {
+				t.Fatalf("unexpected PreScore status: %v", status)
+			}
+			gotScores := make(map[string]int64, len(nodes))
+			for _, n := range nodes {
+				score, status := pl.Score(ctx, state, test.pod, n.Name)
+				if !status.IsSuccess() {
+					t.Fatalf("unexpected Score status: %v", status)
+				}
+				gotScores[n.Name] = score
+			}
+			if !reflect.DeepEqual(gotScores, test.wantScores) {
+				t.Errorf("got scores %v, want %v", gotScores, test.wantScores)
+			}
+		})
+	}
+}
+
+func TestScoreWithoutPreScore(t *testing.T) {
+	snapshot := cache.NewSnapshot(nil, []*v1.Node{spotNode("spot-a", "a", "")})
+	fh, _ := runtime.NewFramework(nil, nil, nil, runtime.WithSnapshotSharedLister(snapshot))
+	p, err := NewScore(nil, fh)
+	if err != nil {
+		t.Fatalf("creating plugin: %v", err)
+	}
+	if _, status := p.(*Score).Score(context.Background(), framework.NewCycleState(), replica("web", "web", ""), "spot-a"); status.Code() != framework.Error {
+		t.Errorf("expected an error without PreScore state, got %v", status)
+	}
+}
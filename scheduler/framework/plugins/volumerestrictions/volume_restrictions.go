@@ -12,6 +12,8 @@ import (
 type VolumeRestrictions struct{}
 
 var _ framework.FilterPlugin = &VolumeRestrictions{}
+var _ framework.EnqueueExtensions = &VolumeRestrictions{}
+var _ framework.CacheableFilterPlugin = &VolumeRestrictions{}
 
 // Name is the name of the plugin used in the plugin registry and configurations.
 const Name = "VolumeRestrictions"
@@ -26,6 +28,20 @@ func (pl *VolumeRestrictions) Name() string {
 	return Name
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *VolumeRestrictions) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Pod, ActionType: framework.Delete},
+	}
+}
+
+// FilterCacheable returns true: the result of Filter only depends on the
+// volumes of the pod and of the pods on the node.
+func (pl *VolumeRestrictions) FilterCacheable(_ *framework.CycleState, _ *v1.Pod) bool {
+	return true
+}
+
 func isVolumeConflict(volume *v1.Volume, pod *v1.Pod) bool {
 	for _, existingVolume := range pod.Spec.Volumes {
 		// Same GCE disk mounted by multiple pods conflicts unless all pods mount it read-only.
@@ -18,6 +18,8 @@ type VolumeZone struct {
 }
 
 var _ framework.FilterPlugin = &VolumeZone{}
+var _ framework.EnqueueExtensions = &VolumeZone{}
+var _ framework.CacheableFilterPlugin = &VolumeZone{}
 
 const (
 	// Name is the name of the plugin used in the plugin registry and configurations.
@@ -39,6 +41,28 @@ func (pl *VolumeZone) Name() string {
 	return Name
 }
 
+// EventsToRegister returns the possible events that may make a Pod
+// failed by this plugin schedulable.
+func (pl *VolumeZone) EventsToRegister() []framework.ClusterEvent {
+	return []framework.ClusterEvent{
+		{Resource: framework.Node, ActionType: framework.Add | framework.UpdateNodeLabel},
+		{Resource: framework.PersistentVolume, ActionType: framework.Add | framework.Update},
+		{Resource: framework.PersistentVolumeClaim, ActionType: framework.Add | framework.Update},
+		{Resource: framework.StorageClass, ActionType: framework.Add},
+	}
+}
+
+// FilterCacheable returns true if pod has no persistent volume claims, whose
+// volumes are looked up at Filter.
+func (pl *VolumeZone) FilterCacheable(_ *framework.CycleState, pod *v1.Pod) bool {
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].PersistentVolumeClaim != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // Filter invoked at the filter extension point.
 //
 // It evaluates if a pod can fit due to the volumes it requests, given
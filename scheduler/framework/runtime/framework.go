@@ -8,6 +8,8 @@ import (
 
 	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
 	"github.com/turtacn/cloud-prophet/scheduler/apis/config/scheme"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/v1beta1"
+	"github.com/turtacn/cloud-prophet/scheduler/explain"
 	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
 	"github.com/turtacn/cloud-prophet/scheduler/internal/parallelize"
 	"github.com/turtacn/cloud-prophet/scheduler/metrics"
@@ -18,7 +20,6 @@ import (
 	"k8s.io/client-go/informers"
 	clientset "k8s.io/client-go/kubernetes"
 	"k8s.io/klog/v2"
-	"k8s.io/kube-scheduler/config/v1beta1"
 )
 
 const (
@@ -111,6 +112,7 @@ type frameworkOptions struct {
 	podNominator         framework.PodNominator
 	extenders            []framework.Extender
 	runAllFilters        bool
+	clusterEventMap      map[framework.ClusterEvent]sets.String
 }
 
 // Option for the frameworkImpl.
@@ -173,6 +175,15 @@ func WithExtenders(extenders []framework.Extender) Option {
 	}
 }
 
+// WithClusterEventMap sets clusterEventMap for the scheduling frameworkImpl.
+// NewFramework fills it with the plugins that may be interested in each
+// cluster event.
+func WithClusterEventMap(m map[framework.ClusterEvent]sets.String) Option {
+	return func(o *frameworkOptions) {
+		o.clusterEventMap = m
+	}
+}
+
 var defaultFrameworkOptions = frameworkOptions{
 	metricsRecorder: newMetricsRecorder(1000, time.Second),
 }
@@ -269,6 +280,15 @@ func NewFramework(r Registry, plugins *config.Plugins, args []config.PluginConfi
 		}
 	}
 
+	if options.clusterEventMap != nil {
+		for _, pl := range f.preFilterPlugins {
+			fillEventToPluginMap(pl, options.clusterEventMap)
+		}
+		for _, pl := range f.filterPlugins {
+			fillEventToPluginMap(pl, options.clusterEventMap)
+		}
+	}
+
 	// Verifying the score weights again since Plugin.Name() could return a different
 	// value from the one used in the configuration.
 	for _, scorePlugin := range f.scorePlugins {
@@ -290,6 +310,25 @@ func NewFramework(r Registry, plugins *config.Plugins, args []config.PluginConfi
 	return f, nil
 }
 
+// allClusterEvents is registered for plugins that don't implement
+// EnqueueExtensions: any event may make pods they rejected schedulable.
+var allClusterEvents = []framework.ClusterEvent{{Resource: framework.WildCard, ActionType: framework.All}}
+
+func fillEventToPluginMap(p framework.Plugin, eventToPlugins map[framework.ClusterEvent]sets.String) {
+	events := allClusterEvents
+	if ext, ok := p.(framework.EnqueueExtensions); ok {
+		// A plugin returning no events is not interested in any: pods it
+		// rejected are only moved by the periodic flush.
+		events = ext.EventsToRegister()
+	}
+	for _, evt := range events {
+		if eventToPlugins[evt] == nil {
+			eventToPlugins[evt] = sets.NewString()
+		}
+		eventToPlugins[evt].Insert(p.Name())
+	}
+}
+
 // getPluginArgsOrDefault returns a configuration provided by the user or builds
 // a default from the scheme. Returns `nil, nil` if the plugin does not have a
 // defined arg types, such as in-tree plugins that don't require configuration
@@ -300,7 +339,7 @@ func getPluginArgsOrDefault(pluginConfig map[string]runtime.Object, name string)
 		return res, nil
 	}
 	// Use defaults from latest config API version.
-	gvk := v1beta1.SchemeGroupVersion.WithKind(name + "Args")
+	gvk := v1beta1.SchemeGroupVersion.WithKind(v1beta1.PluginArgsKind(name))
 	obj, _, err := configDecoder.Decode(nil, &gvk, nil)
 	if runtime.IsNotRegisteredError(err) {
 		// This plugin is out-of-tree or doesn't require configuration.
@@ -367,8 +406,9 @@ func (f *frameworkImpl) RunPreFilterPlugins(ctx context.Context, state *framewor
 	for _, pl := range f.preFilterPlugins {
 		status = f.runPreFilterPlugin(ctx, pl, state, pod)
 		if !status.IsSuccess() {
+			explain.FromState(state).RecordPreFilter(pl.Name(), status)
 			if status.IsUnschedulable() {
-				return status
+				return status.WithFailedPlugin(pl.Name())
 			}
 			msg := fmt.Sprintf("prefilter plugin %q failed for pod %q: %v", pl.Name(), pod.Name, status.Message())
 			klog.Error(msg)
@@ -479,15 +519,19 @@ func (f *frameworkImpl) RunFilterPlugins(
 				// Filter plugins are not supposed to return any status other than
 				// Success or Unschedulable.
 				errStatus := framework.NewStatus(framework.Error, fmt.Sprintf("running %q filter plugin for pod %q: %v", pl.Name(), pod.Name, pluginStatus.Message()))
-				return map[string]*framework.Status{pl.Name(): errStatus}
+				statuses = map[string]*framework.Status{pl.Name(): errStatus}
+				break
 			}
 			statuses[pl.Name()] = pluginStatus
 			if !f.runAllFilters {
 				// Exit early if we don't need to run all filters.
-				return statuses
+				break
 			}
 		}
 	}
+	if attempt := explain.FromState(state); attempt != nil && nodeInfo.Node() != nil {
+		attempt.RecordFilter(nodeInfo.Node().Name, statuses)
+	}
 
 	return statuses
 }
@@ -605,6 +649,8 @@ func (f *frameworkImpl) RunScorePlugins(ctx context.Context, state *framework.Cy
 		klog.Error(msg)
 		return nil, framework.NewStatus(framework.Error, msg)
 	}
+	attempt := explain.FromState(state)
+	f.recordScores(attempt, explain.Raw, pluginToNodeScores)
 
 	// Run NormalizeScore method for each ScorePlugin in parallel.
 	parallelize.Until(ctx, len(f.scorePlugins), func(index int) {
@@ -625,6 +671,7 @@ func (f *frameworkImpl) RunScorePlugins(ctx context.Context, state *framework.Cy
 		klog.Error(msg)
 		return nil, framework.NewStatus(framework.Error, msg)
 	}
+	f.recordScores(attempt, explain.Normalized, pluginToNodeScores)
 
 	// Apply score defaultWeights for each ScorePlugin in parallel.
 	parallelize.Until(ctx, len(f.scorePlugins), func(index int) {
@@ -648,10 +695,22 @@ func (f *frameworkImpl) RunScorePlugins(ctx context.Context, state *framework.Cy
 		klog.Error(msg)
 		return nil, framework.NewStatus(framework.Error, msg)
 	}
+	f.recordScores(attempt, explain.Weighted, pluginToNodeScores)
 
 	return pluginToNodeScores, nil
 }
 
+// recordScores records the scores of every score plugin at step in the
+// attempt, if the cycle is explained.
+func (f *frameworkImpl) recordScores(attempt *explain.Attempt, step explain.Step, scores framework.PluginToNodeScores) {
+	if attempt == nil {
+		return
+	}
+	for _, pl := range f.scorePlugins {
+		attempt.RecordScores(pl.Name(), step, scores[pl.Name()])
+	}
+}
+
 func (f *frameworkImpl) runScorePlugin(ctx context.Context, pl framework.ScorePlugin, state *framework.CycleState, pod *v1.Pod, nodeName string) (int64, *framework.Status) {
 	if !state.ShouldRecordPluginMetrics() {
 		return pl.Score(ctx, state, pod, nodeName)
@@ -926,6 +985,18 @@ func (f *frameworkImpl) HasFilterPlugins() bool {
 	return len(f.filterPlugins) > 0
 }
 
+// FilterCacheable returns true if all filter plugins are CacheableFilterPlugins
+// returning true for pod.
+func (f *frameworkImpl) FilterCacheable(state *framework.CycleState, pod *v1.Pod) bool {
+	for _, pl := range f.filterPlugins {
+		c, ok := pl.(framework.CacheableFilterPlugin)
+		if !ok || !c.FilterCacheable(state, pod) {
+			return false
+		}
+	}
+	return true
+}
+
 // HasPostFilterPlugins returns true if at least one postFilter plugin is defined.
 func (f *frameworkImpl) HasPostFilterPlugins() bool {
 	return len(f.postFilterPlugins) > 0
@@ -0,0 +1,34 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/explain"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRunFilterPluginsExplain(t *testing.T) {
+	f, err := NewFramework(nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}}
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}})
+
+	// Without an attempt nothing is recorded, even without a node.
+	f.RunFilterPlugins(context.Background(), framework.NewCycleState(), pod, framework.NewNodeInfo())
+	f.RunFilterPlugins(context.Background(), framework.NewCycleState(), pod, nodeInfo)
+
+	state := framework.NewCycleState()
+	attempt := explain.NewAttempt(pod, "default-scheduler")
+	state.Write(explain.StateKey, attempt)
+	f.RunFilterPlugins(context.Background(), state, pod, framework.NewNodeInfo())
+	f.RunFilterPlugins(context.Background(), state, pod, nodeInfo)
+	if len(attempt.Nodes) != 1 || attempt.Nodes["node-1"] == nil {
+		t.Errorf("got nodes %v, want node-1 only", attempt.Nodes)
+	}
+}
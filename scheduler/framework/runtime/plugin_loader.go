@@ -0,0 +1,124 @@
+package runtime
+
+import (
+	"fmt"
+	"path/filepath"
+	"plugin"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// APIVersionSymbol is the string variable a plugin shared object exports with the
+	// framework API version it was built against.
+	APIVersionSymbol = "APIVersion"
+	// RegisterSymbol is the function a plugin shared object exports to add its
+	// plugins to the registry, of type RegisterFunc.
+	RegisterSymbol = "Register"
+)
+
+// RegisterFunc is the type of the Register function of plugin shared objects.
+type RegisterFunc = func(Registry) error
+
+// LoadPlugins opens the shared objects (*.so) in dir in lexical order and
+// adds their plugins to r. Each shared object is built with -buildmode=plugin
+// against the same framework sources as the scheduler and exports the
+// APIVersionSymbol and RegisterSymbol symbols.
+func LoadPlugins(dir string, r Registry) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if err := LoadPlugin(path, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadPlugin opens the shared object at path and adds its plugins to r.
+func LoadPlugin(path string, r Registry) error {
+	p, err := plugin.Open(path)
+	if err != nil {
+		// Among others, this fails when the shared object was built with another
+		// Go version or other versions of the packages it shares with the scheduler.
+		return fmt.Errorf("opening plugin %s: %v", path, err)
+	}
+
+	sym, err := p.Lookup(APIVersionSymbol)
+	if err != nil {
+		return fmt.Errorf("plugin %s does not export %s: %v", path, APIVersionSymbol, err)
+	}
+	version, ok := sym.(*string)
+	if !ok {
+		return fmt.Errorf("plugin %s exports %s of type %T, want string", path, APIVersionSymbol, sym)
+	}
+	if err := checkAPIVersion(*version, v1alpha1.APIVersion); err != nil {
+		return fmt.Errorf("plugin %s: %v", path, err)
+	}
+
+	sym, err = p.Lookup(RegisterSymbol)
+	if err != nil {
+		return fmt.Errorf("plugin %s does not export %s: %v", path, RegisterSymbol, err)
+	}
+	register, ok := sym.(RegisterFunc)
+	if !ok {
+		return fmt.Errorf("plugin %s exports %s of type %T, want func(runtime.Registry) error", path, RegisterSymbol, sym)
+	}
+
+	// Register into a scratch registry so that a failing plugin adds nothing.
+	loaded := Registry{}
+	if err := register(loaded); err != nil {
+		return fmt.Errorf("registering plugin %s: %v", path, err)
+	}
+	if err := r.Merge(loaded); err != nil {
+		return fmt.Errorf("registering plugin %s: %v", path, err)
+	}
+	names := make([]string, 0, len(loaded))
+	for name := range loaded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	klog.Infof("Loaded plugins %v from %s (framework API %s)", names, path, *version)
+	return nil
+}
+
+// checkAPIVersion returns an error unless a plugin built against the framework
+// API version got can run with the framework API version want: the major
+// versions must be the same and the plugin can't use a newer minor version.
+func checkAPIVersion(got, want string) error {
+	gotMajor, gotMinor, err := parseAPIVersion(got)
+	if err != nil {
+		return err
+	}
+	wantMajor, wantMinor, err := parseAPIVersion(want)
+	if err != nil {
+		return err
+	}
+	if gotMajor != wantMajor || gotMinor > wantMinor {
+		return fmt.Errorf("built against framework API %s, which is incompatible with %s", got, want)
+	}
+	return nil
+}
+
+func parseAPIVersion(v string) (int, int, error) {
+	parts := strings.Split(v, ".")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid framework API version %q, want MAJOR.MINOR", v)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil || major < 0 {
+		return 0, 0, fmt.Errorf("invalid framework API version %q, want MAJOR.MINOR", v)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return 0, 0, fmt.Errorf("invalid framework API version %q, want MAJOR.MINOR", v)
+	}
+	return major, minor, nil
+}
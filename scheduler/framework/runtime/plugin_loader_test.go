@@ -0,0 +1,134 @@
+package runtime
+
+import (
+	"context"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	goruntime "runtime"
+	"strings"
+	"testing"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// buildSamplePlugin builds testdata/sampleplugin as a shared object in dir.
+func buildSamplePlugin(t *testing.T, dir string, ldflags string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("building plugins is slow")
+	}
+	switch goruntime.GOOS {
+	case "linux", "darwin", "freebsd":
+	default:
+		t.Skipf("plugins are not supported on %s", goruntime.GOOS)
+	}
+	if out, err := exec.Command("go", "env", "CGO_ENABLED").Output(); err != nil || strings.TrimSpace(string(out)) != "1" {
+		t.Skip("plugins need cgo")
+	}
+	path := filepath.Join(dir, "sample.so")
+	cmd := exec.Command("go", "build", "-buildmode=plugin", "-ldflags", ldflags, "-o", path, "./testdata/sampleplugin")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("building sample plugin: %v\n%s", err, out)
+	}
+	return path
+}
+
+func TestLoadPlugins(t *testing.T) {
+	dir := t.TempDir()
+	path := buildSamplePlugin(t, dir, "")
+	// Files without the .so extension are ignored.
+	if err := ioutil.WriteFile(filepath.Join(dir, "README"), []byte("not a plugin"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := Registry{"Other": nil}
+	if err := LoadPlugins(dir, r); err != nil {
+		t.Fatal(err)
+	}
+	factory, ok := r["SampleLabelFilter"]
+	if !ok {
+		t.Fatalf("SampleLabelFilter is not registered, got %v", r)
+	}
+
+	node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Labels: map[string]string{"example.com/drained": ""}}}
+	nodeInfo := framework.NewNodeInfo()
+	nodeInfo.SetNode(node)
+	tests := []struct {
+		name string
+		args runtime.Object
+		want framework.Code
+	}{
+		{
+			name: "default label",
+			want: framework.Success,
+		},
+		{
+			name: "configured label",
+			args: &runtime.Unknown{Raw: []byte(`{"label":"example.com/drained"}`), ContentType: runtime.ContentTypeJSON},
+			want: framework.UnschedulableAndUnresolvable,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pl, err := factory(tc.args, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := pl.(framework.FilterPlugin).Filter(context.Background(), framework.NewCycleState(), &v1.Pod{}, nodeInfo)
+			if got.Code() != tc.want {
+				t.Errorf("got %v, want %v", got.Code(), tc.want)
+			}
+		})
+	}
+
+	// Plugins can't replace registered ones.
+	err := LoadPlugin(path, Registry{"SampleLabelFilter": nil})
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("got error %v, want the plugin to already exist", err)
+	}
+}
+
+func TestLoadPluginErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := buildSamplePlugin(t, dir, "-pluginpath=sampleplugin-v2 -X main.APIVersion=2.0")
+	err := LoadPlugin(path, Registry{})
+	if err == nil || !strings.Contains(err.Error(), "incompatible") {
+		t.Errorf("got error %v, want an incompatible API version", err)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.so")
+	if err := ioutil.WriteFile(bad, []byte("not a plugin"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	err = LoadPlugin(bad, Registry{})
+	if err == nil || !strings.Contains(err.Error(), "opening plugin") {
+		t.Errorf("got error %v, want the plugin to fail to open", err)
+	}
+}
+
+func TestCheckAPIVersion(t *testing.T) {
+	tests := []struct {
+		got     string
+		want    string
+		wantErr bool
+	}{
+		{got: "1.0", want: "1.0"},
+		{got: "1.0", want: "1.2"},
+		{got: "1.3", want: "1.2", wantErr: true},
+		{got: "2.0", want: "1.2", wantErr: true},
+		{got: "0.9", want: "1.2", wantErr: true},
+		{got: "1", want: "1.2", wantErr: true},
+		{got: "1.x", want: "1.2", wantErr: true},
+		{got: "", want: "1.2", wantErr: true},
+	}
+	for _, tc := range tests {
+		err := checkAPIVersion(tc.got, tc.want)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("checkAPIVersion(%q, %q) = %v, want error %v", tc.got, tc.want, err, tc.wantErr)
+		}
+	}
+}
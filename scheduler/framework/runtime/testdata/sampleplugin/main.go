@@ -0,0 +1,78 @@
+// Package main is a sample out-of-tree plugin built as a shared object:
+//
+//	go build -buildmode=plugin -o sample.so ./framework/runtime/testdata/sampleplugin
+//
+// It keeps pods off nodes carrying a label, by default
+// sample.prophet.io/cordoned. Its args are decoded from runtime.Unknown
+// since the scheduler config API doesn't know them:
+//
+//	pluginConfig:
+//	- name: SampleLabelFilter
+//	  args:
+//	    label: example.com/drained
+package main
+
+import (
+	"context"
+	"fmt"
+
+	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Name is the name of the plugin used in the plugin registry and configurations.
+const Name = "SampleLabelFilter"
+
+// APIVersion is the framework API version the plugin is built against.
+var APIVersion = framework.APIVersion
+
+// Register adds the plugin to the scheduler registry.
+func Register(r frameworkruntime.Registry) error {
+	return r.Register(Name, New)
+}
+
+// Args are the args of SampleLabelFilter.
+type Args struct {
+	// Label keeps pods off the nodes that have it, whatever its value.
+	Label string `json:"label"`
+}
+
+// SampleLabelFilter filters out nodes with Args.Label.
+type SampleLabelFilter struct {
+	args Args
+}
+
+var _ framework.FilterPlugin = &SampleLabelFilter{}
+
+// New initializes a new plugin and returns it.
+func New(plArgs runtime.Object, h framework.FrameworkHandle) (framework.Plugin, error) {
+	args := Args{Label: "sample.prophet.io/cordoned"}
+	if err := frameworkruntime.DecodeInto(plArgs, &args); err != nil {
+		return nil, err
+	}
+	if args.Label == "" {
+		return nil, fmt.Errorf("label must not be empty")
+	}
+	return &SampleLabelFilter{args: args}, nil
+}
+
+// Name returns name of the plugin. It is used in logs, etc.
+func (pl *SampleLabelFilter) Name() string {
+	return Name
+}
+
+// Filter invoked at the filter extension point.
+func (pl *SampleLabelFilter) Filter(ctx context.Context, cycleState *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	node := nodeInfo.Node()
+	if node == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+	if _, ok := node.Labels[pl.args.Label]; ok {
+		return framework.NewStatus(framework.UnschedulableAndUnresolvable, fmt.Sprintf("node(s) had label %s", pl.args.Label))
+	}
+	return nil
+}
+
+func main() {}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
@@ -16,6 +17,12 @@ import (
 	clientset "k8s.io/client-go/kubernetes"
 )
 
+// APIVersion is the version of the plugin interfaces in this package, as
+// MAJOR.MINOR. The minor version grows when extension points or handle methods
+// are added, the major version when existing ones change. Plugins loaded from
+// shared objects declare the version they were built against.
+const APIVersion = "1.0"
+
 // NodeScoreList declares a list of nodes and their scores.
 type NodeScoreList []NodeScore
 
@@ -81,6 +88,8 @@ const (
 type Status struct {
 	code    Code
 	reasons []string
+	// failedPlugins are the plugins that returned the status, if known.
+	failedPlugins []string
 }
 
 // Code returns code of the Status.
@@ -104,6 +113,25 @@ func (s *Status) Reasons() []string {
 	return s.reasons
 }
 
+// FailedPlugins returns the plugins that returned the Status, if known.
+func (s *Status) FailedPlugins() []string {
+	if s == nil {
+		return nil
+	}
+	return s.failedPlugins
+}
+
+// WithFailedPlugin returns a copy of the Status recording plugin as the one
+// that returned it.
+func (s *Status) WithFailedPlugin(plugin string) *Status {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	c.failedPlugins = []string{plugin}
+	return &c
+}
+
 // AppendReason appends given reason to the Status.
 func (s *Status) AppendReason(reason string) {
 	s.reasons = append(s.reasons, reason)
@@ -150,7 +178,10 @@ func (p PluginToStatus) Merge() *Status {
 
 	finalStatus := NewStatus(Success)
 	var hasError, hasUnschedulableAndUnresolvable, hasUnschedulable bool
-	for _, s := range p {
+	for name, s := range p {
+		if !s.IsSuccess() {
+			finalStatus.failedPlugins = append(finalStatus.failedPlugins, name)
+		}
 		if s.Code() == Error {
 			hasError = true
 		} else if s.Code() == UnschedulableAndUnresolvable {
@@ -171,6 +202,7 @@ func (p PluginToStatus) Merge() *Status {
 	} else if hasUnschedulable {
 		finalStatus.code = Unschedulable
 	}
+	sort.Strings(finalStatus.failedPlugins)
 	return finalStatus
 }
 
@@ -217,6 +249,19 @@ type PreFilterExtensions interface {
 	RemovePod(ctx context.Context, state *CycleState, podToSchedule *v1.Pod, podToRemove *v1.Pod, nodeInfo *NodeInfo) *Status
 }
 
+// EnqueueExtensions is an optional interface that plugins can implement to efficiently
+// move unschedulable Pods in internal scheduling queues. Plugins
+// that fail pod scheduling (e.g., Filter plugins) are expected to implement this interface.
+type EnqueueExtensions interface {
+	// EventsToRegister returns a series of possible events that may cause a Pod
+	// failed by this plugin schedulable.
+	// The events will be registered when instantiating the internal scheduling queue,
+	// and leveraged to build event handlers dynamically.
+	// Note: the returned list needs to be static (not depend on configuration parameters);
+	// otherwise it would lead to undefined behavior.
+	EventsToRegister() []ClusterEvent
+}
+
 // PreFilterPlugin is an interface that must be implemented by "prefilter" plugins.
 // These plugins are called at the beginning of the scheduling cycle.
 type PreFilterPlugin interface {
@@ -257,6 +302,19 @@ type FilterPlugin interface {
 	Filter(ctx context.Context, state *CycleState, pod *v1.Pod, nodeInfo *NodeInfo) *Status
 }
 
+// CacheableFilterPlugin is an optional interface of Filter plugins. The
+// Filter results of plugins returning true may be reused for pods of the
+// same equivalence class, until the node they were computed on changes.
+type CacheableFilterPlugin interface {
+	FilterPlugin
+	// FilterCacheable is called after PreFilter. It returns true if the result
+	// of Filter only depends on the scheduling-relevant fields of pod
+	// (requests, selectors, affinity, tolerations, volumes, labels and
+	// annotations) and on the NodeInfo passed to Filter, not on other nodes
+	// or objects of the cluster.
+	FilterCacheable(state *CycleState, pod *v1.Pod) bool
+}
+
 // PostFilterPlugin is an interface for PostFilter plugins. These plugins are called
 // after a pod cannot be scheduled.
 type PostFilterPlugin interface {
@@ -462,6 +520,10 @@ type Framework interface {
 	// HasFilterPlugins returns true if at least one filter plugin is defined.
 	HasFilterPlugins() bool
 
+	// FilterCacheable returns true if all filter plugins are CacheableFilterPlugins
+	// returning true for pod.
+	FilterCacheable(state *CycleState, pod *v1.Pod) bool
+
 	// HasPostFilterPlugins returns true if at least one postFilter plugin is defined.
 	HasPostFilterPlugins() bool
 
@@ -0,0 +1,84 @@
+package v1alpha1
+
+import (
+	"math"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// ReservationAnnotation holds the name of the reservation of a
+	// placeholder pod.
+	ReservationAnnotation = "scheduler.cloud-prophet.io/reservation"
+	// ReservationOwnerAnnotation holds the owner of the reservation of a
+	// placeholder pod.
+	ReservationOwnerAnnotation = "scheduler.cloud-prophet.io/reservation-owner"
+)
+
+// Reservation holds capacity on a node for pods that will arrive later, e.g.
+// during a rolling update. The capacity is unavailable to other pods until
+// a pod of the owner consumes it or it expires.
+type Reservation struct {
+	// Name identifies the reservation.
+	Name string
+	// Owner is the "namespace/name" key of the workload whose pods may use
+	// the capacity: a pod, its controller or the deployment of its replica
+	// set.
+	Owner string
+	// Resources is the reserved capacity, for one pod.
+	Resources v1.ResourceList
+	// NodeSelector restricts the nodes the capacity may be held on.
+	NodeSelector map[string]string
+	// NodeName is the node holding the capacity, empty until placed.
+	NodeName string
+	// Expires is when the capacity is released if not consumed.
+	Expires time.Time
+}
+
+// DeepCopy returns a deep copy of r.
+func (r *Reservation) DeepCopy() *Reservation {
+	out := *r
+	out.Resources = r.Resources.DeepCopy()
+	if r.NodeSelector != nil {
+		out.NodeSelector = make(map[string]string, len(r.NodeSelector))
+		for k, v := range r.NodeSelector {
+			out.NodeSelector[k] = v
+		}
+	}
+	return &out
+}
+
+// Pod returns the placeholder pod standing for r on its node. Placeholder
+// pods are accounted in NodeInfos like other pods, have the highest
+// priority so that they are never preempted, and are identified by
+// ReservationAnnotation.
+func (r *Reservation) Pod() *v1.Pod {
+	priority := int32(math.MaxInt32)
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "reservation-" + r.Name,
+			UID:  types.UID("reservation/" + r.Name),
+			Annotations: map[string]string{
+				ReservationAnnotation:      r.Name,
+				ReservationOwnerAnnotation: r.Owner,
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeName: r.NodeName,
+			Priority: &priority,
+			Containers: []v1.Container{{
+				Name:      "reservation",
+				Resources: v1.ResourceRequirements{Requests: r.Resources},
+			}},
+		},
+	}
+}
+
+// IsReservationPod returns true if pod is the placeholder of a reservation.
+func IsReservationPod(pod *v1.Pod) bool {
+	_, ok := pod.Annotations[ReservationAnnotation]
+	return ok
+}
@@ -18,6 +18,64 @@ import (
 
 var generation int64
 
+// ActionType is an integer to represent one type of resource change.
+// Different ActionTypes can be bit-wised to compose new semantics.
+type ActionType int64
+
+// Constants for ActionTypes.
+const (
+	Add    ActionType = 1 << iota // 1
+	Delete                        // 10
+	// UpdateNodeXYZ is only applicable for Node events.
+	UpdateNodeAllocatable // 100
+	UpdateNodeLabel       // 1000
+	UpdateNodeTaint       // 10000
+	UpdateNodeCondition   // 100000
+
+	All ActionType = 1<<iota - 1 // 111111
+
+	// Use the general Update type if you don't either know or care the specific sub-Update type to use.
+	Update = UpdateNodeAllocatable | UpdateNodeLabel | UpdateNodeTaint | UpdateNodeCondition
+)
+
+// GVK is short for group/version/kind, which can uniquely represent a particular API resource.
+type GVK string
+
+// Constants for GVKs.
+const (
+	Pod                   GVK = "Pod"
+	Node                  GVK = "Node"
+	PersistentVolume      GVK = "PersistentVolume"
+	PersistentVolumeClaim GVK = "PersistentVolumeClaim"
+	Service               GVK = "Service"
+	StorageClass          GVK = "storage.k8s.io/StorageClass"
+	CSINode               GVK = "storage.k8s.io/CSINode"
+	WildCard              GVK = "*"
+)
+
+// ClusterEvent abstracts how a system resource's state gets changed.
+// Resource represents the standard API resources such as Pod, Node, etc.
+// ActionType denotes the specific change such as Add, Update or Delete.
+type ClusterEvent struct {
+	Resource   GVK
+	ActionType ActionType
+	Label      string
+}
+
+// IsWildCard returns true if ClusterEvent follows WildCard semantics
+func (ce ClusterEvent) IsWildCard() bool {
+	return ce.Resource == WildCard && ce.ActionType == All
+}
+
+// Match returns true if an event ev of the cluster may be the change ce
+// waits for.
+func (ce ClusterEvent) Match(ev ClusterEvent) bool {
+	if ce.IsWildCard() || ev.IsWildCard() {
+		return true
+	}
+	return (ce.Resource == WildCard || ce.Resource == ev.Resource) && ce.ActionType&ev.ActionType != 0
+}
+
 // QueuedPodInfo is a Pod wrapper with additional information related to
 // the pod's status in the scheduling queue, such as the timestamp when
 // it's added to the queue.
@@ -33,6 +91,19 @@ type QueuedPodInfo struct {
 	// It shouldn't be updated once initialized. It's used to record the e2e scheduling
 	// latency for a pod.
 	InitialAttemptTimestamp time.Time
+	// FailureReason is why the last attempt failed, one of the
+	// FailureReason constants of the config API. It selects the backoff of
+	// the pod.
+	FailureReason string
+	// UnschedulablePlugins are the plugins that rejected the pod at its last
+	// attempt. Empty if unknown, e.g. when an extender rejected it, in which
+	// case any cluster event moves the pod.
+	UnschedulablePlugins sets.String
+	// SortKey is a value the QueueSort plugin ordered the pod by, computed
+	// when the pod was queued at SortKeyTimestamp. Keeping it while the pod
+	// waits keeps the order of the heap consistent when the value changes.
+	SortKey          float64
+	SortKeyTimestamp time.Time
 }
 
 // DeepCopy returns a deep copy of the QueuedPodInfo object.
@@ -42,6 +113,10 @@ func (pqi *QueuedPodInfo) DeepCopy() *QueuedPodInfo {
 		Timestamp:               pqi.Timestamp,
 		Attempts:                pqi.Attempts,
 		InitialAttemptTimestamp: pqi.InitialAttemptTimestamp,
+		FailureReason:           pqi.FailureReason,
+		UnschedulablePlugins:    sets.NewString(pqi.UnschedulablePlugins.UnsortedList()...),
+		SortKey:                 pqi.SortKey,
+		SortKeyTimestamp:        pqi.SortKeyTimestamp,
 	}
 }
 
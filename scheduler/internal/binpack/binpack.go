@@ -0,0 +1,272 @@
+// Package binpack assigns items to bins, packing as many items as possible
+// on as few bins as possible. It is used to place batches of pods on nodes.
+package binpack
+
+import (
+	"sort"
+)
+
+// Item is an object to pack, e.g. a pod.
+type Item struct {
+	// Size is the size of the item in every dimension.
+	Size []int64
+	// Bins are the indexes of the bins the item may be assigned to.
+	Bins []int
+}
+
+// Bin is a container of items, e.g. a node.
+type Bin struct {
+	// Free is the free capacity of the bin in every dimension.
+	Free []int64
+	// Used is true if the bin already holds other objects, so that it counts
+	// as used whether items are assigned to it or not.
+	Used bool
+}
+
+// Solve assigns items to bins. It maximises the number of assigned items
+// and then minimises the number of used bins, with a heuristic rather than
+// an exact solver:
+//
+//  1. Best fit decreasing: items are assigned by decreasing dominant size,
+//     the most constrained first, to the used bin they fill the most, or
+//     the unused one if none fits.
+//  2. Repair: an unassigned item takes the place of an assigned one that
+//     can move to another of its bins.
+//  3. Consolidation: the bins used only by items, emptiest first, are
+//     emptied if all their items fit in other used bins.
+//
+// It returns the index of the bin of every item, -1 for unassigned items.
+func Solve(items []Item, bins []Bin) []int {
+	s := newSolver(items, bins)
+	for _, i := range s.order {
+		if b := s.bestBin(i, -1); b >= 0 {
+			s.assign(i, b)
+		}
+	}
+	for _, i := range s.order {
+		if s.assignment[i] < 0 {
+			s.repair(i)
+		}
+	}
+	s.consolidate()
+	// Consolidation may leave room for items that did not fit before.
+	for _, i := range s.order {
+		if s.assignment[i] < 0 {
+			if b := s.bestBin(i, -1); b >= 0 {
+				s.assign(i, b)
+			}
+		}
+	}
+	return s.assignment
+}
+
+type solver struct {
+	items []Item
+	// free, used and content are the free capacity, state and items of
+	// every bin. usedBefore are the bins used by other objects than items.
+	free       [][]int64
+	used       []bool
+	usedBefore []bool
+	content    [][]int
+	// norm normalises the sizes of every dimension.
+	norm       []float64
+	order      []int
+	assignment []int
+}
+
+func newSolver(items []Item, bins []Bin) *solver {
+	s := &solver{
+		items:      items,
+		free:       make([][]int64, len(bins)),
+		used:       make([]bool, len(bins)),
+		usedBefore: make([]bool, len(bins)),
+		content:    make([][]int, len(bins)),
+		order:      make([]int, len(items)),
+		assignment: make([]int, len(items)),
+	}
+	dims := 0
+	for _, b := range bins {
+		if len(b.Free) > dims {
+			dims = len(b.Free)
+		}
+	}
+	for _, it := range items {
+		if len(it.Size) > dims {
+			dims = len(it.Size)
+		}
+	}
+	s.norm = make([]float64, dims)
+	for i, b := range bins {
+		s.free[i] = make([]int64, dims)
+		copy(s.free[i], b.Free)
+		s.used[i] = b.Used
+		s.usedBefore[i] = b.Used
+		for d, f := range s.free[i] {
+			if float64(f) > s.norm[d] {
+				s.norm[d] = float64(f)
+			}
+		}
+	}
+	for d := range s.norm {
+		if s.norm[d] <= 0 {
+			s.norm[d] = 1
+		}
+	}
+	for i := range items {
+		s.order[i] = i
+		s.assignment[i] = -1
+	}
+	sizes := make([]float64, len(items))
+	for i := range items {
+		sizes[i] = s.dominant(items[i].Size)
+	}
+	sort.SliceStable(s.order, func(a, b int) bool {
+		i, j := s.order[a], s.order[b]
+		if sizes[i] != sizes[j] {
+			return sizes[i] > sizes[j]
+		}
+		return len(items[i].Bins) < len(items[j].Bins)
+	})
+	return s
+}
+
+// dominant returns the largest normalised dimension of v.
+func (s *solver) dominant(v []int64) float64 {
+	var res float64
+	for d, x := range v {
+		if n := float64(x) / s.norm[d]; n > res {
+			res = n
+		}
+	}
+	return res
+}
+
+func (s *solver) fits(i, b int) bool {
+	for d, x := range s.items[i].Size {
+		if x > s.free[b][d] {
+			return false
+		}
+	}
+	return true
+}
+
+// slack returns the normalised free capacity of b after assigning i.
+func (s *solver) slack(i, b int) float64 {
+	var res float64
+	for d, f := range s.free[b] {
+		var x int64
+		if d < len(s.items[i].Size) {
+			x = s.items[i].Size[d]
+		}
+		res += float64(f-x) / s.norm[d]
+	}
+	return res
+}
+
+// bestBin returns the bin i fits in with the least slack, preferring used
+// bins, -1 if it fits in none. The bin except is skipped.
+func (s *solver) bestBin(i, except int) int {
+	best, bestUsed, bestSlack := -1, false, 0.0
+	for _, b := range s.items[i].Bins {
+		if b == except || !s.fits(i, b) {
+			continue
+		}
+		slack := s.slack(i, b)
+		switch {
+		case best < 0,
+			s.used[b] && !bestUsed,
+			s.used[b] == bestUsed && slack < bestSlack:
+			best, bestUsed, bestSlack = b, s.used[b], slack
+		}
+	}
+	return best
+}
+
+func (s *solver) assign(i, b int) {
+	for d, x := range s.items[i].Size {
+		s.free[b][d] -= x
+	}
+	s.used[b] = true
+	s.content[b] = append(s.content[b], i)
+	s.assignment[i] = b
+}
+
+// unassign removes i from its bin. The bin stays used.
+func (s *solver) unassign(i int) {
+	b := s.assignment[i]
+	for d, x := range s.items[i].Size {
+		s.free[b][d] += x
+	}
+	for k, j := range s.content[b] {
+		if j == i {
+			s.content[b] = append(s.content[b][:k], s.content[b][k+1:]...)
+			break
+		}
+	}
+	s.assignment[i] = -1
+}
+
+// repair assigns i to one of its bins by moving an assigned item out of it.
+func (s *solver) repair(i int) bool {
+	for _, b := range s.items[i].Bins {
+		for _, j := range append([]int(nil), s.content[b]...) {
+			s.unassign(j)
+			if s.fits(i, b) {
+				if c := s.bestBin(j, b); c >= 0 {
+					s.assign(j, c)
+					s.assign(i, b)
+					return true
+				}
+			}
+			s.assign(j, b)
+		}
+	}
+	return false
+}
+
+// consolidate empties the bins only holding items if their items fit in
+// other used bins.
+func (s *solver) consolidate() {
+	var candidates []int
+	for b := range s.free {
+		if len(s.content[b]) > 0 && !s.usedBefore[b] {
+			candidates = append(candidates, b)
+		}
+	}
+	sort.SliceStable(candidates, func(x, y int) bool {
+		return len(s.content[candidates[x]]) < len(s.content[candidates[y]])
+	})
+	for _, b := range candidates {
+		var moved []int
+		ok := true
+		for _, i := range append([]int(nil), s.content[b]...) {
+			s.unassign(i)
+			c := s.bestUsedBin(i, b)
+			if c < 0 {
+				s.assign(i, b)
+				ok = false
+				break
+			}
+			s.assign(i, c)
+			moved = append(moved, i)
+		}
+		if !ok {
+			// Move the items back.
+			for _, i := range moved {
+				s.unassign(i)
+				s.assign(i, b)
+			}
+			continue
+		}
+		s.used[b] = false
+	}
+}
+
+// bestUsedBin is bestBin restricted to the bins already used.
+func (s *solver) bestUsedBin(i, except int) int {
+	b := s.bestBin(i, except)
+	if b < 0 || !s.used[b] {
+		return -1
+	}
+	return b
+}
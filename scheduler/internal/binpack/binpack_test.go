@@ -0,0 +1,192 @@
+package binpack
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func allBins(n int) []int {
+	res := make([]int, n)
+	for i := range res {
+		res[i] = i
+	}
+	return res
+}
+
+func bins(used []bool, free ...int64) []Bin {
+	res := make([]Bin, len(free))
+	for i, f := range free {
+		res[i] = Bin{Free: []int64{f}}
+		if used != nil {
+			res[i].Used = used[i]
+		}
+	}
+	return res
+}
+
+// check verifies that assignment respects the bins of every item and the
+// capacity of every bin.
+func check(t *testing.T, items []Item, bins []Bin, assignment []int) {
+	t.Helper()
+	free := make([][]int64, len(bins))
+	for b := range bins {
+		free[b] = append([]int64(nil), bins[b].Free...)
+	}
+	for i, b := range assignment {
+		if b < 0 {
+			continue
+		}
+		allowed := false
+		for _, c := range items[i].Bins {
+			allowed = allowed || c == b
+		}
+		if !allowed {
+			t.Errorf("item %d assigned to bin %d, not one of %v", i, b, items[i].Bins)
+		}
+		for d, x := range items[i].Size {
+			free[b][d] -= x
+			if free[b][d] < 0 {
+				t.Errorf("bin %d overflows in dimension %d", b, d)
+			}
+		}
+	}
+}
+
+func TestSolveBestFitDecreasing(t *testing.T) {
+	items := []Item{
+		{Size: []int64{4}, Bins: allBins(2)},
+		{Size: []int64{6}, Bins: allBins(2)},
+		{Size: []int64{5}, Bins: allBins(2)},
+		{Size: []int64{5}, Bins: allBins(2)},
+	}
+	b := bins(nil, 10, 10)
+	got := Solve(items, b)
+	check(t, items, b, got)
+	if want := []int{0, 0, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSolvePrefersUsedBins(t *testing.T) {
+	items := []Item{{Size: []int64{2}, Bins: allBins(3)}}
+	b := bins([]bool{false, true, false}, 10, 10, 3)
+	if got := Solve(items, b); got[0] != 1 {
+		t.Errorf("expected the used bin 1, got %d", got[0])
+	}
+}
+
+func TestSolveRepairsToPackMoreItems(t *testing.T) {
+	// The large item goes first, to bin 0, leaving no room for the small
+	// one that only fits in bin 0, until the large one moves to bin 1.
+	items := []Item{
+		{Size: []int64{6}, Bins: []int{0, 1}},
+		{Size: []int64{4}, Bins: []int{0}},
+	}
+	b := bins(nil, 6, 6)
+	got := Solve(items, b)
+	check(t, items, b, got)
+	if want := []int{1, 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSolveConsolidatesBins(t *testing.T) {
+	// Best fit puts the 6 in bin 0 and the 1 next to it, while the 3 can
+	// only go to bin 1, where all of them fit.
+	items := []Item{
+		{Size: []int64{6}, Bins: []int{0, 1}},
+		{Size: []int64{3}, Bins: []int{1}},
+		{Size: []int64{1}, Bins: []int{0, 1}},
+	}
+	b := bins(nil, 10, 10)
+	got := Solve(items, b)
+	check(t, items, b, got)
+	if want := []int{1, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSolveLeavesItemsThatDoNotFit(t *testing.T) {
+	items := []Item{
+		{Size: []int64{2, 8}, Bins: allBins(1)},
+		{Size: []int64{2, 4}, Bins: allBins(1)},
+		{Size: []int64{1, 1}, Bins: nil},
+	}
+	b := []Bin{{Free: []int64{4, 10}}}
+	got := Solve(items, b)
+	check(t, items, b, got)
+	if want := []int{0, -1, -1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
+
+func TestSolveRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 100; n++ {
+		numBins := 1 + r.Intn(10)
+		b := make([]Bin, numBins)
+		for i := range b {
+			b[i] = Bin{Free: []int64{r.Int63n(16), r.Int63n(16), r.Int63n(4)}, Used: r.Intn(3) == 0}
+		}
+		items := make([]Item, r.Intn(30))
+		for i := range items {
+			items[i].Size = []int64{r.Int63n(8), r.Int63n(8), 1}
+			for c := 0; c < numBins; c++ {
+				if r.Intn(4) != 0 {
+					items[i].Bins = append(items[i].Bins, c)
+				}
+			}
+		}
+		got := Solve(items, b)
+		check(t, items, b, got)
+
+		// No unassigned item fits in one of its bins.
+		free := make([][]int64, numBins)
+		for c := range b {
+			free[c] = append([]int64(nil), b[c].Free...)
+		}
+		for i, c := range got {
+			if c >= 0 {
+				for d, x := range items[i].Size {
+					free[c][d] -= x
+				}
+			}
+		}
+		for i, c := range got {
+			if c >= 0 {
+				continue
+			}
+			for _, c := range items[i].Bins {
+				fits := true
+				for d, x := range items[i].Size {
+					fits = fits && x <= free[c][d]
+				}
+				if fits {
+					t.Errorf("case %d: item %d unassigned but fits in bin %d", n, i, c)
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkSolve(b *testing.B) {
+	for _, size := range []struct{ items, bins int }{{100, 100}, {500, 1000}} {
+		b.Run(fmt.Sprintf("%dpods-%dnodes", size.items, size.bins), func(b *testing.B) {
+			r := rand.New(rand.NewSource(1))
+			bins := make([]Bin, size.bins)
+			for i := range bins {
+				bins[i] = Bin{Free: []int64{32000, 64 << 30, 110}, Used: r.Intn(2) == 0}
+			}
+			items := make([]Item, size.items)
+			for i := range items {
+				items[i] = Item{Size: []int64{r.Int63n(4000), r.Int63n(8 << 30), 1}, Bins: allBins(size.bins)}
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				Solve(items, bins)
+			}
+		})
+	}
+}
@@ -55,6 +55,10 @@ type schedulerCache struct {
 	nodeTree *nodeTree
 	// A map from image name to its imageState.
 	imageStates map[string]*imageState
+	// A map from reservation name to the reservations placed on nodes.
+	reservations map[string]*framework.Reservation
+	// reservationsExpired is called after reservations expire.
+	reservationsExpired func()
 }
 
 type podState struct {
@@ -91,6 +95,8 @@ func newSchedulerCache(ttl, period time.Duration, stop <-chan struct{}) *schedul
 		assumedPods: make(map[string]bool),
 		podStates:   make(map[string]*podState),
 		imageStates: make(map[string]*imageState),
+
+		reservations: make(map[string]*framework.Reservation),
 	}
 }
 
@@ -610,6 +616,7 @@ func (cache *schedulerCache) RemoveNode(node *v1.Node) error {
 	if !ok {
 		return fmt.Errorf("node %v is not found", node.Name)
 	}
+	cache.removeNodeReservations(node)
 	n.info.RemoveNode()
 	// We remove NodeInfo for this node only if there aren't any pods on this node.
 	// We can't do it unconditionally, because notifications about pods are delivered
@@ -683,15 +690,25 @@ func (cache *schedulerCache) run() {
 }
 
 func (cache *schedulerCache) cleanupExpiredAssumedPods() {
-	cache.cleanupAssumedPods(time.Now())
+	if cache.cleanupAssumedPods(time.Now()) {
+		cache.mu.RLock()
+		handler := cache.reservationsExpired
+		cache.mu.RUnlock()
+		if handler != nil {
+			handler()
+		}
+	}
 }
 
 // cleanupAssumedPods exists for making test deterministic by taking time as input argument.
-// It also reports metrics on the cache size for nodes, pods, and assumed pods.
-func (cache *schedulerCache) cleanupAssumedPods(now time.Time) {
+// It also releases expired reservations, returning whether some expired,
+// and reports metrics on the cache size for nodes, pods, assumed pods and
+// reservations.
+func (cache *schedulerCache) cleanupAssumedPods(now time.Time) bool {
 	cache.mu.Lock()
 	defer cache.mu.Unlock()
 	defer cache.updateMetrics()
+	expired := cache.cleanupReservations(now)
 
 	// The size of assumedPods should be small
 	for key := range cache.assumedPods {
@@ -711,6 +728,7 @@ func (cache *schedulerCache) cleanupAssumedPods(now time.Time) {
 			}
 		}
 	}
+	return expired
 }
 
 func (cache *schedulerCache) expirePod(key string, ps *podState) error {
@@ -727,4 +745,5 @@ func (cache *schedulerCache) updateMetrics() {
 	metrics.CacheSize.WithLabelValues("assumed_pods").Set(float64(len(cache.assumedPods)))
 	metrics.CacheSize.WithLabelValues("pods").Set(float64(len(cache.podStates)))
 	metrics.CacheSize.WithLabelValues("nodes").Set(float64(len(cache.nodes)))
+	metrics.CacheSize.WithLabelValues("reservations").Set(float64(len(cache.reservations)))
 }
@@ -85,6 +85,24 @@ type Cache interface {
 	// on this node.
 	UpdateSnapshot(nodeSnapshot *Snapshot) error
 
+	// AddReservation places a reservation and accounts its resources on its
+	// node like an assumed pod, until it expires or is removed. A reservation
+	// without node name is placed on the fullest node matching its node
+	// selector with room for its resources.
+	AddReservation(r *framework.Reservation) error
+
+	// RemoveReservation releases the resources of a reservation and returns it,
+	// e.g. when a pod of its owner consumes them.
+	RemoveReservation(name string) (*framework.Reservation, error)
+
+	// Reservations returns the reservations placed on nodes.
+	Reservations() []*framework.Reservation
+
+	// OnReservationsExpired sets the function called after reservations
+	// expire, e.g. to retry the pods they kept out. It is called without the
+	// cache locked.
+	OnReservationsExpired(handler func())
+
 	// Dump produces a dump of the current cache.
 	Dump() *Dump
 }
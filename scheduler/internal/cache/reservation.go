@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+func (cache *schedulerCache) AddReservation(r *framework.Reservation) error {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if _, ok := cache.reservations[r.Name]; ok {
+		return fmt.Errorf("reservation %v is in the cache", r.Name)
+	}
+	r = r.DeepCopy()
+	if r.NodeName == "" {
+		r.NodeName = cache.nodeForReservation(r)
+		if r.NodeName == "" {
+			return fmt.Errorf("no node has room for reservation %v", r.Name)
+		}
+	} else if n, ok := cache.nodes[r.NodeName]; !ok || n.info.Node() == nil {
+		return fmt.Errorf("node %v of reservation %v is not found", r.NodeName, r.Name)
+	}
+	cache.addPod(r.Pod())
+	cache.reservations[r.Name] = r
+	klog.V(3).Infof("Reserved %v for %v on node %v until %v", r.Name, r.Owner, r.NodeName, r.Expires)
+	return nil
+}
+
+// nodeForReservation returns the node with the least free capacity among
+// the ones matching the node selector of r with room for its resources,
+// empty if there is none. Assumes that lock is already acquired.
+func (cache *schedulerCache) nodeForReservation(r *framework.Reservation) string {
+	selector := labels.SelectorFromSet(r.NodeSelector)
+	request := framework.NewResource(r.Resources)
+	names := make([]string, 0, len(cache.nodes))
+	for name := range cache.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	best, bestFree := "", int64(0)
+	for _, name := range names {
+		info := cache.nodes[name].info
+		node := info.Node()
+		if node == nil || node.Spec.Unschedulable || !selector.Matches(labels.Set(node.Labels)) {
+			continue
+		}
+		free, ok := freeFor(info, request)
+		if ok && (best == "" || free < bestFree) {
+			best, bestFree = name, free
+		}
+	}
+	return best
+}
+
+// freeFor returns the free milli CPUs of info, and whether it has room for
+// request.
+func freeFor(info *framework.NodeInfo, request *framework.Resource) (int64, bool) {
+	a, r := info.Allocatable, info.Requested
+	if len(info.Pods) >= a.AllowedPodNumber ||
+		request.MilliCPU > a.MilliCPU-r.MilliCPU ||
+		request.Memory > a.Memory-r.Memory ||
+		request.EphemeralStorage > a.EphemeralStorage-r.EphemeralStorage {
+		return 0, false
+	}
+	for name, q := range request.ScalarResources {
+		if q > a.ScalarResources[name]-r.ScalarResources[name] {
+			return 0, false
+		}
+	}
+	return a.MilliCPU - r.MilliCPU, true
+}
+
+func (cache *schedulerCache) RemoveReservation(name string) (*framework.Reservation, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	r, ok := cache.reservations[name]
+	if !ok {
+		return nil, fmt.Errorf("reservation %v is not found", name)
+	}
+	if err := cache.removeReservation(r); err != nil {
+		return nil, err
+	}
+	return r.DeepCopy(), nil
+}
+
+// Assumes that lock is already acquired.
+func (cache *schedulerCache) removeReservation(r *framework.Reservation) error {
+	if err := cache.removePod(r.Pod()); err != nil {
+		return err
+	}
+	delete(cache.reservations, r.Name)
+	return nil
+}
+
+func (cache *schedulerCache) Reservations() []*framework.Reservation {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	res := make([]*framework.Reservation, 0, len(cache.reservations))
+	for _, r := range cache.reservations {
+		res = append(res, r.DeepCopy())
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+func (cache *schedulerCache) OnReservationsExpired(handler func()) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.reservationsExpired = handler
+}
+
+// cleanupReservations releases the reservations expired at now and returns
+// whether there were some.
+// Assumes that lock is already acquired.
+func (cache *schedulerCache) cleanupReservations(now time.Time) bool {
+	expired := false
+	for _, r := range cache.reservations {
+		if now.After(r.Expires) {
+			klog.V(3).Infof("Reservation %v for %v on node %v expired", r.Name, r.Owner, r.NodeName)
+			if err := cache.removeReservation(r); err != nil {
+				klog.Errorf("Removing reservation %v failed: %v", r.Name, err)
+				continue
+			}
+			expired = true
+		}
+	}
+	return expired
+}
+
+// removeNodeReservations releases the reservations on node.
+// Assumes that lock is already acquired.
+func (cache *schedulerCache) removeNodeReservations(node *v1.Node) {
+	for _, r := range cache.reservations {
+		if r.NodeName == node.Name {
+			klog.V(3).Infof("Releasing reservation %v of removed node %v", r.Name, node.Name)
+			if err := cache.removeReservation(r); err != nil {
+				klog.Errorf("Removing reservation %v failed: %v", r.Name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var now = time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+
+func makeNode(name, cpu string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"zone": name}},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func makePod(name, nodeName, cpu string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "c",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+				},
+			}},
+		},
+	}
+}
+
+func makeReservation(name, cpu string, expires time.Time) *framework.Reservation {
+	return &framework.Reservation{
+		Name:      name,
+		Owner:     "default/web",
+		Resources: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu)},
+		Expires:   expires,
+	}
+}
+
+// newReservationCache returns a cache of node a with 4 free cpus and node b
+// with 2 of its 8 cpus free.
+func newReservationCache(t *testing.T) *schedulerCache {
+	cache := newSchedulerCache(time.Minute, time.Second, nil)
+	for _, n := range []*v1.Node{makeNode("a", "4"), makeNode("b", "8")} {
+		if err := cache.AddNode(n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cache.AddPod(makePod("busy", "b", "6")); err != nil {
+		t.Fatal(err)
+	}
+	return cache
+}
+
+func requestedMilliCPU(t *testing.T, cache *schedulerCache, node string) int64 {
+	cache.mu.RLock()
+	defer cache.mu.RUnlock()
+	n, ok := cache.nodes[node]
+	if !ok {
+		t.Fatalf("node %s is not in the cache", node)
+	}
+	return n.info.Requested.MilliCPU
+}
+
+func TestAddReservation(t *testing.T) {
+	tests := []struct {
+		name        string
+		cpu         string
+		nodeName    string
+		selector    map[string]string
+		want        string
+		wantErr     bool
+		wantRequest int64
+	}{
+		{
+			name:        "fullest node with room",
+			cpu:         "1",
+			want:        "b",
+			wantRequest: 7000,
+		},
+		{
+			name:        "only node with room",
+			cpu:         "3",
+			want:        "a",
+			wantRequest: 3000,
+		},
+		{
+			name:        "node selector",
+			cpu:         "1",
+			selector:    map[string]string{"zone": "a"},
+			want:        "a",
+			wantRequest: 1000,
+		},
+		{
+			name:    "no node with room",
+			cpu:     "5",
+			wantErr: true,
+		},
+		{
+			name:     "no node matching the selector",
+			cpu:      "1",
+			selector: map[string]string{"zone": "c"},
+			wantErr:  true,
+		},
+		{
+			name:        "node name",
+			cpu:         "1",
+			nodeName:    "a",
+			want:        "a",
+			wantRequest: 1000,
+		},
+		{
+			name:     "unknown node name",
+			cpu:      "1",
+			nodeName: "c",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cache := newReservationCache(t)
+			r := makeReservation("web-0", test.cpu, now.Add(time.Hour))
+			r.NodeName = test.nodeName
+			r.NodeSelector = test.selector
+			err := cache.AddReservation(r)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if r.NodeName != test.nodeName {
+				t.Errorf("the reservation passed to AddReservation was modified")
+			}
+			res := cache.Reservations()
+			if test.wantErr {
+				if len(res) != 0 {
+					t.Errorf("got reservations %v, want none", res)
+				}
+				return
+			}
+			if len(res) != 1 || res[0].NodeName != test.want {
+				t.Fatalf("got reservations %v, want web-0 on %s", res, test.want)
+			}
+			if got := requestedMilliCPU(t, cache, test.want); got != test.wantRequest {
+				t.Errorf("got %dm cpu requested on %s, want %dm", got, test.want, test.wantRequest)
+			}
+		})
+	}
+}
+
+func TestAddReservationTwice(t *testing.T) {
+	cache := newReservationCache(t)
+	if err := cache.AddReservation(makeReservation("web-0", "1", now)); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.AddReservation(makeReservation("web-0", "1", now)); err == nil {
+		t.Errorf("expected an error for a reservation already in the cache")
+	}
+}
+
+func TestRemoveReservation(t *testing.T) {
+	cache := newReservationCache(t)
+	for _, name := range []string{"web-1", "web-0"} {
+		if err := cache.AddReservation(makeReservation(name, "1", now)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	res := cache.Reservations()
+	if len(res) != 2 || res[0].Name != "web-0" || res[1].Name != "web-1" {
+		t.Fatalf("got reservations %v, want web-0 and web-1", res)
+	}
+
+	r, err := cache.RemoveReservation("web-0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Name != "web-0" || r.NodeName != "b" {
+		t.Errorf("got removed reservation %v, want web-0 on b", r)
+	}
+	if got := requestedMilliCPU(t, cache, "b"); got != 7000 {
+		t.Errorf("got %dm cpu requested on b, want 7000m", got)
+	}
+	if _, err := cache.RemoveReservation("web-0"); err == nil {
+		t.Errorf("expected an error for a removed reservation")
+	}
+}
+
+func TestReservationsExpire(t *testing.T) {
+	cache := newReservationCache(t)
+	expired := 0
+	cache.OnReservationsExpired(func() { expired++ })
+	if err := cache.AddReservation(makeReservation("old", "1", time.Now().Add(-time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.AddReservation(makeReservation("new", "1", time.Now().Add(time.Hour))); err != nil {
+		t.Fatal(err)
+	}
+
+	cache.cleanupExpiredAssumedPods()
+	if expired != 1 {
+		t.Errorf("got %d expiry notifications, want 1", expired)
+	}
+	if res := cache.Reservations(); len(res) != 1 || res[0].Name != "new" {
+		t.Errorf("got reservations %v, want new", res)
+	}
+	if got := requestedMilliCPU(t, cache, "b"); got != 7000 {
+		t.Errorf("got %dm cpu requested on b, want 7000m", got)
+	}
+
+	cache.cleanupExpiredAssumedPods()
+	if expired != 1 {
+		t.Errorf("got %d expiry notifications without expired reservations, want 1", expired)
+	}
+}
+
+func TestRemoveNodeReleasesReservations(t *testing.T) {
+	cache := newReservationCache(t)
+	expired := 0
+	cache.OnReservationsExpired(func() { expired++ })
+	r := makeReservation("web-0", "1", now)
+	r.NodeName = "a"
+	if err := cache.AddReservation(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := cache.RemoveNode(makeNode("a", "4")); err != nil {
+		t.Fatal(err)
+	}
+	if res := cache.Reservations(); len(res) != 0 {
+		t.Errorf("got reservations %v, want none", res)
+	}
+	if _, ok := cache.nodes["a"]; ok {
+		t.Errorf("expected the node without pods to leave the cache")
+	}
+	if expired != 0 {
+		t.Errorf("got %d expiry notifications, want none", expired)
+	}
+}
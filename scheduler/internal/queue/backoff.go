@@ -0,0 +1,140 @@
+package queue
+
+import (
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+)
+
+// BackoffPolicy decides how long a pod backs off after a failed attempt.
+// Backoff is called whenever the backoff queue is reordered, so it must
+// return the same duration for the same attempt of a pod.
+type BackoffPolicy interface {
+	Backoff(podInfo *framework.QueuedPodInfo) time.Duration
+}
+
+// ExponentialBackoff grows the backoff from Initial by Multiplier on each
+// attempt, up to Max. Jitter, from 0 to 1, spreads the backoff by up to
+// that fraction in either direction.
+type ExponentialBackoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// Backoff implements BackoffPolicy.
+func (b *ExponentialBackoff) Backoff(podInfo *framework.QueuedPodInfo) time.Duration {
+	duration := b.Initial
+	for i := 1; i < podInfo.Attempts; i++ {
+		duration = time.Duration(float64(duration) * b.Multiplier)
+		if duration > b.Max {
+			duration = b.Max
+			break
+		}
+	}
+	if b.Jitter > 0 {
+		duration += time.Duration(float64(duration) * b.Jitter * jitterFraction(podInfo))
+		if duration > b.Max {
+			duration = b.Max
+		}
+	}
+	return duration
+}
+
+// jitterFraction returns a fraction in [-1, 1) derived from the pod and its
+// attempt, so that it is stable across calls.
+func jitterFraction(podInfo *framework.QueuedPodInfo) float64 {
+	h := fnv.New32a()
+	h.Write([]byte(podInfo.Pod.UID))
+	h.Write([]byte(strconv.Itoa(podInfo.Attempts)))
+	return float64(h.Sum32())/float64(1<<31) - 1
+}
+
+// ReasonBackoff picks the policy of the reason the last attempt of a pod
+// failed for, Default if the reason has none.
+type ReasonBackoff struct {
+	Default BackoffPolicy
+	Reasons map[string]BackoffPolicy
+}
+
+// Backoff implements BackoffPolicy.
+func (b *ReasonBackoff) Backoff(podInfo *framework.QueuedPodInfo) time.Duration {
+	if policy, ok := b.Reasons[podInfo.FailureReason]; ok {
+		return policy.Backoff(podInfo)
+	}
+	return b.Default.Backoff(podInfo)
+}
+
+// PriorityCap caps the backoff of pods of at least MinPriority to Max.
+type PriorityCap struct {
+	MinPriority int32
+	Max         time.Duration
+}
+
+// PriorityCappedBackoff caps the backoff of Policy by the priority of pods.
+// The cap of the highest MinPriority not above the priority of a pod
+// applies.
+type PriorityCappedBackoff struct {
+	Policy BackoffPolicy
+	// Caps are sorted by decreasing MinPriority.
+	Caps []PriorityCap
+}
+
+// Backoff implements BackoffPolicy.
+func (b *PriorityCappedBackoff) Backoff(podInfo *framework.QueuedPodInfo) time.Duration {
+	duration := b.Policy.Backoff(podInfo)
+	var priority int32
+	if podInfo.Pod.Spec.Priority != nil {
+		priority = *podInfo.Pod.Spec.Priority
+	}
+	for _, c := range b.Caps {
+		if priority >= c.MinPriority {
+			if duration > c.Max {
+				duration = c.Max
+			}
+			break
+		}
+	}
+	return duration
+}
+
+// NewBackoffPolicy returns the policy configured by cfg, growing from
+// initial to max unless the reason of a failure overrides them. A nil cfg
+// doubles the backoff.
+func NewBackoffPolicy(cfg *config.BackoffPolicy, initial, max time.Duration) BackoffPolicy {
+	if cfg == nil {
+		return &ExponentialBackoff{Initial: initial, Max: max, Multiplier: 2}
+	}
+	multiplier := float64(cfg.Multiplier)
+	if multiplier == 0 {
+		multiplier = 2
+	}
+	jitter := float64(cfg.JitterPercent) / 100
+	var policy BackoffPolicy = &ExponentialBackoff{Initial: initial, Max: max, Multiplier: multiplier, Jitter: jitter}
+	if len(cfg.Reasons) > 0 {
+		reasons := make(map[string]BackoffPolicy, len(cfg.Reasons))
+		for _, r := range cfg.Reasons {
+			reasons[r.Reason] = &ExponentialBackoff{
+				Initial:    time.Duration(r.InitialSeconds) * time.Second,
+				Max:        time.Duration(r.MaxSeconds) * time.Second,
+				Multiplier: multiplier,
+				Jitter:     jitter,
+			}
+		}
+		policy = &ReasonBackoff{Default: policy, Reasons: reasons}
+	}
+	if len(cfg.PriorityCaps) > 0 {
+		caps := make([]PriorityCap, 0, len(cfg.PriorityCaps))
+		for _, c := range cfg.PriorityCaps {
+			caps = append(caps, PriorityCap{MinPriority: c.MinPriority, Max: time.Duration(c.MaxSeconds) * time.Second})
+		}
+		sort.SliceStable(caps, func(i, j int) bool { return caps[i].MinPriority > caps[j].MinPriority })
+		policy = &PriorityCappedBackoff{Policy: policy, Caps: caps}
+	}
+	return policy
+}
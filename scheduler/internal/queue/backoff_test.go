@@ -0,0 +1,184 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// fakeClock is a clock only moving when told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) step(d time.Duration) { c.now = c.now.Add(d) }
+
+func newClock() *fakeClock {
+	return &fakeClock{now: time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)}
+}
+
+func makePod(name string) *v1.Pod {
+	return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, UID: types.UID(name)}}
+}
+
+func failedPod(name, reason string, attempts int) *framework.QueuedPodInfo {
+	return &framework.QueuedPodInfo{Pod: makePod(name), FailureReason: reason, Attempts: attempts}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := &ExponentialBackoff{Initial: time.Second, Max: 10 * time.Second, Multiplier: 2}
+	for attempts, want := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 8 * time.Second,
+		5: 10 * time.Second,
+		9: 10 * time.Second,
+	} {
+		if got := b.Backoff(failedPod("p", "", attempts)); got != want {
+			t.Errorf("attempt %d: got %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+func TestExponentialBackoffJitter(t *testing.T) {
+	b := &ExponentialBackoff{Initial: 4 * time.Second, Max: time.Minute, Multiplier: 2, Jitter: 0.5}
+	spread := false
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		pInfo := failedPod(name, "", 1)
+		got := b.Backoff(pInfo)
+		if got < 2*time.Second || got > 6*time.Second {
+			t.Errorf("pod %s: got %v, want within 50%% of 4s", name, got)
+		}
+		if again := b.Backoff(pInfo); again != got {
+			t.Errorf("pod %s: got %v then %v, want a stable backoff", name, got, again)
+		}
+		if got != 4*time.Second {
+			spread = true
+		}
+	}
+	if !spread {
+		t.Errorf("expected the jitter to spread the backoff of some pod")
+	}
+}
+
+func TestReasonBackoff(t *testing.T) {
+	policy := NewBackoffPolicy(&config.BackoffPolicy{
+		Reasons: []config.ReasonBackoff{
+			{Reason: config.FailureReasonInsufficientResources, InitialSeconds: 5, MaxSeconds: 60},
+			{Reason: config.FailureReasonError, InitialSeconds: 1, MaxSeconds: 2},
+		},
+	}, time.Second, 10*time.Second)
+
+	tests := []struct {
+		name     string
+		reason   string
+		attempts int
+		want     time.Duration
+	}{
+		{name: "default curve", reason: config.FailureReasonUnschedulable, attempts: 1, want: time.Second},
+		{name: "default curve grows", reason: config.FailureReasonUnschedulable, attempts: 3, want: 4 * time.Second},
+		{name: "default curve max", reason: config.FailureReasonUnschedulable, attempts: 6, want: 10 * time.Second},
+		{name: "reason without a curve", reason: config.FailureReasonNoNodesAvailable, attempts: 2, want: 2 * time.Second},
+		{name: "no reason", attempts: 2, want: 2 * time.Second},
+		{name: "insufficient resources initial", reason: config.FailureReasonInsufficientResources, attempts: 1, want: 5 * time.Second},
+		{name: "insufficient resources grows", reason: config.FailureReasonInsufficientResources, attempts: 3, want: 20 * time.Second},
+		{name: "insufficient resources above the default max", reason: config.FailureReasonInsufficientResources, attempts: 5, want: 60 * time.Second},
+		{name: "error initial", reason: config.FailureReasonError, attempts: 1, want: time.Second},
+		{name: "error max", reason: config.FailureReasonError, attempts: 3, want: 2 * time.Second},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := policy.Backoff(failedPod("p", test.reason, test.attempts)); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPriorityCappedBackoff(t *testing.T) {
+	policy := NewBackoffPolicy(&config.BackoffPolicy{
+		PriorityCaps: []config.PriorityBackoffCap{
+			{MinPriority: 100, MaxSeconds: 4},
+			{MinPriority: 1000, MaxSeconds: 1},
+		},
+	}, time.Second, 10*time.Second)
+
+	for priority, want := range map[int32]time.Duration{
+		0:    8 * time.Second,
+		100:  4 * time.Second,
+		999:  4 * time.Second,
+		1000: time.Second,
+	} {
+		pInfo := failedPod("p", "", 4)
+		p := priority
+		pInfo.Pod.Spec.Priority = &p
+		if got := policy.Backoff(pInfo); got != want {
+			t.Errorf("priority %d: got %v, want %v", priority, got, want)
+		}
+	}
+}
+
+// TestBackoffQueueByReason checks that pods leave the backoff queue along
+// the curve of the reason their last attempt failed for.
+func TestBackoffQueueByReason(t *testing.T) {
+	clock := newClock()
+	policy := NewBackoffPolicy(&config.BackoffPolicy{
+		Reasons: []config.ReasonBackoff{
+			{Reason: config.FailureReasonInsufficientResources, InitialSeconds: 10, MaxSeconds: 60},
+		},
+	}, time.Second, 10*time.Second)
+	q := NewPriorityQueue(func(p1, p2 *framework.QueuedPodInfo) bool { return p1.Timestamp.Before(p2.Timestamp) },
+		WithClock(clock), WithBackoffPolicy(policy))
+
+	reasons := map[string]string{
+		"unschedulable": config.FailureReasonUnschedulable,
+		"insufficient":  config.FailureReasonInsufficientResources,
+	}
+	for name := range reasons {
+		if err := q.Add(makePod(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var popped []*framework.QueuedPodInfo
+	for range reasons {
+		pInfo, err := q.Pop()
+		if err != nil {
+			t.Fatal(err)
+		}
+		popped = append(popped, pInfo)
+	}
+	// A move request sends pods failing afterwards to the backoff queue.
+	q.MoveAllToActiveOrBackoffQueue(Unknown)
+	for _, pInfo := range popped {
+		pInfo.FailureReason = reasons[pInfo.Pod.Name]
+		if err := q.AddUnschedulableIfNotPresent(pInfo, q.SchedulingCycle()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if q.podBackoffQ.Len() != 2 {
+		t.Fatalf("got %d pods backing off, want 2", q.podBackoffQ.Len())
+	}
+
+	clock.step(2 * time.Second)
+	q.flushBackoffQCompleted()
+	if q.activeQ.Len() != 1 || q.podBackoffQ.Len() != 1 {
+		t.Fatalf("got %d active and %d backing off pods after 2s, want 1 and 1", q.activeQ.Len(), q.podBackoffQ.Len())
+	}
+	if pInfo, _ := q.Pop(); pInfo.Pod.Name != "unschedulable" {
+		t.Errorf("got %s first, want the unschedulable pod", pInfo.Pod.Name)
+	}
+
+	clock.step(8 * time.Second)
+	q.flushBackoffQCompleted()
+	if q.activeQ.Len() != 1 || q.podBackoffQ.Len() != 0 {
+		t.Errorf("got %d active and %d backing off pods after 10s, want 1 and 0", q.activeQ.Len(), q.podBackoffQ.Len())
+	}
+}
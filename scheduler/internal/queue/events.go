@@ -1,56 +1,69 @@
 package queue
 
-// Events that trigger scheduler queue to change.
+import (
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+)
+
+// Events that trigger scheduler queue to change, used as metric labels only.
 const (
-	// Unknown event
-	Unknown = "Unknown"
 	// PodAdd is the event when a new pod is added to API server.
 	PodAdd = "PodAdd"
-	// NodeAdd is the event when a new node is added to the cluster.
-	NodeAdd = "NodeAdd"
 	// ScheduleAttemptFailure is the event when a schedule attempt fails.
 	ScheduleAttemptFailure = "ScheduleAttemptFailure"
 	// BackoffComplete is the event when a pod finishes backoff.
 	BackoffComplete = "BackoffComplete"
+)
+
+// Cluster events that move unschedulable pods. A pod is only moved by the
+// events registered by the plugins that rejected it, see
+// framework.EnqueueExtensions.
+var (
+	// Unknown event, moves every pod.
+	Unknown = framework.ClusterEvent{Resource: framework.WildCard, ActionType: framework.All, Label: "Unknown"}
+	// NodeAdd is the event when a new node is added to the cluster.
+	NodeAdd = framework.ClusterEvent{Resource: framework.Node, ActionType: framework.Add, Label: "NodeAdd"}
 	// UnschedulableTimeout is the event when a pod stays in unschedulable for longer than timeout.
-	UnschedulableTimeout = "UnschedulableTimeout"
+	UnschedulableTimeout = framework.ClusterEvent{Resource: framework.WildCard, ActionType: framework.All, Label: "UnschedulableTimeout"}
 	// AssignedPodAdd is the event when a pod is added that causes pods with matching affinity terms
 	// to be more schedulable.
-	AssignedPodAdd = "AssignedPodAdd"
+	AssignedPodAdd = framework.ClusterEvent{Resource: framework.Pod, ActionType: framework.Add, Label: "AssignedPodAdd"}
 	// AssignedPodUpdate is the event when a pod is updated that causes pods with matching affinity
 	// terms to be more schedulable.
-	AssignedPodUpdate = "AssignedPodUpdate"
+	AssignedPodUpdate = framework.ClusterEvent{Resource: framework.Pod, ActionType: framework.Update, Label: "AssignedPodUpdate"}
 	// AssignedPodDelete is the event when a pod is deleted that causes pods with matching affinity
 	// terms to be more schedulable.
-	AssignedPodDelete = "AssignedPodDelete"
+	AssignedPodDelete = framework.ClusterEvent{Resource: framework.Pod, ActionType: framework.Delete, Label: "AssignedPodDelete"}
+	// ReservationRelease is the event when reserved capacity is released,
+	// e.g. when the reservation expires.
+	ReservationRelease = framework.ClusterEvent{Resource: framework.Pod, ActionType: framework.Delete, Label: "ReservationRelease"}
 	// PvAdd is the event when a persistent volume is added in the cluster.
-	PvAdd = "PvAdd"
+	PvAdd = framework.ClusterEvent{Resource: framework.PersistentVolume, ActionType: framework.Add, Label: "PvAdd"}
 	// PvUpdate is the event when a persistent volume is updated in the cluster.
-	PvUpdate = "PvUpdate"
+	PvUpdate = framework.ClusterEvent{Resource: framework.PersistentVolume, ActionType: framework.Update, Label: "PvUpdate"}
 	// PvcAdd is the event when a persistent volume claim is added in the cluster.
-	PvcAdd = "PvcAdd"
+	PvcAdd = framework.ClusterEvent{Resource: framework.PersistentVolumeClaim, ActionType: framework.Add, Label: "PvcAdd"}
 	// PvcUpdate is the event when a persistent volume claim is updated in the cluster.
-	PvcUpdate = "PvcUpdate"
+	PvcUpdate = framework.ClusterEvent{Resource: framework.PersistentVolumeClaim, ActionType: framework.Update, Label: "PvcUpdate"}
 	// StorageClassAdd is the event when a StorageClass is added in the cluster.
-	StorageClassAdd = "StorageClassAdd"
+	StorageClassAdd = framework.ClusterEvent{Resource: framework.StorageClass, ActionType: framework.Add, Label: "StorageClassAdd"}
 	// ServiceAdd is the event when a service is added in the cluster.
-	ServiceAdd = "ServiceAdd"
+	ServiceAdd = framework.ClusterEvent{Resource: framework.Service, ActionType: framework.Add, Label: "ServiceAdd"}
 	// ServiceUpdate is the event when a service is updated in the cluster.
-	ServiceUpdate = "ServiceUpdate"
+	ServiceUpdate = framework.ClusterEvent{Resource: framework.Service, ActionType: framework.Update, Label: "ServiceUpdate"}
 	// ServiceDelete is the event when a service is deleted in the cluster.
-	ServiceDelete = "ServiceDelete"
+	ServiceDelete = framework.ClusterEvent{Resource: framework.Service, ActionType: framework.Delete, Label: "ServiceDelete"}
 	// CSINodeAdd is the event when a CSI node is added in the cluster.
-	CSINodeAdd = "CSINodeAdd"
+	CSINodeAdd = framework.ClusterEvent{Resource: framework.CSINode, ActionType: framework.Add, Label: "CSINodeAdd"}
 	// CSINodeUpdate is the event when a CSI node is updated in the cluster.
-	CSINodeUpdate = "CSINodeUpdate"
+	CSINodeUpdate = framework.ClusterEvent{Resource: framework.CSINode, ActionType: framework.Update, Label: "CSINodeUpdate"}
 	// NodeSpecUnschedulableChange is the event when unschedulable node spec is changed.
-	NodeSpecUnschedulableChange = "NodeSpecUnschedulableChange"
+	NodeSpecUnschedulableChange = framework.ClusterEvent{Resource: framework.Node, ActionType: framework.UpdateNodeTaint, Label: "NodeSpecUnschedulableChange"}
 	// NodeAllocatableChange is the event when node allocatable is changed.
-	NodeAllocatableChange = "NodeAllocatableChange"
-	// NodeLabelsChange is the event when node label is changed.
-	NodeLabelChange = "NodeLabelChange"
-	// NodeTaintsChange is the event when node taint is changed.
-	NodeTaintChange = "NodeTaintChange"
+	NodeAllocatableChange = framework.ClusterEvent{Resource: framework.Node, ActionType: framework.UpdateNodeAllocatable, Label: "NodeAllocatableChange"}
+	// NodeLabelChange is the event when node label is changed.
+	NodeLabelChange = framework.ClusterEvent{Resource: framework.Node, ActionType: framework.UpdateNodeLabel, Label: "NodeLabelChange"}
+	// NodeTaintChange is the event when node taint is changed.
+	NodeTaintChange = framework.ClusterEvent{Resource: framework.Node, ActionType: framework.UpdateNodeTaint, Label: "NodeTaintChange"}
 	// NodeConditionChange is the event when node condition is changed.
-	NodeConditionChange = "NodeConditionChange"
+	NodeConditionChange = framework.ClusterEvent{Resource: framework.Node, ActionType: framework.UpdateNodeCondition, Label: "NodeConditionChange"}
 )
@@ -22,6 +22,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
 )
@@ -62,9 +63,12 @@ type SchedulingQueue interface {
 	// Pop removes the head of the queue and returns it. It blocks if the
 	// queue is empty and waits until a new item is added to the queue.
 	Pop() (*framework.QueuedPodInfo, error)
+	// PopCompatible removes up to max pods from the head of the queue as
+	// long as compatible returns true for them. It does not block.
+	PopCompatible(max int, compatible func(*v1.Pod) bool) []*framework.QueuedPodInfo
 	Update(oldPod, newPod *v1.Pod) error
 	Delete(pod *v1.Pod) error
-	MoveAllToActiveOrBackoffQueue(event string)
+	MoveAllToActiveOrBackoffQueue(event framework.ClusterEvent)
 	AssignedPodAdded(pod *v1.Pod)
 	AssignedPodUpdated(pod *v1.Pod)
 	PendingPods() []*v1.Pod
@@ -105,6 +109,10 @@ type PriorityQueue struct {
 	podInitialBackoffDuration time.Duration
 	// pod maximum backoff duration.
 	podMaxBackoffDuration time.Duration
+	// backoffPolicy decides how long pods back off.
+	backoffPolicy BackoffPolicy
+	// clusterEventMap holds the plugins interested in each cluster event.
+	clusterEventMap map[framework.ClusterEvent]sets.String
 
 	lock sync.RWMutex
 	cond sync.Cond
@@ -135,7 +143,9 @@ type priorityQueueOptions struct {
 	clock                     util.Clock
 	podInitialBackoffDuration time.Duration
 	podMaxBackoffDuration     time.Duration
+	backoffPolicy             BackoffPolicy
 	podNominator              framework.PodNominator
+	clusterEventMap           map[framework.ClusterEvent]sets.String
 }
 
 // Option configures a PriorityQueue
@@ -162,6 +172,22 @@ func WithPodMaxBackoffDuration(duration time.Duration) Option {
 	}
 }
 
+// WithBackoffPolicy sets the backoff policy of PriorityQueue. By default the
+// backoff doubles from the initial to the max pod backoff duration.
+func WithBackoffPolicy(policy BackoffPolicy) Option {
+	return func(o *priorityQueueOptions) {
+		o.backoffPolicy = policy
+	}
+}
+
+// WithClusterEventMap sets the plugins interested in each cluster event.
+// Without it every event moves every unschedulable pod.
+func WithClusterEventMap(m map[framework.ClusterEvent]sets.String) Option {
+	return func(o *priorityQueueOptions) {
+		o.clusterEventMap = m
+	}
+}
+
 // WithPodNominator sets pod nominator for PriorityQueue.
 func WithPodNominator(pn framework.PodNominator) Option {
 	return func(o *priorityQueueOptions) {
@@ -204,6 +230,9 @@ func NewPriorityQueue(
 	if options.podNominator == nil {
 		options.podNominator = NewPodNominator()
 	}
+	if options.backoffPolicy == nil {
+		options.backoffPolicy = NewBackoffPolicy(nil, options.podInitialBackoffDuration, options.podMaxBackoffDuration)
+	}
 
 	pq := &PriorityQueue{
 		PodNominator:              options.podNominator,
@@ -211,6 +240,8 @@ func NewPriorityQueue(
 		stop:                      make(chan struct{}),
 		podInitialBackoffDuration: options.podInitialBackoffDuration,
 		podMaxBackoffDuration:     options.podMaxBackoffDuration,
+		backoffPolicy:             options.backoffPolicy,
+		clusterEventMap:           options.clusterEventMap,
 		activeQ:                   heap.NewWithRecorder(podInfoKeyFunc, comp, metrics.NewActivePodsRecorder()),
 		unschedulableQ:            newUnschedulablePodsMap(metrics.NewUnschedulablePodsRecorder()),
 		moveRequestCycle:          -1,
@@ -332,6 +363,8 @@ func (p *PriorityQueue) flushBackoffQCompleted() {
 		}
 		p.activeQ.Add(rawPodInfo)
 		metrics.SchedulerQueueIncomingPods.WithLabelValues("active", BackoffComplete).Inc()
+		pInfo := rawPodInfo.(*framework.QueuedPodInfo)
+		metrics.PodBackoffDuration.WithLabelValues(pInfo.FailureReason).Observe(p.clock.Now().Sub(pInfo.Timestamp).Seconds())
 		defer p.cond.Broadcast()
 	}
 }
@@ -381,6 +414,29 @@ func (p *PriorityQueue) Pop() (*framework.QueuedPodInfo, error) {
 	return pInfo, err
 }
 
+// PopCompatible removes up to max pods from the head of the active queue, as
+// long as compatible returns true for them, e.g. to schedule them as a batch.
+// Unlike Pop, it does not block. It increments scheduling cycle for every
+// popped pod.
+func (p *PriorityQueue) PopCompatible(max int, compatible func(*v1.Pod) bool) []*framework.QueuedPodInfo {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var res []*framework.QueuedPodInfo
+	for len(res) < max && p.activeQ.Len() > 0 {
+		head := p.activeQ.Peek().(*framework.QueuedPodInfo)
+		if !compatible(head.Pod) {
+			break
+		}
+		if _, err := p.activeQ.Pop(); err != nil {
+			break
+		}
+		head.Attempts++
+		p.schedulingCycle++
+		res = append(res, head)
+	}
+	return res
+}
+
 // isPodUpdated checks if the pod is updated in a way that it may have become
 // schedulable. It drops status of the pod and compares it with old version.
 func isPodUpdated(oldPod, newPod *v1.Pod) bool {
@@ -477,11 +533,12 @@ func (p *PriorityQueue) AssignedPodUpdated(pod *v1.Pod) {
 	p.lock.Unlock()
 }
 
-// MoveAllToActiveOrBackoffQueue moves all pods from unschedulableQ to activeQ or backoffQ.
+// MoveAllToActiveOrBackoffQueue moves all pods from unschedulableQ which may
+// be schedulable after event to activeQ or backoffQ.
 // This function adds all pods and then signals the condition variable to ensure that
 // if Pop() is waiting for an item, it receives it after all the pods are in the
 // queue and the head is the highest priority pod.
-func (p *PriorityQueue) MoveAllToActiveOrBackoffQueue(event string) {
+func (p *PriorityQueue) MoveAllToActiveOrBackoffQueue(event framework.ClusterEvent) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	unschedulablePods := make([]*framework.QueuedPodInfo, 0, len(p.unschedulableQ.podInfoMap))
@@ -492,21 +549,24 @@ func (p *PriorityQueue) MoveAllToActiveOrBackoffQueue(event string) {
 }
 
 // NOTE: this function assumes lock has been acquired in caller
-func (p *PriorityQueue) movePodsToActiveOrBackoffQueue(podInfoList []*framework.QueuedPodInfo, event string) {
+func (p *PriorityQueue) movePodsToActiveOrBackoffQueue(podInfoList []*framework.QueuedPodInfo, event framework.ClusterEvent) {
 	for _, pInfo := range podInfoList {
+		if !p.podMatchesEvent(pInfo, event) {
+			continue
+		}
 		pod := pInfo.Pod
 		if p.isPodBackingoff(pInfo) {
 			if err := p.podBackoffQ.Add(pInfo); err != nil {
 				klog.Errorf("Error adding pod %v to the backoff queue: %v", pod.Name, err)
 			} else {
-				metrics.SchedulerQueueIncomingPods.WithLabelValues("backoff", event).Inc()
+				metrics.SchedulerQueueIncomingPods.WithLabelValues("backoff", event.Label).Inc()
 				p.unschedulableQ.delete(pod)
 			}
 		} else {
 			if err := p.activeQ.Add(pInfo); err != nil {
 				klog.Errorf("Error adding pod %v to the scheduling queue: %v", pod.Name, err)
 			} else {
-				metrics.SchedulerQueueIncomingPods.WithLabelValues("active", event).Inc()
+				metrics.SchedulerQueueIncomingPods.WithLabelValues("active", event.Label).Inc()
 				p.unschedulableQ.delete(pod)
 			}
 		}
@@ -515,6 +575,21 @@ func (p *PriorityQueue) movePodsToActiveOrBackoffQueue(podInfoList []*framework.
 	p.cond.Broadcast()
 }
 
+// podMatchesEvent returns true if event may make pInfo schedulable, that is
+// if one of the plugins that rejected it registered a matching event. Pods
+// rejected by unknown plugins match any event.
+func (p *PriorityQueue) podMatchesEvent(pInfo *framework.QueuedPodInfo, event framework.ClusterEvent) bool {
+	if event.IsWildCard() || p.clusterEventMap == nil || len(pInfo.UnschedulablePlugins) == 0 {
+		return true
+	}
+	for evt, plugins := range p.clusterEventMap {
+		if evt.Match(event) && plugins.HasAny(pInfo.UnschedulablePlugins.UnsortedList()...) {
+			return true
+		}
+	}
+	return false
+}
+
 // getUnschedulablePodsWithMatchingAffinityTerm returns unschedulable pods which have
 // any affinity term that matches "pod".
 // NOTE: this function assumes lock has been acquired in caller.
@@ -626,16 +701,9 @@ func (p *PriorityQueue) getBackoffTime(podInfo *framework.QueuedPodInfo) time.Ti
 }
 
 // calculateBackoffDuration is a helper function for calculating the backoffDuration
-// based on the number of attempts the pod has made.
+// based on the number of attempts the pod has made and the backoff policy.
 func (p *PriorityQueue) calculateBackoffDuration(podInfo *framework.QueuedPodInfo) time.Duration {
-	duration := p.podInitialBackoffDuration
-	for i := 1; i < podInfo.Attempts; i++ {
-		duration = duration * 2
-		if duration > p.podMaxBackoffDuration {
-			return p.podMaxBackoffDuration
-		}
-	}
-	return duration
+	return p.backoffPolicy.Backoff(podInfo)
 }
 
 func updatePod(oldPodInfo interface{}, newPod *v1.Pod) *framework.QueuedPodInfo {
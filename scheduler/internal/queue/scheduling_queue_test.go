@@ -0,0 +1,169 @@
+package queue
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// clusterEventMap is the events registered by the plugins of the tests.
+// NoExtensions does not implement EnqueueExtensions, so the framework
+// registers it for every event.
+func clusterEventMap() map[framework.ClusterEvent]sets.String {
+	return map[framework.ClusterEvent]sets.String{
+		NodeAdd:           sets.NewString("NodeResourcesFit", "TaintToleration"),
+		NodeTaintChange:   sets.NewString("TaintToleration"),
+		AssignedPodDelete: sets.NewString("NodeResourcesFit"),
+		{Resource: framework.WildCard, ActionType: framework.All}: sets.NewString("NoExtensions"),
+	}
+}
+
+func TestPodMatchesEvent(t *testing.T) {
+	nodeResized := framework.ClusterEvent{
+		Resource:   framework.Node,
+		ActionType: framework.UpdateNodeAllocatable | framework.UpdateNodeTaint,
+		Label:      NodeAllocatableChange.Label,
+	}
+	tests := []struct {
+		name     string
+		eventMap map[framework.ClusterEvent]sets.String
+		plugins  sets.String
+		event    framework.ClusterEvent
+		want     bool
+	}{
+		{
+			name:     "registered event",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("TaintToleration"),
+			event:    NodeTaintChange,
+			want:     true,
+		},
+		{
+			name:     "event registered by another plugin",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("NodeResourcesFit"),
+			event:    NodeTaintChange,
+		},
+		{
+			name:     "event not registered",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("TaintToleration"),
+			event:    NodeAllocatableChange,
+		},
+		{
+			name:     "one of several plugins registered the event",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("NodeResourcesFit", "TaintToleration"),
+			event:    NodeTaintChange,
+			want:     true,
+		},
+		{
+			name:     "event of several action types",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("TaintToleration"),
+			event:    nodeResized,
+			want:     true,
+		},
+		{
+			name:     "event of the same resource and action",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("NodeResourcesFit"),
+			event:    ReservationRelease,
+			want:     true,
+		},
+		{
+			name:     "plugin without extensions",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("NoExtensions"),
+			event:    PvcAdd,
+			want:     true,
+		},
+		{
+			name:     "wildcard event",
+			eventMap: clusterEventMap(),
+			plugins:  sets.NewString("TaintToleration"),
+			event:    UnschedulableTimeout,
+			want:     true,
+		},
+		{
+			name:     "unknown plugins",
+			eventMap: clusterEventMap(),
+			event:    NodeAllocatableChange,
+			want:     true,
+		},
+		{
+			name:    "no event map",
+			plugins: sets.NewString("TaintToleration"),
+			event:   NodeAllocatableChange,
+			want:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			q := NewPriorityQueue(nil, WithClusterEventMap(test.eventMap))
+			pInfo := &framework.QueuedPodInfo{Pod: makePod("p"), UnschedulablePlugins: test.plugins}
+			if got := q.podMatchesEvent(pInfo, test.event); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// TestMoveOnRegisteredEvents checks that unschedulable pods only leave the
+// unschedulable queue on the events registered by the plugins that
+// rejected them.
+func TestMoveOnRegisteredEvents(t *testing.T) {
+	q := NewPriorityQueue(func(p1, p2 *framework.QueuedPodInfo) bool { return p1.Timestamp.Before(p2.Timestamp) },
+		WithClock(newClock()), WithClusterEventMap(clusterEventMap()))
+	rejected := map[string]sets.String{
+		"tainted":  sets.NewString("TaintToleration"),
+		"too-big":  sets.NewString("NodeResourcesFit"),
+		"extender": nil,
+	}
+	for name := range rejected {
+		if err := q.Add(makePod(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range rejected {
+		pInfo, err := q.Pop()
+		if err != nil {
+			t.Fatal(err)
+		}
+		pInfo.UnschedulablePlugins = rejected[pInfo.Pod.Name]
+		if err := q.AddUnschedulableIfNotPresent(pInfo, q.SchedulingCycle()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	unschedulable := func() []string {
+		var names []string
+		for _, pInfo := range q.unschedulableQ.podInfoMap {
+			names = append(names, pInfo.Pod.Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	steps := []struct {
+		event framework.ClusterEvent
+		want  []string
+	}{
+		{event: PvAdd, want: []string{"tainted", "too-big"}},
+		{event: NodeLabelChange, want: []string{"tainted", "too-big"}},
+		{event: NodeTaintChange, want: []string{"too-big"}},
+		{event: ServiceAdd, want: []string{"too-big"}},
+		{event: AssignedPodDelete},
+	}
+	for _, step := range steps {
+		q.MoveAllToActiveOrBackoffQueue(step.event)
+		if got := unschedulable(); !reflect.DeepEqual(got, step.want) {
+			t.Errorf("after %s: got unschedulable pods %v, want %v", step.event.Label, got, step.want)
+		}
+	}
+	if n := q.activeQ.Len() + q.podBackoffQ.Len(); n != len(rejected) {
+		t.Errorf("got %d pods moved, want %d", n, len(rejected))
+	}
+}
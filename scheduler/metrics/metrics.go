@@ -198,11 +198,29 @@ var (
 		},
 		[]string{"result"})
 
+	PodBackoffDuration = metrics.NewHistogramVec(
+		&metrics.HistogramOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "pod_backoff_duration_seconds",
+			Help:           "Duration from a failed scheduling attempt until the pod leaves the backoff queue, by failure reason.",
+			Buckets:        metrics.ExponentialBuckets(0.5, 2, 12),
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"reason"})
+
+	EquivalenceCacheLookups = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      SchedulerSubsystem,
+			Name:           "equiv_cache_lookups_total",
+			Help:           "Number of filter result lookups in the equivalence cache, by result (hit or miss).",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result"})
+
 	CacheSize = metrics.NewGaugeVec(
 		&metrics.GaugeOpts{
 			Subsystem:      SchedulerSubsystem,
 			Name:           "scheduler_cache_size",
-			Help:           "Number of nodes, pods, and assumed (bound) pods in the scheduler cache.",
+			Help:           "Number of nodes, pods, assumed (bound) pods and reservations in the scheduler cache.",
 			StabilityLevel: metrics.ALPHA,
 		}, []string{"type"})
 
@@ -225,6 +243,8 @@ var (
 		SchedulerQueueIncomingPods,
 		SchedulerGoroutines,
 		PermitWaitDuration,
+		PodBackoffDuration,
+		EquivalenceCacheLookups,
 		CacheSize,
 	}
 )
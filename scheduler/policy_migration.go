@@ -0,0 +1,91 @@
+package scheduler
+
+import (
+	"fmt"
+
+	schedulerapi "github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	frameworkplugins "github.com/turtacn/cloud-prophet/scheduler/framework/plugins"
+)
+
+// MigratePolicy returns the profile equivalent to a legacy Policy, running the same
+// translation as createFromConfig, along with warnings for the parts of the policy
+// the profile can't represent. Predicates and priorities the legacy registry would
+// reject are dropped with a warning instead of aborting.
+func MigratePolicy(policy schedulerapi.Policy, schedulerName string) (*schedulerapi.KubeSchedulerProfile, []string, error) {
+	lr := frameworkplugins.NewLegacyRegistry()
+	var warnings []string
+	warnf := func(format string, a ...interface{}) {
+		warnings = append(warnings, fmt.Sprintf(format, a...))
+	}
+
+	if policy.Predicates != nil {
+		predicates := make([]schedulerapi.PredicatePolicy, 0, len(policy.Predicates))
+		for _, p := range policy.Predicates {
+			if err := lr.CheckPredicatePolicy(p); err != nil {
+				warnf("predicate %q is dropped: %v", p.Name, err)
+				continue
+			}
+			name := p.Name
+			if name == "PodFitsPorts" {
+				name = frameworkplugins.PodFitsHostPortsPred
+			}
+			_, registered := lr.PredicateToConfigProducer[name]
+			switch {
+			case registered && p.Argument != nil:
+				warnf("argument of predicate %q is ignored, it always runs with the plugin defaults", p.Name)
+			case !registered:
+				key := lr.ProcessPredicatePolicy(p, &frameworkplugins.ConfigProducerArgs{})
+				warnf("custom predicate %q is merged into %s, its name is not kept", p.Name, key)
+			}
+			predicates = append(predicates, p)
+		}
+		policy.Predicates = predicates
+	}
+
+	if policy.Priorities != nil {
+		priorities := make([]schedulerapi.PriorityPolicy, 0, len(policy.Priorities))
+		weights := make(map[string]schedulerapi.PriorityPolicy)
+		for _, p := range policy.Priorities {
+			if p.Name == frameworkplugins.EqualPriority {
+				warnf("priority %q is dropped, it gives every node the same score", p.Name)
+				continue
+			}
+			if err := lr.CheckPriorityPolicy(p); err != nil {
+				warnf("priority %q is dropped: %v", p.Name, err)
+				continue
+			}
+			name := p.Name
+			if name == frameworkplugins.ServiceSpreadingPriority {
+				name = frameworkplugins.SelectorSpreadPriority
+			}
+			_, registered := lr.PriorityToConfigProducer[name]
+			key := lr.ProcessPriorityPolicy(p, &frameworkplugins.ConfigProducerArgs{})
+			switch {
+			case registered && p.Argument != nil:
+				warnf("argument of priority %q is ignored, it always runs with the plugin defaults", p.Name)
+			case !registered:
+				warnf("custom priority %q is merged into %s, its name is not kept", p.Name, key)
+			}
+			if prev, ok := weights[key]; ok && prev.Weight != p.Weight {
+				warnf("priorities %q and %q both run as %s, only weight %d is kept", prev.Name, p.Name, key, p.Weight)
+			}
+			weights[key] = p
+			priorities = append(priorities, p)
+		}
+		policy.Priorities = priorities
+	}
+
+	if policy.AlwaysCheckAllPredicates {
+		warnf("alwaysCheckAllPredicates has no profile equivalent, filters stop at the first failure")
+	}
+
+	plugins, pluginConfig, err := PluginsFromPolicy(policy)
+	if err != nil {
+		return nil, warnings, err
+	}
+	return &schedulerapi.KubeSchedulerProfile{
+		SchedulerName: schedulerName,
+		Plugins:       plugins,
+		PluginConfig:  pluginConfig,
+	}, warnings, nil
+}
@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	schedulerapi "github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	frameworkplugins "github.com/turtacn/cloud-prophet/scheduler/framework/plugins"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/defaultbinder"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodelabel"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeports"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/noderesources"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/nodeunschedulable"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/queuesort"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/selectorspread"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/tainttoleration"
+)
+
+func TestMigratePolicy(t *testing.T) {
+	// Filters of the predicates the legacy registry always configures.
+	mandatory := []schedulerapi.Plugin{{Name: nodeunschedulable.Name}, {Name: tainttoleration.Name}}
+	fit := []schedulerapi.Plugin{{Name: nodeunschedulable.Name}, {Name: noderesources.FitName}, {Name: tainttoleration.Name}}
+
+	tests := []struct {
+		name         string
+		policy       schedulerapi.Policy
+		wantFilter   []schedulerapi.Plugin
+		wantScore    []schedulerapi.Plugin
+		wantConfig   []schedulerapi.PluginConfig
+		wantWarnings []string
+		wantErr      bool
+	}{
+		{
+			name: "predicates and priorities",
+			policy: schedulerapi.Policy{
+				Predicates: []schedulerapi.PredicatePolicy{{Name: "PodFitsPorts"}, {Name: frameworkplugins.PodFitsResourcesPred}},
+				Priorities: []schedulerapi.PriorityPolicy{
+					{Name: frameworkplugins.LeastRequestedPriority, Weight: 2},
+					{Name: frameworkplugins.ServiceSpreadingPriority, Weight: 1},
+				},
+			},
+			wantFilter: []schedulerapi.Plugin{
+				{Name: nodeunschedulable.Name},
+				{Name: nodeports.Name},
+				{Name: noderesources.FitName},
+				{Name: tainttoleration.Name},
+			},
+			wantScore: []schedulerapi.Plugin{
+				{Name: noderesources.LeastAllocatedName, Weight: 2},
+				{Name: selectorspread.Name, Weight: 1},
+			},
+		},
+		{
+			name: "unknown predicate",
+			policy: schedulerapi.Policy{
+				Predicates: []schedulerapi.PredicatePolicy{{Name: "PodFitsGPU"}, {Name: frameworkplugins.PodFitsResourcesPred}},
+				Priorities: []schedulerapi.PriorityPolicy{},
+			},
+			wantFilter:   fit,
+			wantWarnings: []string{`predicate "PodFitsGPU" is dropped: predicate type not found for "PodFitsGPU"`},
+		},
+		{
+			name: "predicate with invalid argument",
+			policy: schedulerapi.Policy{
+				Predicates: []schedulerapi.PredicatePolicy{{
+					Name:     "ZoneAffinity",
+					Argument: &schedulerapi.PredicateArgument{},
+				}},
+				Priorities: []schedulerapi.PriorityPolicy{},
+			},
+			wantFilter:   mandatory,
+			wantWarnings: []string{`predicate "ZoneAffinity" is dropped: exactly 1 predicate argument is required, numArgs: 0, Predicate: ZoneAffinity`},
+		},
+		{
+			name: "custom predicate",
+			policy: schedulerapi.Policy{
+				Predicates: []schedulerapi.PredicatePolicy{{
+					Name: "ZonePresent",
+					Argument: &schedulerapi.PredicateArgument{
+						LabelsPresence: &schedulerapi.LabelsPresence{Labels: []string{"zone"}, Presence: true},
+					},
+				}},
+				Priorities: []schedulerapi.PriorityPolicy{},
+			},
+			wantFilter: append(append([]schedulerapi.Plugin{}, mandatory...), schedulerapi.Plugin{Name: nodelabel.Name}),
+			wantConfig: []schedulerapi.PluginConfig{{
+				Name: nodelabel.Name,
+				Args: &schedulerapi.NodeLabelArgs{PresentLabels: []string{"zone"}},
+			}},
+			wantWarnings: []string{`custom predicate "ZonePresent" is merged into CheckNodeLabelPresence, its name is not kept`},
+		},
+		{
+			name: "argument of a registered predicate",
+			policy: schedulerapi.Policy{
+				Predicates: []schedulerapi.PredicatePolicy{{
+					Name: frameworkplugins.PodFitsResourcesPred,
+					Argument: &schedulerapi.PredicateArgument{
+						LabelsPresence: &schedulerapi.LabelsPresence{Labels: []string{"zone"}},
+					},
+				}},
+				Priorities: []schedulerapi.PriorityPolicy{},
+			},
+			wantFilter:   fit,
+			wantWarnings: []string{`argument of predicate "PodFitsResources" is ignored, it always runs with the plugin defaults`},
+		},
+		{
+			name: "unknown and equal priorities",
+			policy: schedulerapi.Policy{
+				Predicates: []schedulerapi.PredicatePolicy{},
+				Priorities: []schedulerapi.PriorityPolicy{
+					{Name: "GPUPriority", Weight: 1},
+					{Name: frameworkplugins.EqualPriority, Weight: 1},
+					{Name: frameworkplugins.LeastRequestedPriority, Weight: 1},
+				},
+			},
+			wantFilter: mandatory,
+			wantScore:  []schedulerapi.Plugin{{Name: noderesources.LeastAllocatedName, Weight: 1}},
+			wantWarnings: []string{
+				`priority "GPUPriority" is dropped: priority type not found for "GPUPriority"`,
+				`priority "EqualPriority" is dropped, it gives every node the same score`,
+			},
+		},
+		{
+			name: "priorities of the same plugin",
+			policy: schedulerapi.Policy{
+				Predicates: []schedulerapi.PredicatePolicy{},
+				Priorities: []schedulerapi.PriorityPolicy{
+					{Name: frameworkplugins.ServiceSpreadingPriority, Weight: 1},
+					{Name: frameworkplugins.SelectorSpreadPriority, Weight: 3},
+				},
+			},
+			wantFilter:   mandatory,
+			wantScore:    []schedulerapi.Plugin{{Name: selectorspread.Name, Weight: 3}},
+			wantWarnings: []string{`priorities "ServiceSpreadingPriority" and "SelectorSpreadPriority" both run as SelectorSpreadPriority, only weight 3 is kept`},
+		},
+		{
+			name: "always check all predicates",
+			policy: schedulerapi.Policy{
+				Predicates:               []schedulerapi.PredicatePolicy{},
+				Priorities:               []schedulerapi.PriorityPolicy{},
+				AlwaysCheckAllPredicates: true,
+			},
+			wantFilter:   mandatory,
+			wantWarnings: []string{"alwaysCheckAllPredicates has no profile equivalent, filters stop at the first failure"},
+		},
+		{
+			name: "invalid weight",
+			policy: schedulerapi.Policy{
+				Priorities: []schedulerapi.PriorityPolicy{{Name: frameworkplugins.LeastRequestedPriority}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profile, warnings, err := MigratePolicy(test.policy, "prophet")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error %v", err, test.wantErr)
+			}
+			if diff := cmp.Diff(test.wantWarnings, warnings); diff != "" {
+				t.Errorf("unexpected warnings (-want, +got):\n%s", diff)
+			}
+			if test.wantErr {
+				return
+			}
+			if profile.SchedulerName != "prophet" {
+				t.Errorf("got scheduler name %q, want prophet", profile.SchedulerName)
+			}
+			plugins := profile.Plugins
+			if diff := cmp.Diff([]schedulerapi.Plugin{{Name: queuesort.Name}}, plugins.QueueSort.Enabled); diff != "" {
+				t.Errorf("unexpected queue sort plugins (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff([]schedulerapi.Plugin{{Name: defaultbinder.Name}}, plugins.Bind.Enabled); diff != "" {
+				t.Errorf("unexpected bind plugins (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantFilter, plugins.Filter.Enabled); diff != "" {
+				t.Errorf("unexpected filter plugins (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantScore, plugins.Score.Enabled); diff != "" {
+				t.Errorf("unexpected score plugins (-want, +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.wantConfig, profile.PluginConfig, cmpEmpty); diff != "" {
+				t.Errorf("unexpected plugin config (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// cmpEmpty compares empty slices equal to nil ones.
+var cmpEmpty = cmp.FilterValues(func(x, y []schedulerapi.PluginConfig) bool {
+	return len(x) == 0 && len(y) == 0
+}, cmp.Ignore())
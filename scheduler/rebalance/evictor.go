@@ -0,0 +1,63 @@
+package rebalance
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+)
+
+// Evictor moves a pod off its node.
+type Evictor interface {
+	// Evict moves pod away from its node. target is the node the move was
+	// planned to, if any; evictors that cannot choose the destination
+	// leave it to the scheduler.
+	Evict(ctx context.Context, pod *v1.Pod, target string) error
+}
+
+// NewEvictor returns an Evictor using the eviction API, which enforces
+// PodDisruptionBudgets. In dry run it only logs the evictions.
+func NewEvictor(client kubernetes.Interface, dryRun bool) Evictor {
+	return &apiEvictor{client: client, dryRun: dryRun}
+}
+
+type apiEvictor struct {
+	client kubernetes.Interface
+	dryRun bool
+}
+
+func (e *apiEvictor) Evict(ctx context.Context, pod *v1.Pod, target string) error {
+	if e.dryRun {
+		klog.Infof("Would evict pod %s/%s from node %s", pod.Namespace, pod.Name, pod.Spec.NodeName)
+		return nil
+	}
+	eviction := &policy.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+	}
+	if err := e.client.PolicyV1beta1().Evictions(pod.Namespace).Evict(ctx, eviction); err != nil {
+		return fmt.Errorf("evicting pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	klog.Infof("Evicted pod %s/%s from node %s", pod.Namespace, pod.Name, pod.Spec.NodeName)
+	return nil
+}
+
+// Execute carries out the moves of plan with evictor. It carries on after
+// failed moves and returns their errors.
+func Execute(ctx context.Context, plan *Plan, evictor Evictor) error {
+	var errs []error
+	for _, m := range plan.Moves {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := evictor.Evict(ctx, m.Pod, m.To); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
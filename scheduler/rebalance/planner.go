@@ -0,0 +1,283 @@
+// Package rebalance relieves hot nodes by moving pods and VMs to nodes with
+// spare utilisation.
+package rebalance
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	schedutil "github.com/turtacn/cloud-prophet/scheduler/util"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/klog/v2"
+)
+
+// Config configures a Planner.
+type Config struct {
+	// CPUThreshold and MemoryThreshold are the utilisations in percent
+	// every node should stay under.
+	CPUThreshold    float64
+	MemoryThreshold float64
+	// MaxMoves bounds the moves of a plan and MaxMovesPerNode the pods moved
+	// off a single node. Zero means unbounded.
+	MaxMoves        int
+	MaxMovesPerNode int
+	// EvictLocalStorage allows moving pods with emptyDir or hostPath volumes.
+	EvictLocalStorage bool
+}
+
+// Usage is the utilisation of a node in percent of its allocatable
+// resources.
+type Usage struct {
+	CPU    float64
+	Memory float64
+}
+
+// Move is a pod moved from one node to another.
+type Move struct {
+	Pod       *v1.Pod `json:"-"`
+	Namespace string  `json:"namespace"`
+	Name      string  `json:"name"`
+	From      string  `json:"from"`
+	To        string  `json:"to"`
+	// CPU and Memory are the utilisation moved, in percent of the source
+	// node.
+	CPU    float64 `json:"cpu"`
+	Memory float64 `json:"memory"`
+}
+
+// Plan is a set of moves bringing hot nodes under the thresholds.
+type Plan struct {
+	Moves []Move `json:"moves"`
+	// Hot are the nodes over the thresholds before the moves.
+	Hot []string `json:"hot"`
+	// Unresolved are the hot nodes still over the thresholds after the moves.
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// Write writes the plan in a human readable form.
+func (p *Plan) Write(w io.Writer) error {
+	for _, m := range p.Moves {
+		if _, err := fmt.Fprintf(w, "move %s/%s from %s to %s (cpu %.1f%%, memory %.1f%%)\n", m.Namespace, m.Name, m.From, m.To, m.CPU, m.Memory); err != nil {
+			return err
+		}
+	}
+	for _, n := range p.Unresolved {
+		if _, err := fmt.Fprintf(w, "node %s remains hot\n", n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Planner computes the moves relieving the hot nodes of a snapshot.
+type Planner struct {
+	fwk      framework.Framework
+	snapshot *cache.Snapshot
+	config   Config
+}
+
+// NewPlanner returns a planner checking moves with the filters of fwk,
+// which must read snapshot, i.e. be built WithSnapshotSharedLister. The
+// snapshot is updated with the planned moves, so a planner is good for a
+// single plan.
+func NewPlanner(fwk framework.Framework, snapshot *cache.Snapshot, config Config) *Planner {
+	return &Planner{fwk: fwk, snapshot: snapshot, config: config}
+}
+
+type nodeState struct {
+	info  *framework.NodeInfo
+	usage Usage
+	moved int
+}
+
+// hotness is the largest ratio of a utilisation to its threshold. Nodes
+// above one are hot.
+func (p *Planner) hotness(u Usage) float64 {
+	return math.Max(u.CPU/p.config.CPUThreshold, u.Memory/p.config.MemoryThreshold)
+}
+
+// Plan computes moves bringing every node under the thresholds given their
+// observed usage. Nodes without usage are assumed to use what their pods
+// request. Hot nodes are relieved hottest first, each by as few moves as
+// possible: the smallest pod cooling the node down on its own if there is
+// one, the biggest otherwise. Moves must pass the filters of the
+// framework, leave the target under the thresholds and respect pdbs.
+func (p *Planner) Plan(ctx context.Context, usage map[string]Usage, pdbs []*policy.PodDisruptionBudget) (*Plan, error) {
+	if p.config.CPUThreshold <= 0 || p.config.MemoryThreshold <= 0 {
+		return nil, fmt.Errorf("thresholds must be positive, got cpu %v and memory %v", p.config.CPUThreshold, p.config.MemoryThreshold)
+	}
+	infos, err := p.snapshot.NodeInfos().List()
+	if err != nil {
+		return nil, err
+	}
+	var nodes, hot []*nodeState
+	for _, info := range infos {
+		if info.Node() == nil {
+			continue
+		}
+		n := &nodeState{info: info}
+		if u, ok := usage[info.Node().Name]; ok {
+			n.usage = u
+		} else {
+			n.usage = Usage{
+				CPU:    percent(float64(info.NonZeroRequested.MilliCPU), info.Allocatable.MilliCPU),
+				Memory: percent(float64(info.NonZeroRequested.Memory), info.Allocatable.Memory),
+			}
+		}
+		nodes = append(nodes, n)
+		if p.hotness(n.usage) > 1 {
+			hot = append(hot, n)
+		}
+	}
+	sort.SliceStable(hot, func(i, j int) bool { return p.hotness(hot[i].usage) > p.hotness(hot[j].usage) })
+
+	plan := &Plan{}
+	budget := NewDisruptionBudget(pdbs)
+	for _, n := range hot {
+		plan.Hot = append(plan.Hot, n.info.Node().Name)
+		for p.hotness(n.usage) > 1 && ctx.Err() == nil {
+			if p.config.MaxMoves > 0 && len(plan.Moves) >= p.config.MaxMoves {
+				break
+			}
+			if p.config.MaxMovesPerNode > 0 && n.moved >= p.config.MaxMovesPerNode {
+				break
+			}
+			m, ok := p.relieve(ctx, n, nodes, budget)
+			if !ok {
+				break
+			}
+			plan.Moves = append(plan.Moves, m)
+		}
+		if p.hotness(n.usage) > 1 {
+			plan.Unresolved = append(plan.Unresolved, n.info.Node().Name)
+		}
+	}
+	return plan, ctx.Err()
+}
+
+type candidate struct {
+	pod         *v1.Pod
+	cpu, memory float64
+	sufficient  bool
+}
+
+// relieve moves one pod off src.
+func (p *Planner) relieve(ctx context.Context, src *nodeState, nodes []*nodeState, budget *DisruptionBudget) (Move, bool) {
+	var candidates []candidate
+	for _, pi := range src.info.Pods {
+		if !Evictable(pi.Pod, p.config.EvictLocalStorage) || !budget.Allows(pi.Pod) {
+			continue
+		}
+		c := candidate{pod: pi.Pod}
+		c.cpu, c.memory = podUsage(pi.Pod, src)
+		c.sufficient = p.hotness(Usage{CPU: src.usage.CPU - c.cpu, Memory: src.usage.Memory - c.memory}) <= 1
+		candidates = append(candidates, c)
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.sufficient != b.sufficient {
+			return a.sufficient
+		}
+		ha, hb := p.hotness(Usage{a.cpu, a.memory}), p.hotness(Usage{b.cpu, b.memory})
+		if a.sufficient {
+			return ha < hb
+		}
+		return ha > hb
+	})
+	for _, c := range candidates {
+		if dst := p.target(ctx, c, src, nodes); dst != nil {
+			budget.Take(c.pod)
+			src.moved++
+			return Move{
+				Pod:       c.pod,
+				Namespace: c.pod.Namespace,
+				Name:      c.pod.Name,
+				From:      src.info.Node().Name,
+				To:        dst.info.Node().Name,
+				CPU:       c.cpu,
+				Memory:    c.memory,
+			}, true
+		}
+	}
+	return Move{}, false
+}
+
+// target finds the coolest node c.pod can move to from src and moves it
+// there in the snapshot. It returns nil if no node fits.
+func (p *Planner) target(ctx context.Context, c candidate, src *nodeState, nodes []*nodeState) *nodeState {
+	if err := src.info.RemovePod(c.pod); err != nil {
+		klog.Warningf("Cannot remove pod %s/%s from the snapshot: %v", c.pod.Namespace, c.pod.Name, err)
+		return nil
+	}
+	// The pod is filtered as its replacement would be: not bound yet.
+	pod := c.pod.DeepCopy()
+	pod.Spec.NodeName = ""
+	state := framework.NewCycleState()
+	var best *nodeState
+	var bestUsage Usage
+	if s := p.fwk.RunPreFilterPlugins(ctx, state, pod); s.IsSuccess() {
+		// The utilisation moved in absolute terms.
+		milliCPU := c.cpu / 100 * float64(src.info.Allocatable.MilliCPU)
+		bytes := c.memory / 100 * float64(src.info.Allocatable.Memory)
+		for _, dst := range nodes {
+			if dst == src {
+				continue
+			}
+			after := Usage{
+				CPU:    dst.usage.CPU + percent(milliCPU, dst.info.Allocatable.MilliCPU),
+				Memory: dst.usage.Memory + percent(bytes, dst.info.Allocatable.Memory),
+			}
+			if p.hotness(after) > 1 || (best != nil && p.hotness(after) >= p.hotness(bestUsage)) {
+				continue
+			}
+			if !p.fwk.RunFilterPlugins(ctx, state, pod, dst.info).Merge().IsSuccess() {
+				continue
+			}
+			best, bestUsage = dst, after
+		}
+	} else {
+		klog.V(4).Infof("Pod %s/%s cannot move: %v", c.pod.Namespace, c.pod.Name, s.Message())
+	}
+	if best == nil {
+		src.info.AddPod(c.pod)
+		return nil
+	}
+	pod.Spec.NodeName = best.info.Node().Name
+	best.info.AddPod(pod)
+	best.usage = bestUsage
+	src.usage.CPU -= c.cpu
+	src.usage.Memory -= c.memory
+	return best
+}
+
+// podUsage estimates the utilisation of pod in percent of its node n as
+// its share of the requests of the pods of the node.
+func podUsage(pod *v1.Pod, n *nodeState) (cpu, memory float64) {
+	var milliCPU, bytes int64
+	for i := range pod.Spec.Containers {
+		c, m := schedutil.GetNonzeroRequests(&pod.Spec.Containers[i].Resources.Requests)
+		milliCPU += c
+		bytes += m
+	}
+	requested := n.info.NonZeroRequested
+	if requested.MilliCPU > 0 {
+		cpu = n.usage.CPU * float64(milliCPU) / float64(requested.MilliCPU)
+	}
+	if requested.Memory > 0 {
+		memory = n.usage.Memory * float64(bytes) / float64(requested.Memory)
+	}
+	return cpu, memory
+}
+
+func percent(v float64, capacity int64) float64 {
+	if capacity <= 0 {
+		return 100
+	}
+	return v * 100 / float64(capacity)
+}
@@ -0,0 +1,245 @@
+package rebalance
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/defaultbinder"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/queuesort"
+	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
+	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
+	"github.com/turtacn/cloud-prophet/scheduler/internal/cache"
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const rejectLabel = "test/reject"
+
+// rejectFilter rejects the nodes labelled rejectLabel.
+type rejectFilter struct{}
+
+func (rejectFilter) Name() string { return "RejectFilter" }
+
+func (rejectFilter) Filter(_ context.Context, _ *framework.CycleState, _ *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if nodeInfo.Node().Labels[rejectLabel] == "true" {
+		return framework.NewStatus(framework.Unschedulable, "rejected")
+	}
+	return nil
+}
+
+func newFramework(t *testing.T, snapshot *cache.Snapshot) framework.Framework {
+	registry := frameworkruntime.Registry{
+		queuesort.Name:     queuesort.New,
+		defaultbinder.Name: defaultbinder.New,
+		"RejectFilter": func(_ runtime.Object, _ framework.FrameworkHandle) (framework.Plugin, error) {
+			return rejectFilter{}, nil
+		},
+	}
+	pls := &config.Plugins{
+		QueueSort: &config.PluginSet{Enabled: []config.Plugin{{Name: queuesort.Name}}},
+		Filter:    &config.PluginSet{Enabled: []config.Plugin{{Name: "RejectFilter"}}},
+		Bind:      &config.PluginSet{Enabled: []config.Plugin{{Name: defaultbinder.Name}}},
+	}
+	fwk, err := frameworkruntime.NewFramework(registry, pls, nil, frameworkruntime.WithSnapshotSharedLister(snapshot))
+	if err != nil {
+		t.Fatalf("creating framework: %v", err)
+	}
+	return fwk
+}
+
+func makeNode(name string, labels map[string]string) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse("4"),
+				v1.ResourceMemory: resource.MustParse("8Gi"),
+				v1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func makePod(name, nodeName, cpu string) *v1.Pod {
+	controller := true
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			Labels:    map[string]string{"app": name},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: name, Controller: &controller},
+			},
+		},
+		Spec: v1.PodSpec{
+			NodeName: nodeName,
+			Containers: []v1.Container{{
+				Name: "c",
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{
+						v1.ResourceCPU:    resource.MustParse(cpu),
+						v1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	// The hot node runs pods requesting 500m, 1 and 2 cpus: at 90% cpu
+	// they use about 13%, 26% and 51% of it.
+	hotPods := func() []*v1.Pod {
+		return []*v1.Pod{
+			makePod("small", "hot", "500m"),
+			makePod("mid", "hot", "1"),
+			makePod("big", "hot", "2"),
+		}
+	}
+	// Two pods each using 15% of the hot node, which needs both moved.
+	twoSmallPods := func() []*v1.Pod {
+		big := makePod("big", "hot", "2")
+		big.Annotations = map[string]string{ExcludeAnnotation: "true"}
+		return []*v1.Pod{makePod("small-1", "hot", "500m"), makePod("small-2", "hot", "500m"), big}
+	}
+	usage := map[string]Usage{
+		"hot":  {CPU: 90, Memory: 40},
+		"cool": {CPU: 10, Memory: 10},
+		"warm": {CPU: 50, Memory: 50},
+	}
+	config := Config{CPUThreshold: 70, MemoryThreshold: 70}
+
+	tests := []struct {
+		name           string
+		config         Config
+		pods           []*v1.Pod
+		pdbs           []*policy.PodDisruptionBudget
+		usage          map[string]Usage
+		rejectCool     bool
+		wantMoves      []string
+		wantUnresolved []string
+	}{
+		{
+			name:      "smallest pod cooling the node on its own",
+			config:    config,
+			pods:      hotPods(),
+			wantMoves: []string{"default/mid hot->cool"},
+		},
+		{
+			name:   "budget forbids the smallest sufficient pod",
+			config: config,
+			pods:   hotPods(),
+			pdbs: []*policy.PodDisruptionBudget{{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mid"},
+				Spec:       policy.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mid"}}},
+			}},
+			wantMoves: []string{"default/big hot->cool"},
+		},
+		{
+			name:   "excluded pods stay",
+			config: config,
+			pods: func() []*v1.Pod {
+				pods := hotPods()
+				for _, p := range pods[1:] {
+					p.Annotations = map[string]string{ExcludeAnnotation: "true"}
+				}
+				return pods
+			}(),
+			// Moving the small pod does not cool the node enough.
+			wantMoves:      []string{"default/small hot->cool"},
+			wantUnresolved: []string{"hot"},
+		},
+		{
+			name:       "filters and thresholds of the targets",
+			config:     config,
+			pods:       hotPods(),
+			rejectCool: true,
+			// Only the small pod fits under the thresholds of the warm node.
+			wantMoves:      []string{"default/small hot->warm"},
+			wantUnresolved: []string{"hot"},
+		},
+		{
+			name:      "several moves",
+			config:    config,
+			pods:      twoSmallPods(),
+			usage:     map[string]Usage{"hot": {CPU: 90, Memory: 40}, "cool": {CPU: 10, Memory: 10}, "warm": {CPU: 12, Memory: 12}},
+			wantMoves: []string{"default/small-1 hot->cool", "default/small-2 hot->warm"},
+		},
+		{
+			name:           "move limit",
+			config:         Config{CPUThreshold: 70, MemoryThreshold: 70, MaxMovesPerNode: 1},
+			pods:           twoSmallPods(),
+			usage:          map[string]Usage{"hot": {CPU: 90, Memory: 40}, "cool": {CPU: 10, Memory: 10}, "warm": {CPU: 12, Memory: 12}},
+			wantMoves:      []string{"default/small-1 hot->cool"},
+			wantUnresolved: []string{"hot"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cool := makeNode("cool", nil)
+			if test.rejectCool {
+				cool.Labels = map[string]string{rejectLabel: "true"}
+			}
+			nodes := []*v1.Node{makeNode("hot", nil), cool, makeNode("warm", nil)}
+			snapshot := cache.NewSnapshot(test.pods, nodes)
+			nodeUsage := usage
+			if test.usage != nil {
+				nodeUsage = test.usage
+			}
+			plan, err := NewPlanner(newFramework(t, snapshot), snapshot, test.config).Plan(context.Background(), nodeUsage, test.pdbs)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var gotMoves []string
+			for _, m := range plan.Moves {
+				gotMoves = append(gotMoves, fmt.Sprintf("%s/%s %s->%s", m.Namespace, m.Name, m.From, m.To))
+			}
+			if !reflect.DeepEqual(gotMoves, test.wantMoves) {
+				t.Errorf("got moves %v, want %v", gotMoves, test.wantMoves)
+			}
+			if !reflect.DeepEqual(plan.Hot, []string{"hot"}) {
+				t.Errorf("got hot nodes %v, want [hot]", plan.Hot)
+			}
+			if !reflect.DeepEqual(plan.Unresolved, test.wantUnresolved) {
+				t.Errorf("got unresolved nodes %v, want %v", plan.Unresolved, test.wantUnresolved)
+			}
+		})
+	}
+}
+
+func TestPlanByRequests(t *testing.T) {
+	// Without usage the hot node is at 87.5% cpu, by requests.
+	pods := []*v1.Pod{
+		makePod("small", "hot", "500m"),
+		makePod("mid", "hot", "1"),
+		makePod("big", "hot", "2"),
+		makePod("other", "warm", "1"),
+	}
+	snapshot := cache.NewSnapshot(pods, []*v1.Node{makeNode("hot", nil), makeNode("warm", nil)})
+	plan, err := NewPlanner(newFramework(t, snapshot), snapshot, Config{CPUThreshold: 70, MemoryThreshold: 70}).Plan(context.Background(), nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plan.Moves) != 1 || plan.Moves[0].Name != "mid" || plan.Moves[0].To != "warm" {
+		t.Errorf("expected mid to move to warm, got %+v", plan.Moves)
+	}
+	// The snapshot reflects the move.
+	if info, err := snapshot.Get("warm"); err != nil || len(info.Pods) != 2 {
+		t.Errorf("expected the moved pod on warm in the snapshot")
+	}
+}
+
+func TestPlanInvalidThresholds(t *testing.T) {
+	snapshot := cache.NewSnapshot(nil, nil)
+	if _, err := NewPlanner(newFramework(t, snapshot), snapshot, Config{CPUThreshold: 70}).Plan(context.Background(), nil, nil); err == nil {
+		t.Errorf("expected an error for a zero memory threshold")
+	}
+}
@@ -0,0 +1,109 @@
+package rebalance
+
+import (
+	v1 "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	// MigratableAnnotation marks pods without a controller that can move
+	// anyway, e.g. VMs that live migrate.
+	MigratableAnnotation = "prophet.io/migratable"
+	// ExcludeAnnotation marks pods that must never be moved.
+	ExcludeAnnotation = "prophet.io/rebalance-exclude"
+
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+	// systemCriticalPriority is the lowest priority of the system critical
+	// priority classes.
+	systemCriticalPriority = 2 * 1000000000
+)
+
+// Evictable reports whether pod may be moved to another node: it must be
+// recreated by a controller other than a DaemonSet or be marked migratable,
+// and must not be a mirror pod, system critical, excluded, or use local
+// storage unless evictLocalStorage.
+func Evictable(pod *v1.Pod, evictLocalStorage bool) bool {
+	if pod.Annotations[ExcludeAnnotation] == "true" {
+		return false
+	}
+	if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+		return false
+	}
+	if pod.DeletionTimestamp != nil || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return false
+	}
+	if pod.Spec.Priority != nil && *pod.Spec.Priority >= systemCriticalPriority {
+		return false
+	}
+	if !evictLocalStorage {
+		for _, v := range pod.Spec.Volumes {
+			if v.EmptyDir != nil || v.HostPath != nil {
+				return false
+			}
+		}
+	}
+	if pod.Annotations[MigratableAnnotation] == "true" {
+		return true
+	}
+	owner := metav1.GetControllerOf(pod)
+	return owner != nil && owner.Kind != "DaemonSet"
+}
+
+// DisruptionBudget tracks the disruptions PodDisruptionBudgets still allow
+// while moves are planned.
+type DisruptionBudget struct {
+	pdbs    []*policy.PodDisruptionBudget
+	allowed []int32
+}
+
+// NewDisruptionBudget returns the budget left by pdbs.
+func NewDisruptionBudget(pdbs []*policy.PodDisruptionBudget) *DisruptionBudget {
+	b := &DisruptionBudget{pdbs: pdbs, allowed: make([]int32, len(pdbs))}
+	for i, pdb := range pdbs {
+		b.allowed[i] = pdb.Status.DisruptionsAllowed
+	}
+	return b
+}
+
+// matching returns the indexes of the budgets covering pod.
+func (b *DisruptionBudget) matching(pod *v1.Pod) []int {
+	if b == nil || len(pod.Labels) == 0 {
+		return nil
+	}
+	var res []int
+	for i, pdb := range b.pdbs {
+		if pdb.Namespace != pod.Namespace {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		// A PDB with a nil or empty selector matches nothing.
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		// Pods already being disrupted have been accounted for.
+		if _, ok := pdb.Status.DisruptedPods[pod.Name]; ok {
+			continue
+		}
+		res = append(res, i)
+	}
+	return res
+}
+
+// Allows reports whether pod can be disrupted without violating a budget.
+func (b *DisruptionBudget) Allows(pod *v1.Pod) bool {
+	for _, i := range b.matching(pod) {
+		if b.allowed[i] <= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Take consumes a disruption of every budget covering pod.
+func (b *DisruptionBudget) Take(pod *v1.Pod) {
+	for _, i := range b.matching(pod) {
+		b.allowed[i]--
+	}
+}
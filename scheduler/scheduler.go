@@ -11,8 +11,13 @@ import (
 
 	schedulerapi "github.com/turtacn/cloud-prophet/scheduler/apis/config"
 	"github.com/turtacn/cloud-prophet/scheduler/apis/config/scheme"
+	"github.com/turtacn/cloud-prophet/scheduler/apis/config/validation"
 	"github.com/turtacn/cloud-prophet/scheduler/core"
+	"github.com/turtacn/cloud-prophet/scheduler/core/equivalence"
+	"github.com/turtacn/cloud-prophet/scheduler/explain"
 	frameworkplugins "github.com/turtacn/cloud-prophet/scheduler/framework/plugins"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/drf"
+	"github.com/turtacn/cloud-prophet/scheduler/framework/plugins/reservation"
 	frameworkruntime "github.com/turtacn/cloud-prophet/scheduler/framework/runtime"
 	framework "github.com/turtacn/cloud-prophet/scheduler/framework/v1alpha1"
 	internalcache "github.com/turtacn/cloud-prophet/scheduler/internal/cache"
@@ -23,6 +28,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
@@ -36,6 +42,8 @@ const (
 	SchedulerError = "SchedulerError"
 	// Percentage of plugin metrics to be sampled.
 	pluginMetricsSamplePercent = 10
+	// Period of reading the reservation file again.
+	reservationSyncPeriod = 10 * time.Second
 )
 
 // Scheduler watches for new unscheduled pods. It attempts to find
@@ -69,6 +77,13 @@ type Scheduler struct {
 	scheduledPodsHasSynced func() bool
 
 	client clientset.Interface
+
+	// explainRecorder records the scheduling attempts, if set.
+	explainRecorder *explain.Recorder
+
+	// batchSize is the maximum number of pods placed jointly, batches are
+	// disabled if it is not greater than 1.
+	batchSize int
 }
 
 // Cache returns the cache in scheduler for test to check the data in scheduler.
@@ -81,11 +96,17 @@ type schedulerOptions struct {
 	percentageOfNodesToScore int32
 	podInitialBackoffSeconds int64
 	podMaxBackoffSeconds     int64
+	backoffPolicy            *schedulerapi.BackoffPolicy
 	// Contains out-of-tree plugins to be merged with the in-tree registry.
 	frameworkOutOfTreeRegistry frameworkruntime.Registry
 	profiles                   []schedulerapi.KubeSchedulerProfile
 	extenders                  []schedulerapi.Extender
 	frameworkCapturer          FrameworkCapturer
+	explainRecorder            *explain.Recorder
+	equivalenceCache           *equivalence.Cache
+	batchSize                  int
+	reservationFile            string
+	pluginDir                  string
 }
 
 // Option configures a Scheduler
@@ -135,6 +156,15 @@ func WithPodMaxBackoffSeconds(podMaxBackoffSeconds int64) Option {
 	}
 }
 
+// WithBackoffPolicy sets the backoff policy of unschedulable pods for
+// Scheduler. By default the backoff doubles from podInitialBackoffSeconds to
+// podMaxBackoffSeconds.
+func WithBackoffPolicy(policy *schedulerapi.BackoffPolicy) Option {
+	return func(o *schedulerOptions) {
+		o.backoffPolicy = policy
+	}
+}
+
 // WithExtenders sets extenders for the Scheduler
 func WithExtenders(e ...schedulerapi.Extender) Option {
 	return func(o *schedulerOptions) {
@@ -152,6 +182,47 @@ func WithBuildFrameworkCapturer(fc FrameworkCapturer) Option {
 	}
 }
 
+// WithExplainRecorder records every scheduling attempt in r, so that
+// r can explain where, and why, pods were placed.
+func WithExplainRecorder(r *explain.Recorder) Option {
+	return func(o *schedulerOptions) {
+		o.explainRecorder = r
+	}
+}
+
+// WithEquivalenceCache reuses the filter results of equivalent pods, e.g.
+// the replicas of a Deployment, kept in c until their node changes.
+func WithEquivalenceCache(c *equivalence.Cache) Option {
+	return func(o *schedulerOptions) {
+		o.equivalenceCache = c
+	}
+}
+
+// WithBatchScheduling places up to size pods of the same profile at once,
+// packing them on as few nodes as possible, see core.BatchScheduler.
+func WithBatchScheduling(size int) Option {
+	return func(o *schedulerOptions) {
+		o.batchSize = size
+	}
+}
+
+// WithReservationFile holds the capacity described in the reservation file
+// at path for the pods of its owners, see reservation.File. The file is
+// read again every reservationSyncPeriod.
+func WithReservationFile(path string) Option {
+	return func(o *schedulerOptions) {
+		o.reservationFile = path
+	}
+}
+
+// WithPluginDir loads the out-of-tree plugins of the shared objects in dir,
+// see frameworkruntime.LoadPlugins.
+func WithPluginDir(dir string) Option {
+	return func(o *schedulerOptions) {
+		o.pluginDir = dir
+	}
+}
+
 var defaultSchedulerOptions = schedulerOptions{
 	profiles: []schedulerapi.KubeSchedulerProfile{
 		// Profiles' default plugins are set from the algorithm provider.
@@ -181,13 +252,27 @@ func New(client clientset.Interface,
 	for _, opt := range opts {
 		opt(&options)
 	}
+	if errs := validation.ValidateBackoffPolicy(field.NewPath("backoffPolicy"), options.backoffPolicy); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
 
 	schedulerCache := internalcache.New(30*time.Second, stopEverything)
 
 	registry := frameworkplugins.NewInTreeRegistry()
+	if err := registry.Register(reservation.Name, reservation.NewFactory(schedulerCache)); err != nil {
+		return nil, err
+	}
+	if err := registry.Register(drf.Name, drf.NewFactory(schedulerCache)); err != nil {
+		return nil, err
+	}
 	if err := registry.Merge(options.frameworkOutOfTreeRegistry); err != nil {
 		return nil, err
 	}
+	if options.pluginDir != "" {
+		if err := frameworkruntime.LoadPlugins(options.pluginDir, registry); err != nil {
+			return nil, err
+		}
+	}
 
 	snapshot := internalcache.NewEmptySnapshot()
 
@@ -200,11 +285,13 @@ func New(client clientset.Interface,
 		percentageOfNodesToScore: options.percentageOfNodesToScore,
 		podInitialBackoffSeconds: options.podInitialBackoffSeconds,
 		podMaxBackoffSeconds:     options.podMaxBackoffSeconds,
+		backoffPolicy:            options.backoffPolicy,
 		profiles:                 append([]schedulerapi.KubeSchedulerProfile(nil), options.profiles...),
 		registry:                 registry,
 		nodeInfoSnapshot:         snapshot,
 		extenders:                options.extenders,
 		frameworkCapturer:        options.frameworkCapturer,
+		equivalenceCache:         options.equivalenceCache,
 	}
 
 	metrics.Register()
@@ -248,6 +335,17 @@ func New(client clientset.Interface,
 	sched.StopEverything = stopEverything
 	sched.client = client
 	sched.scheduledPodsHasSynced = podInformer.Informer().HasSynced
+	sched.explainRecorder = options.explainRecorder
+	sched.batchSize = options.batchSize
+	schedulerCache.OnReservationsExpired(func() {
+		sched.SchedulingQueue.MoveAllToActiveOrBackoffQueue(internalqueue.ReservationRelease)
+	})
+	if options.reservationFile != "" {
+		syncer := reservation.NewSyncer(options.reservationFile, schedulerCache, func() {
+			sched.SchedulingQueue.MoveAllToActiveOrBackoffQueue(internalqueue.ReservationRelease)
+		})
+		go wait.Until(syncer.Sync, reservationSyncPeriod, stopEverything)
+	}
 
 	addAllEventHandlers(sched, informerFactory, podInformer)
 	return sched, nil
@@ -295,7 +393,11 @@ func (sched *Scheduler) Run(ctx context.Context) {
 		return
 	}
 	sched.SchedulingQueue.Run()
-	wait.UntilWithContext(ctx, sched.scheduleOne, 0)
+	if _, ok := sched.Algorithm.(core.BatchScheduler); ok && sched.batchSize > 1 {
+		wait.UntilWithContext(ctx, sched.scheduleBatch, 0)
+	} else {
+		wait.UntilWithContext(ctx, sched.scheduleOne, 0)
+	}
 	sched.SchedulingQueue.Close()
 }
 
@@ -427,7 +529,12 @@ func (sched *Scheduler) scheduleOne(ctx context.Context) {
 	if sched.skipPodSchedule(prof, pod) {
 		return
 	}
+	sched.schedulePod(ctx, prof, podInfo)
+}
 
+// schedulePod finds a node for the pod and then assumes and binds it.
+func (sched *Scheduler) schedulePod(ctx context.Context, prof *profile.Profile, podInfo *framework.QueuedPodInfo) {
+	pod := podInfo.Pod
 	klog.V(3).Infof("Attempting to schedule pod: %v/%v", pod.Namespace, pod.Name)
 
 	// Synchronously attempt to find a fit for the pod.
@@ -436,7 +543,16 @@ func (sched *Scheduler) scheduleOne(ctx context.Context) {
 	state.SetRecordPluginMetrics(rand.Intn(100) < pluginMetricsSamplePercent)
 	schedulingCycleCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	var attempt *explain.Attempt
+	if sched.explainRecorder != nil {
+		attempt = explain.NewAttempt(pod, prof.Name)
+		state.Write(explain.StateKey, attempt)
+	}
 	scheduleResult, err := sched.Algorithm.Schedule(schedulingCycleCtx, prof, state, pod)
+	if attempt != nil {
+		attempt.Finish(scheduleResult.SuggestedHost, err)
+		sched.explainRecorder.Add(attempt)
+	}
 	if err != nil {
 		// Schedule() may have failed because the pod would not fit on any host, so we try to
 		// preempt, with the expectation that the next time the pod is tried for scheduling it
@@ -473,13 +589,21 @@ func (sched *Scheduler) scheduleOne(ctx context.Context) {
 		return
 	}
 	metrics.SchedulingAlgorithmLatency.Observe(metrics.SinceInSeconds(start))
+	sched.assumeAndBind(ctx, prof, podInfo, state, scheduleResult, start)
+}
+
+// assumeAndBind assumes the pod on the suggested host, runs the reserve and
+// permit plugins, and then binds the pod asynchronously.
+func (sched *Scheduler) assumeAndBind(ctx context.Context, prof *profile.Profile, podInfo *framework.QueuedPodInfo, state *framework.CycleState, scheduleResult core.ScheduleResult, start time.Time) {
+	pod := podInfo.Pod
+	schedulingCycleCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 	// Tell the cache to assume that a pod now is running on a given node, even though it hasn't been bound yet.
 	// This allows us to keep scheduling without waiting on binding to occur.
 	assumedPodInfo := podInfo.DeepCopy()
 	assumedPod := assumedPodInfo.Pod
 	// assume modifies `assumedPod` by setting NodeName=scheduleResult.SuggestedHost
-	err = sched.assume(assumedPod, scheduleResult.SuggestedHost)
-	if err != nil {
+	if err := sched.assume(assumedPod, scheduleResult.SuggestedHost); err != nil {
 		metrics.PodScheduleError(prof.Name, metrics.SinceInSeconds(start))
 		// This is most probably result of a BUG in retrying logic.
 		// We report an error here so that pod scheduling can be retried.
@@ -585,6 +709,81 @@ func (sched *Scheduler) scheduleOne(ctx context.Context) {
 	}()
 }
 
+// scheduleBatch pops up to batchSize pods of the same profile and places them
+// jointly. The pods the batch does not place are scheduled one by one, after
+// the placed ones are assumed.
+func (sched *Scheduler) scheduleBatch(ctx context.Context) {
+	podInfo := sched.NextPod()
+	// pod could be nil when schedulerQueue is closed
+	if podInfo == nil || podInfo.Pod == nil {
+		return
+	}
+	prof, err := sched.profileForPod(podInfo.Pod)
+	if err != nil {
+		klog.Error(err)
+		return
+	}
+	podInfos := append([]*framework.QueuedPodInfo{podInfo}, sched.SchedulingQueue.PopCompatible(sched.batchSize-1, func(pod *v1.Pod) bool {
+		return pod.Spec.SchedulerName == podInfo.Pod.Spec.SchedulerName
+	})...)
+	var batch []*framework.QueuedPodInfo
+	for _, p := range podInfos {
+		if !sched.skipPodSchedule(prof, p.Pod) {
+			batch = append(batch, p)
+		}
+	}
+	if len(batch) == 0 {
+		return
+	}
+	if len(batch) == 1 {
+		sched.schedulePod(ctx, prof, batch[0])
+		return
+	}
+
+	klog.V(3).Infof("Attempting to schedule a batch of %d pods", len(batch))
+	start := time.Now()
+	states := make([]*framework.CycleState, len(batch))
+	pods := make([]*v1.Pod, len(batch))
+	attempts := make([]*explain.Attempt, len(batch))
+	for i, p := range batch {
+		states[i] = framework.NewCycleState()
+		states[i].SetRecordPluginMetrics(rand.Intn(100) < pluginMetricsSamplePercent)
+		pods[i] = p.Pod
+		if sched.explainRecorder != nil {
+			attempts[i] = explain.NewAttempt(p.Pod, prof.Name)
+			states[i].Write(explain.StateKey, attempts[i])
+		}
+	}
+	results, err := sched.Algorithm.(core.BatchScheduler).ScheduleBatch(ctx, prof, states, pods)
+	if err != nil {
+		klog.ErrorS(err, "Error scheduling batch, scheduling its pods one by one")
+		results = make([]core.ScheduleResult, len(batch))
+	}
+
+	var unplaced []*framework.QueuedPodInfo
+	nodes := make(map[string]bool)
+	for i, p := range batch {
+		host := results[i].SuggestedHost
+		if host == "" {
+			unplaced = append(unplaced, p)
+			continue
+		}
+		nodes[host] = true
+		// Batch attempts have filter statuses but no scores. The pods left
+		// unplaced are recorded again by their own attempt.
+		if attempts[i] != nil {
+			attempts[i].Finish(host, nil)
+			sched.explainRecorder.Add(attempts[i])
+		}
+		metrics.SchedulingAlgorithmLatency.Observe(metrics.SinceInSeconds(start))
+		sched.assumeAndBind(ctx, prof, p, states[i], results[i], start)
+	}
+	klog.V(3).Infof("Placed %d of %d pods of the batch on %d nodes", len(batch)-len(unplaced), len(batch), len(nodes))
+	for _, p := range unplaced {
+		sched.schedulePod(ctx, prof, p)
+	}
+}
+
 func getAttemptsLabel(p *framework.QueuedPodInfo) string {
 	// We breakdown the pod scheduling duration by attempts capped to a limit
 	// to avoid ending up with a high cardinality metric.
@@ -0,0 +1,666 @@
+// Package expr implements the small expression language of the Expression
+// scheduler plugin: boolean and numeric expressions over pod and node
+// attributes, type checked when compiled.
+//
+// Expressions have the operators, from lowest to highest precedence,
+//
+//	c ? a : b
+//	||
+//	&&
+//	== != < <= > >=
+//	+ -
+//	* /
+//	! -  (unary)
+//
+// number, string ("..." with Go escapes, or '...' without), true and false
+// literals, and the functions
+//
+//	has(m[key])      whether the map attribute m has key
+//	number(s)        s parsed as a number, 0 if it isn't one
+//	min(a, b, ...)   the smallest number
+//	max(a, b, ...)   the largest number
+//
+// Attributes are named by paths such as node.name and map attributes take
+// a key, node.labels["topology.kubernetes.io/zone"], or node.allocatable.cpu
+// when the key is an identifier. Missing keys read as "" or 0.
+//
+// Evaluation never fails: dividing by zero gives 0.
+package expr
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is the type of an expression.
+type Type int
+
+const (
+	// Bool is the type of conditions.
+	Bool Type = iota
+	// Number is the type of float64 values.
+	Number
+	// String is the type of strings.
+	String
+)
+
+func (t Type) String() string {
+	switch t {
+	case Bool:
+		return "bool"
+	case Number:
+		return "number"
+	case String:
+		return "string"
+	}
+	return fmt.Sprintf("Type(%d)", int(t))
+}
+
+// Attributes that expressions can refer to. Resource quantities are in
+// the units of framework.Resource: cpu in millicores, memory and
+// ephemeral-storage in bytes, other resources in units.
+const (
+	// PodName is the name of the pod, a string.
+	PodName = "pod.name"
+	// PodNamespace is the namespace of the pod, a string.
+	PodNamespace = "pod.namespace"
+	// PodPriority is the priority of the pod, a number.
+	PodPriority = "pod.priority"
+	// PodLabels are the labels of the pod, a map of strings.
+	PodLabels = "pod.labels"
+	// PodAnnotations are the annotations of the pod, a map of strings.
+	PodAnnotations = "pod.annotations"
+	// PodRequests are the resource requests of the pod, a map of numbers.
+	PodRequests = "pod.requests"
+	// NodeName is the name of the node, a string.
+	NodeName = "node.name"
+	// NodeLabels are the labels of the node, a map of strings.
+	NodeLabels = "node.labels"
+	// NodeAnnotations are the annotations of the node, a map of strings.
+	NodeAnnotations = "node.annotations"
+	// NodeAllocatable is the allocatable resources of the node, with pods
+	// the number of pods it can run, a map of numbers.
+	NodeAllocatable = "node.allocatable"
+	// NodeRequested is the sum of the requests of the pods on the node,
+	// with pods their number, a map of numbers.
+	NodeRequested = "node.requested"
+)
+
+type attribute struct {
+	typ Type
+	// isMap attributes are accessed with a key.
+	isMap bool
+}
+
+var attributes = map[string]attribute{
+	PodName:         {typ: String},
+	PodNamespace:    {typ: String},
+	PodPriority:     {typ: Number},
+	PodLabels:       {typ: String, isMap: true},
+	PodAnnotations:  {typ: String, isMap: true},
+	PodRequests:     {typ: Number, isMap: true},
+	NodeName:        {typ: String},
+	NodeLabels:      {typ: String, isMap: true},
+	NodeAnnotations: {typ: String, isMap: true},
+	NodeAllocatable: {typ: Number, isMap: true},
+	NodeRequested:   {typ: Number, isMap: true},
+}
+
+// Env provides the values of attributes to evaluated expressions. key is
+// empty for attributes that aren't maps, ok is false if a map has no key.
+type Env interface {
+	String(attr, key string) (value string, ok bool)
+	Number(attr, key string) (value float64, ok bool)
+}
+
+// Expression is a compiled expression.
+type Expression struct {
+	src   string
+	root  node
+	attrs []string
+}
+
+// Compile parses and type checks src.
+func Compile(src string) (*Expression, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %v", src, err)
+	}
+	p := &parser{toks: toks, attrs: map[string]bool{}}
+	root, err := p.parse()
+	if err != nil {
+		return nil, fmt.Errorf("expression %q: %v", src, err)
+	}
+	attrs := make([]string, 0, len(p.attrs))
+	for a := range p.attrs {
+		attrs = append(attrs, a)
+	}
+	sort.Strings(attrs)
+	return &Expression{src: src, root: root, attrs: attrs}, nil
+}
+
+// CompileAs compiles src and checks that it is of type t.
+func CompileAs(src string, t Type) (*Expression, error) {
+	e, err := Compile(src)
+	if err != nil {
+		return nil, err
+	}
+	if e.Type() != t {
+		return nil, fmt.Errorf("expression %q is a %v, want a %v", src, e.Type(), t)
+	}
+	return e, nil
+}
+
+// Type returns the type of the expression.
+func (e *Expression) Type() Type {
+	return e.root.typ
+}
+
+// Attributes returns the sorted attributes the expression refers to.
+func (e *Expression) Attributes() []string {
+	return e.attrs
+}
+
+// String returns the source of the expression.
+func (e *Expression) String() string {
+	return e.src
+}
+
+// Bool evaluates a Bool expression in env.
+func (e *Expression) Bool(env Env) bool {
+	return e.root.b(env)
+}
+
+// Number evaluates a Number expression in env.
+func (e *Expression) Number(env Env) float64 {
+	return e.root.n(env)
+}
+
+// node is a compiled sub-expression. Only the evaluation function of its
+// type is set.
+type node struct {
+	typ Type
+	b   func(Env) bool
+	n   func(Env) float64
+	s   func(Env) string
+}
+
+func boolNode(f func(Env) bool) node {
+	return node{typ: Bool, b: f}
+}
+
+func numberNode(f func(Env) float64) node {
+	return node{typ: Number, n: f}
+}
+
+func stringNode(f func(Env) string) node {
+	return node{typ: String, s: f}
+}
+
+// parser is a recursive descent parser compiling tokens into nodes.
+type parser struct {
+	toks  []token
+	i     int
+	attrs map[string]bool
+}
+
+func (p *parser) peek() token {
+	return p.toks[p.i]
+}
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the next token if it is the operator op.
+func (p *parser) accept(op string) bool {
+	if t := p.peek(); t.kind == tokOp && t.text == op {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(op string) error {
+	if !p.accept(op) {
+		t := p.peek()
+		return fmt.Errorf("at %d: expected %q, got %v", t.pos, op, t)
+	}
+	return nil
+}
+
+func (p *parser) parse() (node, error) {
+	n, err := p.conditional()
+	if err != nil {
+		return node{}, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return node{}, fmt.Errorf("at %d: unexpected %v", t.pos, t)
+	}
+	return n, nil
+}
+
+func (p *parser) conditional() (node, error) {
+	pos := p.peek().pos
+	c, err := p.or()
+	if err != nil || !p.accept("?") {
+		return c, err
+	}
+	if c.typ != Bool {
+		return node{}, fmt.Errorf("at %d: condition is a %v, want a bool", pos, c.typ)
+	}
+	a, err := p.conditional()
+	if err != nil {
+		return node{}, err
+	}
+	if err := p.expect(":"); err != nil {
+		return node{}, err
+	}
+	b, err := p.conditional()
+	if err != nil {
+		return node{}, err
+	}
+	if a.typ != b.typ {
+		return node{}, fmt.Errorf("at %d: branches are a %v and a %v", pos, a.typ, b.typ)
+	}
+	cond := c.b
+	switch a.typ {
+	case Bool:
+		return boolNode(func(env Env) bool {
+			if cond(env) {
+				return a.b(env)
+			}
+			return b.b(env)
+		}), nil
+	case Number:
+		return numberNode(func(env Env) float64 {
+			if cond(env) {
+				return a.n(env)
+			}
+			return b.n(env)
+		}), nil
+	default:
+		return stringNode(func(env Env) string {
+			if cond(env) {
+				return a.s(env)
+			}
+			return b.s(env)
+		}), nil
+	}
+}
+
+func (p *parser) or() (node, error) {
+	l, err := p.and()
+	if err != nil {
+		return node{}, err
+	}
+	for {
+		pos := p.peek().pos
+		if !p.accept("||") {
+			return l, nil
+		}
+		r, err := p.and()
+		if err != nil {
+			return node{}, err
+		}
+		if l.typ != Bool || r.typ != Bool {
+			return node{}, fmt.Errorf("at %d: || of a %v and a %v, want bools", pos, l.typ, r.typ)
+		}
+		a, b := l.b, r.b
+		l = boolNode(func(env Env) bool { return a(env) || b(env) })
+	}
+}
+
+func (p *parser) and() (node, error) {
+	l, err := p.comparison()
+	if err != nil {
+		return node{}, err
+	}
+	for {
+		pos := p.peek().pos
+		if !p.accept("&&") {
+			return l, nil
+		}
+		r, err := p.comparison()
+		if err != nil {
+			return node{}, err
+		}
+		if l.typ != Bool || r.typ != Bool {
+			return node{}, fmt.Errorf("at %d: && of a %v and a %v, want bools", pos, l.typ, r.typ)
+		}
+		a, b := l.b, r.b
+		l = boolNode(func(env Env) bool { return a(env) && b(env) })
+	}
+}
+
+func (p *parser) comparison() (node, error) {
+	l, err := p.additive()
+	if err != nil {
+		return node{}, err
+	}
+	t := p.peek()
+	if t.kind != tokOp {
+		return l, nil
+	}
+	switch t.text {
+	case "==", "!=", "<", "<=", ">", ">=":
+	default:
+		return l, nil
+	}
+	p.next()
+	r, err := p.additive()
+	if err != nil {
+		return node{}, err
+	}
+	if l.typ != r.typ {
+		return node{}, fmt.Errorf("at %d: %s of a %v and a %v", t.pos, t.text, l.typ, r.typ)
+	}
+	if l.typ != Number && t.text != "==" && t.text != "!=" {
+		return node{}, fmt.Errorf("at %d: %s of %vs, want numbers", t.pos, t.text, l.typ)
+	}
+	switch l.typ {
+	case Bool:
+		a, b := l.b, r.b
+		if t.text == "==" {
+			return boolNode(func(env Env) bool { return a(env) == b(env) }), nil
+		}
+		return boolNode(func(env Env) bool { return a(env) != b(env) }), nil
+	case String:
+		a, b := l.s, r.s
+		if t.text == "==" {
+			return boolNode(func(env Env) bool { return a(env) == b(env) }), nil
+		}
+		return boolNode(func(env Env) bool { return a(env) != b(env) }), nil
+	}
+	a, b := l.n, r.n
+	switch t.text {
+	case "==":
+		return boolNode(func(env Env) bool { return a(env) == b(env) }), nil
+	case "!=":
+		return boolNode(func(env Env) bool { return a(env) != b(env) }), nil
+	case "<":
+		return boolNode(func(env Env) bool { return a(env) < b(env) }), nil
+	case "<=":
+		return boolNode(func(env Env) bool { return a(env) <= b(env) }), nil
+	case ">":
+		return boolNode(func(env Env) bool { return a(env) > b(env) }), nil
+	default:
+		return boolNode(func(env Env) bool { return a(env) >= b(env) }), nil
+	}
+}
+
+func (p *parser) additive() (node, error) {
+	l, err := p.multiplicative()
+	if err != nil {
+		return node{}, err
+	}
+	for {
+		t := p.peek()
+		if !p.accept("+") && !p.accept("-") {
+			return l, nil
+		}
+		r, err := p.multiplicative()
+		if err != nil {
+			return node{}, err
+		}
+		if l.typ != Number || r.typ != Number {
+			return node{}, fmt.Errorf("at %d: %s of a %v and a %v, want numbers", t.pos, t.text, l.typ, r.typ)
+		}
+		a, b := l.n, r.n
+		if t.text == "+" {
+			l = numberNode(func(env Env) float64 { return a(env) + b(env) })
+		} else {
+			l = numberNode(func(env Env) float64 { return a(env) - b(env) })
+		}
+	}
+}
+
+func (p *parser) multiplicative() (node, error) {
+	l, err := p.unary()
+	if err != nil {
+		return node{}, err
+	}
+	for {
+		t := p.peek()
+		if !p.accept("*") && !p.accept("/") {
+			return l, nil
+		}
+		r, err := p.unary()
+		if err != nil {
+			return node{}, err
+		}
+		if l.typ != Number || r.typ != Number {
+			return node{}, fmt.Errorf("at %d: %s of a %v and a %v, want numbers", t.pos, t.text, l.typ, r.typ)
+		}
+		a, b := l.n, r.n
+		if t.text == "*" {
+			l = numberNode(func(env Env) float64 { return a(env) * b(env) })
+		} else {
+			l = numberNode(func(env Env) float64 {
+				d := b(env)
+				if d == 0 {
+					return 0
+				}
+				return a(env) / d
+			})
+		}
+	}
+}
+
+func (p *parser) unary() (node, error) {
+	t := p.peek()
+	switch {
+	case p.accept("!"):
+		x, err := p.unary()
+		if err != nil {
+			return node{}, err
+		}
+		if x.typ != Bool {
+			return node{}, fmt.Errorf("at %d: ! of a %v, want a bool", t.pos, x.typ)
+		}
+		f := x.b
+		return boolNode(func(env Env) bool { return !f(env) }), nil
+	case p.accept("-"):
+		x, err := p.unary()
+		if err != nil {
+			return node{}, err
+		}
+		if x.typ != Number {
+			return node{}, fmt.Errorf("at %d: - of a %v, want a number", t.pos, x.typ)
+		}
+		f := x.n
+		return numberNode(func(env Env) float64 { return -f(env) }), nil
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNumber:
+		v := t.num
+		return numberNode(func(Env) float64 { return v }), nil
+	case tokString:
+		v := t.text
+		return stringNode(func(Env) string { return v }), nil
+	case tokIdent:
+		switch t.text {
+		case "true", "false":
+			v := t.text == "true"
+			return boolNode(func(Env) bool { return v }), nil
+		case "pod", "node":
+			attr, key, err := p.attribute(t)
+			if err != nil {
+				return node{}, err
+			}
+			return p.attributeNode(attr, key), nil
+		}
+		if p.accept("(") {
+			return p.call(t)
+		}
+		return node{}, fmt.Errorf("at %d: unknown identifier %q", t.pos, t.text)
+	case tokOp:
+		if t.text == "(" {
+			n, err := p.conditional()
+			if err != nil {
+				return node{}, err
+			}
+			return n, p.expect(")")
+		}
+	}
+	return node{}, fmt.Errorf("at %d: unexpected %v", t.pos, t)
+}
+
+// attribute parses the path starting with root, returning the attribute
+// and its key if it is a map.
+func (p *parser) attribute(root token) (string, string, error) {
+	path := root.text
+	for {
+		if _, ok := attributes[path]; ok {
+			break
+		}
+		if err := p.expect("."); err != nil {
+			return "", "", err
+		}
+		t := p.next()
+		if t.kind != tokIdent {
+			return "", "", fmt.Errorf("at %d: expected an attribute name, got %v", t.pos, t)
+		}
+		path += "." + t.text
+		if !isAttributePrefix(path) {
+			return "", "", fmt.Errorf("at %d: unknown attribute %q", root.pos, path)
+		}
+	}
+	p.attrs[path] = true
+	if !attributes[path].isMap {
+		return path, "", nil
+	}
+	switch {
+	case p.accept("."):
+		t := p.next()
+		if t.kind != tokIdent {
+			return "", "", fmt.Errorf("at %d: expected a key, got %v", t.pos, t)
+		}
+		return path, t.text, nil
+	case p.accept("["):
+		t := p.next()
+		if t.kind != tokString {
+			return "", "", fmt.Errorf("at %d: keys of %s must be strings, got %v", t.pos, path, t)
+		}
+		return path, t.text, p.expect("]")
+	}
+	t := p.peek()
+	return "", "", fmt.Errorf("at %d: %s needs a key", t.pos, path)
+}
+
+// isAttributePrefix returns whether path is an attribute or leads to one.
+func isAttributePrefix(path string) bool {
+	for a := range attributes {
+		if strings.HasPrefix(a, path) && (len(a) == len(path) || a[len(path)] == '.') {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) attributeNode(attr, key string) node {
+	if attributes[attr].typ == Number {
+		return numberNode(func(env Env) float64 {
+			v, _ := env.Number(attr, key)
+			return v
+		})
+	}
+	return stringNode(func(env Env) string {
+		v, _ := env.String(attr, key)
+		return v
+	})
+}
+
+// call parses the arguments of the function fn, after its opening
+// parenthesis.
+func (p *parser) call(fn token) (node, error) {
+	if fn.text == "has" {
+		t := p.next()
+		if t.kind != tokIdent || (t.text != "pod" && t.text != "node") {
+			return node{}, fmt.Errorf("at %d: has takes a map attribute, got %v", t.pos, t)
+		}
+		attr, key, err := p.attribute(t)
+		if err != nil {
+			return node{}, err
+		}
+		if !attributes[attr].isMap {
+			return node{}, fmt.Errorf("at %d: has takes a map attribute, %s is not a map", t.pos, attr)
+		}
+		if err := p.expect(")"); err != nil {
+			return node{}, err
+		}
+		if attributes[attr].typ == Number {
+			return boolNode(func(env Env) bool {
+				_, ok := env.Number(attr, key)
+				return ok
+			}), nil
+		}
+		return boolNode(func(env Env) bool {
+			_, ok := env.String(attr, key)
+			return ok
+		}), nil
+	}
+
+	var args []node
+	for !p.accept(")") {
+		if len(args) > 0 {
+			if err := p.expect(","); err != nil {
+				return node{}, err
+			}
+		}
+		a, err := p.conditional()
+		if err != nil {
+			return node{}, err
+		}
+		args = append(args, a)
+	}
+	switch fn.text {
+	case "number":
+		if len(args) != 1 || args[0].typ != String {
+			return node{}, fmt.Errorf("at %d: number takes a string", fn.pos)
+		}
+		f := args[0].s
+		return numberNode(func(env Env) float64 {
+			v, err := strconv.ParseFloat(f(env), 64)
+			if err != nil {
+				return 0
+			}
+			return v
+		}), nil
+	case "min", "max":
+		if len(args) == 0 {
+			return node{}, fmt.Errorf("at %d: %s takes at least one number", fn.pos, fn.text)
+		}
+		fs := make([]func(Env) float64, len(args))
+		for i, a := range args {
+			if a.typ != Number {
+				return node{}, fmt.Errorf("at %d: %s takes numbers, argument %d is a %v", fn.pos, fn.text, i+1, a.typ)
+			}
+			fs[i] = a.n
+		}
+		pick := math.Max
+		if fn.text == "min" {
+			pick = math.Min
+		}
+		return numberNode(func(env Env) float64 {
+			v := fs[0](env)
+			for _, f := range fs[1:] {
+				v = pick(v, f(env))
+			}
+			return v
+		}), nil
+	}
+	return node{}, fmt.Errorf("at %d: unknown function %q", fn.pos, fn.text)
+}
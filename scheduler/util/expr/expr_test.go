@@ -0,0 +1,169 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+)
+
+type testEnv struct {
+	strings map[string]string
+	numbers map[string]float64
+}
+
+func (e testEnv) String(attr, key string) (string, bool) {
+	v, ok := e.strings[attr+"/"+key]
+	return v, ok
+}
+
+func (e testEnv) Number(attr, key string) (float64, bool) {
+	v, ok := e.numbers[attr+"/"+key]
+	return v, ok
+}
+
+var env = testEnv{
+	strings: map[string]string{
+		"pod.name/":                                      "web-0",
+		"pod.annotations/prophet.io/gpu":                 "true",
+		"node.name/":                                     "node-1",
+		"node.labels/topology.kubernetes.io/zone":        "zone-a",
+		"node.labels/pool":                               "gpu",
+		"node.annotations/prophet.io/free-memory-factor": "0.5",
+	},
+	numbers: map[string]float64{
+		"pod.priority/":            1000,
+		"pod.requests/cpu":         500,
+		"node.allocatable/cpu":     4000,
+		"node.allocatable/memory":  8e9,
+		"node.requested/cpu":       1000,
+		"node.requested/memory":    2e9,
+		"node.allocatable/example": 0,
+	},
+}
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		src  string
+		want bool
+	}{
+		{src: `true`, want: true},
+		{src: `!true || false`, want: false},
+		{src: `has(node.labels.pool)`, want: true},
+		{src: `has(node.labels["missing"])`, want: false},
+		{src: `has(node.allocatable.example)`, want: true},
+		{src: `has(node.requested["example"])`, want: false},
+		{src: `!has(pod.annotations["prophet.io/gpu"]) || node.labels.pool == "gpu"`, want: true},
+		{src: `node.labels["topology.kubernetes.io/zone"] == 'zone-b'`, want: false},
+		{src: `node.labels.missing == ""`, want: true},
+		{src: `pod.name != "web-1" && node.name == "node-1"`, want: true},
+		{src: `node.requested.cpu + pod.requests.cpu <= node.allocatable.cpu * 0.5`, want: true},
+		{src: `pod.priority > 1000`, want: false},
+		{src: `pod.priority >= 1000`, want: true},
+		{src: `(1 < 2) == (3 < 4)`, want: true},
+		{src: `1 < 2 ? pod.priority == 1000 : false`, want: true},
+		{src: `false && 1 / 0 == 0`, want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.src, func(t *testing.T) {
+			e, err := CompileAs(tc.src, Bool)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.Bool(env); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNumber(t *testing.T) {
+	tests := []struct {
+		src  string
+		want float64
+	}{
+		{src: `1.5`, want: 1.5},
+		{src: `2 + 3 * 4 - 6 / 2`, want: 11},
+		{src: `(2 + 3) * 4`, want: 20},
+		{src: `-pod.priority / -10`, want: 100},
+		{src: `(node.allocatable.memory - node.requested.memory) / node.allocatable.memory * 100`, want: 75},
+		{src: `node.allocatable.missing`, want: 0},
+		{src: `1 / node.allocatable.missing`, want: 0},
+		{src: `number(node.annotations["prophet.io/free-memory-factor"]) * 10`, want: 5},
+		{src: `number(node.name)`, want: 0},
+		{src: `min(3, 1, 2)`, want: 1},
+		{src: `max(3, 1, 2)`, want: 3},
+		{src: `max(min(pod.priority, 50), 0)`, want: 50},
+		{src: `has(pod.annotations["prophet.io/gpu"]) ? 100 : 0`, want: 100},
+		{src: `false ? 1 : true ? 2 : 3`, want: 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.src, func(t *testing.T) {
+			e, err := CompileAs(tc.src, Number)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := e.Number(env); got != tc.want {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		{src: ``, want: "unexpected end of expression"},
+		{src: `1 +`, want: "unexpected end of expression"},
+		{src: `(1`, want: `expected ")"`},
+		{src: `1 2`, want: `unexpected "2"`},
+		{src: `"abc`, want: "unterminated string"},
+		{src: `'abc`, want: "unterminated string"},
+		{src: `1.2.3`, want: "invalid number"},
+		{src: `1 # 2`, want: "unexpected character"},
+		{src: `foo`, want: `unknown identifier "foo"`},
+		{src: `foo(1)`, want: `unknown function "foo"`},
+		{src: `pod.uid`, want: `unknown attribute "pod.uid"`},
+		{src: `node`, want: `expected "."`},
+		{src: `node.labels`, want: "node.labels needs a key"},
+		{src: `node.labels[1]`, want: "keys of node.labels must be strings"},
+		{src: `has(node.name)`, want: "node.name is not a map"},
+		{src: `has(1)`, want: "has takes a map attribute"},
+		{src: `1 + "a"`, want: "+ of a number and a string"},
+		{src: `"a" < "b"`, want: "< of strings, want numbers"},
+		{src: `1 == "1"`, want: "== of a number and a string"},
+		{src: `1 && true`, want: "&& of a number and a bool"},
+		{src: `!1`, want: "! of a number"},
+		{src: `-true`, want: "- of a bool"},
+		{src: `1 ? 2 : 3`, want: "condition is a number"},
+		{src: `true ? 2 : "3"`, want: "branches are a number and a string"},
+		{src: `number(1)`, want: "number takes a string"},
+		{src: `min()`, want: "min takes at least one number"},
+		{src: `max(1, "2")`, want: "argument 2 is a string"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.src, func(t *testing.T) {
+			_, err := Compile(tc.src)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("got error %v, want %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompileAs(t *testing.T) {
+	if _, err := CompileAs(`node.allocatable.cpu`, Bool); err == nil || !strings.Contains(err.Error(), "is a number, want a bool") {
+		t.Errorf("got error %v, want a type error", err)
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	e, err := Compile(`node.requested.cpu / node.allocatable.cpu + node.requested.memory + (has(pod.labels.app) ? 1 : 0)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Join(e.Attributes(), ",")
+	if want := "node.allocatable,node.requested,pod.labels"; got != want {
+		t.Errorf("got attributes %s, want %s", got, want)
+	}
+}